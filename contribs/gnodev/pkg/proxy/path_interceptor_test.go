@@ -104,7 +104,7 @@ func TestProxy(t *testing.T) {
 		// Build transaction with multiple messages
 		var tx std.Tx
 		send := std.MustParseCoins(ugnot.ValueString(10_000_000))
-		tx.Fee = std.Fee{GasWanted: 1e6, GasFee: std.Coin{Amount: 1e6, Denom: "ugnot"}}
+		tx.Fee = std.Fee{GasWanted: 1e6, GasFee: std.Coin{Amount: std.NewInt(1e6), Denom: "ugnot"}}
 		tx.Msgs = []std.Msg{
 			vm.NewMsgCall(creator, send, targetPath, "Render", []string{""}),
 			vm.NewMsgCall(creator, send, targetPath, "Render", []string{""}),
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
@@ -24,6 +25,9 @@ var (
 	errNoBalanceSource       = errors.New("at least one balance source must be set")
 	errBalanceParsingAborted = errors.New("balance parsing aborted")
 	errInvalidAddress        = errors.New("invalid address encountered")
+	errInvalidVestingStart   = errors.New("invalid vesting start")
+	errInvalidVestingEnd     = errors.New("invalid vesting end")
+	errInvalidVestingPeriod  = errors.New("vesting period must be at least a second")
 )
 
 type balancesAddCfg struct {
@@ -32,6 +36,11 @@ type balancesAddCfg struct {
 	balanceSheet  string
 	singleEntries commands.StringArr
 	parseExport   string
+
+	vestingKind   string
+	vestingStart  string
+	vestingEnd    string
+	vestingPeriod time.Duration
 }
 
 // newBalancesAddCmd creates the genesis balances add subcommand
@@ -73,6 +82,39 @@ func (c *balancesAddCfg) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"the path to the transaction export containing a list of transactions (JSONL)",
 	)
+
+	fs.StringVar(
+		&c.vestingKind,
+		"vesting-kind",
+		"",
+		fmt.Sprintf(
+			"if set, the added balances are locked and vest over time (%s, %s, %s)",
+			gnoland.VestingContinuous,
+			gnoland.VestingDelayed,
+			gnoland.VestingPeriodic,
+		),
+	)
+
+	fs.StringVar(
+		&c.vestingStart,
+		"vesting-start",
+		"",
+		"the RFC 3339 time at which the balances start vesting (only useful with --vesting-kind)",
+	)
+
+	fs.StringVar(
+		&c.vestingEnd,
+		"vesting-end",
+		"",
+		"the RFC 3339 time at which the balances are fully vested (only useful with --vesting-kind)",
+	)
+
+	fs.DurationVar(
+		&c.vestingPeriod,
+		"vesting-period",
+		0,
+		"the interval between the installments of a periodic vesting (only useful with --vesting-kind periodic)",
+	)
 }
 
 func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) error {
@@ -93,6 +135,11 @@ func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) e
 		return errNoBalanceSource
 	}
 
+	vestingFn, err := cfg.vestingFn()
+	if err != nil {
+		return err
+	}
+
 	finalBalances := gnoland.NewBalances()
 
 	// Get the balance sheet from the source
@@ -147,6 +194,14 @@ func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) e
 		return err
 	}
 
+	// Lock the new balances, replacing the existing vesting schedules
+	if vestingFn != nil {
+		state.Vesting, err = setGenesisVesting(state.Vesting, finalBalances, vestingFn)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Merge the two balance sheets, with the input
 	// having precedence over the genesis balances
 	finalBalances.LeftMerge(genesisBalances)
@@ -209,7 +264,7 @@ func getBalancesFromTransactions(
 			}
 
 			feeAmount := std.NewCoins(tx.Fee.GasFee)
-			if feeAmount.AmountOf(ugnot.Denom) <= 0 {
+			if !feeAmount.AmountOf(ugnot.Denom).IsPositive() {
 				io.ErrPrintfln(
 					"invalid gas fee amount encountered: %q",
 					tx.Fee.GasFee.String(),
@@ -224,7 +279,7 @@ func getBalancesFromTransactions(
 				msgSend := msg.(bank.MsgSend)
 
 				sendAmount := msgSend.Amount
-				if sendAmount.AmountOf(ugnot.Denom) <= 0 {
+				if !sendAmount.AmountOf(ugnot.Denom).IsPositive() {
 					io.ErrPrintfln(
 						"invalid send amount encountered: %s",
 						msgSend.Amount.String(),
@@ -284,6 +339,87 @@ func getBalancesFromTransactions(
 	return balances, nil
 }
 
+// vestingFn returns the function creating the vesting schedule of a balance,
+// if a vesting kind is set.
+func (c *balancesAddCfg) vestingFn() (func(gnoland.Balance) gnoland.Vesting, error) {
+	if c.vestingKind == "" {
+		return nil, nil
+	}
+
+	var (
+		start, end time.Time
+		err        error
+	)
+
+	if c.vestingKind != gnoland.VestingDelayed {
+		start, err = time.Parse(time.RFC3339, c.vestingStart)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidVestingStart, err)
+		}
+	}
+
+	end, err = time.Parse(time.RFC3339, c.vestingEnd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidVestingEnd, err)
+	}
+
+	if c.vestingKind == gnoland.VestingPeriodic && c.vestingPeriod < time.Second {
+		return nil, errInvalidVestingPeriod
+	}
+
+	fn := func(balance gnoland.Balance) gnoland.Vesting {
+		if c.vestingKind == gnoland.VestingPeriodic {
+			return gnoland.NewPeriodicVesting(
+				balance.Address,
+				balance.Amount,
+				start.Unix(),
+				end.Unix(),
+				int64(c.vestingPeriod/time.Second),
+			)
+		}
+
+		v := gnoland.Vesting{
+			Address: balance.Address,
+			Kind:    c.vestingKind,
+			Amount:  balance.Amount,
+			EndTime: end.Unix(),
+		}
+		if c.vestingKind != gnoland.VestingDelayed {
+			v.StartTime = start.Unix()
+		}
+		return v
+	}
+
+	return fn, nil
+}
+
+// setGenesisVesting sets the vesting schedules of the given balances,
+// replacing the existing ones
+func setGenesisVesting(
+	vesting []gnoland.Vesting,
+	balances gnoland.Balances,
+	vestingFn func(gnoland.Balance) gnoland.Vesting,
+) ([]gnoland.Vesting, error) {
+	updated := make([]gnoland.Vesting, 0, len(vesting)+len(balances))
+
+	for _, v := range vesting {
+		if _, ok := balances[v.Address]; !ok {
+			updated = append(updated, v)
+		}
+	}
+
+	for _, balance := range balances.List() {
+		v := vestingFn(balance)
+		if err := v.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid vesting schedule for %s, %w", balance.Address, err)
+		}
+
+		updated = append(updated, v)
+	}
+
+	return updated, nil
+}
+
 // mapGenesisBalancesFromState extracts the initial account balances from the
 // genesis app state
 func mapGenesisBalancesFromState(state gnoland.GnoGenesisState) (gnoland.Balances, error) {
@@ -103,7 +103,7 @@ func TestGenesis_Balances_Add(t *testing.T) {
 				fmt.Sprintf(
 					"%s=%s",
 					dummyKey.Address().String(),
-					ugnot.ValueString(amount.AmountOf(ugnot.Denom)),
+					ugnot.ValueString(amount.AmountOf(ugnot.Denom).Int64()),
 				),
 			)
 		}
@@ -163,7 +163,7 @@ func TestGenesis_Balances_Add(t *testing.T) {
 			balances[index] = fmt.Sprintf(
 				"%s=%s",
 				key.Address().String(),
-				ugnot.ValueString(amount.AmountOf(ugnot.Denom)),
+				ugnot.ValueString(amount.AmountOf(ugnot.Denom).Int64()),
 			)
 		}
 
@@ -361,7 +361,7 @@ func TestGenesis_Balances_Add(t *testing.T) {
 				fmt.Sprintf(
 					"%s=%s",
 					dummyKey.Address().String(),
-					ugnot.ValueString(amount.AmountOf(ugnot.Denom)),
+					ugnot.ValueString(amount.AmountOf(ugnot.Denom).Int64()),
 				),
 			)
 		}
@@ -399,6 +399,111 @@ func TestGenesis_Balances_Add(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("balances with vesting", func(t *testing.T) {
+		t.Parallel()
+
+		dummyKeys := common.GetDummyKeys(t, 2)
+
+		tempGenesis, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		genesis := common.GetDefaultGenesis()
+		genesis.AppState = gnoland.GnoGenesisState{
+			// Set an existing vesting schedule, which is replaced
+			Vesting: []gnoland.Vesting{
+				{
+					Address: dummyKeys[0].Address(),
+					Kind:    gnoland.VestingDelayed,
+					Amount:  std.NewCoins(std.NewCoin(ugnot.Denom, 100)),
+					EndTime: 10,
+				},
+			},
+		}
+		require.NoError(t, genesis.SaveAs(tempGenesis.Name()))
+
+		amount := std.NewCoins(std.NewCoin(ugnot.Denom, 10))
+
+		// Create the command
+		cmd := NewBalancesCmd(commands.NewTestIO())
+		args := []string{
+			"add",
+			"--genesis-path",
+			tempGenesis.Name(),
+			"--vesting-kind",
+			gnoland.VestingPeriodic,
+			"--vesting-start",
+			"2025-01-01T00:00:00Z",
+			"--vesting-end",
+			"2025-01-01T00:03:00Z",
+			"--vesting-period",
+			"1m",
+		}
+
+		for _, dummyKey := range dummyKeys {
+			args = append(args, "--single")
+			args = append(
+				args,
+				fmt.Sprintf(
+					"%s=%s",
+					dummyKey.Address().String(),
+					ugnot.ValueString(amount.AmountOf(ugnot.Denom).Int64()),
+				),
+			)
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		require.NoError(t, cmdErr)
+
+		// Validate the genesis was updated
+		genesis, loadErr := types.GenesisDocFromFile(tempGenesis.Name())
+		require.NoError(t, loadErr)
+
+		state, ok := genesis.AppState.(gnoland.GnoGenesisState)
+		require.True(t, ok)
+
+		require.Len(t, state.Balances, len(dummyKeys))
+		require.Len(t, state.Vesting, len(dummyKeys))
+
+		for _, vesting := range state.Vesting {
+			require.NoError(t, vesting.Verify())
+
+			assert.Equal(t, gnoland.VestingPeriodic, vesting.Kind)
+			assert.Equal(t, amount, vesting.Amount)
+			assert.Equal(t, int64(1735689600), vesting.StartTime)
+			assert.Equal(t, int64(1735689780), vesting.EndTime)
+			assert.Len(t, vesting.Periods, 3)
+		}
+	})
+
+	t.Run("invalid vesting end", func(t *testing.T) {
+		t.Parallel()
+
+		tempGenesis, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		genesis := common.GetDefaultGenesis()
+		require.NoError(t, genesis.SaveAs(tempGenesis.Name()))
+
+		// Create the command
+		cmd := NewBalancesCmd(commands.NewTestIO())
+		args := []string{
+			"add",
+			"--genesis-path",
+			tempGenesis.Name(),
+			"--single",
+			fmt.Sprintf("%s=%s", common.GetDummyKey(t).Address().String(), ugnot.ValueString(10)),
+			"--vesting-kind",
+			gnoland.VestingDelayed,
+			"--vesting-end",
+			"tomorrow",
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errInvalidVestingEnd)
+	})
 }
 
 func TestBalances_GetBalancesFromTransactions(t *testing.T) {
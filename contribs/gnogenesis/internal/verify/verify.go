@@ -13,8 +13,9 @@ import (
 )
 
 var (
-	errInvalidGenesisState = errors.New("invalid genesis state type")
-	errInvalidTxSignature  = errors.New("invalid tx signature")
+	errInvalidGenesisState   = errors.New("invalid genesis state type")
+	errInvalidTxSignature    = errors.New("invalid tx signature")
+	errVestingExceedsBalance = errors.New("vesting exceeds the genesis balance")
 )
 
 type verifyCfg struct {
@@ -92,10 +93,25 @@ func execVerify(cfg *verifyCfg, io commands.IO) error {
 		}
 
 		// Validate the initial balances
+		balances := gnoland.NewBalances()
 		for _, balance := range state.Balances {
 			if err := balance.Verify(); err != nil {
 				return fmt.Errorf("invalid balance: %w", err)
 			}
+
+			balances[balance.Address] = balance
+		}
+
+		// Validate the vesting schedules of the balances
+		for _, vesting := range state.Vesting {
+			if err := vesting.Verify(); err != nil {
+				return fmt.Errorf("invalid vesting of %s: %w", vesting.Address, err)
+			}
+
+			balance, ok := balances.Get(vesting.Address)
+			if !ok || !balance.Amount.IsAllGTE(vesting.Amount) {
+				return fmt.Errorf("%w: %s", errVestingExceedsBalance, vesting.Address)
+			}
 		}
 	}
 
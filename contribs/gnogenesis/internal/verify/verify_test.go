@@ -8,6 +8,7 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/mock"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
@@ -189,6 +190,47 @@ func TestGenesis_Verify(t *testing.T) {
 		require.Error(t, cmdErr)
 	})
 
+	t.Run("vesting exceeds balance", func(t *testing.T) {
+		t.Parallel()
+
+		tempFile, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		address := crypto.AddressFromPreimage([]byte("contributor"))
+
+		g := getValidTestGenesis()
+		g.AppState = gnoland.GnoGenesisState{
+			Balances: []gnoland.Balance{
+				{
+					Address: address,
+					Amount:  std.NewCoins(std.NewCoin("ugnot", 10)),
+				},
+			},
+			Vesting: []gnoland.Vesting{
+				{
+					Address: address,
+					Kind:    gnoland.VestingDelayed,
+					Amount:  std.NewCoins(std.NewCoin("ugnot", 11)),
+					EndTime: 10,
+				},
+			},
+			Txs: []gnoland.TxWithMetadata{},
+		}
+
+		require.NoError(t, g.SaveAs(tempFile.Name()))
+
+		// Create the command
+		cmd := NewVerifyCmd(commands.NewTestIO())
+		args := []string{
+			"--genesis-path",
+			tempFile.Name(),
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		require.ErrorIs(t, cmdErr, errVestingExceedsBalance)
+	})
+
 	t.Run("valid genesis", func(t *testing.T) {
 		t.Parallel()
 
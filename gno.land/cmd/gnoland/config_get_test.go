@@ -544,6 +544,14 @@ func TestConfig_Get_P2P(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"transport",
+			"p2p.transport",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.P2P.Transport, unmarshalJSONCommon[string](t, value))
+			},
+			false,
+		},
 		{
 			"listen address",
 			"p2p.laddr",
@@ -680,6 +688,30 @@ func TestConfig_Get_P2P(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"address book file",
+			"p2p.addr_book_file",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.P2P.AddrBook, unmarshalJSONCommon[string](t, value))
+			},
+			false,
+		},
+		{
+			"ban threshold",
+			"p2p.ban_threshold",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.P2P.BanThreshold, unmarshalJSONCommon[uint64](t, value))
+			},
+			false,
+		},
+		{
+			"ban duration",
+			"p2p.ban_duration",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.P2P.BanDuration, unmarshalJSONCommon[time.Duration](t, value))
+			},
+			false,
+		},
 	}
 
 	verifyGetTestTableCommon(t, testTable)
@@ -452,6 +452,16 @@ func TestConfig_Set_P2P(t *testing.T) {
 				assert.Equal(t, value, loadedCfg.P2P.RootDir)
 			},
 		},
+		{
+			"transport updated",
+			[]string{
+				"p2p.transport",
+				"quic",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.P2P.Transport)
+			},
+		},
 		{
 			"listen address updated",
 			[]string{
@@ -575,6 +585,36 @@ func TestConfig_Set_P2P(t *testing.T) {
 				assert.Equal(t, value, loadedCfg.P2P.PrivatePeerIDs)
 			},
 		},
+		{
+			"address book file updated",
+			[]string{
+				"p2p.addr_book_file",
+				"example path",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.P2P.AddrBook)
+			},
+		},
+		{
+			"ban threshold updated",
+			[]string{
+				"p2p.ban_threshold",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.P2P.BanThreshold))
+			},
+		},
+		{
+			"ban duration updated",
+			[]string{
+				"p2p.ban_duration",
+				"1h0m0s",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.P2P.BanDuration.String())
+			},
+		},
 	}
 
 	verifySetTestTableCommon(t, testTable)
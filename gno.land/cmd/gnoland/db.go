@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+var errMissingDB = errors.New("database does not exist")
+
+const (
+	blockStoreDBName = "blockstore" // NOTE: keep in sync with tm2/pkg/bft/node/node.go
+	stateDBName      = "state"      // NOTE: keep in sync with tm2/pkg/bft/node/node.go
+	appDBName        = "gnolang"    // NOTE: keep in sync with gno.land/pkg/gnoland/app.go
+)
+
+// newDBCmd creates the db root command
+func newDBCmd(io commands.IO) *commands.Command {
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			Name:       "db",
+			ShortUsage: "db <subcommand> [flags] [<arg>...]",
+			ShortHelp:  "gno node database manipulation suite",
+			LongHelp: "Gno node database manipulation suite, for inspecting and repairing the block store, " +
+				"the consensus state and the application state. The node must be stopped while running these commands",
+		},
+		commands.NewEmptyConfig(),
+		commands.HelpExec,
+	)
+
+	cmd.AddSubCommands(
+		newDBInfoCmd(io),
+		newDBCompactCmd(io),
+		newDBExportCmd(io),
+		newDBImportCmd(io),
+		newDBRollbackCmd(io),
+	)
+
+	return cmd
+}
+
+// dbCfg is the common
+// configuration for db commands
+type dbCfg struct {
+	dataDir string
+}
+
+func (c *dbCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+}
+
+// nodeDBs groups the databases of a single gno.land node
+type nodeDBs struct {
+	cfg *config.Config
+
+	blockStore dbm.DB
+	state      dbm.DB
+	app        dbm.DB
+}
+
+// dbs returns the node databases, by name
+func (n *nodeDBs) dbs() map[string]dbm.DB {
+	return map[string]dbm.DB{
+		blockStoreDBName: n.blockStore,
+		stateDBName:      n.state,
+		appDBName:        n.app,
+	}
+}
+
+// close closes all opened node databases
+func (n *nodeDBs) close() {
+	for _, db := range n.dbs() {
+		if db != nil {
+			db.Close()
+		}
+	}
+}
+
+// loadNodeConfig loads the node configuration from the given node directory,
+// falling back to the default configuration if it's not present
+func loadNodeConfig(nodeDir string) (*config.Config, error) {
+	if !osm.FileExists(constructConfigPath(nodeDir)) {
+		return config.DefaultConfig().SetRootDir(nodeDir), nil
+	}
+
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load config, %w", err)
+	}
+
+	return cfg, nil
+}
+
+// openNodeDBs opens the block store, consensus state and application
+// databases of the node located in the given directory
+func openNodeDBs(dataDir string) (*nodeDBs, error) {
+	nodeDir, err := filepath.Abs(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	cfg, err := loadNodeConfig(nodeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &nodeDBs{
+		cfg: cfg,
+	}
+
+	var (
+		backend = dbm.BackendType(cfg.DBBackend)
+		dbDir   = cfg.DBDir()
+	)
+
+	if n.blockStore, err = openExistingDB(blockStoreDBName, backend, dbDir); err != nil {
+		n.close()
+
+		return nil, err
+	}
+
+	if n.state, err = openExistingDB(stateDBName, backend, dbDir); err != nil {
+		n.close()
+
+		return nil, err
+	}
+
+	// The application DB is always stored using goleveldb,
+	// in the node's default DB directory
+	if n.app, err = openExistingDB(
+		appDBName,
+		dbm.GoLevelDBBackend,
+		appDBDir(nodeDir),
+	); err != nil {
+		n.close()
+
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// appDBDir returns the directory of the application DB,
+// for the node located in the given directory
+func appDBDir(nodeDir string) string {
+	return filepath.Join(nodeDir, config.DefaultDBDir)
+}
+
+// openExistingDB opens the given database, making sure
+// it exists beforehand, as opening a DB creates it otherwise
+func openExistingDB(name string, backend dbm.BackendType, dir string) (dbm.DB, error) {
+	if !osm.DirExists(dbPath(name, dir)) && !osm.FileExists(dbPath(name, dir)) {
+		return nil, fmt.Errorf("%w: %q in %q", errMissingDB, name, dir)
+	}
+
+	db, err := dbm.NewDB(name, backend, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %q database, %w", name, err)
+	}
+
+	return db, nil
+}
+
+// dbPath returns the on-disk path of the given database
+func dbPath(name, dir string) string {
+	return filepath.Join(dir, name+".db")
+}
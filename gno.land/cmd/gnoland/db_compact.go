@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+var (
+	errUnknownDB             = errors.New("unknown database")
+	errCompactionUnsupported = errors.New("database backend does not support compaction")
+)
+
+type dbCompactCfg struct {
+	dbCfg
+}
+
+// newDBCompactCmd creates the db compact command
+func newDBCompactCmd(io commands.IO) *commands.Command {
+	cfg := &dbCompactCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "compact",
+			ShortUsage: "db compact [flags] [<db>...]",
+			ShortHelp:  "compacts the node databases",
+			LongHelp: fmt.Sprintf(
+				"Compacts the underlying storage of the given node databases, reclaiming "+
+					"the space taken up by deleted and overwritten entries. "+
+					"If no database is specified, all of them are compacted. Available databases: %s",
+				[]string{blockStoreDBName, stateDBName, appDBName},
+			),
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execDBCompact(cfg, args, io)
+		},
+	)
+}
+
+func (c *dbCompactCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.dbCfg.RegisterFlags(fs)
+}
+
+func execDBCompact(cfg *dbCompactCfg, args []string, io commands.IO) error {
+	dbs, err := openNodeDBs(cfg.dataDir)
+	if err != nil {
+		return err
+	}
+	defer dbs.close()
+
+	named := dbs.dbs()
+
+	// Compact all databases by default
+	if len(args) == 0 {
+		args = []string{blockStoreDBName, stateDBName, appDBName}
+	}
+
+	// Make sure the databases are valid before
+	// starting the (potentially long) compaction
+	for _, name := range args {
+		if _, ok := named[name]; !ok {
+			return fmt.Errorf("%w: %q", errUnknownDB, name)
+		}
+	}
+
+	for _, name := range args {
+		compacter, ok := named[name].(dbm.Compacter)
+		if !ok {
+			return fmt.Errorf("%w: %q", errCompactionUnsupported, name)
+		}
+
+		start := time.Now()
+
+		if err := compacter.Compact(nil, nil); err != nil {
+			return fmt.Errorf("unable to compact %q database, %w", name, err)
+		}
+
+		io.Printfln("Compacted %q database in %s", name, time.Since(start))
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_Compact(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown database", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		generateNodeData(t, nodeDir, 1)
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"compact",
+			"--data-dir",
+			nodeDir,
+			"unknown",
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errUnknownDB)
+	})
+
+	t.Run("all databases compacted", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		generateNodeData(t, nodeDir, 3)
+
+		mockOutput := bytes.NewBufferString("")
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(mockOutput))
+
+		// Create the command
+		cmd := newRootCmd(io)
+		args := []string{
+			"db",
+			"compact",
+			"--data-dir",
+			nodeDir,
+		}
+
+		// Run the command
+		require.NoError(t, cmd.ParseAndRun(context.Background(), args))
+
+		for _, name := range []string{blockStoreDBName, stateDBName, appDBName} {
+			assert.Contains(t, mockOutput.String(), name)
+		}
+	})
+}
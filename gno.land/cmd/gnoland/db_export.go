@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	errInvalidExportArgs   = errors.New("invalid number of db export arguments provided")
+	errInvalidExportHeight = errors.New("invalid export height")
+	errInvalidAppState     = errors.New("invalid genesis app state")
+)
+
+type dbExportCfg struct {
+	dbCfg
+
+	genesisFile string
+	chainID     string
+	height      int64
+}
+
+// newDBExportCmd creates the db export command
+func newDBExportCmd(io commands.IO) *commands.Command {
+	cfg := &dbExportCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "export",
+			ShortUsage: "db export [flags] <output-path>",
+			ShortHelp:  "exports the chain up to a height as a tx replay genesis.json",
+			LongHelp: "Exports the chain up to the given height as a tx replay: a genesis-compatible file " +
+				"containing the original genesis balances, params and transactions, followed by every successful " +
+				"transaction committed up to the given height, along with its block timestamp. " +
+				"The validator set and consensus params are the ones in effect after the given height. " +
+				"The store state itself is not exported: it is rebuilt by replaying the transactions, " +
+				"so the effects of the block hooks are lost, or replayed at different heights. This includes " +
+				"the scheduled calls, the messages between realms, the random seeds, the validator set changes, " +
+				"the gas price updates and the vesting schedules, which are all relative to the block heights and times. " +
+				"The replayed state thus generally differs from the state at the given height. " +
+				"Exported block transactions keep their original signatures, so a node starting from the exported genesis " +
+				"needs to skip genesis signature verification",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execDBExport(cfg, args, io)
+		},
+	)
+}
+
+func (c *dbExportCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.dbCfg.RegisterFlags(fs)
+
+	fs.StringVar(
+		&c.genesisFile,
+		"genesis",
+		"genesis.json",
+		"the path to the node's genesis.json",
+	)
+
+	fs.StringVar(
+		&c.chainID,
+		"chainid",
+		"",
+		"the chain ID of the exported genesis, if different from the original one",
+	)
+
+	fs.Int64Var(
+		&c.height,
+		"height",
+		0,
+		"the height up to which to export the txs (latest if 0)",
+	)
+}
+
+func execDBExport(cfg *dbExportCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return errInvalidExportArgs
+	}
+
+	outputPath := args[0]
+
+	// Load the original genesis
+	genesis, err := bft.GenesisDocFromFile(cfg.genesisFile)
+	if err != nil {
+		return fmt.Errorf("unable to load genesis, %w", err)
+	}
+
+	appState, ok := genesis.AppState.(gnoland.GnoGenesisState)
+	if !ok {
+		return fmt.Errorf("%w: %T", errInvalidAppState, genesis.AppState)
+	}
+
+	dbs, err := openNodeDBs(cfg.dataDir)
+	if err != nil {
+		return err
+	}
+	defer dbs.close()
+
+	var (
+		bs    = store.NewBlockStore(dbs.blockStore)
+		state = sm.LoadState(dbs.state)
+	)
+
+	// Figure out the export height
+	height := cfg.height
+	if height == 0 {
+		height = state.LastBlockHeight
+	}
+
+	if height < 0 || height > state.LastBlockHeight {
+		return fmt.Errorf(
+			"%w: %d, last committed height is %d",
+			errInvalidExportHeight,
+			height,
+			state.LastBlockHeight,
+		)
+	}
+
+	// Gather the successful transactions in each block
+	txs := make([]gnoland.TxWithMetadata, 0, len(appState.Txs))
+	txs = append(txs, appState.Txs...)
+
+	for h := int64(1); h <= height; h++ {
+		blockTxs, err := loadSuccessfulBlockTxs(bs, dbs, h)
+		if err != nil {
+			return err
+		}
+
+		txs = append(txs, blockTxs...)
+	}
+
+	appState.Txs = txs
+
+	// Use the validator set and consensus params
+	// in effect for the block following the export height
+	validators, err := sm.LoadValidators(dbs.state, height+1)
+	if err != nil {
+		return fmt.Errorf("unable to load validators for height %d, %w", height+1, err)
+	}
+
+	params, err := sm.LoadConsensusParams(dbs.state, height+1)
+	if err != nil {
+		return fmt.Errorf("unable to load consensus params for height %d, %w", height+1, err)
+	}
+
+	exported := &bft.GenesisDoc{
+		GenesisTime:     genesis.GenesisTime,
+		ChainID:         genesis.ChainID,
+		ConsensusParams: params,
+		Validators:      exportValidators(validators, genesis.Validators),
+		AppHash:         genesis.AppHash,
+		AppState:        appState,
+	}
+
+	if cfg.chainID != "" {
+		exported.ChainID = cfg.chainID
+	}
+
+	if err := exported.ValidateAndComplete(); err != nil {
+		return fmt.Errorf("invalid exported genesis, %w", err)
+	}
+
+	if err := exported.SaveAs(outputPath); err != nil {
+		return fmt.Errorf("unable to save exported genesis, %w", err)
+	}
+
+	io.Printfln(
+		"Exported tx replay up to height %d (%d txs) to %q",
+		height,
+		len(appState.Txs),
+		outputPath,
+	)
+
+	return nil
+}
+
+// loadSuccessfulBlockTxs loads the transactions of the block at the given height,
+// omitting the ones that failed execution
+func loadSuccessfulBlockTxs(bs *store.BlockStore, dbs *nodeDBs, height int64) ([]gnoland.TxWithMetadata, error) {
+	block := bs.LoadBlock(height)
+	if block == nil {
+		return nil, sm.UnknownBlockError{Height: height}
+	}
+
+	if len(block.Txs) == 0 {
+		return nil, nil
+	}
+
+	responses, err := sm.LoadABCIResponses(dbs.state, height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load tx results for height %d, %w", height, err)
+	}
+
+	txs := make([]gnoland.TxWithMetadata, 0, len(block.Txs))
+
+	for i, encodedTx := range block.Txs {
+		if i < len(responses.DeliverTxs) && responses.DeliverTxs[i].IsErr() {
+			continue
+		}
+
+		var tx std.Tx
+		if err := amino.Unmarshal(encodedTx, &tx); err != nil {
+			return nil, fmt.Errorf("unable to decode tx %d at height %d, %w", i, height, err)
+		}
+
+		txs = append(txs, gnoland.TxWithMetadata{
+			Tx: tx,
+			Metadata: &gnoland.GnoTxMetadata{
+				Timestamp: block.Time.Unix(),
+			},
+		})
+	}
+
+	return txs, nil
+}
+
+// exportValidators converts the given validator set into genesis validators,
+// preserving the validator names from the original genesis
+func exportValidators(valSet *bft.ValidatorSet, original []bft.GenesisValidator) []bft.GenesisValidator {
+	names := make(map[string]string, len(original))
+	for _, val := range original {
+		names[val.Address.String()] = val.Name
+	}
+
+	validators := make([]bft.GenesisValidator, 0, valSet.Size())
+	for _, val := range valSet.Validators {
+		validators = append(validators, bft.GenesisValidator{
+			Address: val.Address,
+			PubKey:  val.PubKey,
+			Power:   val.VotingPower,
+			Name:    names[val.Address.String()],
+		})
+	}
+
+	return validators
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_Export(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		t.Parallel()
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"export",
+			"--data-dir",
+			t.TempDir(),
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errInvalidExportArgs)
+	})
+
+	t.Run("invalid height", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		data := generateNodeData(t, nodeDir, 3)
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"export",
+			"--data-dir",
+			nodeDir,
+			"--genesis",
+			data.genesisPath,
+			"--height",
+			"4",
+			filepath.Join(nodeDir, "exported.json"),
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errInvalidExportHeight)
+	})
+
+	t.Run("valid export", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			nodeDir    = t.TempDir()
+			data       = generateNodeData(t, nodeDir, 5)
+			outputPath = filepath.Join(nodeDir, "exported.json")
+		)
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"export",
+			"--data-dir",
+			nodeDir,
+			"--genesis",
+			data.genesisPath,
+			"--height",
+			"3",
+			"--chainid",
+			"exported",
+			outputPath,
+		}
+
+		// Run the command
+		require.NoError(t, cmd.ParseAndRun(context.Background(), args))
+
+		// Make sure the exported genesis is valid
+		exported, err := bft.GenesisDocFromFile(outputPath)
+		require.NoError(t, err)
+
+		assert.Equal(t, "exported", exported.ChainID)
+		require.Len(t, exported.Validators, 1)
+		assert.Equal(t, "validator", exported.Validators[0].Name)
+
+		// Make sure only the successful txs
+		// up until the export height are present
+		state, ok := exported.AppState.(gnoland.GnoGenesisState)
+		require.True(t, ok)
+		require.Len(t, state.Txs, 3)
+
+		for i, tx := range state.Txs {
+			height := int64(i + 1)
+
+			assert.Equal(t, data.txs[height][0].Memo, tx.Tx.Memo)
+
+			require.NotNil(t, tx.Metadata)
+			assert.Equal(t, data.states[height].LastBlockTime.Unix(), tx.Metadata.Timestamp)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+var (
+	errInvalidImportArgs = errors.New("invalid number of db import arguments provided")
+	errNodeStateExists   = errors.New("node state already exists, use --force to remove it")
+)
+
+type dbImportCfg struct {
+	dbCfg
+
+	genesisFile string
+	force       bool
+}
+
+// newDBImportCmd creates the db import command
+func newDBImportCmd(io commands.IO) *commands.Command {
+	cfg := &dbImportCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "import",
+			ShortUsage: "db import [flags] <genesis-path>",
+			ShortHelp:  "imports an exported tx replay into the node",
+			LongHelp: "Validates a tx replay genesis file produced by `db export`, and installs it as the node's genesis.json. " +
+				"The node databases must not exist, unless --force is specified, in which case " +
+				"they are removed along with the consensus WAL. The state is rebuilt from the imported genesis " +
+				"on the next node start",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execDBImport(cfg, args, io)
+		},
+	)
+}
+
+func (c *dbImportCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.dbCfg.RegisterFlags(fs)
+
+	fs.StringVar(
+		&c.genesisFile,
+		"genesis",
+		"genesis.json",
+		"the path to the node's genesis.json",
+	)
+
+	fs.BoolVar(
+		&c.force,
+		"force",
+		false,
+		"remove the existing node state, if any",
+	)
+}
+
+func execDBImport(cfg *dbImportCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return errInvalidImportArgs
+	}
+
+	// Load and validate the imported genesis
+	genesis, err := bft.GenesisDocFromFile(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to load imported genesis, %w", err)
+	}
+
+	if _, ok := genesis.AppState.(gnoland.GnoGenesisState); !ok {
+		return fmt.Errorf("%w: %T", errInvalidAppState, genesis.AppState)
+	}
+
+	nodeDir, err := filepath.Abs(cfg.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	nodeCfg, err := loadNodeConfig(nodeDir)
+	if err != nil {
+		return err
+	}
+
+	// Make sure there is no existing state,
+	// as it would not match the imported genesis
+	var (
+		walDir = filepath.Dir(nodeCfg.Consensus.WalFile())
+		paths  = []string{
+			dbPath(blockStoreDBName, nodeCfg.DBDir()),
+			dbPath(stateDBName, nodeCfg.DBDir()),
+			dbPath(appDBName, appDBDir(nodeDir)),
+			walDir,
+		}
+	)
+
+	for _, path := range paths {
+		if !osm.DirExists(path) && !osm.FileExists(path) {
+			continue
+		}
+
+		if !cfg.force {
+			return fmt.Errorf("%w: %q", errNodeStateExists, path)
+		}
+
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("unable to remove %q, %w", path, err)
+		}
+
+		io.Printfln("Removed %q", path)
+	}
+
+	if err := genesis.SaveAs(cfg.genesisFile); err != nil {
+		return fmt.Errorf("unable to save genesis, %w", err)
+	}
+
+	io.Printfln("Imported genesis for chain %q to %q", genesis.ChainID, cfg.genesisFile)
+
+	return nil
+}
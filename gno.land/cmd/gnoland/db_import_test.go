@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_Import(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		t.Parallel()
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"import",
+			"--data-dir",
+			t.TempDir(),
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errInvalidImportArgs)
+	})
+
+	t.Run("existing node state", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		data := generateNodeData(t, nodeDir, 1)
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"import",
+			"--data-dir",
+			nodeDir,
+			"--genesis",
+			filepath.Join(nodeDir, "imported.json"),
+			data.genesisPath,
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errNodeStateExists)
+	})
+
+	t.Run("forced import", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			nodeDir     = t.TempDir()
+			data        = generateNodeData(t, nodeDir, 1)
+			genesisPath = filepath.Join(nodeDir, "imported.json")
+		)
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"import",
+			"--data-dir",
+			nodeDir,
+			"--genesis",
+			genesisPath,
+			"--force",
+			data.genesisPath,
+		}
+
+		// Run the command
+		require.NoError(t, cmd.ParseAndRun(context.Background(), args))
+
+		// Make sure the node state is removed
+		assert.NoDirExists(t, dbPath(blockStoreDBName, filepath.Join(nodeDir, "db")))
+		assert.NoDirExists(t, dbPath(stateDBName, filepath.Join(nodeDir, "db")))
+		assert.NoDirExists(t, dbPath(appDBName, appDBDir(nodeDir)))
+
+		// Make sure the genesis is imported
+		imported, err := bft.GenesisDocFromFile(genesisPath)
+		require.NoError(t, err)
+
+		assert.Equal(t, testDBChainID, imported.ChainID)
+	})
+}
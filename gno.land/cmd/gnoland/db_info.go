@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
+)
+
+type dbInfoCfg struct {
+	dbCfg
+}
+
+// newDBInfoCmd creates the db info command
+func newDBInfoCmd(io commands.IO) *commands.Command {
+	cfg := &dbInfoCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "info",
+			ShortUsage: "db info [flags]",
+			ShortHelp:  "shows the heights and versions stored in the node databases",
+			LongHelp: "Shows the latest block store height, the latest consensus state " +
+				"and the latest committed version of each application store",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execDBInfo(cfg, io)
+		},
+	)
+}
+
+func (c *dbInfoCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.dbCfg.RegisterFlags(fs)
+}
+
+func execDBInfo(cfg *dbInfoCfg, io commands.IO) error {
+	dbs, err := openNodeDBs(cfg.dataDir)
+	if err != nil {
+		return err
+	}
+	defer dbs.close()
+
+	// Block store info
+	bsState := store.LoadBlockStoreStateJSON(dbs.blockStore)
+
+	io.Println("Block store:")
+	io.Printfln("  height: %d", bsState.Height)
+
+	// Consensus state info
+	state := sm.LoadState(dbs.state)
+
+	io.Println("Consensus state:")
+
+	if state.IsEmpty() {
+		io.Println("  (empty)")
+	} else {
+		io.Printfln("  chain ID: %s", state.ChainID)
+		io.Printfln("  last block height: %d", state.LastBlockHeight)
+		io.Printfln("  last block time: %s", state.LastBlockTime)
+		io.Printfln("  app hash: %X", state.AppHash)
+		io.Printfln("  app version: %s", state.AppVersion)
+	}
+
+	// Application stores info
+	latest := rootmulti.GetLatestVersion(dbs.app)
+
+	io.Println("Application:")
+	io.Printfln("  latest version: %d", latest)
+
+	if latest == 0 {
+		return nil
+	}
+
+	ids, err := rootmulti.GetStoreCommitIDs(dbs.app, latest)
+	if err != nil {
+		return fmt.Errorf("unable to load application commit info, %w", err)
+	}
+
+	names := make([]string, 0, len(ids))
+	for name := range ids {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		io.Printfln(
+			"  store %q: version %d, hash %X",
+			name,
+			ids[name].Version,
+			ids[name].Hash,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_Info(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing databases", func(t *testing.T) {
+		t.Parallel()
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"info",
+			"--data-dir",
+			t.TempDir(),
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errMissingDB)
+	})
+
+	t.Run("heights and versions shown", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		data := generateNodeData(t, nodeDir, 5)
+
+		mockOutput := bytes.NewBufferString("")
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(mockOutput))
+
+		// Create the command
+		cmd := newRootCmd(io)
+		args := []string{
+			"db",
+			"info",
+			"--data-dir",
+			nodeDir,
+		}
+
+		// Run the command
+		require.NoError(t, cmd.ParseAndRun(context.Background(), args))
+
+		output := mockOutput.String()
+
+		assert.Contains(t, output, "height: 5")
+		assert.Contains(t, output, "last block height: 5")
+		assert.Contains(t, output, "latest version: 5")
+		assert.Contains(t, output, fmt.Sprintf("app hash: %X", data.states[5].AppHash))
+		assert.Contains(t, output, fmt.Sprintf("store %q: version 5", "main"))
+	})
+}
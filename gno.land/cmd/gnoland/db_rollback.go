@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+var errInvalidRollbackHeights = errors.New("invalid number of rollback heights")
+
+type dbRollbackCfg struct {
+	dbCfg
+
+	heights int64
+}
+
+// newDBRollbackCmd creates the db rollback command
+func newDBRollbackCmd(io commands.IO) *commands.Command {
+	cfg := &dbRollbackCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "rollback",
+			ShortUsage: "db rollback [flags]",
+			ShortHelp:  "rolls back the node state by a number of heights",
+			LongHelp: "Rolls back the block store and the consensus state by the given number of heights, " +
+				"and removes the consensus WAL. The application state is not versioned as a whole, " +
+				"so it is removed and rebuilt on the next node start by replaying the genesis and the remaining blocks. " +
+				"Note that a validator will not sign again at heights it has already signed",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execDBRollback(cfg, io)
+		},
+	)
+}
+
+func (c *dbRollbackCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.dbCfg.RegisterFlags(fs)
+
+	fs.Int64Var(
+		&c.heights,
+		"n",
+		1,
+		"the number of heights to roll back",
+	)
+}
+
+func execDBRollback(cfg *dbRollbackCfg, io commands.IO) error {
+	if cfg.heights < 1 {
+		return fmt.Errorf("%w: %d", errInvalidRollbackHeights, cfg.heights)
+	}
+
+	dbs, err := openNodeDBs(cfg.dataDir)
+	if err != nil {
+		return err
+	}
+	defer dbs.close()
+
+	var (
+		bs    = store.NewBlockStore(dbs.blockStore)
+		state = sm.LoadState(dbs.state)
+
+		target = state.LastBlockHeight - cfg.heights
+	)
+
+	if target < 1 {
+		return fmt.Errorf(
+			"%w: %d, last committed height is %d",
+			errInvalidRollbackHeights,
+			cfg.heights,
+			state.LastBlockHeight,
+		)
+	}
+
+	// Roll back the consensus state first, as it relies
+	// on the block store headers above the target height
+	rolledBack, err := sm.Rollback(bs, dbs.state, target)
+	if err != nil {
+		return fmt.Errorf("unable to roll back consensus state, %w", err)
+	}
+
+	if err := bs.Rollback(target); err != nil {
+		return fmt.Errorf("unable to roll back block store, %w", err)
+	}
+
+	// The WAL contains consensus messages for heights
+	// that no longer exist, so it needs to be discarded
+	walDir := filepath.Dir(dbs.cfg.Consensus.WalFile())
+	if err := os.RemoveAll(walDir); err != nil {
+		return fmt.Errorf("unable to remove consensus WAL, %w", err)
+	}
+
+	// Remove the application state, so it is replayed
+	// from genesis up until the rolled back height
+	dbs.app.Close()
+	dbs.app = nil
+
+	appPath := dbPath(appDBName, appDBDir(dbs.cfg.RootDir))
+	if err := os.RemoveAll(appPath); err != nil {
+		return fmt.Errorf("unable to remove application state, %w", err)
+	}
+
+	io.Printfln(
+		"Rolled back state to height %d, app hash %X",
+		rolledBack.LastBlockHeight,
+		rolledBack.AppHash,
+	)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB_Rollback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid number of heights", func(t *testing.T) {
+		t.Parallel()
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"rollback",
+			"--data-dir",
+			t.TempDir(),
+			"-n",
+			"0",
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errInvalidRollbackHeights)
+	})
+
+	t.Run("rollback past genesis", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		generateNodeData(t, nodeDir, 3)
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"rollback",
+			"--data-dir",
+			nodeDir,
+			"-n",
+			"3",
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, cmdErr, errInvalidRollbackHeights)
+	})
+
+	t.Run("valid rollback", func(t *testing.T) {
+		t.Parallel()
+
+		nodeDir := t.TempDir()
+		data := generateNodeData(t, nodeDir, 5)
+
+		cfg := config.DefaultConfig().SetRootDir(nodeDir)
+
+		// Create a dummy WAL
+		walFile := cfg.Consensus.WalFile()
+		require.NoError(t, osm.EnsureDir(filepath.Dir(walFile), 0o755))
+		require.NoError(t, os.WriteFile(walFile, []byte("wal"), 0o644))
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"db",
+			"rollback",
+			"--data-dir",
+			nodeDir,
+			"-n",
+			"2",
+		}
+
+		// Run the command
+		require.NoError(t, cmd.ParseAndRun(context.Background(), args))
+
+		// Make sure the WAL and the application state are removed
+		assert.NoFileExists(t, walFile)
+		assert.NoDirExists(t, dbPath(appDBName, appDBDir(nodeDir)))
+
+		// Make sure the block store is rolled back
+		blockDB, err := dbm.NewDB(blockStoreDBName, dbm.GoLevelDBBackend, cfg.DBDir())
+		require.NoError(t, err)
+		defer blockDB.Close()
+
+		bs := store.NewBlockStore(blockDB)
+		assert.Equal(t, int64(3), bs.Height())
+		assert.Nil(t, bs.LoadBlock(4))
+
+		// Make sure the state is rolled back
+		stateDB, err := dbm.NewDB(stateDBName, dbm.GoLevelDBBackend, cfg.DBDir())
+		require.NoError(t, err)
+		defer stateDB.Close()
+
+		assert.True(t, data.states[3].Equals(sm.LoadState(stateDB)))
+	})
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	tmstore "github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/stretchr/testify/require"
+)
+
+const testDBChainID = "db-test"
+
+// testNodeData is the node data generated by generateNodeData
+type testNodeData struct {
+	genesisPath string
+	states      map[int64]sm.State // states by height
+	txs         map[int64][]std.Tx // successful txs by height
+}
+
+// generateNodeData generates the block store, consensus state and application
+// databases of a single-validator node that committed the given number of blocks,
+// each containing a successful and a failing tx
+func generateNodeData(t *testing.T, nodeDir string, height int64) *testNodeData {
+	t.Helper()
+
+	cfg := config.DefaultConfig().SetRootDir(nodeDir)
+
+	blockDB, err := dbm.NewDB(blockStoreDBName, dbm.GoLevelDBBackend, cfg.DBDir())
+	require.NoError(t, err)
+	defer blockDB.Close()
+
+	stateDB, err := dbm.NewDB(stateDBName, dbm.GoLevelDBBackend, cfg.DBDir())
+	require.NoError(t, err)
+	defer stateDB.Close()
+
+	appDB, err := dbm.NewDB(appDBName, dbm.GoLevelDBBackend, appDBDir(nodeDir))
+	require.NoError(t, err)
+	defer appDB.Close()
+
+	// Generate the genesis
+	var (
+		key     = ed25519.GenPrivKey()
+		address = key.PubKey().Address()
+	)
+
+	genesis := &bft.GenesisDoc{
+		GenesisTime: time.Unix(1_000_000, 0).UTC(),
+		ChainID:     testDBChainID,
+		Validators: []bft.GenesisValidator{
+			{
+				Address: address,
+				PubKey:  key.PubKey(),
+				Power:   10,
+				Name:    "validator",
+			},
+		},
+		AppState: gnoland.DefaultGenState(),
+	}
+
+	data := &testNodeData{
+		genesisPath: filepath.Join(nodeDir, "genesis.json"),
+		states:      make(map[int64]sm.State),
+		txs:         make(map[int64][]std.Tx),
+	}
+
+	require.NoError(t, genesis.SaveAs(data.genesisPath))
+
+	state, err := sm.MakeGenesisState(genesis)
+	require.NoError(t, err)
+
+	sm.SaveState(stateDB, state)
+
+	// Set up the application store
+	mainKey := tmstore.NewStoreKey("main")
+
+	ms := tmstore.NewCommitMultiStore(appDB)
+	ms.MountStoreWithDB(mainKey, iavl.StoreConstructor, appDB)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	var (
+		bs         = store.NewBlockStore(blockDB)
+		lastCommit = bft.NewCommit(bft.BlockID{}, nil)
+	)
+
+	for h := int64(1); h <= height; h++ {
+		// Generate a successful and a failing tx
+		var (
+			okTx = generateSendTx(h)
+			koTx = generateSendTx(-h)
+		)
+
+		txs := []bft.Tx{
+			amino.MustMarshal(okTx),
+			amino.MustMarshal(koTx),
+		}
+
+		block := bft.MakeBlock(h, txs, lastCommit)
+		block.Header.Populate(
+			state.ChainID,
+			state.LastBlockTime.Add(time.Second),
+			state.LastBlockID,
+			state.LastBlockTotalTx+block.NumTxs,
+			state.AppVersion,
+			state.Validators.Hash(),
+			state.NextValidators.Hash(),
+			state.ConsensusParams.Hash(),
+			state.AppHash,
+			state.LastResultsHash,
+			address,
+		)
+
+		parts := block.MakePartSet(bft.BlockPartSizeBytes)
+		blockID := bft.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		seenCommit := bft.NewCommit(blockID, nil)
+
+		bs.SaveBlock(block, parts, seenCommit)
+
+		// Save the results
+		sm.SaveABCIResponses(stateDB, h, &sm.ABCIResponses{
+			DeliverTxs: []abci.ResponseDeliverTx{
+				{},
+				{ResponseBase: abci.ResponseBase{Error: abci.StringError("failed")}},
+			},
+		})
+
+		// Commit the application state
+		ms.GetStore(mainKey).Set([]byte(fmt.Sprintf("height-%d", h)), []byte("value"))
+		commitID := ms.Commit()
+
+		// Save the new state
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastBlockTotalTx = block.TotalTxs
+		state.LastValidators = state.Validators.Copy()
+		state.AppHash = commitID.Hash
+
+		sm.SaveState(stateDB, state)
+
+		data.states[h] = state.Copy()
+		data.txs[h] = []std.Tx{okTx}
+		lastCommit = seenCommit
+	}
+
+	return data
+}
+
+// generateSendTx generates a unique send tx, using the given seed
+func generateSendTx(seed int64) std.Tx {
+	var (
+		from = ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("from-%d", seed))).PubKey().Address()
+		to   = ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("to-%d", seed))).PubKey().Address()
+	)
+
+	return std.Tx{
+		Msgs: []std.Msg{
+			bank.NewMsgSend(from, to, std.NewCoins(std.NewCoin(ugnot.Denom, 10))),
+		},
+		Fee:  std.NewFee(50000, std.MustParseCoin(ugnot.ValueString(1000000))),
+		Memo: fmt.Sprintf("tx-%d", seed),
+	}
+}
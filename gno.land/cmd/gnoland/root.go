@@ -27,6 +27,8 @@ func newRootCmd(io commands.IO) *commands.Command {
 		newStartCmd(io),
 		newSecretsCmd(io),
 		newConfigCmd(io),
+		newDBCmd(io),
+		newSignerCmd(io),
 	)
 
 	return cmd
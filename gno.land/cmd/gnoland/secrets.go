@@ -18,6 +18,8 @@ const (
 	defaultValidatorKeyName   = "priv_validator_key.json"
 	defaultNodeKeyName        = "node_key.json"
 	defaultValidatorStateName = "priv_validator_state.json"
+
+	defaultValidatorSignStateName = "priv_validator_sign_state.json"
 )
 
 const (
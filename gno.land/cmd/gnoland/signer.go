@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/bft/privval"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	errMissingRemoteAddr = errors.New("missing remote node address")
+	errInvalidLeaseTTL   = errors.New("invalid signing lease TTL")
+)
+
+type signerCfg struct {
+	commonAllCfg
+
+	remoteAddr  string
+	chainID     string
+	signState   string
+	leaseFile   string
+	leaseHolder string
+	leaseTTL    time.Duration
+	logLevel    string
+	logFormat   string
+}
+
+// newSignerCmd creates the remote signer command
+func newSignerCmd(io commands.IO) *commands.Command {
+	cfg := &signerCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "signer",
+			ShortUsage: "signer [flags]",
+			ShortHelp:  "runs a remote signer for a gnoland validator node",
+			LongHelp: "Runs a remote signer, serving the validator private key to the node listening " +
+				"on -remote (the node's priv_validator_laddr). Every signature is checked against a " +
+				"height/round/step high-water mark, durably saved in the -sign-state file before it is " +
+				"handed out, and conflicting sign requests are refused.\n\n" +
+				"For an active / standby pair of signers, point both to the same -sign-state and -lease-file " +
+				"on shared storage, with a different -lease-holder: only the holder of the lease signs, " +
+				"and the standby takes over once the lease expires.",
+		},
+		cfg,
+		func(ctx context.Context, _ []string) error {
+			return execSigner(ctx, cfg, io)
+		},
+	)
+}
+
+func (c *signerCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.commonAllCfg.RegisterFlags(fs)
+
+	fs.StringVar(
+		&c.remoteAddr,
+		"remote",
+		"",
+		"the TCP or UNIX socket address of the node (its priv_validator_laddr)",
+	)
+
+	fs.StringVar(
+		&c.chainID,
+		"chainid",
+		"dev",
+		"the ID of the chain",
+	)
+
+	fs.StringVar(
+		&c.signState,
+		"sign-state",
+		"",
+		fmt.Sprintf("the path to the sign state file (default <data-dir>/%s)", defaultValidatorSignStateName),
+	)
+
+	fs.StringVar(
+		&c.leaseFile,
+		"lease-file",
+		"",
+		"the path to the signing lease file, shared with the standby signer. Disabled if empty",
+	)
+
+	fs.StringVar(
+		&c.leaseHolder,
+		"lease-holder",
+		defaultMoniker(),
+		"the ID of this signer in the signing lease",
+	)
+
+	fs.DurationVar(
+		&c.leaseTTL,
+		"lease-ttl",
+		15*time.Second,
+		"the duration of the signing lease, renewed while signing",
+	)
+
+	fs.StringVar(
+		&c.logLevel,
+		"log-level",
+		zapcore.InfoLevel.String(),
+		"log level for the signer",
+	)
+
+	fs.StringVar(
+		&c.logFormat,
+		"log-format",
+		log.ConsoleFormat.String(),
+		"log format for the signer",
+	)
+}
+
+func execSigner(ctx context.Context, c *signerCfg, io commands.IO) error {
+	// Make sure the secrets directory is there
+	if c.dataDir == "" || !isValidDirectory(c.dataDir) {
+		return errInvalidDataDir
+	}
+
+	if c.remoteAddr == "" {
+		return errMissingRemoteAddr
+	}
+
+	if c.leaseFile != "" && c.leaseTTL <= 0 {
+		return errInvalidLeaseTTL
+	}
+
+	// Initialize the logger
+	zapLogger, err := initializeLogger(io.Out(), c.logLevel, c.logFormat)
+	if err != nil {
+		return fmt.Errorf("unable to initialize zap logger, %w", err)
+	}
+
+	defer func() {
+		// Sync the logger before exiting
+		_ = zapLogger.Sync()
+	}()
+
+	logger := log.ZapLoggerToSlog(zapLogger)
+
+	// Load the validator key, and guard it with the high-water mark
+	filePV := privval.LoadFilePV(
+		filepath.Join(c.dataDir, defaultValidatorKeyName),
+		filepath.Join(c.dataDir, defaultValidatorStateName),
+	)
+
+	signStatePath := c.signState
+	if signStatePath == "" {
+		signStatePath = filepath.Join(c.dataDir, defaultValidatorSignStateName)
+	}
+
+	var opts []privval.GuardedOption
+
+	if c.leaseFile != "" {
+		opts = append(opts, privval.WithSigningLease(
+			privval.NewFileLeaseStore(c.leaseFile),
+			c.leaseHolder,
+			c.leaseTTL,
+		))
+	}
+
+	guardedPV, err := privval.NewGuardedPrivValidator(
+		filePV,
+		privval.NewFileSignStateStore(signStatePath),
+		opts...,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to load the guarded signer, %w", err)
+	}
+
+	// Dial the node
+	var dialer privval.SocketDialer
+
+	protocol, address := osm.ProtocolAndAddress(c.remoteAddr)
+	switch protocol {
+	case "unix":
+		dialer = privval.DialUnixFn(address)
+	case "tcp":
+		dialer = privval.DialTCPFn(address, 3*time.Second, ed25519.GenPrivKey())
+	default:
+		return fmt.Errorf("unsupported remote protocol %q", protocol)
+	}
+
+	server := privval.NewSignerServer(
+		privval.NewSignerDialerEndpoint(logger, dialer),
+		c.chainID,
+		guardedPV,
+	)
+
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("unable to start the signer, %w", err)
+	}
+
+	logger.Info(
+		"Signer started",
+		"remote", c.remoteAddr,
+		"address", guardedPV.GetPubKey().Address(),
+		"sign_state", signStatePath,
+		"lease", c.leaseFile,
+	)
+
+	// Wait for the exit signal
+	signerCtx, cancel := signal.NotifyContext(
+		ctx,
+		os.Interrupt,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	)
+	defer cancel()
+
+	<-signerCtx.Done()
+
+	if err := server.Stop(); err != nil {
+		logger.Error("unable to gracefully stop the signer", "err", err)
+	}
+
+	// Let the standby signer take over right away
+	if err := guardedPV.ReleaseLease(); err != nil {
+		return fmt.Errorf("unable to release the signing lease, %w", err)
+	}
+
+	return nil
+}
+
+// defaultMoniker returns the host name, used as the default lease holder ID
+func defaultMoniker() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "signer"
+	}
+
+	return hostname
+}
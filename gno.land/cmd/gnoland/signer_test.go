@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/privval"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid data directory", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"signer",
+			"--data-dir",
+			"",
+		}
+
+		assert.ErrorIs(t, cmd.ParseAndRun(context.Background(), args), errInvalidDataDir)
+	})
+
+	t.Run("missing remote address", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"signer",
+			"--data-dir",
+			t.TempDir(),
+		}
+
+		assert.ErrorIs(t, cmd.ParseAndRun(context.Background(), args), errMissingRemoteAddr)
+	})
+
+	t.Run("guarded signing with a lease", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			dataDir    = t.TempDir()
+			sharedDir  = t.TempDir()
+			signState  = filepath.Join(sharedDir, "sign_state.json")
+			leaseFile  = filepath.Join(sharedDir, "lease.json")
+			remoteAddr = "unix://" + filepath.Join(t.TempDir(), "pv.sock")
+			chainID    = "test"
+		)
+
+		// Generate the validator secrets
+		require.NoError(t, newRootCmd(commands.NewTestIO()).ParseAndRun(
+			context.Background(),
+			[]string{"secrets", "init", "--data-dir", dataDir},
+		))
+
+		// Listen for the signer, as the node does
+		listener, err := privval.NewSignerListener(remoteAddr, log.NewNoopLogger())
+		require.NoError(t, err)
+
+		client, err := privval.NewSignerClient(listener)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+
+		// Run the signer
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(new(bytes.Buffer)))
+
+		doneCh := make(chan error)
+
+		go func() {
+			doneCh <- newRootCmd(io).ParseAndRun(ctx, []string{
+				"signer",
+				"--data-dir", dataDir,
+				"--remote", remoteAddr,
+				"--chainid", chainID,
+				"--sign-state", signState,
+				"--lease-file", leaseFile,
+				"--lease-holder", "active",
+			})
+		}()
+
+		require.NoError(t, client.WaitForConnection(5*time.Second))
+
+		vote := &types.Vote{
+			Type:             types.PrevoteType,
+			Height:           1,
+			ValidatorAddress: client.GetPubKey().Address(),
+			Timestamp:        time.Now().UTC(),
+			BlockID:          types.BlockID{Hash: []byte("block hash")},
+		}
+		require.NoError(t, client.SignVote(chainID, vote))
+		assert.NotEmpty(t, vote.Signature)
+
+		// A conflicting vote for the same height/round/step is refused
+		conflicting := vote.Copy()
+		conflicting.BlockID = types.BlockID{Hash: []byte("other block hash")}
+		assert.Error(t, client.SignVote(chainID, conflicting))
+
+		// The high-water mark was saved
+		state, err := privval.NewFileSignStateStore(signState).Load()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), state.Height)
+
+		// The lease is held by the signer
+		leases := privval.NewFileLeaseStore(leaseFile)
+		_, err = leases.Acquire("standby", time.Now(), time.Second)
+		assert.ErrorIs(t, err, privval.ErrLeaseHeld)
+
+		// Stopping the signer releases the lease
+		cancel()
+
+		select {
+		case err := <-doneCh:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the signer to stop")
+		}
+
+		_, err = leases.Acquire("standby", time.Now(), time.Second)
+		assert.NoError(t, err)
+	})
+}
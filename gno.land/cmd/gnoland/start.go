@@ -240,6 +240,7 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 	// Create a top-level shared event switch
 	evsw := events.NewEventSwitch()
 	minGasPrices := cfg.Application.MinGasPrices
+	parallelTxs := cfg.Application.ParallelTxs
 
 	// Create application and node
 	cfg.LocalApp, err = gnoland.NewApp(
@@ -251,6 +252,7 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 		evsw,
 		logger,
 		minGasPrices,
+		parallelTxs,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create the Gnoland app, %w", err)
@@ -0,0 +1,340 @@
+// Package bindgen generates typed Go bindings for the exported functions of a
+// realm, on top of gnoclient.
+//
+// For each exported function Foo of the realm, the generated binding type has:
+//
+//   - FooMsg, returning the vm.MsgCall calling Foo, to be broadcast with
+//     gnoclient.Client.Call, possibly with other messages;
+//   - Foo, calling Foo in a transaction signed by the client's signer, and
+//     decoding its results;
+//   - EvalFoo, evaluating Foo in a read-only query and decoding its results,
+//     if Foo returns results and only has arguments of primitive types or
+//     byte slices.
+//
+// The signatures of the functions are either queried from a node (vm/qfuncs),
+// or read from the source of the realm.
+package bindgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+
+	_ "embed"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+)
+
+//go:embed template.tmpl
+var templateText string
+
+var bindingTemplate = template.Must(template.New("").Parse(templateText))
+
+var ErrNoFuncs = errors.New("no exported functions")
+
+// Config configures the generated bindings.
+type Config struct {
+	// PkgPath is the path of the realm, like gno.land/r/demo/counter.
+	PkgPath string
+
+	// Package is the name of the generated Go package.
+	// Defaults to the last element of PkgPath.
+	Package string
+
+	// TypeName is the name of the generated binding type.
+	// Defaults to the capitalized last element of PkgPath.
+	TypeName string
+}
+
+// Generate returns the formatted Go source of the bindings for the functions
+// fsigs of the realm.
+func Generate(cfg Config, fsigs vm.FunctionSignatures) ([]byte, error) {
+	if cfg.PkgPath == "" {
+		return nil, errors.New("missing realm path")
+	}
+	if len(fsigs) == 0 {
+		return nil, ErrNoFuncs
+	}
+
+	name := identifier(path.Base(cfg.PkgPath))
+	if cfg.Package == "" {
+		cfg.Package = strings.ToLower(name)
+	}
+	if cfg.TypeName == "" {
+		cfg.TypeName = capitalize(name)
+	}
+	if !token.IsIdentifier(cfg.Package) {
+		return nil, fmt.Errorf("invalid package name %q", cfg.Package)
+	}
+	if !token.IsIdentifier(cfg.TypeName) || !token.IsExported(cfg.TypeName) {
+		return nil, fmt.Errorf("invalid type name %q", cfg.TypeName)
+	}
+
+	data := templateData{Config: cfg}
+
+	// Generated methods must not collide
+	methods := map[string]struct{}{"caller": {}}
+	declare := func(method string) error {
+		if _, ok := methods[method]; ok {
+			return fmt.Errorf("duplicate method %s", method)
+		}
+		methods[method] = struct{}{}
+		return nil
+	}
+
+	for _, fsig := range fsigs {
+		fn := newFunc(fsig)
+
+		if err := declare(fn.Name); err != nil {
+			return nil, err
+		}
+		if err := declare(fn.Name + "Msg"); err != nil {
+			return nil, err
+		}
+		if fn.Evaluable {
+			if err := declare("Eval" + fn.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, res := range fn.Results {
+			if res.EvalType == "json.RawMessage" && fn.Evaluable {
+				data.ImportJSON = true
+			}
+		}
+
+		data.Funcs = append(data.Funcs, fn)
+	}
+
+	var buf bytes.Buffer
+	if err := bindingTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format bindings, %w", err)
+	}
+	return src, nil
+}
+
+type templateData struct {
+	Config
+	ImportJSON bool
+	Funcs      []function
+}
+
+type function struct {
+	Name      string
+	Signature string // gno signature, for documentation
+	Params    []param
+	Results   []result
+	Evaluable bool // can be called with EvalExpr
+
+	PrintedResults bool // some results of the call are returned as printed
+}
+
+type param struct {
+	Name    string // Go identifier
+	GnoType string
+	GoType  string
+}
+
+type result struct {
+	Name     string // Go identifier
+	GnoType  string
+	CallType string // Go type decoded from the MsgCall results
+	EvalType string // Go type decoded from the qevaljson results
+}
+
+// reservedNames are used by the generated methods, or are imported packages,
+// and can't be used as parameter names.
+var reservedNames = map[string]struct{}{
+	"r": {}, "cfg": {}, "caller": {}, "msg": {}, "args": {}, "expr": {},
+	"out": {}, "res": {}, "err": {},
+	"gnoclient": {}, "vm": {}, "ctypes": {}, "crypto": {}, "json": {},
+}
+
+var resultName = regexp.MustCompile(`^res\d+$`)
+
+func newFunc(fsig vm.FunctionSignature) function {
+	fn := function{
+		Name:      fsig.FuncName,
+		Signature: signature(fsig),
+		Evaluable: len(fsig.Results) > 0,
+	}
+
+	for i, p := range fsig.Params {
+		name := p.Name
+		switch _, reserved := reservedNames[name]; {
+		case name == "" || name == "_":
+			name = fmt.Sprintf("arg%d", i)
+		case reserved, resultName.MatchString(name), token.IsKeyword(name):
+			name += "_"
+		}
+
+		typ := newGnoType(p.Type)
+		if !typ.evaluable() {
+			fn.Evaluable = false
+		}
+
+		fn.Params = append(fn.Params, param{
+			Name:    name,
+			GnoType: p.Type,
+			GoType:  typ.argType(),
+		})
+	}
+
+	for i, r := range fsig.Results {
+		typ := newGnoType(r.Type)
+		if !typ.primitive() {
+			fn.PrintedResults = true
+		}
+		fn.Results = append(fn.Results, result{
+			Name:     fmt.Sprintf("res%d", i),
+			GnoType:  r.Type,
+			CallType: typ.callResultType(),
+			EvalType: typ.evalResultType(),
+		})
+	}
+
+	return fn
+}
+
+// signature returns the gno signature of fsig, like "Transfer(to string, amount uint64) bool".
+func signature(fsig vm.FunctionSignature) string {
+	var sb strings.Builder
+	sb.WriteString(fsig.FuncName)
+	writeTypes := func(types []vm.NamedType, names bool) {
+		for i, nt := range types {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if names && nt.Name != "" && nt.Name != "_" {
+				sb.WriteString(nt.Name + " ")
+			}
+			sb.WriteString(nt.Type)
+		}
+	}
+
+	sb.WriteByte('(')
+	writeTypes(fsig.Params, true)
+	sb.WriteByte(')')
+
+	switch len(fsig.Results) {
+	case 0:
+	case 1:
+		sb.WriteByte(' ')
+		writeTypes(fsig.Results, false)
+	default:
+		sb.WriteString(" (")
+		writeTypes(fsig.Results, false)
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
+
+// gnoType is the string representation of a gno type, as returned by qfuncs.
+type gnoType string
+
+var primitiveTypes = map[gnoType]struct{}{
+	"bool": {}, "string": {},
+	"int": {}, "int8": {}, "int16": {}, "int32": {}, "int64": {},
+	"uint": {}, "uint8": {}, "uint16": {}, "uint32": {}, "uint64": {},
+	"float32": {}, "float64": {},
+}
+
+var arrayType = regexp.MustCompile(`^\[\d*\]`)
+
+func newGnoType(s string) gnoType {
+	return gnoType(strings.TrimSpace(s))
+}
+
+func (t gnoType) primitive() bool {
+	_, ok := primitiveTypes[t]
+	return ok
+}
+
+func (t gnoType) bytes() bool {
+	return t == "[]uint8"
+}
+
+func (t gnoType) evaluable() bool {
+	return t.primitive() || t.bytes()
+}
+
+// goType returns the Go type equivalent to t when encoded as JSON, or an
+// empty string if it has none.
+func (t gnoType) goType() string {
+	switch {
+	case t.primitive():
+		return string(t)
+	case t.bytes():
+		return "[]byte"
+	}
+
+	s := string(t)
+	if prefix := arrayType.FindString(s); prefix != "" {
+		if elt := gnoType(s[len(prefix):]).goType(); elt != "" {
+			return prefix + elt
+		}
+		return ""
+	}
+	if rest, ok := strings.CutPrefix(s, "map["); ok {
+		// Only maps with primitive keys, which don't contain brackets
+		key, value, ok := strings.Cut(rest, "]")
+		if !ok || !gnoType(key).primitive() {
+			return ""
+		}
+		if elt := gnoType(value).goType(); elt != "" {
+			return "map[" + key + "]" + elt
+		}
+	}
+	return ""
+}
+
+func (t gnoType) argType() string {
+	if typ := t.goType(); typ != "" {
+		return typ
+	}
+	return "any"
+}
+
+func (t gnoType) callResultType() string {
+	if t.primitive() {
+		return string(t)
+	}
+	return "string" // as printed
+}
+
+func (t gnoType) evalResultType() string {
+	if typ := t.goType(); typ != "" {
+		return typ
+	}
+	return "json.RawMessage"
+}
+
+// identifier strips the characters of s which can't be used in an identifier.
+func identifier(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "realm" + s
+	}
+	return s
+}
+
+func capitalize(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,177 @@
+package bindgen
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+var update = flag.Bool("update", false, "update the generated bindings of the counter realm")
+
+const counterPkgPath = "gno.land/r/demo/counter"
+
+func counterFuncs(t *testing.T) vm.FunctionSignatures {
+	t.Helper()
+
+	mpkg, err := gno.ReadMemPackage(filepath.Join("testdata", "counter"), counterPkgPath)
+	require.NoError(t, err)
+
+	fsigs, err := FuncsFromMemPackage(mpkg)
+	require.NoError(t, err)
+
+	return fsigs
+}
+
+func TestFuncsFromMemPackage(t *testing.T) {
+	t.Parallel()
+
+	fsigs := counterFuncs(t)
+
+	expected := vm.FunctionSignatures{
+		{
+			FuncName: "Incr",
+			Params:   []vm.NamedType{{Name: "name", Type: "string"}, {Name: "delta", Type: "uint64"}},
+			Results:  []vm.NamedType{{Name: "_", Type: "int"}},
+		},
+		{
+			FuncName: "Get",
+			Params:   []vm.NamedType{{Name: "name", Type: "string"}},
+			Results:  []vm.NamedType{{Name: "_", Type: "int"}, {Name: "_", Type: "bool"}},
+		},
+		{
+			FuncName: "GetCounter",
+			Params:   []vm.NamedType{{Name: "name", Type: "string"}},
+			Results:  []vm.NamedType{{Name: "_", Type: "*struct{Name string; Value int}"}},
+		},
+		{
+			FuncName: "Names",
+			Results:  []vm.NamedType{{Name: "_", Type: "[]string"}},
+		},
+		{
+			FuncName: "SetAll",
+			Params:   []vm.NamedType{{Name: "values", Type: "map[string]int"}},
+		},
+		{
+			FuncName: "Reset",
+			Params: []vm.NamedType{
+				{Name: "addr", Type: "string"},
+				{Name: "name", Type: "string"},
+				{Name: "_", Type: "[]uint8"},
+			},
+		},
+		{
+			FuncName: "Render",
+			Params:   []vm.NamedType{{Name: "path", Type: "string"}},
+			Results:  []vm.NamedType{{Name: "_", Type: "string"}},
+		},
+	}
+	assert.Equal(t, expected, fsigs)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	src, err := Generate(Config{PkgPath: counterPkgPath}, counterFuncs(t))
+	require.NoError(t, err)
+
+	// The generated bindings are compiled and tested in internal/counter
+	path := filepath.Join("internal", "counter", "counter.gen.go")
+	if *update {
+		require.NoError(t, os.WriteFile(path, src, 0o644))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src))
+}
+
+func TestGenerate_Names(t *testing.T) {
+	t.Parallel()
+
+	fsigs := vm.FunctionSignatures{
+		{
+			FuncName: "Do",
+			Params: []vm.NamedType{
+				{Name: "err", Type: "string"},
+				{Name: "_", Type: "int"},
+				{Name: "res0", Type: "bool"},
+				{Name: "crypto", Type: "string"},
+			},
+		},
+	}
+
+	src, err := Generate(Config{PkgPath: "gno.land/r/demo/my-realm"}, fsigs)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(src), "package myrealm")
+	assert.Contains(t, string(src), "type Myrealm struct")
+	assert.Contains(t, string(src), "func (r *Myrealm) Do(cfg gnoclient.BaseTxCfg, err_ string, arg1 int, res0_ bool, crypto_ string)")
+
+	src, err = Generate(Config{
+		PkgPath:  "gno.land/r/demo/my-realm",
+		Package:  "bindings",
+		TypeName: "MyRealm",
+	}, fsigs)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(src), "package bindings")
+	assert.Contains(t, string(src), "type MyRealm struct")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	t.Parallel()
+
+	fsigs := vm.FunctionSignatures{{FuncName: "Foo"}}
+
+	testTable := []struct {
+		name  string
+		cfg   Config
+		fsigs vm.FunctionSignatures
+		err   string
+	}{
+		{
+			name:  "missing realm path",
+			fsigs: fsigs,
+			err:   "missing realm path",
+		},
+		{
+			name: "no functions",
+			cfg:  Config{PkgPath: counterPkgPath},
+			err:  ErrNoFuncs.Error(),
+		},
+		{
+			name:  "invalid type name",
+			cfg:   Config{PkgPath: counterPkgPath, TypeName: "counter"},
+			fsigs: fsigs,
+			err:   `invalid type name "counter"`,
+		},
+		{
+			name:  "invalid package name",
+			cfg:   Config{PkgPath: counterPkgPath, Package: "my-counter"},
+			fsigs: fsigs,
+			err:   `invalid package name "my-counter"`,
+		},
+		{
+			name:  "duplicate method",
+			cfg:   Config{PkgPath: counterPkgPath},
+			fsigs: vm.FunctionSignatures{{FuncName: "Foo"}, {FuncName: "FooMsg"}},
+			err:   "duplicate method FooMsg",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Generate(testCase.cfg, testCase.fsigs)
+			assert.EqualError(t, err, testCase.err)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+// Code generated by gnoclient/bindgen from gno.land/r/demo/counter; DO NOT EDIT.
+
+package counter
+
+import (
+	"encoding/json"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoclient"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// CounterPkgPath is the path of the realm bound by Counter.
+const CounterPkgPath = "gno.land/r/demo/counter"
+
+// Counter calls the exported functions of the gno.land/r/demo/counter realm.
+type Counter struct {
+	Client  *gnoclient.Client
+	PkgPath string // path of the realm, CounterPkgPath by default
+}
+
+// NewCounter returns the bindings of the gno.land/r/demo/counter realm, using client.
+func NewCounter(client *gnoclient.Client) *Counter {
+	return &Counter{
+		Client:  client,
+		PkgPath: CounterPkgPath,
+	}
+}
+
+// caller returns the address of the client's signer.
+func (r *Counter) caller() (crypto.Address, error) {
+	if r.Client.Signer == nil {
+		return crypto.Address{}, gnoclient.ErrMissingSigner
+	}
+	info, err := r.Client.Signer.Info()
+	if err != nil {
+		return crypto.Address{}, err
+	}
+	return info.GetAddress(), nil
+}
+
+// IncrMsg returns the message calling Incr(name string, delta uint64) int, sent by caller.
+func (r *Counter) IncrMsg(caller crypto.Address, name string, delta uint64) (vm.MsgCall, error) {
+	args, err := gnoclient.EncodeArgs(name, delta)
+	if err != nil {
+		return vm.MsgCall{}, err
+	}
+	return vm.MsgCall{
+		Caller:  caller,
+		PkgPath: r.PkgPath,
+		Func:    "Incr",
+		Args:    args,
+	}, nil
+}
+
+// Incr calls Incr(name string, delta uint64) int in a transaction signed by the client's signer.
+func (r *Counter) Incr(cfg gnoclient.BaseTxCfg, name string, delta uint64) (res0 int, res *ctypes.ResultBroadcastTxCommit, err error) {
+	caller, err := r.caller()
+	if err != nil {
+		return
+	}
+	msg, err := r.IncrMsg(caller, name, delta)
+	if err != nil {
+		return
+	}
+	res, err = r.Client.Call(cfg, msg)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeResults(string(res.DeliverTx.Data), &res0)
+	return
+}
+
+// EvalIncr evaluates Incr(name string, delta uint64) int in a read-only query.
+func (r *Counter) EvalIncr(name string, delta uint64) (res0 int, err error) {
+	expr, err := gnoclient.EvalExpr("Incr", name, delta)
+	if err != nil {
+		return
+	}
+	out, _, err := r.Client.QEvalJSON(r.PkgPath, expr)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeEvalJSON(out, &res0)
+	return
+}
+
+// GetMsg returns the message calling Get(name string) (int, bool), sent by caller.
+func (r *Counter) GetMsg(caller crypto.Address, name string) (vm.MsgCall, error) {
+	args, err := gnoclient.EncodeArgs(name)
+	if err != nil {
+		return vm.MsgCall{}, err
+	}
+	return vm.MsgCall{
+		Caller:  caller,
+		PkgPath: r.PkgPath,
+		Func:    "Get",
+		Args:    args,
+	}, nil
+}
+
+// Get calls Get(name string) (int, bool) in a transaction signed by the client's signer.
+func (r *Counter) Get(cfg gnoclient.BaseTxCfg, name string) (res0 int, res1 bool, res *ctypes.ResultBroadcastTxCommit, err error) {
+	caller, err := r.caller()
+	if err != nil {
+		return
+	}
+	msg, err := r.GetMsg(caller, name)
+	if err != nil {
+		return
+	}
+	res, err = r.Client.Call(cfg, msg)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeResults(string(res.DeliverTx.Data), &res0, &res1)
+	return
+}
+
+// EvalGet evaluates Get(name string) (int, bool) in a read-only query.
+func (r *Counter) EvalGet(name string) (res0 int, res1 bool, err error) {
+	expr, err := gnoclient.EvalExpr("Get", name)
+	if err != nil {
+		return
+	}
+	out, _, err := r.Client.QEvalJSON(r.PkgPath, expr)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeEvalJSON(out, &res0, &res1)
+	return
+}
+
+// GetCounterMsg returns the message calling GetCounter(name string) *struct{Name string; Value int}, sent by caller.
+func (r *Counter) GetCounterMsg(caller crypto.Address, name string) (vm.MsgCall, error) {
+	args, err := gnoclient.EncodeArgs(name)
+	if err != nil {
+		return vm.MsgCall{}, err
+	}
+	return vm.MsgCall{
+		Caller:  caller,
+		PkgPath: r.PkgPath,
+		Func:    "GetCounter",
+		Args:    args,
+	}, nil
+}
+
+// GetCounter calls GetCounter(name string) *struct{Name string; Value int} in a transaction signed by the client's signer.
+// Results of other types than booleans, numbers and strings are returned as printed.
+func (r *Counter) GetCounter(cfg gnoclient.BaseTxCfg, name string) (res0 string, res *ctypes.ResultBroadcastTxCommit, err error) {
+	caller, err := r.caller()
+	if err != nil {
+		return
+	}
+	msg, err := r.GetCounterMsg(caller, name)
+	if err != nil {
+		return
+	}
+	res, err = r.Client.Call(cfg, msg)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeResults(string(res.DeliverTx.Data), &res0)
+	return
+}
+
+// EvalGetCounter evaluates GetCounter(name string) *struct{Name string; Value int} in a read-only query.
+func (r *Counter) EvalGetCounter(name string) (res0 json.RawMessage, err error) {
+	expr, err := gnoclient.EvalExpr("GetCounter", name)
+	if err != nil {
+		return
+	}
+	out, _, err := r.Client.QEvalJSON(r.PkgPath, expr)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeEvalJSON(out, &res0)
+	return
+}
+
+// NamesMsg returns the message calling Names() []string, sent by caller.
+func (r *Counter) NamesMsg(caller crypto.Address) (vm.MsgCall, error) {
+	args, err := gnoclient.EncodeArgs()
+	if err != nil {
+		return vm.MsgCall{}, err
+	}
+	return vm.MsgCall{
+		Caller:  caller,
+		PkgPath: r.PkgPath,
+		Func:    "Names",
+		Args:    args,
+	}, nil
+}
+
+// Names calls Names() []string in a transaction signed by the client's signer.
+// Results of other types than booleans, numbers and strings are returned as printed.
+func (r *Counter) Names(cfg gnoclient.BaseTxCfg) (res0 string, res *ctypes.ResultBroadcastTxCommit, err error) {
+	caller, err := r.caller()
+	if err != nil {
+		return
+	}
+	msg, err := r.NamesMsg(caller)
+	if err != nil {
+		return
+	}
+	res, err = r.Client.Call(cfg, msg)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeResults(string(res.DeliverTx.Data), &res0)
+	return
+}
+
+// EvalNames evaluates Names() []string in a read-only query.
+func (r *Counter) EvalNames() (res0 []string, err error) {
+	expr, err := gnoclient.EvalExpr("Names")
+	if err != nil {
+		return
+	}
+	out, _, err := r.Client.QEvalJSON(r.PkgPath, expr)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeEvalJSON(out, &res0)
+	return
+}
+
+// SetAllMsg returns the message calling SetAll(values map[string]int), sent by caller.
+func (r *Counter) SetAllMsg(caller crypto.Address, values map[string]int) (vm.MsgCall, error) {
+	args, err := gnoclient.EncodeArgs(values)
+	if err != nil {
+		return vm.MsgCall{}, err
+	}
+	return vm.MsgCall{
+		Caller:  caller,
+		PkgPath: r.PkgPath,
+		Func:    "SetAll",
+		Args:    args,
+	}, nil
+}
+
+// SetAll calls SetAll(values map[string]int) in a transaction signed by the client's signer.
+func (r *Counter) SetAll(cfg gnoclient.BaseTxCfg, values map[string]int) (res *ctypes.ResultBroadcastTxCommit, err error) {
+	caller, err := r.caller()
+	if err != nil {
+		return
+	}
+	msg, err := r.SetAllMsg(caller, values)
+	if err != nil {
+		return
+	}
+	res, err = r.Client.Call(cfg, msg)
+	return
+}
+
+// ResetMsg returns the message calling Reset(addr string, name string, []uint8), sent by caller.
+func (r *Counter) ResetMsg(caller crypto.Address, addr string, name string, arg2 []byte) (vm.MsgCall, error) {
+	args, err := gnoclient.EncodeArgs(addr, name, arg2)
+	if err != nil {
+		return vm.MsgCall{}, err
+	}
+	return vm.MsgCall{
+		Caller:  caller,
+		PkgPath: r.PkgPath,
+		Func:    "Reset",
+		Args:    args,
+	}, nil
+}
+
+// Reset calls Reset(addr string, name string, []uint8) in a transaction signed by the client's signer.
+func (r *Counter) Reset(cfg gnoclient.BaseTxCfg, addr string, name string, arg2 []byte) (res *ctypes.ResultBroadcastTxCommit, err error) {
+	caller, err := r.caller()
+	if err != nil {
+		return
+	}
+	msg, err := r.ResetMsg(caller, addr, name, arg2)
+	if err != nil {
+		return
+	}
+	res, err = r.Client.Call(cfg, msg)
+	return
+}
+
+// RenderMsg returns the message calling Render(path string) string, sent by caller.
+func (r *Counter) RenderMsg(caller crypto.Address, path string) (vm.MsgCall, error) {
+	args, err := gnoclient.EncodeArgs(path)
+	if err != nil {
+		return vm.MsgCall{}, err
+	}
+	return vm.MsgCall{
+		Caller:  caller,
+		PkgPath: r.PkgPath,
+		Func:    "Render",
+		Args:    args,
+	}, nil
+}
+
+// Render calls Render(path string) string in a transaction signed by the client's signer.
+func (r *Counter) Render(cfg gnoclient.BaseTxCfg, path string) (res0 string, res *ctypes.ResultBroadcastTxCommit, err error) {
+	caller, err := r.caller()
+	if err != nil {
+		return
+	}
+	msg, err := r.RenderMsg(caller, path)
+	if err != nil {
+		return
+	}
+	res, err = r.Client.Call(cfg, msg)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeResults(string(res.DeliverTx.Data), &res0)
+	return
+}
+
+// EvalRender evaluates Render(path string) string in a read-only query.
+func (r *Counter) EvalRender(path string) (res0 string, err error) {
+	expr, err := gnoclient.EvalExpr("Render", path)
+	if err != nil {
+		return
+	}
+	out, _, err := r.Client.QEvalJSON(r.PkgPath, expr)
+	if err != nil {
+		return
+	}
+	err = gnoclient.DecodeEvalJSON(out, &res0)
+	return
+}
@@ -0,0 +1,5 @@
+// Package counter has the bindings of the testdata counter realm, to check
+// the generated code compiles and works.
+package counter
+
+//go:generate go run ../../../cmd/bindgen -pkgpath gno.land/r/demo/counter -dir ../../testdata/counter -o counter.gen.go
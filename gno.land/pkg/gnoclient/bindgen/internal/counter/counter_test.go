@@ -0,0 +1,190 @@
+package counter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoclient"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// fakeRPCClient answers the queries and txs of the bindings
+type fakeRPCClient struct {
+	rpcclient.Client
+
+	abciQuery         func(path string, data []byte) (*ctypes.ResultABCIQuery, error)
+	broadcastTxCommit func(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
+}
+
+func (f *fakeRPCClient) ABCIQuery(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+	return f.abciQuery(path, data)
+}
+
+func (f *fakeRPCClient) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	return f.broadcastTxCommit(tx)
+}
+
+func newTestSigner(t *testing.T) gnoclient.Signer {
+	t.Helper()
+
+	kb := keys.NewInMemory()
+	_, err := kb.CreateAccount(
+		"test",
+		"equip will roof matter pink blind book anxiety banner elbow sun young",
+		"",
+		"",
+		0,
+		0,
+	)
+	require.NoError(t, err)
+
+	return gnoclient.SignerFromKeybase{
+		Keybase: kb,
+		Account: "test",
+		ChainID: "dev",
+	}
+}
+
+func TestCounter_Eval(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	results := map[string]string{
+		"gno.land/r/demo/counter.Get(\"foo\")":        `[3,true]`,
+		"gno.land/r/demo/counter.Names()":             `[["foo","bar"]]`,
+		"gno.land/r/demo/counter.GetCounter(\"foo\")": `[{"Name":"foo","Value":3}]`,
+		"gno.land/r/demo/counter.Incr(\"foo\", 2)":    `[5]`,
+	}
+
+	r := NewCounter(&gnoclient.Client{
+		RPCClient: &fakeRPCClient{
+			abciQuery: func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				assert.Equal(t, "vm/qevaljson", path)
+				queries = append(queries, string(data))
+
+				return &ctypes.ResultABCIQuery{
+					Response: abci.ResponseQuery{
+						ResponseBase: abci.ResponseBase{
+							Data: []byte(results[string(data)]),
+						},
+					},
+				}, nil
+			},
+		},
+	})
+
+	value, ok, err := r.EvalGet("foo")
+	require.NoError(t, err)
+	assert.Equal(t, 3, value)
+	assert.True(t, ok)
+
+	names, err := r.EvalNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, names)
+
+	raw, err := r.EvalGetCounter("foo")
+	require.NoError(t, err)
+
+	var counter struct {
+		Name  string
+		Value int
+	}
+	require.NoError(t, json.Unmarshal(raw, &counter))
+	assert.Equal(t, "foo", counter.Name)
+	assert.Equal(t, 3, counter.Value)
+
+	value, err = r.EvalIncr("foo", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 5, value)
+
+	assert.Len(t, queries, 4)
+}
+
+func TestCounter_Call(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	info, err := signer.Info()
+	require.NoError(t, err)
+
+	var msgs []vm.MsgCall
+	r := NewCounter(&gnoclient.Client{
+		Signer: signer,
+		RPCClient: &fakeRPCClient{
+			broadcastTxCommit: func(bz types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+				var tx std.Tx
+				require.NoError(t, amino.Unmarshal(bz, &tx))
+				require.Len(t, tx.Msgs, 1)
+
+				msg := tx.Msgs[0].(vm.MsgCall)
+				msgs = append(msgs, msg)
+
+				var data string
+				switch msg.Func {
+				case "Get":
+					data = "(3 int)\n(true bool)\n\n"
+				case "GetCounter":
+					data = "(&(struct{(\"foo\" string),(3 int)} gno.land/r/demo/counter.Counter) *gno.land/r/demo/counter.Counter)\n\n"
+				}
+
+				return &ctypes.ResultBroadcastTxCommit{
+					DeliverTx: abci.ResponseDeliverTx{
+						ResponseBase: abci.ResponseBase{
+							Data: []byte(data),
+						},
+					},
+				}, nil
+			},
+		},
+	})
+
+	cfg := gnoclient.BaseTxCfg{
+		GasFee:         "1ugnot",
+		GasWanted:      1_000_000,
+		AccountNumber:  1,
+		SequenceNumber: 1,
+	}
+
+	value, ok, _, err := r.Get(cfg, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, 3, value)
+	assert.True(t, ok)
+
+	printed, _, err := r.GetCounter(cfg, "foo")
+	require.NoError(t, err)
+	assert.Contains(t, printed, "gno.land/r/demo/counter.Counter")
+
+	_, err = r.SetAll(cfg, map[string]int{"foo": 1})
+	require.NoError(t, err)
+
+	_, err = r.Reset(cfg, info.GetAddress().String(), "foo", []byte("hi"))
+	require.NoError(t, err)
+
+	require.Len(t, msgs, 4)
+	for _, msg := range msgs {
+		assert.Equal(t, info.GetAddress(), msg.Caller)
+		assert.Equal(t, CounterPkgPath, msg.PkgPath)
+	}
+	assert.Equal(t, []string{"foo"}, msgs[0].Args)
+	assert.Equal(t, []string{`{"foo":1}`}, msgs[2].Args)
+	assert.Equal(t, []string{info.GetAddress().String(), "foo", "aGk="}, msgs[3].Args)
+}
+
+func TestCounter_MissingSigner(t *testing.T) {
+	t.Parallel()
+
+	r := NewCounter(&gnoclient.Client{})
+
+	_, err := r.SetAll(gnoclient.BaseTxCfg{}, nil)
+	assert.ErrorIs(t, err, gnoclient.ErrMissingSigner)
+}
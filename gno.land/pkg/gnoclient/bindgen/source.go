@@ -0,0 +1,164 @@
+package bindgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm"
+)
+
+// importedTypes are the underlying types of the types of other packages,
+// commonly used in the signatures of realm functions.
+var importedTypes = map[string]string{
+	"std.Address": "string",
+}
+
+// FuncsFromMemPackage returns the signatures of the exported functions of
+// mpkg, like qfuncs would once the package is deployed. Named types are
+// resolved to their underlying type when they're declared in the package.
+func FuncsFromMemPackage(mpkg *gnovm.MemPackage) (vm.FunctionSignatures, error) {
+	fset := token.NewFileSet()
+	r := typeResolver{
+		types: make(map[string]ast.Expr),
+	}
+
+	var files []*ast.File
+	for _, mfile := range mpkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") ||
+			strings.HasSuffix(mfile.Name, "_test.gno") ||
+			strings.HasSuffix(mfile.Name, "_filetest.gno") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, mfile.Name, mfile.Body, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				r.types[ts.Name.Name] = ts.Type
+			}
+		}
+	}
+
+	var fsigs vm.FunctionSignatures
+	for _, file := range files {
+		imports := make(map[string]string)
+		for _, imp := range file.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			name := path[strings.LastIndexByte(path, '/')+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			imports[name] = path
+		}
+		r.imports = imports
+
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv != nil || !fd.Name.IsExported() {
+				continue
+			}
+
+			fsig := vm.FunctionSignature{FuncName: fd.Name.Name}
+			fsig.Params = r.fields(fd.Type.Params)
+			fsig.Results = r.fields(fd.Type.Results)
+			fsigs = append(fsigs, fsig)
+		}
+	}
+
+	return fsigs, nil
+}
+
+type typeResolver struct {
+	types   map[string]ast.Expr // types declared in the package
+	imports map[string]string   // imports of the current file
+}
+
+func (r typeResolver) fields(fl *ast.FieldList) []vm.NamedType {
+	if fl == nil {
+		return nil
+	}
+
+	var res []vm.NamedType
+	for _, field := range fl.List {
+		typ := r.typeString(field.Type, 0)
+		if len(field.Names) == 0 {
+			res = append(res, vm.NamedType{Name: "_", Type: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			res = append(res, vm.NamedType{Name: name.Name, Type: typ})
+		}
+	}
+	return res
+}
+
+// maxTypeDepth bounds the resolution of recursive types.
+const maxTypeDepth = 32
+
+// typeString returns the string representation of the underlying type of
+// expr, following the format of qfuncs where possible.
+func (r typeResolver) typeString(expr ast.Expr, depth int) string {
+	if depth > maxTypeDepth {
+		return r.print(expr)
+	}
+
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "byte":
+			return "uint8"
+		case "rune":
+			return "int32"
+		}
+		if _, ok := primitiveTypes[gnoType(t.Name)]; ok {
+			return t.Name
+		}
+		if underlying, ok := r.types[t.Name]; ok {
+			return r.typeString(underlying, depth+1)
+		}
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			if path, ok := r.imports[x.Name]; ok {
+				if underlying, ok := importedTypes[path+"."+t.Sel.Name]; ok {
+					return underlying
+				}
+			}
+		}
+	case *ast.ParenExpr:
+		return r.typeString(t.X, depth+1)
+	case *ast.Ellipsis:
+		// Variadic parameters are slices
+		return "[]" + r.typeString(t.Elt, depth+1)
+	case *ast.ArrayType:
+		elt := r.typeString(t.Elt, depth+1)
+		if t.Len == nil {
+			return "[]" + elt
+		}
+		return fmt.Sprintf("[%s]%s", r.print(t.Len), elt)
+	case *ast.MapType:
+		return fmt.Sprintf("map[%s]%s", r.typeString(t.Key, depth+1), r.typeString(t.Value, depth+1))
+	case *ast.StarExpr:
+		return "*" + r.typeString(t.X, depth+1)
+	}
+
+	return r.print(expr)
+}
+
+func (r typeResolver) print(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
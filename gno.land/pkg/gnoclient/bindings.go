@@ -0,0 +1,220 @@
+package gnoclient
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The helpers below convert Go values to the arguments of realm functions,
+// and the results of realm functions to Go values. They are used by the
+// bindings generated by the bindgen package, but can be used on their own.
+
+// EncodeArgs encodes args as the string arguments of a MsgCall. Booleans,
+// numbers and strings use their literal representation, byte slices are
+// base64-encoded, and other values are encoded as JSON.
+func EncodeArgs(args ...any) ([]string, error) {
+	res := make([]string, 0, len(args))
+	for i, arg := range args {
+		s, err := encodeArg(arg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode argument %d: %w", i, err)
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+func encodeArg(arg any) (string, error) {
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(bytesOf(v)), nil
+		}
+	}
+
+	bz, err := json.Marshal(arg)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+// EvalExpr returns the expression calling fn with args, to be evaluated with
+// QEval or QEvalJSON. Only booleans, numbers, strings and byte slices can be
+// used as arguments.
+func EvalExpr(fn string, args ...any) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(fn)
+	sb.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		lit, err := evalLiteral(arg)
+		if err != nil {
+			return "", fmt.Errorf("unable to encode argument %d: %w", i, err)
+		}
+		sb.WriteString(lit)
+	}
+	sb.WriteByte(')')
+	return sb.String(), nil
+}
+
+func evalLiteral(arg any) (string, error) {
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("no literal for %v", f)
+		}
+		return strconv.FormatFloat(f, 'g', -1, v.Type().Bits()), nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return "[]byte(" + strconv.Quote(string(bytesOf(v))) + ")", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %T", arg)
+}
+
+func bytesOf(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+	bz := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(bz), v)
+	return bz
+}
+
+// DecodeEvalJSON decodes the results returned by QEvalJSON into dsts, which
+// must be pointers, one per result.
+func DecodeEvalJSON(res string, dsts ...any) error {
+	var results []json.RawMessage
+	if err := json.Unmarshal([]byte(res), &results); err != nil {
+		return fmt.Errorf("unable to decode results: %w", err)
+	}
+	if len(results) != len(dsts) {
+		return fmt.Errorf("expected %d results, got %d", len(dsts), len(results))
+	}
+
+	for i, result := range results {
+		if err := json.Unmarshal(result, dsts[i]); err != nil {
+			return fmt.Errorf("unable to decode result %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// DecodeResults decodes the typed results returned by QEval or by a MsgCall,
+// like "(1 int)\n(\"foo\" string)", into dsts, which must be pointers, one per
+// result. Booleans, numbers and strings are decoded into values of the same
+// kind; other results can only be decoded into strings, as they're printed.
+func DecodeResults(res string, dsts ...any) error {
+	res = strings.TrimRight(res, "\n")
+
+	var results []string
+	if res != "" {
+		results = strings.Split(res, "\n")
+	}
+	if len(results) != len(dsts) {
+		return fmt.Errorf("expected %d results, got %d", len(dsts), len(results))
+	}
+
+	for i, result := range results {
+		if err := decodeResult(result, dsts[i]); err != nil {
+			return fmt.Errorf("unable to decode result %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func decodeResult(result string, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("non-pointer destination %T", dst)
+	}
+	v = v.Elem()
+
+	inner, ok := strings.CutPrefix(result, "(")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, ")")
+	}
+	if !ok {
+		return fmt.Errorf("malformed result %q", result)
+	}
+
+	// Quoted strings may contain spaces, but types don't
+	var value string
+	if strings.HasPrefix(inner, `"`) {
+		quoted, err := strconv.QuotedPrefix(inner)
+		if err != nil {
+			return fmt.Errorf("malformed string in %q: %w", result, err)
+		}
+		if value, err = strconv.Unquote(quoted); err != nil {
+			return err
+		}
+		if v.Kind() == reflect.String {
+			v.SetString(value)
+			return nil
+		}
+	} else if i := strings.LastIndexByte(inner, ' '); i >= 0 {
+		value = inner[:i]
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		// Not a string result, keep it as printed
+		v.SetString(result)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unable to decode %q into %T", result, dst)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package gnoclient
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeArgs(t *testing.T) {
+	t.Parallel()
+
+	type amount uint64
+
+	args, err := EncodeArgs(
+		"foo",
+		true,
+		int8(-3),
+		amount(42),
+		1.5,
+		[]byte("hi"),
+		[]string{"a", "b"},
+		struct {
+			Name string `json:"name"`
+		}{Name: "bar"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"foo",
+		"true",
+		"-3",
+		"42",
+		"1.5",
+		"aGk=",
+		`["a","b"]`,
+		`{"name":"bar"}`,
+	}, args)
+
+	_, err = EncodeArgs(make(chan int))
+	assert.ErrorContains(t, err, "unable to encode argument 0")
+}
+
+func TestEvalExpr(t *testing.T) {
+	t.Parallel()
+
+	expr, err := EvalExpr("Foo", "a \"b\"", false, -1, uint(2), 0.25, []byte{0, 'x'})
+	require.NoError(t, err)
+	assert.Equal(t, `Foo("a \"b\"", false, -1, 2, 0.25, []byte("\x00x"))`, expr)
+
+	expr, err = EvalExpr("Bar")
+	require.NoError(t, err)
+	assert.Equal(t, "Bar()", expr)
+
+	_, err = EvalExpr("Foo", []string{"a"})
+	assert.ErrorContains(t, err, "unsupported type []string")
+
+	_, err = EvalExpr("Foo", math.Inf(1))
+	assert.ErrorContains(t, err, "unable to encode argument 0")
+}
+
+func TestDecodeResults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s       string
+		b       bool
+		i       int
+		u       uint8
+		f       float64
+		printed string
+	)
+
+	res := "(\"hello world\" string)\n(true bool)\n(-5 int)\n(7 gno.land/r/demo/foo.Small)\n(1.5 float64)\n(slice[(1 int)] []int)\n\n"
+	require.NoError(t, DecodeResults(res, &s, &b, &i, &u, &f, &printed))
+
+	assert.Equal(t, "hello world", s)
+	assert.True(t, b)
+	assert.Equal(t, -5, i)
+	assert.Equal(t, uint8(7), u)
+	assert.Equal(t, 1.5, f)
+	assert.Equal(t, "(slice[(1 int)] []int)", printed)
+
+	assert.NoError(t, DecodeResults(""))
+	assert.ErrorContains(t, DecodeResults("(1 int)"), "expected 0 results, got 1")
+	assert.ErrorContains(t, DecodeResults("(1 int)", i), "non-pointer destination")
+	assert.ErrorContains(t, DecodeResults("(256 uint8)", &u), "unable to decode result 0")
+	assert.ErrorContains(t, DecodeResults("1 int", &i), "malformed result")
+}
+
+func TestDecodeEvalJSON(t *testing.T) {
+	t.Parallel()
+
+	var (
+		names []string
+		ok    bool
+	)
+
+	require.NoError(t, DecodeEvalJSON(`[["a","b"],true]`, &names, &ok))
+	assert.Equal(t, []string{"a", "b"}, names)
+	assert.True(t, ok)
+
+	assert.ErrorContains(t, DecodeEvalJSON(`[true]`, &names, &ok), "expected 2 results, got 1")
+	assert.ErrorContains(t, DecodeEvalJSON(`[1]`, &names), "unable to decode result 0")
+	assert.ErrorContains(t, DecodeEvalJSON(`(1 int)`, &ok), "unable to decode results")
+}
@@ -3,11 +3,13 @@ package gnoclient
 import (
 	"fmt"
 
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -63,6 +65,63 @@ func (c *Client) QueryAccount(addr crypto.Address) (*std.BaseAccount, *ctypes.Re
 	return &qret.BaseAccount, qres, nil
 }
 
+// QuerySupply retrieves the total supply of all the denoms, or of the given
+// denom if it is not empty.
+func (c *Client) QuerySupply(denom string) (std.Coins, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, nil, err
+	}
+
+	path := "bank/supply"
+	if denom != "" {
+		path += "/" + denom
+	}
+
+	qres, err := c.RPCClient.ABCIQuery(path, []byte{})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query supply")
+	}
+	if qres.Response.Error != nil {
+		return nil, nil, errors.Wrapf(qres.Response.Error, "QuerySupply failed: log:%s", qres.Response.Log)
+	}
+
+	if denom != "" {
+		var coin std.Coin
+		if err := amino.UnmarshalJSON(qres.Response.Data, &coin); err != nil {
+			return nil, nil, errors.Wrap(err, "unmarshal supply")
+		}
+		return std.NewCoins(coin), qres, nil
+	}
+
+	var supply std.Coins
+	if err := amino.UnmarshalJSON(qres.Response.Data, &supply); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal supply")
+	}
+	return supply, qres, nil
+}
+
+// QueryDenoms retrieves the metadata of the denoms registered in the bank,
+// like their display name and the realm issuing them.
+func (c *Client) QueryDenoms() ([]bank.DenomMetadata, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, nil, err
+	}
+
+	qres, err := c.RPCClient.ABCIQuery("bank/denoms", []byte{})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query denoms")
+	}
+	if qres.Response.Error != nil {
+		return nil, nil, errors.Wrapf(qres.Response.Error, "QueryDenoms failed: log:%s", qres.Response.Log)
+	}
+
+	var denoms []bank.DenomMetadata
+	if err := amino.UnmarshalJSON(qres.Response.Data, &denoms); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal denoms")
+	}
+	return denoms, qres, nil
+}
+
 // QueryAppVersion retrieves information about the app version
 func (c *Client) QueryAppVersion() (string, *ctypes.ResultABCIQuery, error) {
 	if err := c.validateRPCClient(); err != nil {
@@ -103,6 +162,32 @@ func (c *Client) Render(pkgPath string, args string) (string, *ctypes.ResultABCI
 	return string(qres.Response.Data), qres, nil
 }
 
+// QueryFuncs returns the signatures of the exported functions of the realm
+// at pkgPath, as returned by the vm/qfuncs query.
+func (c *Client) QueryFuncs(pkgPath string) (vm.FunctionSignatures, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, nil, err
+	}
+
+	path := "vm/qfuncs"
+	data := []byte(pkgPath)
+
+	qres, err := c.RPCClient.ABCIQuery(path, data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query qfuncs")
+	}
+	if qres.Response.Error != nil {
+		return nil, nil, errors.Wrapf(qres.Response.Error, "QueryFuncs failed: log:%s", qres.Response.Log)
+	}
+
+	var fsigs vm.FunctionSignatures
+	if err := amino.UnmarshalJSON(qres.Response.Data, &fsigs); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal function signatures")
+	}
+
+	return fsigs, qres, nil
+}
+
 // QEval evaluates the given expression with the realm code at pkgPath. The pkgPath should
 // include the prefix like "gno.land/". The expression is usually a function call like
 // "GetBoardIDFromName(\"testboard\")". The return value is a typed expression like
@@ -126,6 +211,28 @@ func (c *Client) QEval(pkgPath string, expression string) (string, *ctypes.Resul
 	return string(qres.Response.Data), qres, nil
 }
 
+// QEvalJSON is like QEval, but the results are returned as a JSON array, encoded
+// following the rules of the encoding/json stdlib. For instance, evaluating
+// "GetBoard(1)" may return `[{"id":1,"name":"testboard"}]`.
+func (c *Client) QEvalJSON(pkgPath string, expression string) (string, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return "", nil, err
+	}
+
+	path := "vm/qevaljson"
+	data := []byte(fmt.Sprintf("%s.%s", pkgPath, expression))
+
+	qres, err := c.RPCClient.ABCIQuery(path, data)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "query qevaljson")
+	}
+	if qres.Response.Error != nil {
+		return "", nil, errors.Wrapf(qres.Response.Error, "QEvalJSON failed: log:%s", qres.Response.Log)
+	}
+
+	return string(qres.Response.Data), qres, nil
+}
+
 // Block gets the latest block at height, if any
 // Height must be larger than 0
 func (c *Client) Block(height int64) (*ctypes.ResultBlock, error) {
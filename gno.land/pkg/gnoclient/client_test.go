@@ -63,6 +63,101 @@ func TestRender(t *testing.T) {
 	assert.Equal(t, data.Response.Data, expectedRender)
 }
 
+func TestQueryFuncs(t *testing.T) {
+	t.Parallel()
+
+	testRealmPath := "gno.land/r/demo/counter"
+	expectedFuncs := vm.FunctionSignatures{
+		{
+			FuncName: "Incr",
+			Params:   []vm.NamedType{{Name: "delta", Type: "int"}},
+			Results:  []vm.NamedType{{Name: "_", Type: "int"}},
+		},
+	}
+
+	client := Client{
+		RPCClient: &mockRPCClient{
+			abciQuery: func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				assert.Equal(t, "vm/qfuncs", path)
+				assert.Equal(t, testRealmPath, string(data))
+
+				return &ctypes.ResultABCIQuery{
+					Response: abci.ResponseQuery{
+						ResponseBase: abci.ResponseBase{
+							Data: []byte(expectedFuncs.JSON()),
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	fsigs, _, err := client.QueryFuncs(testRealmPath)
+	require.NoError(t, err)
+	assert.Equal(t, expectedFuncs, fsigs)
+}
+
+func TestQuerySupply(t *testing.T) {
+	t.Parallel()
+
+	denom := "/gno.land/r/demo/foo:foo"
+	client := Client{
+		RPCClient: &mockRPCClient{
+			abciQuery: func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				var res any = std.MustParseCoins("10" + denom + ",1000ugnot")
+				if path != "bank/supply" {
+					assert.Equal(t, "bank/supply/"+denom, path)
+					res = std.NewCoin(denom, 10)
+				}
+				return &ctypes.ResultABCIQuery{
+					Response: abci.ResponseQuery{
+						ResponseBase: abci.ResponseBase{
+							Data: amino.MustMarshalJSON(res),
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	supply, _, err := client.QuerySupply("")
+	require.NoError(t, err)
+	assert.Equal(t, "10"+denom+",1000ugnot", supply.String())
+
+	supply, _, err = client.QuerySupply(denom)
+	require.NoError(t, err)
+	assert.Equal(t, "10"+denom, supply.String())
+}
+
+func TestQueryDenoms(t *testing.T) {
+	t.Parallel()
+
+	expectedDenoms := []bank.DenomMetadata{
+		{Denom: "/gno.land/r/demo/foo:foo", Display: "foo", Realm: "gno.land/r/demo/foo"},
+		{Denom: "ugnot", Display: "gnot", Exponent: 6},
+	}
+
+	client := Client{
+		RPCClient: &mockRPCClient{
+			abciQuery: func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				assert.Equal(t, "bank/denoms", path)
+
+				return &ctypes.ResultABCIQuery{
+					Response: abci.ResponseQuery{
+						ResponseBase: abci.ResponseBase{
+							Data: amino.MustMarshalJSON(expectedDenoms),
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	denoms, _, err := client.QueryDenoms()
+	require.NoError(t, err)
+	assert.Equal(t, expectedDenoms, denoms)
+}
+
 // Call tests
 func TestCallSingle(t *testing.T) {
 	t.Parallel()
@@ -112,7 +207,7 @@ func TestCallSingle(t *testing.T) {
 			PkgPath: "gno.land/r/demo/deep/very/deep",
 			Func:    "Render",
 			Args:    []string{""},
-			Send:    std.Coins{{Denom: ugnot.Denom, Amount: int64(100)}},
+			Send:    std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(100))}},
 		},
 	}
 
@@ -181,14 +276,14 @@ func TestCallMultiple(t *testing.T) {
 			PkgPath: "gno.land/r/demo/deep/very/deep",
 			Func:    "Render",
 			Args:    []string{""},
-			Send:    std.Coins{{Denom: ugnot.Denom, Amount: int64(100)}},
+			Send:    std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(100))}},
 		},
 		{
 			Caller:  caller.GetAddress(),
 			PkgPath: "gno.land/r/demo/wugnot",
 			Func:    "Deposit",
 			Args:    []string{""},
-			Send:    std.Coins{{Denom: ugnot.Denom, Amount: int64(1000)}},
+			Send:    std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(1000))}},
 		},
 		{
 			Caller:  caller.GetAddress(),
@@ -432,7 +527,7 @@ func TestClient_Send_Errors(t *testing.T) {
 				{
 					FromAddress: mockAddress,
 					ToAddress:   toAddress,
-					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(1)}},
+					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(1))}},
 				},
 			},
 			expectedError: ErrMissingSigner.Error(),
@@ -454,7 +549,7 @@ func TestClient_Send_Errors(t *testing.T) {
 				{
 					FromAddress: mockAddress,
 					ToAddress:   toAddress,
-					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(1)}},
+					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(1))}},
 				},
 			},
 			expectedError: ErrMissingRPCClient.Error(),
@@ -476,7 +571,7 @@ func TestClient_Send_Errors(t *testing.T) {
 				{
 					FromAddress: mockAddress,
 					ToAddress:   toAddress,
-					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(1)}},
+					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(1))}},
 				},
 			},
 			expectedError: ErrInvalidGasFee.Error(),
@@ -498,7 +593,7 @@ func TestClient_Send_Errors(t *testing.T) {
 				{
 					FromAddress: mockAddress,
 					ToAddress:   toAddress,
-					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(1)}},
+					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(1))}},
 				},
 			},
 			expectedError: ErrInvalidGasWanted.Error(),
@@ -520,7 +615,7 @@ func TestClient_Send_Errors(t *testing.T) {
 				{
 					FromAddress: mockAddress,
 					ToAddress:   toAddress,
-					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(1)}},
+					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(1))}},
 				},
 			},
 			expectedError: ErrInvalidGasWanted.Error(),
@@ -551,7 +646,7 @@ func TestClient_Send_Errors(t *testing.T) {
 				{
 					FromAddress: mockAddress,
 					ToAddress:   crypto.Address{},
-					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(1)}},
+					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(1))}},
 				},
 			},
 			expectedError: std.InvalidAddressError{}.Error(),
@@ -582,7 +677,7 @@ func TestClient_Send_Errors(t *testing.T) {
 				{
 					FromAddress: mockAddress,
 					ToAddress:   toAddress,
-					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(-1)}},
+					Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(-1))}},
 				},
 			},
 			expectedError: std.InvalidCoinsError{}.Error(),
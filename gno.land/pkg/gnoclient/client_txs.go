@@ -297,6 +297,49 @@ func (c *Client) BroadcastTxCommit(signedTx *std.Tx) (*ctypes.ResultBroadcastTxC
 	return bres, nil
 }
 
+// BroadcastTxSync marshals and broadcasts the signed transaction, returning
+// once it passed CheckTx, without waiting for it to be committed.
+// If the result has a check error, then return a wrapped error.
+func (c *Client) BroadcastTxSync(signedTx *std.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+	bz, err := amino.Marshal(signedTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling tx binary bytes")
+	}
+
+	bres, err := c.RPCClient.BroadcastTxSync(bz)
+	if err != nil {
+		return nil, errors.Wrap(err, "broadcasting bytes")
+	}
+
+	if bres.Error != nil {
+		return bres, errors.Wrapf(bres.Error, "check transaction failed: log:%s", bres.Log)
+	}
+
+	return bres, nil
+}
+
+// BroadcastTxAsync marshals and broadcasts the signed transaction, returning
+// without waiting for CheckTx.
+func (c *Client) BroadcastTxAsync(signedTx *std.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+	bz, err := amino.Marshal(signedTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling tx binary bytes")
+	}
+
+	bres, err := c.RPCClient.BroadcastTxAsync(bz)
+	if err != nil {
+		return nil, errors.Wrap(err, "broadcasting bytes")
+	}
+
+	return bres, nil
+}
+
 // EstimateGas returns the least amount of gas required
 // for the transaction to go through on the chain (minimum gas wanted).
 // The estimation process assumes the transaction is properly signed
@@ -0,0 +1,146 @@
+// Command bindgen generates typed Go bindings for the exported functions of a
+// realm, to call it with gnoclient. See the gnoclient/bindgen package.
+//
+// It can be used with go:generate, for instance:
+//
+//	//go:generate go run github.com/gnolang/gno/gno.land/pkg/gnoclient/cmd/bindgen -pkgpath gno.land/r/demo/counter -dir ../../examples/gno.land/r/demo/counter -o counter.gen.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoclient"
+	"github.com/gnolang/gno/gno.land/pkg/gnoclient/bindgen"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+type bindgenCfg struct {
+	pkgPath  string
+	remote   string
+	dir      string
+	pkgName  string
+	typeName string
+	output   string
+}
+
+func main() {
+	cfg := &bindgenCfg{}
+
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			Name:       "bindgen",
+			ShortUsage: "bindgen -pkgpath <realm path> [flags]",
+			ShortHelp:  "generates typed Go bindings for a realm",
+			LongHelp: `Generates typed Go bindings for the exported functions of a realm, on top of gnoclient.
+The signatures of the functions are read from the source of the realm in -dir, or
+queried from the node at -remote.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execBindgen(cfg, args, commands.NewDefaultIO())
+		},
+	)
+
+	cmd.Execute(context.Background(), os.Args[1:])
+}
+
+func (c *bindgenCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.pkgPath,
+		"pkgpath",
+		"",
+		"path of the realm, like gno.land/r/demo/counter",
+	)
+
+	fs.StringVar(
+		&c.remote,
+		"remote",
+		"",
+		"remote node URL, to query the functions of the deployed realm",
+	)
+
+	fs.StringVar(
+		&c.dir,
+		"dir",
+		".",
+		"directory of the realm source, if -remote is not set",
+	)
+
+	fs.StringVar(
+		&c.pkgName,
+		"package",
+		"",
+		"name of the generated Go package (default: last element of the realm path)",
+	)
+
+	fs.StringVar(
+		&c.typeName,
+		"type",
+		"",
+		"name of the generated binding type (default: capitalized package name)",
+	)
+
+	fs.StringVar(
+		&c.output,
+		"o",
+		"",
+		"output file (default: stdout)",
+	)
+}
+
+func execBindgen(cfg *bindgenCfg, args []string, io commands.IO) error {
+	if len(args) != 0 || cfg.pkgPath == "" {
+		return flag.ErrHelp
+	}
+
+	var (
+		fsigs vm.FunctionSignatures
+		err   error
+	)
+
+	if cfg.remote != "" {
+		rpcClient, err := rpcclient.NewHTTPClient(cfg.remote)
+		if err != nil {
+			return fmt.Errorf("unable to create RPC client, %w", err)
+		}
+
+		client := gnoclient.Client{RPCClient: rpcClient}
+		if fsigs, _, err = client.QueryFuncs(cfg.pkgPath); err != nil {
+			return err
+		}
+	} else {
+		mpkg, err := gno.ReadMemPackage(cfg.dir, cfg.pkgPath)
+		if err != nil {
+			return fmt.Errorf("unable to read realm source, %w", err)
+		}
+
+		if fsigs, err = bindgen.FuncsFromMemPackage(mpkg); err != nil {
+			return err
+		}
+	}
+
+	src, err := bindgen.Generate(bindgen.Config{
+		PkgPath:  cfg.pkgPath,
+		Package:  cfg.pkgName,
+		TypeName: cfg.typeName,
+	}, fsigs)
+	if err != nil {
+		if errors.Is(err, bindgen.ErrNoFuncs) {
+			return fmt.Errorf("%s has %w", cfg.pkgPath, err)
+		}
+		return err
+	}
+
+	if cfg.output == "" {
+		io.Out().Write(src)
+		return nil
+	}
+	return os.WriteFile(cfg.output, src, 0o644)
+}
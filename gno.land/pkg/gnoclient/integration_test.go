@@ -1,8 +1,10 @@
 package gnoclient
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
 
@@ -184,7 +186,7 @@ func TestSendSingle_Integration(t *testing.T) {
 	msg := bank.MsgSend{
 		FromAddress: caller.GetAddress(),
 		ToAddress:   toAddress,
-		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(amount)}},
+		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(amount))}},
 	}
 
 	// Execute send
@@ -196,7 +198,7 @@ func TestSendSingle_Integration(t *testing.T) {
 	account, _, err := client.QueryAccount(toAddress)
 	require.NoError(t, err)
 
-	expected := std.Coins{{Denom: ugnot.Denom, Amount: int64(amount)}}
+	expected := std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(amount))}}
 	got := account.GetCoins()
 
 	assert.Equal(t, expected, got)
@@ -208,7 +210,7 @@ func TestSendSingle_Integration(t *testing.T) {
 	// Get the new account balance
 	account, _, err = client.QueryAccount(toAddress)
 	require.NoError(t, err)
-	expected2 := std.Coins{{Denom: ugnot.Denom, Amount: int64(2 * amount)}}
+	expected2 := std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(2 * amount))}}
 	got = account.GetCoins()
 	assert.Equal(t, expected2, got)
 }
@@ -248,7 +250,7 @@ func TestSendMultiple_Integration(t *testing.T) {
 	msg1 := bank.MsgSend{
 		FromAddress: caller.GetAddress(),
 		ToAddress:   toAddress,
-		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(amount1)}},
+		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(amount1))}},
 	}
 
 	// Same send, different argument
@@ -256,7 +258,7 @@ func TestSendMultiple_Integration(t *testing.T) {
 	msg2 := bank.MsgSend{
 		FromAddress: caller.GetAddress(),
 		ToAddress:   toAddress,
-		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: int64(amount2)}},
+		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(amount2))}},
 	}
 
 	// Execute send
@@ -268,7 +270,7 @@ func TestSendMultiple_Integration(t *testing.T) {
 	account, _, err := client.QueryAccount(toAddress)
 	assert.NoError(t, err)
 
-	expected := std.Coins{{Denom: ugnot.Denom, Amount: int64(amount1 + amount2)}}
+	expected := std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(amount1 + amount2))}}
 	got := account.GetCoins()
 
 	assert.Equal(t, expected, got)
@@ -280,7 +282,7 @@ func TestSendMultiple_Integration(t *testing.T) {
 	// Get the new account balance
 	account, _, err = client.QueryAccount(toAddress)
 	require.NoError(t, err)
-	expected2 := std.Coins{{Denom: ugnot.Denom, Amount: int64(2 * (amount1 + amount2))}}
+	expected2 := std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(2 * (amount1 + amount2)))}}
 	got = account.GetCoins()
 	assert.Equal(t, expected2, got)
 }
@@ -497,7 +499,7 @@ func Echo(str string) string {
 
 	fileName := "echo.gno"
 	deploymentPath := "gno.land/p/demo/integration/test/echo"
-	deposit := std.Coins{{Denom: ugnot.Denom, Amount: int64(100)}}
+	deposit := std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(100))}}
 
 	caller, err := client.Signer.Info()
 	require.NoError(t, err)
@@ -574,7 +576,7 @@ func TestAddPackageMultiple_Integration(t *testing.T) {
 		Memo:           "",
 	}
 
-	deposit := std.Coins{{Denom: ugnot.Denom, Amount: int64(100)}}
+	deposit := std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(int64(100))}}
 	deploymentPath1 := "gno.land/p/demo/integration/test/echo"
 
 	body1 := `package echo
@@ -680,6 +682,150 @@ func Hello(str string) string {
 	assert.Contains(t, string(query.Response.Data), "gno.mod")
 }
 
+func TestPipeline_Integration(t *testing.T) {
+	// Set up in-memory node
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClient
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	toAddress, _ := crypto.AddressFromBech32("g14a0y9a64dugh3l7hneshdxr4w0rfkkww9ls35p")
+	tx, err := NewSendTx(BaseTxCfg{
+		GasFee:    ugnot.ValueString(2100000),
+		GasWanted: 21000000,
+	}, bank.MsgSend{
+		FromAddress: caller.GetAddress(),
+		ToAddress:   toAddress,
+		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(10)}},
+	})
+	require.NoError(t, err)
+
+	p := NewPipeline(&client, PipelineCfg{
+		MaxRetries:   3,
+		PollInterval: 100 * time.Millisecond,
+	})
+
+	// Broadcast several txs at once, without waiting for them
+	const numTxs = 10
+	ptxs := make([]*PendingTx, 0, numTxs)
+	for i := 0; i < numTxs; i++ {
+		ptx, err := p.Submit(*tx)
+		require.NoError(t, err)
+
+		ptxs = append(ptxs, ptx)
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelFn()
+
+	for _, ptx := range ptxs {
+		_, err := ptx.Wait(ctx)
+		require.NoError(t, err)
+	}
+
+	account, _, err := client.QueryAccount(toAddress)
+	require.NoError(t, err)
+	assert.Equal(t, std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(10 * numTxs)}}, account.GetCoins())
+
+	// Desynchronize the pipeline, by sending another tx with the client
+	_, err = client.Send(BaseTxCfg{
+		GasFee:    ugnot.ValueString(2100000),
+		GasWanted: 21000000,
+	}, bank.MsgSend{
+		FromAddress: caller.GetAddress(),
+		ToAddress:   toAddress,
+		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: std.NewInt(1)}},
+	})
+	require.NoError(t, err)
+
+	ptx, err := p.Submit(*tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(numTxs+1), ptx.Sequence)
+
+	_, err = ptx.Wait(ctx)
+	require.NoError(t, err)
+}
+
+func TestQueryFuncsAndDecode_Integration(t *testing.T) {
+	// Setup packages
+	rootdir := gnoenv.RootDir()
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+	meta := loadpkgs(t, rootdir, "gno.land/r/demo/deep/very/deep")
+	state := config.Genesis.AppState.(gnoland.GnoGenesisState)
+	state.Txs = append(state.Txs, meta...)
+	config.Genesis.AppState = state
+
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClient
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	pkgPath := "gno.land/r/demo/deep/very/deep"
+
+	fsigs, _, err := client.QueryFuncs(pkgPath)
+	require.NoError(t, err)
+	assert.Equal(t, vm.FunctionSignatures{
+		{
+			FuncName: "Render",
+			Params:   []vm.NamedType{{Name: "path", Type: "string"}},
+			Results:  []vm.NamedType{{Name: "_", Type: "string"}},
+		},
+	}, fsigs)
+
+	// Evaluate
+	expr, err := EvalExpr("Render", "test argument")
+	require.NoError(t, err)
+
+	out, _, err := client.QEvalJSON(pkgPath, expr)
+	require.NoError(t, err)
+
+	var evalRes string
+	require.NoError(t, DecodeEvalJSON(out, &evalRes))
+	assert.Equal(t, "hi test argument", evalRes)
+
+	// Call
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	args, err := EncodeArgs("test argument")
+	require.NoError(t, err)
+
+	res, err := client.Call(BaseTxCfg{
+		GasFee:    ugnot.ValueString(2100000),
+		GasWanted: 21000000,
+	}, vm.MsgCall{
+		Caller:  caller.GetAddress(),
+		PkgPath: pkgPath,
+		Func:    "Render",
+		Args:    args,
+	})
+	require.NoError(t, err)
+
+	var callRes string
+	require.NoError(t, DecodeResults(string(res.DeliverTx.Data), &callRes))
+	assert.Equal(t, "hi test argument", callRes)
+}
+
 // todo add more integration tests:
 // MsgCall with Send field populated (single/multiple)
 // MsgRun with Send field populated (single/multiple)
@@ -0,0 +1,89 @@
+package gnoclient
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// SignMultisig makes the partial signature of a transaction by the signer's
+// key, for the multisig account with the given public key. The account number
+// and sequence in cfg are those of the multisig account. The partial
+// signatures of enough keys are combined with CombineMultisig.
+func (s SignerFromKeybase) SignMultisig(cfg SignCfg, multisigPub crypto.PubKey) (std.Signature, error) {
+	info, err := s.Info()
+	if err != nil {
+		return std.Signature{}, err
+	}
+	if !std.IsMultisigKey(multisigPub, info.GetPubKey()) {
+		return std.Signature{}, fmt.Errorf("key %s is not part of multisig %s", s.Account, multisigPub.Address())
+	}
+
+	tx := cfg.UnsignedTX
+	if !slices.Contains(tx.GetSigners(), multisigPub.Address()) {
+		return std.Signature{}, fmt.Errorf("multisig %s is not a signer of the transaction", multisigPub.Address())
+	}
+
+	// Derive sign doc bytes.
+	signbz, err := tx.GetSignBytes(s.ChainID, cfg.AccountNumber, cfg.SequenceNumber)
+	if err != nil {
+		return std.Signature{}, fmt.Errorf("unable to get tx signature payload, %w", err)
+	}
+
+	sig, pub, err := s.Keybase.Sign(s.Account, s.Password, signbz)
+	if err != nil {
+		return std.Signature{}, err
+	}
+
+	return std.Signature{
+		PubKey:    pub,
+		Signature: sig,
+	}, nil
+}
+
+// CombineMultisig combines the partial signatures made with SignMultisig into
+// the signature of the multisig account with the given public key, and
+// returns the transaction in cfg signed by it. At least as many partial
+// signatures as the threshold of the multisig account are required.
+func CombineMultisig(cfg SignCfg, chainID string, multisigPub crypto.PubKey, sigs []std.Signature) (*std.Tx, error) {
+	tx := cfg.UnsignedTX
+
+	// Initialize tx signatures.
+	signers := tx.GetSigners()
+	if tx.Signatures == nil {
+		tx.Signatures = make([]std.Signature, len(signers))
+	}
+
+	// Derive sign doc bytes.
+	signbz, err := tx.GetSignBytes(chainID, cfg.AccountNumber, cfg.SequenceNumber)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get tx signature payload, %w", err)
+	}
+
+	sig, err := std.CombineMultisig(multisigPub, signbz, sigs)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := multisigPub.Address()
+	found := false
+	for i := range tx.Signatures {
+		if i < len(signers) && signers[i] == addr {
+			found = true
+			tx.Signatures[i] = sig
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("multisig %s not in signer set", addr)
+	}
+
+	// Validate the signed transaction.
+	if err := tx.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
@@ -0,0 +1,90 @@
+package gnoclient
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestMultisig(t *testing.T) {
+	t.Parallel()
+
+	const chainID = "dev"
+
+	// Create a 3-of-5 multisig account
+	kb := keys.NewInMemory()
+	signers := make([]SignerFromKeybase, 5)
+	pubs := make([]crypto.PubKey, 5)
+	for i := range signers {
+		entropy, err := bip39.NewEntropy(256)
+		require.NoError(t, err)
+
+		mnemonic, err := bip39.NewMnemonic(entropy)
+		require.NoError(t, err)
+
+		name := fmt.Sprintf("key-%d", i)
+		info, err := kb.CreateAccount(name, mnemonic, "", "", 0, 0)
+		require.NoError(t, err)
+
+		signers[i] = SignerFromKeybase{
+			Keybase: kb,
+			Account: name,
+			ChainID: chainID,
+		}
+		pubs[i] = info.GetPubKey()
+	}
+	multisigPub := multisig.NewPubKeyMultisigThreshold(3, pubs)
+
+	cfg := SignCfg{
+		UnsignedTX: std.Tx{
+			Msgs: []std.Msg{
+				bank.MsgSend{
+					FromAddress: multisigPub.Address(),
+					ToAddress:   pubs[0].Address(),
+					Amount:      std.NewCoins(std.NewCoin(ugnot.Denom, 100)),
+				},
+			},
+			Fee: std.NewFee(100000, std.NewCoin(ugnot.Denom, 1000)),
+		},
+		AccountNumber:  7,
+		SequenceNumber: 2,
+	}
+
+	sigs := make([]std.Signature, 0, 3)
+	for _, i := range []int{3, 0, 1} {
+		sig, err := signers[i].SignMultisig(cfg, multisigPub)
+		require.NoError(t, err)
+
+		sigs = append(sigs, sig)
+	}
+
+	// Not enough partial signatures
+	_, err := CombineMultisig(cfg, chainID, multisigPub, sigs[:2])
+	assert.ErrorContains(t, err, "not enough partial signatures")
+
+	tx, err := CombineMultisig(cfg, chainID, multisigPub, sigs)
+	require.NoError(t, err)
+
+	require.Len(t, tx.Signatures, 1)
+	sig := tx.Signatures[0]
+	assert.True(t, sig.PubKey.Equals(multisigPub))
+
+	signbz, err := tx.GetSignBytes(chainID, cfg.AccountNumber, cfg.SequenceNumber)
+	require.NoError(t, err)
+	assert.True(t, multisigPub.VerifyBytes(signbz, sig.Signature))
+
+	// Keys outside of the multisig can't sign for it
+	_, err = SignerFromKeybase{Keybase: kb, Account: "key-0", ChainID: chainID}.
+		SignMultisig(cfg, multisig.NewPubKeyMultisigThreshold(1, pubs[1:]))
+	assert.ErrorContains(t, err, "is not part of multisig")
+}
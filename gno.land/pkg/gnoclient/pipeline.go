@@ -0,0 +1,281 @@
+package gnoclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// BroadcastMode is the way a Pipeline broadcasts transactions.
+type BroadcastMode int
+
+const (
+	// BroadcastSync waits for the transaction to pass CheckTx, so check errors
+	// are returned, and retried when possible.
+	BroadcastSync BroadcastMode = iota
+
+	// BroadcastAsync doesn't wait for CheckTx. Rejected transactions are
+	// only noticed when waiting for their inclusion.
+	BroadcastAsync
+)
+
+const (
+	defaultRetryDelay   = time.Second
+	defaultPollInterval = 500 * time.Millisecond
+)
+
+// PipelineCfg configures a Pipeline.
+type PipelineCfg struct {
+	Mode BroadcastMode // Broadcast mode, sync by default
+
+	// MaxRetries is the number of times a transaction rejected by CheckTx
+	// is retried, after resyncing the sequence or bumping the fee
+	MaxRetries int
+
+	// RetryDelay is the delay before retrying a transaction whose sequence
+	// was resynced, so pending transactions can be committed (1s by default)
+	RetryDelay time.Duration
+
+	// FeeBumpPercent is the increase of the gas fee, in percent, when a
+	// transaction is retried after an insufficient fee error. Transactions
+	// aren't retried on insufficient fees if it's 0
+	FeeBumpPercent int64
+
+	// PollInterval is the interval between the queries made when waiting
+	// for the inclusion of a transaction (500ms by default)
+	PollInterval time.Duration
+}
+
+// Pipeline signs and broadcasts transactions with the client's signer
+// without waiting for them to be committed, so several transactions of the
+// same account can be pending at once.
+//
+// The account number and sequence are queried once, then tracked locally:
+// each broadcast transaction uses the next sequence. When a transaction is
+// rejected because of its sequence, the sequence is resynced with the chain.
+// A Pipeline is safe for concurrent use.
+type Pipeline struct {
+	client *Client
+	cfg    PipelineCfg
+
+	mu            sync.Mutex
+	synced        bool
+	accountNumber uint64
+	sequence      uint64 // next sequence to use
+}
+
+// NewPipeline creates a Pipeline broadcasting transactions with client.
+func NewPipeline(client *Client, cfg PipelineCfg) *Pipeline {
+	if cfg.RetryDelay == 0 {
+		cfg.RetryDelay = defaultRetryDelay
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+
+	return &Pipeline{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+// PendingTx is a transaction broadcast by a Pipeline.
+type PendingTx struct {
+	Tx            *std.Tx // Signed transaction
+	Hash          []byte  // Hash of the transaction
+	AccountNumber uint64
+	Sequence      uint64
+
+	client       *Client
+	pollInterval time.Duration
+}
+
+// Wait waits for the inclusion of the transaction in a block, until ctx is done.
+func (ptx *PendingTx) Wait(ctx context.Context) (*ctypes.ResultTx, error) {
+	return ptx.client.WaitForTx(ctx, ptx.Hash, ptx.pollInterval)
+}
+
+// Sequence returns the account number and the next sequence of the signer,
+// querying them if they aren't known yet.
+func (p *Pipeline) Sequence() (accountNumber, sequence uint64, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.syncLocked(false); err != nil {
+		return 0, 0, err
+	}
+	return p.accountNumber, p.sequence, nil
+}
+
+// Resync queries the account number and sequence of the signer from the
+// chain, discarding the local sequence.
+func (p *Pipeline) Resync() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.syncLocked(true)
+}
+
+func (p *Pipeline) syncLocked(force bool) error {
+	if p.synced && !force {
+		return nil
+	}
+
+	if err := p.client.validateSigner(); err != nil {
+		return err
+	}
+	caller, err := p.client.Signer.Info()
+	if err != nil {
+		return err
+	}
+
+	account, _, err := p.client.QueryAccount(caller.GetAddress())
+	if err != nil {
+		return errors.Wrap(err, "query account")
+	}
+
+	p.accountNumber = account.AccountNumber
+	p.sequence = account.Sequence
+	p.synced = true
+	return nil
+}
+
+// Submit signs tx with the next sequence of the signer, and broadcasts it.
+// Transactions rejected because of their sequence or fee are retried, as
+// configured by the PipelineCfg.
+func (p *Pipeline) Submit(tx std.Tx) (*PendingTx, error) {
+	for retry := 0; ; retry++ {
+		ptx, checkErr, err := p.submit(tx)
+		if err != nil {
+			return nil, err
+		}
+		if checkErr == nil {
+			return ptx, nil
+		}
+
+		retryErr := errors.Wrapf(checkErr, "check transaction failed")
+		if retry >= p.cfg.MaxRetries {
+			return nil, retryErr
+		}
+
+		switch checkErr.(type) {
+		case std.InvalidSequenceError, std.UnauthorizedError:
+			// The sequence is part of the signed bytes, so a wrong sequence
+			// fails the signature verification. Let the pending transactions
+			// be committed, then resync it
+			time.Sleep(p.cfg.RetryDelay)
+			if err := p.Resync(); err != nil {
+				return nil, err
+			}
+		case std.InsufficientFeeError:
+			if p.cfg.FeeBumpPercent <= 0 {
+				return nil, retryErr
+			}
+			tx.Fee.GasFee = bumpFee(tx.Fee.GasFee, p.cfg.FeeBumpPercent)
+		default:
+			return nil, retryErr
+		}
+	}
+}
+
+// submit signs and broadcasts tx with the next sequence, which is only used
+// if the transaction isn't rejected. CheckTx errors are returned separately.
+func (p *Pipeline) submit(tx std.Tx) (*PendingTx, abci.Error, error) {
+	// Sign and broadcast while holding the lock, so the transactions
+	// reach the node in the order of their sequences
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.syncLocked(false); err != nil {
+		return nil, nil, err
+	}
+
+	signedTx, err := p.client.Signer.Sign(SignCfg{
+		UnsignedTX:     tx,
+		SequenceNumber: p.sequence,
+		AccountNumber:  p.accountNumber,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "sign")
+	}
+
+	bz, err := amino.Marshal(signedTx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "marshaling tx binary bytes")
+	}
+
+	var bres *ctypes.ResultBroadcastTx
+	switch p.cfg.Mode {
+	case BroadcastAsync:
+		bres, err = p.client.RPCClient.BroadcastTxAsync(bz)
+	default:
+		bres, err = p.client.RPCClient.BroadcastTxSync(bz)
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "broadcasting bytes")
+	}
+	if bres.Error != nil {
+		return nil, bres.Error, nil
+	}
+
+	ptx := &PendingTx{
+		Tx:            signedTx,
+		Hash:          types.Tx(bz).Hash(),
+		AccountNumber: p.accountNumber,
+		Sequence:      p.sequence,
+		client:        p.client,
+		pollInterval:  p.cfg.PollInterval,
+	}
+	p.sequence++
+
+	return ptx, nil, nil
+}
+
+// bumpFee increases fee by percent, and at least by one unit.
+func bumpFee(fee std.Coin, percent int64) std.Coin {
+	bump := fee.Amount.Mul(std.NewInt(percent)).Quo(std.NewInt(100))
+	if !bump.IsPositive() {
+		bump = std.NewInt(1)
+	}
+	return std.Coin{Denom: fee.Denom, Amount: fee.Amount.Add(bump)}
+}
+
+// WaitForTx polls the transaction with the given hash every pollInterval,
+// until it's included in a block or ctx is done. If the result has a
+// delivery error, then return a wrapped error.
+func (c *Client) WaitForTx(ctx context.Context, hash []byte, pollInterval time.Duration) (*ctypes.ResultTx, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// The transaction is not found until it's committed
+		res, err := c.RPCClient.Tx(hash)
+		if err == nil && res != nil {
+			if res.TxResult.IsErr() {
+				return res, errors.Wrapf(res.TxResult.Error, "deliver transaction failed: log:%s", res.TxResult.Log)
+			}
+			return res, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %X not committed: %w", hash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package gnoclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// pipelineTestChain is a fake chain checking the sequences of the txs of a
+// single account
+type pipelineTestChain struct {
+	mu sync.Mutex
+
+	accountNumber uint64
+	sequence      uint64 // committed sequence, returned by account queries
+	checkSequence uint64 // next sequence accepted by CheckTx
+	minFee        int64
+
+	accountQueries int
+	broadcasts     []SignCfg
+	signing        SignCfg
+}
+
+func (c *pipelineTestChain) client() *Client {
+	return &Client{
+		Signer: &mockSigner{
+			sign: func(cfg SignCfg) (*std.Tx, error) {
+				c.signing = cfg // signed and broadcast under the pipeline lock
+				return &cfg.UnsignedTX, nil
+			},
+			info: func() (keys.Info, error) {
+				return &mockKeysInfo{
+					getAddress: func() crypto.Address {
+						return crypto.Address{1}
+					},
+				}, nil
+			},
+		},
+		RPCClient: &mockRPCClient{
+			abciQuery: func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+
+				c.accountQueries++
+				bz := amino.MustMarshalJSON(struct{ BaseAccount std.BaseAccount }{
+					BaseAccount: std.BaseAccount{
+						AccountNumber: c.accountNumber,
+						Sequence:      c.sequence,
+					},
+				})
+
+				return &ctypes.ResultABCIQuery{
+					Response: abci.ResponseQuery{
+						ResponseBase: abci.ResponseBase{
+							Data: bz,
+						},
+					},
+				}, nil
+			},
+			broadcastTxSync: c.broadcast,
+			broadcastTxAsync: func(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+				res, err := c.broadcast(tx)
+				if err != nil {
+					return nil, err
+				}
+				// The result of CheckTx is not known
+				return &ctypes.ResultBroadcastTx{Hash: res.Hash}, nil
+			},
+		},
+	}
+}
+
+func (c *pipelineTestChain) broadcast(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cfg := c.signing
+	c.broadcasts = append(c.broadcasts, cfg)
+
+	res := &ctypes.ResultBroadcastTx{Hash: tx.Hash()}
+	switch {
+	case cfg.SequenceNumber != c.checkSequence:
+		res.Error = std.UnauthorizedError{}
+	case cfg.UnsignedTX.Fee.GasFee.Amount.Int64() < c.minFee:
+		res.Error = std.InsufficientFeeError{}
+	default:
+		c.checkSequence++
+	}
+	return res, nil
+}
+
+func newPipelineTestTx(fee int64) std.Tx {
+	return std.Tx{
+		Fee: std.NewFee(100, std.NewCoin("ugnot", fee)),
+	}
+}
+
+func TestPipeline_Submit(t *testing.T) {
+	t.Parallel()
+
+	chain := &pipelineTestChain{accountNumber: 3, sequence: 5, checkSequence: 5}
+	p := NewPipeline(chain.client(), PipelineCfg{})
+
+	for i := 0; i < 3; i++ {
+		ptx, err := p.Submit(newPipelineTestTx(1))
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(3), ptx.AccountNumber)
+		assert.Equal(t, uint64(5+i), ptx.Sequence)
+		assert.NotEmpty(t, ptx.Hash)
+	}
+
+	// The sequence is only queried once
+	assert.Equal(t, 1, chain.accountQueries)
+
+	accountNumber, sequence, err := p.Sequence()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), accountNumber)
+	assert.Equal(t, uint64(8), sequence)
+}
+
+func TestPipeline_Submit_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	chain := &pipelineTestChain{}
+	p := NewPipeline(chain.client(), PipelineCfg{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := p.Submit(newPipelineTestTx(1))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(50), chain.checkSequence)
+	assert.Len(t, chain.broadcasts, 50)
+}
+
+func TestPipeline_Submit_Resync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resynced sequence", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &pipelineTestChain{sequence: 2, checkSequence: 2}
+		p := NewPipeline(chain.client(), PipelineCfg{
+			MaxRetries: 1,
+			RetryDelay: time.Millisecond,
+		})
+
+		_, err := p.Submit(newPipelineTestTx(1))
+		require.NoError(t, err)
+
+		// Another client uses the account
+		chain.sequence, chain.checkSequence = 10, 10
+
+		ptx, err := p.Submit(newPipelineTestTx(1))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), ptx.Sequence)
+
+		assert.Equal(t, 2, chain.accountQueries)
+		require.Len(t, chain.broadcasts, 3)
+		assert.Equal(t, uint64(3), chain.broadcasts[1].SequenceNumber)
+	})
+
+	t.Run("no retries", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &pipelineTestChain{sequence: 2, checkSequence: 4}
+		p := NewPipeline(chain.client(), PipelineCfg{})
+
+		_, err := p.Submit(newPipelineTestTx(1))
+		assert.True(t, errors.As(err, new(std.UnauthorizedError)))
+
+		// The sequence is not used
+		_, sequence, err := p.Sequence()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), sequence)
+	})
+}
+
+func TestPipeline_Submit_FeeBump(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bumped fee", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &pipelineTestChain{minFee: 120}
+		p := NewPipeline(chain.client(), PipelineCfg{
+			MaxRetries:     3,
+			FeeBumpPercent: 10,
+		})
+
+		ptx, err := p.Submit(newPipelineTestTx(100))
+		require.NoError(t, err)
+
+		// 100 -> 110 -> 121
+		assert.Equal(t, int64(121), ptx.Tx.Fee.GasFee.Amount.Int64())
+		assert.Equal(t, uint64(0), ptx.Sequence)
+		assert.Len(t, chain.broadcasts, 3)
+	})
+
+	t.Run("too many retries", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &pipelineTestChain{minFee: 1000}
+		p := NewPipeline(chain.client(), PipelineCfg{
+			MaxRetries:     2,
+			FeeBumpPercent: 10,
+		})
+
+		_, err := p.Submit(newPipelineTestTx(100))
+		assert.True(t, errors.As(err, new(std.InsufficientFeeError)))
+		assert.Len(t, chain.broadcasts, 3)
+	})
+
+	t.Run("fee bump disabled", func(t *testing.T) {
+		t.Parallel()
+
+		chain := &pipelineTestChain{minFee: 1000}
+		p := NewPipeline(chain.client(), PipelineCfg{MaxRetries: 2})
+
+		_, err := p.Submit(newPipelineTestTx(100))
+		assert.True(t, errors.As(err, new(std.InsufficientFeeError)))
+		assert.Len(t, chain.broadcasts, 1)
+	})
+}
+
+func TestPipeline_Submit_Async(t *testing.T) {
+	t.Parallel()
+
+	chain := &pipelineTestChain{minFee: 1000}
+	p := NewPipeline(chain.client(), PipelineCfg{Mode: BroadcastAsync})
+
+	// CheckTx errors are not known
+	ptx, err := p.Submit(newPipelineTestTx(1))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), ptx.Sequence)
+
+	ptx, err = p.Submit(newPipelineTestTx(1))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), ptx.Sequence)
+}
+
+func TestClient_WaitForTx(t *testing.T) {
+	t.Parallel()
+
+	hash := []byte("hash")
+
+	newClient := func(committedAfter int, txResult abci.ResponseDeliverTx) *Client {
+		queries := 0
+		return &Client{
+			RPCClient: &mockRPCClient{
+				tx: func(h []byte) (*ctypes.ResultTx, error) {
+					assert.Equal(t, hash, h)
+
+					queries++
+					if queries <= committedAfter {
+						return nil, errors.New("tx not found")
+					}
+					return &ctypes.ResultTx{Hash: h, Height: 10, TxResult: txResult}, nil
+				},
+			},
+		}
+	}
+
+	t.Run("committed", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(3, abci.ResponseDeliverTx{})
+
+		res, err := client.WaitForTx(context.Background(), hash, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), res.Height)
+	})
+
+	t.Run("deliver error", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(0, abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Error: std.InsufficientFundsError{},
+			},
+		})
+
+		_, err := client.WaitForTx(context.Background(), hash, time.Millisecond)
+		assert.True(t, errors.As(err, new(std.InsufficientFundsError)))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(1_000_000, abci.ResponseDeliverTx{})
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancelFn()
+
+		_, err := client.WaitForTx(ctx, hash, time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("pending tx", func(t *testing.T) {
+		t.Parallel()
+
+		ptx := &PendingTx{
+			Hash:         hash,
+			client:       newClient(1, abci.ResponseDeliverTx{}),
+			pollInterval: time.Millisecond,
+		}
+
+		res, err := ptx.Wait(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, hash, res.Hash)
+	})
+}
@@ -133,6 +133,25 @@ func (s SignerFromKeybase) Sign(cfg SignCfg) (*std.Tx, error) {
 // Ensure SignerFromKeybase implements the Signer interface.
 var _ Signer = (*SignerFromKeybase)(nil)
 
+// SignerFromKeyring creates a signer from the keybase of the given keyring backend,
+// signing with the given account (name or bech32 address).
+// Backends can store keys on disk, in the OS keychain, or delegate signing to an external signer.
+func SignerFromKeyring(opts keys.BackendOptions, account string, password string, chainID string) (*SignerFromKeybase, error) {
+	kb, err := keys.NewKeybase(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := SignerFromKeybase{
+		Keybase:  kb,
+		Account:  account,
+		Password: password,
+		ChainID:  chainID,
+	}
+
+	return &signer, nil
+}
+
 // SignerFromBip39 creates a signer from an in-memory keybase with a single default account, derived from the given mnemonic.
 // This can be useful in scenarios where storing private keys in the filesystem isn't feasible.
 //
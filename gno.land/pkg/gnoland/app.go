@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
@@ -21,7 +22,9 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/feegrant"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/sdk/session"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
@@ -41,6 +44,8 @@ type AppOptions struct {
 	SkipGenesisVerification bool               // default to verify genesis transactions
 	InitChainerConfig                          // options related to InitChainer
 	MinGasPrices            string             // optional
+	ParallelTxs             int                // optional; see sdk.BaseApp.SetParallelTxs
+	ObjectCacheSize         int64              // optional; 0 for vm.DefaultObjectCacheSize, negative to disable
 }
 
 // TestAppOptions provides a "ready" default [AppOptions] for use with
@@ -99,10 +104,15 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	paramsKpr := params.NewParamsKeeper(mainKey, "vm")
 	acctKpr := auth.NewAccountKeeper(mainKey, paramsKpr, ProtoGnoAccount)
 	gpKpr := auth.NewGasPriceKeeper(mainKey)
-	bankKpr := bank.NewBankKeeper(acctKpr)
+	bankKpr := bank.NewBankKeeper(mainKey, acctKpr)
+	feegrantKpr := feegrant.NewFeeGrantKeeper(mainKey, acctKpr)
+	sessionKpr := session.NewSessionKeeper(mainKey)
 
 	vmk := vm.NewVMKeeper(baseKey, mainKey, acctKpr, bankKpr, paramsKpr)
 	vmk.Output = cfg.VMOutput
+	if cfg.ObjectCacheSize != 0 {
+		vmk.ObjectCacheSize = cfg.ObjectCacheSize
+	}
 
 	// Set InitChainer
 	icc := cfg.InitChainerConfig
@@ -113,6 +123,8 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	// Set AnteHandler
 	authOptions := auth.AnteOptions{
 		VerifyGenesisSignatures: !cfg.SkipGenesisVerification,
+		FeeGrantKeeper:          feegrantKpr,
+		SessionKeeper:           sessionKpr,
 	}
 	authAnteHandler := auth.NewAnteHandler(
 		acctKpr, bankKpr, auth.DefaultSigVerificationGasConsumer, authOptions)
@@ -126,6 +138,15 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 
 			// Override auth params.
 			ctx = ctx.WithValue(auth.AuthParamsContextKey{}, acctKpr.GetParams(ctx))
+
+			// Package upgrades take effect once the transaction is committed,
+			// so they can't be bundled with other messages.
+			if err := checkUpgradeTx(tx); err != nil {
+				res = sdk.ABCIResultFromError(err)
+				abort = true
+				return
+			}
+
 			// Continue on with default auth ante handler.
 			newCtx, res, abort = authAnteHandler(ctx, tx, simulate)
 			return
@@ -141,17 +162,26 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 		return vmk.MakeGnoTransactionStore(ctx)
 	})
 	baseApp.SetEndTxHook(func(ctx sdk.Context, result sdk.Result) {
+		vmk.WriteOutput(ctx)
 		if result.IsOK() {
 			vmk.CommitGnoTransactionStore(ctx)
 		}
 	})
 
+	// Enable the parallel execution of txs, if requested.
+	if cfg.ParallelTxs > 0 {
+		baseApp.SetParallelTxs(cfg.ParallelTxs, parallelTxFilter)
+	}
+
 	// Set up the event collector
 	c := newCollector[validatorUpdate](
 		cfg.EventSwitch,      // global event switch filled by the node
 		validatorEventFilter, // filter fn that keeps the collector valid
 	)
 
+	// Set BeginBlocker
+	baseApp.SetBeginBlocker(BeginBlocker(vmk))
+
 	// Set EndBlocker
 	baseApp.SetEndBlocker(
 		EndBlocker(
@@ -166,7 +196,9 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	// Set a handler Route.
 	baseApp.Router().AddRoute("auth", auth.NewHandler(acctKpr))
 	baseApp.Router().AddRoute("bank", bank.NewHandler(bankKpr))
+	baseApp.Router().AddRoute("feegrant", feegrant.NewHandler(feegrantKpr))
 	baseApp.Router().AddRoute("params", params.NewHandler(paramsKpr))
+	baseApp.Router().AddRoute("session", session.NewHandler(sessionKpr))
 	baseApp.Router().AddRoute("vm", vm.NewHandler(vmk))
 
 	// Load latest version.
@@ -204,6 +236,7 @@ func NewApp(
 	evsw events.EventSwitch,
 	logger *slog.Logger,
 	minGasPrices string,
+	parallelTxs int,
 ) (abci.Application, error) {
 	var err error
 
@@ -215,6 +248,7 @@ func NewApp(
 			StdlibDir:              filepath.Join(gnoenv.RootDir(), "gnovm", "stdlibs"),
 		},
 		MinGasPrices:            minGasPrices,
+		ParallelTxs:             parallelTxs,
 		SkipGenesisVerification: genesisCfg.SkipSigVerification,
 	}
 	if genesisCfg.SkipFailingTxs {
@@ -230,6 +264,35 @@ func NewApp(
 	return NewAppWithOptions(cfg)
 }
 
+// parallelTxFilter allows the parallel execution of txs made only of calls
+// and sends. The other messages preprocess new code or upgrade packages, which
+// may modify the types and nodes shared with the concurrent executions.
+func parallelTxFilter(tx std.Tx) bool {
+	for _, msg := range tx.Msgs {
+		switch msg.(type) {
+		case vm.MsgCall, bank.MsgSend:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// checkUpgradeTx makes sure a vm.MsgUpgradePackage is the only message of its transaction
+func checkUpgradeTx(tx std.Tx) error {
+	if len(tx.Msgs) < 2 {
+		return nil
+	}
+
+	for _, msg := range tx.Msgs {
+		if _, ok := msg.(vm.MsgUpgradePackage); ok {
+			return std.ErrUnknownRequest("package upgrades must be the only message of a transaction")
+		}
+	}
+
+	return nil
+}
+
 // GenesisTxResultHandler is called in the InitChainer after a genesis
 // transaction is executed.
 type GenesisTxResultHandler func(ctx sdk.Context, tx std.Tx, res sdk.Result)
@@ -330,16 +393,63 @@ func (cfg InitChainerConfig) loadAppState(ctx sdk.Context, appState any) ([]abci
 	ctx = ctx.WithValue(auth.AuthParamsContextKey{}, params)
 	auth.InitChainer(ctx, cfg.gpKpr.(auth.GasPriceKeeper), params.InitialGasPrice)
 
-	// Apply genesis balances.
-	for _, bal := range state.Balances {
+	// Apply genesis denom metadata, with a default one for ugnot.
+	cfg.bankKpr.InitGenesis(ctx, state.Bank)
+	if _, ok := cfg.bankKpr.GetDenomMetadata(ctx, ugnot.Denom); !ok {
+		md := bank.DenomMetadata{Denom: ugnot.Denom, Display: ugnot.Display, Exponent: ugnot.Exponent}
+		if err := cfg.bankKpr.SetDenomMetadata(ctx, md); err != nil {
+			panic(err)
+		}
+	}
+
+	// Apply genesis balances, locking the vesting ones. They are minted, so
+	// that they make up the initial total supply.
+	vestings := make(map[crypto.Address]Vesting, len(state.Vesting))
+	for _, v := range state.Vesting {
+		if err := v.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid genesis vesting of %s: %w", v.Address, err)
+		}
+		vestings[v.Address] = v
+	}
+
+	// The last balance of an address overrides the previous ones. Overridden
+	// balances still take an account number, as they always did, so that
+	// existing genesis files keep their account numbers and app hash.
+	last := make(map[crypto.Address]int, len(state.Balances))
+	for i, bal := range state.Balances {
+		last[bal.Address] = i
+	}
+	for i, bal := range state.Balances {
 		acc := cfg.acctKpr.NewAccountWithAddress(ctx, bal.Address)
+		if last[bal.Address] != i {
+			continue
+		}
+
+		if v, ok := vestings[bal.Address]; ok {
+			if !bal.Amount.IsAllGTE(v.Amount) {
+				return nil, fmt.Errorf("genesis vesting of %s exceeds its balance %s", v.Address, bal.Amount)
+			}
+			base := std.NewBaseAccount(bal.Address, nil, nil, acc.GetAccountNumber(), 0)
+			vacc, err := v.NewAccount(*base)
+			if err != nil {
+				return nil, fmt.Errorf("invalid genesis vesting of %s: %w", v.Address, err)
+			}
+			acc = vacc
+			delete(vestings, bal.Address)
+		}
 		cfg.acctKpr.SetAccount(ctx, acc)
-		err := cfg.bankKpr.SetCoins(ctx, bal.Address, bal.Amount)
+		err := cfg.bankKpr.MintCoins(ctx, bal.Address, bal.Amount)
 		if err != nil {
 			panic(err)
 		}
 	}
 
+	for _, v := range state.Vesting {
+		if _, ok := vestings[v.Address]; ok {
+			return nil, fmt.Errorf("genesis vesting of %s has no balance", v.Address)
+		}
+	}
+
 	// Apply genesis params.
 	for _, param := range state.Params {
 		param.register(ctx, cfg.paramsKpr)
@@ -392,6 +502,24 @@ func (cfg InitChainerConfig) loadAppState(ctx sdk.Context, appState any) ([]abci
 	return txResponses, nil
 }
 
+// BeginBlocker defines the logic executed before the txs of every block.
+// Currently, it derives the random seed of the block from the randomness
+// of the validators in the last commit.
+func BeginBlocker(vmk vm.VMKeeperI) func(
+	ctx sdk.Context,
+	req abci.RequestBeginBlock,
+) abci.ResponseBeginBlock {
+	return func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+		var votes []abci.VoteInfo
+		if req.LastCommitInfo != nil {
+			votes = req.LastCommitInfo.Votes
+		}
+		vmk.UpdateRandomSeed(ctx, votes)
+
+		return abci.ResponseBeginBlock{}
+	}
+}
+
 // endBlockerApp is the app abstraction required by any EndBlocker
 type endBlockerApp interface {
 	// LastBlockHeight returns the latest app height
@@ -423,10 +551,19 @@ func EndBlocker(
 		if acctKpr != nil && gpKpr != nil {
 			auth.EndBlocker(ctx, gpKpr)
 		}
+		// Execute the due scheduled realm calls, and deliver the pending
+		// realm messages
+		ctx = ctx.WithEventLogger(sdk.NewEventLogger())
+		if vmk != nil {
+			vmk.RunScheduledCalls(ctx)
+			vmk.DeliverMessages(ctx)
+		}
+		events := ctx.EventLogger().Events()
+
 		// Check if there was a valset change
 		if len(collector.getEvents()) == 0 {
 			// No valset updates
-			return abci.ResponseEndBlock{}
+			return abci.ResponseEndBlock{Events: events}
 		}
 
 		// Run the VM to get the updates from the chain
@@ -438,7 +575,7 @@ func EndBlocker(
 		if err != nil {
 			app.Logger().Error("unable to call VM during EndBlocker", "err", err)
 
-			return abci.ResponseEndBlock{}
+			return abci.ResponseEndBlock{Events: events}
 		}
 
 		// Extract the updates from the VM response
@@ -446,11 +583,12 @@ func EndBlocker(
 		if err != nil {
 			app.Logger().Error("unable to extract updates from response", "err", err)
 
-			return abci.ResponseEndBlock{}
+			return abci.ResponseEndBlock{Events: events}
 		}
 
 		return abci.ResponseEndBlock{
 			ValidatorUpdates: updates,
+			Events:           events,
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package gnoland
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/log"
@@ -47,7 +49,7 @@ func TestNewAppWithOptions(t *testing.T) {
 	appState.Balances = []Balance{
 		{
 			Address: addr,
-			Amount:  []std.Coin{{Amount: 1e15, Denom: "ugnot"}},
+			Amount:  []std.Coin{{Amount: std.NewInt(1e15), Denom: "ugnot"}},
 		},
 	}
 	appState.Txs = []TxWithMetadata{
@@ -59,7 +61,7 @@ func TestNewAppWithOptions(t *testing.T) {
 						Body: "package demo; func Hello() string { return `hello`; }",
 					},
 				})},
-				Fee:        std.Fee{GasWanted: 1e6, GasFee: std.Coin{Amount: 1e6, Denom: "ugnot"}},
+				Fee:        std.Fee{GasWanted: 1e6, GasFee: std.Coin{Amount: std.NewInt(1e6), Denom: "ugnot"}},
 				Signatures: []std.Signature{{}}, // one empty signature
 			},
 		},
@@ -89,7 +91,7 @@ func TestNewAppWithOptions(t *testing.T) {
 			GasWanted: 100_000,
 			GasFee: std.Coin{
 				Denom:  "ugnot",
-				Amount: 1_000_000,
+				Amount: std.NewInt(1_000_000),
 			},
 		},
 		Signatures: []std.Signature{{}}, // one empty signature
@@ -134,7 +136,7 @@ func TestNewApp(t *testing.T) {
 	// NewApp should have good defaults and manage to run InitChain.
 	td := t.TempDir()
 
-	app, err := NewApp(td, NewTestGenesisAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), "")
+	app, err := NewApp(td, NewTestGenesisAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), "", 0)
 	require.NoError(t, err, "NewApp should be successful")
 
 	resp := app.InitChain(abci.RequestInitChain{
@@ -220,7 +222,9 @@ func testInitChainerLoadStdlib(t *testing.T, cached bool) { //nolint:thelper
 	}
 	// Construct keepers.
 	paramsKpr := params.NewParamsKeeper(iavlCapKey, "")
-	cfg.acctKpr = auth.NewAccountKeeper(iavlCapKey, paramsKpr, ProtoGnoAccount)
+	acctKpr := auth.NewAccountKeeper(iavlCapKey, paramsKpr, ProtoGnoAccount)
+	cfg.acctKpr = acctKpr
+	cfg.bankKpr = bank.NewBankKeeper(iavlCapKey, acctKpr)
 	cfg.gpKpr = auth.NewGasPriceKeeper(iavlCapKey)
 	cfg.InitChainer(testCtx, abci.RequestInitChain{
 		AppState: DefaultGenState(),
@@ -443,6 +447,198 @@ func TestInitChainer_MetadataTxs(t *testing.T) {
 	}
 }
 
+func TestInitChainer_Vesting(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesisTime = time.Unix(1_000, 0)
+		address     = crypto.AddressFromPreimage([]byte("contributor"))
+		balance     = std.NewCoins(std.NewCoin("ugnot", 1_500))
+		vesting     = Vesting{
+			Address:   address,
+			Kind:      VestingContinuous,
+			Amount:    std.NewCoins(std.NewCoin("ugnot", 1_000)),
+			StartTime: 500,
+			EndTime:   2_500,
+		}
+	)
+
+	initChain := func(t *testing.T, balances []Balance, vestings []Vesting) (*sdk.BaseApp, abci.ResponseInitChain) {
+		t.Helper()
+
+		app, err := NewAppWithOptions(TestAppOptions(memdb.NewMemDB()))
+		require.NoError(t, err)
+
+		resp := app.InitChain(abci.RequestInitChain{
+			ChainID: "test",
+			Time:    genesisTime,
+			ConsensusParams: &abci.ConsensusParams{
+				Block: defaultBlockParams(),
+				Validator: &abci.ValidatorParams{
+					PubKeyTypeURLs: []string{},
+				},
+			},
+			AppState: GnoGenesisState{
+				Balances: balances,
+				Vesting:  vestings,
+			},
+		})
+		return app.(*sdk.BaseApp), resp
+	}
+
+	t.Run("vesting account", func(t *testing.T) {
+		t.Parallel()
+
+		app, resp := initChain(t, []Balance{{Address: address, Amount: balance}}, []Vesting{vesting})
+		require.True(t, resp.IsOK(), "resp is not OK: %v", resp)
+		app.Commit()
+
+		qres := app.Query(abci.RequestQuery{
+			Path: "auth/vesting/" + address.String(),
+		})
+		require.True(t, qres.IsOK(), "query is not OK: %v", qres)
+
+		var schedule auth.VestingSchedule
+		require.NoError(t, amino.UnmarshalJSON(qres.Data, &schedule))
+		assert.Equal(t, vesting.Amount, schedule.OriginalVesting)
+		assert.Equal(t, int64(2_500), schedule.EndTime)
+		assert.Equal(t, "750ugnot", schedule.Locked.String())
+		assert.Equal(t, "750ugnot", schedule.Spendable.String())
+	})
+
+	t.Run("vesting exceeds balance", func(t *testing.T) {
+		t.Parallel()
+
+		_, resp := initChain(t, []Balance{{Address: address, Amount: std.NewCoins(std.NewCoin("ugnot", 999))}}, []Vesting{vesting})
+		require.False(t, resp.IsOK())
+		assert.Contains(t, resp.Error.Error(), "exceeds its balance")
+	})
+
+	t.Run("vesting without balance", func(t *testing.T) {
+		t.Parallel()
+
+		_, resp := initChain(t, nil, []Vesting{vesting})
+		require.False(t, resp.IsOK())
+		assert.Contains(t, resp.Error.Error(), "has no balance")
+	})
+}
+
+func TestInitChainer_Supply(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = crypto.AddressFromPreimage([]byte("addr1"))
+		addr2 = crypto.AddressFromPreimage([]byte("addr2"))
+		foo   = bank.DenomMetadata{Denom: "foo", Display: "kfoo", Exponent: 3}
+	)
+
+	initChain := func(t *testing.T, balances []Balance, denoms []bank.DenomMetadata) (*sdk.BaseApp, abci.ResponseInitChain) {
+		t.Helper()
+
+		app, err := NewAppWithOptions(TestAppOptions(memdb.NewMemDB()))
+		require.NoError(t, err)
+
+		resp := app.InitChain(abci.RequestInitChain{
+			ChainID: "test",
+			ConsensusParams: &abci.ConsensusParams{
+				Block: defaultBlockParams(),
+				Validator: &abci.ValidatorParams{
+					PubKeyTypeURLs: []string{},
+				},
+			},
+			AppState: GnoGenesisState{
+				Balances: balances,
+				Bank:     bank.GenesisState{Denoms: denoms},
+			},
+		})
+		return app.(*sdk.BaseApp), resp
+	}
+
+	t.Run("genesis balances", func(t *testing.T) {
+		t.Parallel()
+
+		app, resp := initChain(t, []Balance{
+			{Address: addr1, Amount: std.MustParseCoins("1000ugnot,10foo")},
+			{Address: addr2, Amount: std.MustParseCoins("500ugnot")},
+		}, []bank.DenomMetadata{foo})
+		require.True(t, resp.IsOK(), "resp is not OK: %v", resp)
+		app.Commit()
+
+		qres := app.Query(abci.RequestQuery{Path: "bank/supply"})
+		require.True(t, qres.IsOK(), "query is not OK: %v", qres)
+		var supply std.Coins
+		require.NoError(t, amino.UnmarshalJSON(qres.Data, &supply))
+		assert.Equal(t, "10foo,1500ugnot", supply.String())
+
+		qres = app.Query(abci.RequestQuery{Path: "bank/denoms"})
+		require.True(t, qres.IsOK(), "query is not OK: %v", qres)
+		var denoms []bank.DenomMetadata
+		require.NoError(t, amino.UnmarshalJSON(qres.Data, &denoms))
+		assert.Equal(t, []bank.DenomMetadata{
+			foo,
+			{Denom: "ugnot", Display: "gnot", Exponent: 6},
+		}, denoms)
+	})
+
+	t.Run("overridden balance", func(t *testing.T) {
+		t.Parallel()
+
+		app, resp := initChain(t, []Balance{
+			{Address: addr1, Amount: std.MustParseCoins("1000ugnot")},
+			{Address: addr2, Amount: std.MustParseCoins("200ugnot")},
+			{Address: addr1, Amount: std.MustParseCoins("500ugnot")},
+		}, nil)
+		require.True(t, resp.IsOK(), "resp is not OK: %v", resp)
+		app.Commit()
+
+		qres := app.Query(abci.RequestQuery{Path: "bank/balances/" + addr1.String()})
+		require.True(t, qres.IsOK(), "query is not OK: %v", qres)
+		var balance std.Coins
+		require.NoError(t, amino.UnmarshalJSON(qres.Data, &balance))
+		assert.Equal(t, "500ugnot", balance.String())
+
+		qres = app.Query(abci.RequestQuery{Path: "bank/supply/ugnot"})
+		require.True(t, qres.IsOK(), "query is not OK: %v", qres)
+		var supply std.Coin
+		require.NoError(t, amino.UnmarshalJSON(qres.Data, &supply))
+		assert.Equal(t, "700ugnot", supply.String())
+
+		// the overridden balance takes an account number
+		for addr, num := range map[crypto.Address]uint64{addr1: 2, addr2: 1} {
+			qres = app.Query(abci.RequestQuery{Path: "auth/accounts/" + addr.String()})
+			require.True(t, qres.IsOK(), "query is not OK: %v", qres)
+			var qret struct{ BaseAccount std.BaseAccount }
+			require.NoError(t, amino.UnmarshalJSON(qres.Data, &qret))
+			assert.Equal(t, num, qret.BaseAccount.AccountNumber)
+		}
+	})
+}
+
+func TestBeginBlocker(t *testing.T) {
+	t.Parallel()
+
+	var (
+		votes = []abci.VoteInfo{
+			{Address: crypto.AddressFromPreimage([]byte("val1")), Randomness: []byte("randomness")},
+		}
+
+		calls        [][]abci.VoteInfo
+		mockVMKeeper = &mockVMKeeper{
+			updateRandomSeedFn: func(_ sdk.Context, votes []abci.VoteInfo) {
+				calls = append(calls, votes)
+			},
+		}
+	)
+
+	bb := BeginBlocker(mockVMKeeper)
+
+	// The random seed is updated with the votes of the last commit, if any
+	bb(sdk.Context{}, abci.RequestBeginBlock{LastCommitInfo: &abci.LastCommitInfo{Votes: votes}})
+	bb(sdk.Context{}, abci.RequestBeginBlock{})
+
+	assert.Equal(t, [][]abci.VoteInfo{votes, nil}, calls)
+}
+
 func TestEndBlocker(t *testing.T) {
 	t.Parallel()
 
@@ -504,6 +700,83 @@ func TestEndBlocker(t *testing.T) {
 		assert.Equal(t, abci.ResponseEndBlock{}, res)
 	})
 
+	t.Run("scheduled call events", func(t *testing.T) {
+		t.Parallel()
+
+		noFilter := func(_ events.Event) []validatorUpdate {
+			return []validatorUpdate{}
+		}
+
+		var (
+			event = gnostdlibs.GnoEvent{
+				Type:    "ScheduledCallExecuted",
+				PkgPath: "gno.land/r/demo/auction",
+				Func:    "Close",
+			}
+
+			mockVMKeeper = &mockVMKeeper{
+				runScheduledCallsFn: func(ctx sdk.Context) {
+					ctx.EventLogger().EmitEvent(event)
+				},
+			}
+		)
+
+		// Create the collector
+		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
+
+		// Create the EndBlocker
+		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+
+		// Run the EndBlocker
+		res := eb(sdk.Context{}, abci.RequestEndBlock{})
+
+		// Verify the scheduled call events are returned
+		assert.Equal(t, []abci.Event{event}, res.Events)
+		assert.Empty(t, res.ValidatorUpdates)
+	})
+
+	t.Run("realm message events", func(t *testing.T) {
+		t.Parallel()
+
+		noFilter := func(_ events.Event) []validatorUpdate {
+			return []validatorUpdate{}
+		}
+
+		var (
+			callEvent = gnostdlibs.GnoEvent{
+				Type:    "ScheduledCallExecuted",
+				PkgPath: "gno.land/r/demo/auction",
+				Func:    "Close",
+			}
+			msgEvent = gnostdlibs.GnoEvent{
+				Type:    "MessageDelivered",
+				PkgPath: "gno.land/r/demo/dex",
+				Func:    "Settle",
+			}
+
+			mockVMKeeper = &mockVMKeeper{
+				runScheduledCallsFn: func(ctx sdk.Context) {
+					ctx.EventLogger().EmitEvent(callEvent)
+				},
+				deliverMessagesFn: func(ctx sdk.Context) {
+					ctx.EventLogger().EmitEvent(msgEvent)
+				},
+			}
+		)
+
+		// Create the collector
+		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
+
+		// Create the EndBlocker
+		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+
+		// Run the EndBlocker
+		res := eb(sdk.Context{}, abci.RequestEndBlock{})
+
+		// Verify the messages are delivered after the scheduled calls
+		assert.Equal(t, []abci.Event{callEvent, msgEvent}, res.Events)
+	})
+
 	t.Run("invalid VM call", func(t *testing.T) {
 		t.Parallel()
 
@@ -687,7 +960,7 @@ func TestGasPriceUpdate(t *testing.T) {
 	tx.Fee = std.Fee{
 		GasWanted: 100,
 		GasFee: sdk.Coin{
-			Amount: 9,
+			Amount: std.NewInt(9),
 			Denom:  "ugnot",
 		},
 	}
@@ -703,7 +976,7 @@ func TestGasPriceUpdate(t *testing.T) {
 	tx2.Fee = std.Fee{
 		GasWanted: 1000,
 		GasFee: sdk.Coin{
-			Amount: 100,
+			Amount: std.NewInt(100),
 			Denom:  "ugnot",
 		},
 	}
@@ -721,7 +994,7 @@ func TestGasPriceUpdate(t *testing.T) {
 	tx6001.Fee = std.Fee{
 		GasWanted: 20000,
 		GasFee: sdk.Coin{
-			Amount: 200,
+			Amount: std.NewInt(200),
 			Denom:  "ugnot",
 		},
 	}
@@ -751,7 +1024,7 @@ func TestGasPriceUpdate(t *testing.T) {
 	tx200.Fee = std.Fee{
 		GasWanted: 20000,
 		GasFee: sdk.Coin{
-			Amount: 200,
+			Amount: std.NewInt(200),
 			Denom:  "ugnot",
 		},
 	}
@@ -825,7 +1098,7 @@ func newGasPriceTestApp(t *testing.T) abci.Application {
 	paramsKpr := params.NewParamsKeeper(mainKey, "")
 	acctKpr := auth.NewAccountKeeper(mainKey, paramsKpr, ProtoGnoAccount)
 	gpKpr := auth.NewGasPriceKeeper(mainKey)
-	bankKpr := bank.NewBankKeeper(acctKpr)
+	bankKpr := bank.NewBankKeeper(mainKey, acctKpr)
 	vmk := vm.NewVMKeeper(baseKey, mainKey, acctKpr, bankKpr, paramsKpr)
 
 	// Set InitChainer
@@ -962,7 +1235,7 @@ func replayBlock(t *testing.T, app *sdk.BaseApp, gas int64, hight int64) {
 	tx.Fee = std.Fee{
 		GasWanted: 20000,
 		GasFee: sdk.Coin{
-			Amount: 1000,
+			Amount: std.NewInt(1000),
 			Denom:  "ugnot",
 		},
 	}
@@ -996,3 +1269,163 @@ func newTestHandler(proc func(sdk.Context, sdk.Msg) sdk.Result) sdk.Handler {
 		process: proc,
 	}
 }
+
+func TestCheckUpgradeTx(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr    = crypto.AddressFromPreimage([]byte("addr1"))
+		upgrade = vm.NewMsgUpgradePackage(addr, "gno.land/r/test", nil)
+		call    = vm.NewMsgCall(addr, nil, "gno.land/r/test", "Echo", nil)
+	)
+
+	testTable := []struct {
+		name  string
+		msgs  []std.Msg
+		valid bool
+	}{
+		{"single upgrade", []std.Msg{upgrade}, true},
+		{"multiple calls", []std.Msg{call, call}, true},
+		{"upgrade with call", []std.Msg{upgrade, call}, false},
+		{"call with upgrade", []std.Msg{call, upgrade}, false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkUpgradeTx(std.Tx{Msgs: testCase.msgs})
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, std.UnknownRequestError{})
+			}
+		})
+	}
+}
+
+func TestParallelTxs(t *testing.T) {
+	t.Parallel()
+
+	const chainID = "test-chain"
+	keys := make([]crypto.PrivKey, 8)
+	for i := range keys {
+		keys[i] = secp256k1.GenPrivKeySecp256k1([]byte{byte(i)})
+	}
+	addr := func(i int) crypto.Address { return keys[i].PubKey().Address() }
+
+	appState := DefaultGenState()
+	for i := range keys {
+		appState.Balances = append(appState.Balances, Balance{
+			Address: addr(i),
+			Amount:  std.MustParseCoins("10000000000ugnot"),
+		})
+	}
+	counter := `package counter
+
+var n int
+
+func Inc() int {
+	n++
+	println("inc", n)
+	return n
+}
+
+func Fail() { panic("fail") }`
+	proxy := `package proxy
+
+import "gno.land/r/demo/counter"
+
+func Inc() int { return counter.Inc() }`
+	for _, pkg := range []struct{ path, body string }{
+		{"gno.land/r/demo/counter", counter},
+		{"gno.land/r/demo/counter2", strings.Replace(counter, "counter", "counter2", 1)},
+		{"gno.land/r/demo/proxy", proxy},
+	} {
+		appState.Txs = append(appState.Txs, TxWithMetadata{
+			Tx: std.Tx{
+				Msgs: []std.Msg{vm.NewMsgAddPackage(addr(0), pkg.path, []*gnovm.MemFile{
+					{Name: "pkg.gno", Body: pkg.body},
+				})},
+				Fee:        std.Fee{GasWanted: 1e7, GasFee: std.NewCoin("ugnot", 1e6)},
+				Signatures: []std.Signature{{}},
+			},
+		})
+	}
+
+	newApp := func(parallelTxs int) (*sdk.BaseApp, *bytes.Buffer) {
+		var output bytes.Buffer
+		opts := TestAppOptions(memdb.NewMemDB())
+		opts.VMOutput = &output
+		opts.ParallelTxs = parallelTxs
+		app, err := NewAppWithOptions(opts)
+		require.NoError(t, err)
+		resp := app.InitChain(abci.RequestInitChain{
+			ChainID:         chainID,
+			ConsensusParams: &abci.ConsensusParams{Block: defaultBlockParams()},
+			AppState:        appState,
+		})
+		require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+		return app.(*sdk.BaseApp), &output
+	}
+
+	sequences := make([]uint64, len(keys))
+	newTx := func(i int, msg std.Msg) []byte {
+		tx := std.Tx{
+			Msgs: []std.Msg{msg},
+			Fee:  std.Fee{GasWanted: 1e7, GasFee: std.NewCoin("ugnot", 1e6)},
+		}
+		// accounts are created in the order of the balances.
+		signBytes, err := tx.GetSignBytes(chainID, uint64(i), sequences[i])
+		require.NoError(t, err)
+		sequences[i]++
+		sig, err := keys[i].Sign(signBytes)
+		require.NoError(t, err)
+		tx.Signatures = []std.Signature{{PubKey: keys[i].PubKey(), Signature: sig}}
+		return amino.MustMarshal(tx)
+	}
+	call := func(i int, pkgPath, fn string) []byte {
+		return newTx(i, vm.NewMsgCall(addr(i), nil, pkgPath, fn, nil))
+	}
+	newBlock := func() [][]byte {
+		return [][]byte{
+			call(1, "gno.land/r/demo/counter", "Inc"),
+			call(2, "gno.land/r/demo/counter2", "Inc"),
+			call(3, "gno.land/r/demo/proxy", "Inc"), // conflicts with tx 0
+			newTx(4, bank.NewMsgSend(addr(4), addr(5), std.MustParseCoins("1000ugnot"))),
+			call(5, "gno.land/r/demo/counter2", "Fail"),
+			call(1, "gno.land/r/demo/counter2", "Inc"), // second tx of 1
+			newTx(6, vm.NewMsgAddPackage(addr(6), "gno.land/r/demo/other", []*gnovm.MemFile{
+				{Name: "other.gno", Body: "package other\n\nfunc Hello() string { return \"hello\" }"},
+			})),
+			call(7, "gno.land/r/demo/counter", "Inc"), // after an addpkg
+		}
+	}
+
+	seq, seqOutput := newApp(0)
+	par, parOutput := newApp(4)
+	for height := int64(1); height <= 2; height++ {
+		txs := newBlock()
+		var hashes [2][]byte
+		var responses [2][]abci.ResponseDeliverTx
+		for i, app := range []*sdk.BaseApp{seq, par} {
+			app.BeginBlock(abci.RequestBeginBlock{
+				Header: &bft.Header{ChainID: chainID, Height: height},
+				Txs:    txs,
+			})
+			for _, tx := range txs {
+				responses[i] = append(responses[i], app.DeliverTx(abci.RequestDeliverTx{Tx: tx}))
+			}
+			app.EndBlock(abci.RequestEndBlock{})
+			hashes[i] = app.Commit().Data
+		}
+
+		for i, resp := range responses[0] {
+			assert.Equal(t, i != 4 && (i != 6 || height == 1), resp.IsOK(), "tx %d: %v", i, resp)
+		}
+		require.Equal(t, responses[0], responses[1])
+		require.Equal(t, hashes[0], hashes[1])
+		require.Equal(t, seqOutput.String(), parOutput.String())
+	}
+	assert.Contains(t, seqOutput.String(), "inc 6")
+}
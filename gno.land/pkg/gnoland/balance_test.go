@@ -2,8 +2,7 @@ package gnoland
 
 import (
 	"fmt"
-	"math"
-	"strconv"
+	"math/big"
 	"strings"
 	"testing"
 
@@ -121,7 +120,7 @@ func TestBalances_GetBalancesFromEntries(t *testing.T) {
 			entries[index] = fmt.Sprintf(
 				"%s=%s",
 				key.PubKey().Address().String(),
-				ugnot.ValueString(amount.AmountOf(ugnot.Denom)),
+				ugnot.ValueString(amount.AmountOf(ugnot.Denom).Int64()),
 			)
 		}
 
@@ -168,7 +167,7 @@ func TestBalances_GetBalancesFromEntries(t *testing.T) {
 			fmt.Sprintf(
 				"%s=%s%s",
 				dummyKey.Address().String(),
-				strconv.FormatUint(math.MaxUint64, 10),
+				new(big.Int).Lsh(big.NewInt(1), std.MaxIntBitLen).String(), // too large
 				ugnot.Denom,
 			),
 		}
@@ -195,7 +194,7 @@ func TestBalances_GetBalancesFromSheet(t *testing.T) {
 			balances[index] = fmt.Sprintf(
 				"%s=%s",
 				key.PubKey().Address().String(),
-				ugnot.ValueString(amount.AmountOf(ugnot.Denom)),
+				ugnot.ValueString(amount.AmountOf(ugnot.Denom).Int64()),
 			)
 		}
 
@@ -219,7 +218,7 @@ func TestBalances_GetBalancesFromSheet(t *testing.T) {
 			fmt.Sprintf(
 				"%s=%s%s",
 				dummyKey.Address().String(),
-				strconv.FormatUint(math.MaxUint64, 10),
+				new(big.Int).Lsh(big.NewInt(1), std.MaxIntBitLen).String(), // too large
 				ugnot.Denom,
 			),
 		}
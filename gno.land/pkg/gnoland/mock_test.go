@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/log"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
@@ -47,6 +48,7 @@ func (m *mockEventSwitch) RemoveListener(listenerID string) {
 
 type mockVMKeeper struct {
 	addPackageFn                func(sdk.Context, vm.MsgAddPackage) error
+	upgradePackageFn            func(sdk.Context, vm.MsgUpgradePackage) error
 	callFn                      func(sdk.Context, vm.MsgCall) (string, error)
 	queryFn                     func(sdk.Context, string, string) (string, error)
 	runFn                       func(sdk.Context, vm.MsgRun) (string, error)
@@ -54,6 +56,9 @@ type mockVMKeeper struct {
 	loadStdlibCachedFn          func(sdk.Context, string)
 	makeGnoTransactionStoreFn   func(ctx sdk.Context) sdk.Context
 	commitGnoTransactionStoreFn func(ctx sdk.Context)
+	runScheduledCallsFn         func(ctx sdk.Context)
+	deliverMessagesFn           func(ctx sdk.Context)
+	updateRandomSeedFn          func(ctx sdk.Context, votes []abci.VoteInfo)
 }
 
 func (m *mockVMKeeper) AddPackage(ctx sdk.Context, msg vm.MsgAddPackage) error {
@@ -64,6 +69,14 @@ func (m *mockVMKeeper) AddPackage(ctx sdk.Context, msg vm.MsgAddPackage) error {
 	return nil
 }
 
+func (m *mockVMKeeper) UpgradePackage(ctx sdk.Context, msg vm.MsgUpgradePackage) error {
+	if m.upgradePackageFn != nil {
+		return m.upgradePackageFn(ctx, msg)
+	}
+
+	return nil
+}
+
 func (m *mockVMKeeper) Call(ctx sdk.Context, msg vm.MsgCall) (res string, err error) {
 	if m.callFn != nil {
 		return m.callFn(ctx, msg)
@@ -113,6 +126,24 @@ func (m *mockVMKeeper) CommitGnoTransactionStore(ctx sdk.Context) {
 	}
 }
 
+func (m *mockVMKeeper) RunScheduledCalls(ctx sdk.Context) {
+	if m.runScheduledCallsFn != nil {
+		m.runScheduledCallsFn(ctx)
+	}
+}
+
+func (m *mockVMKeeper) DeliverMessages(ctx sdk.Context) {
+	if m.deliverMessagesFn != nil {
+		m.deliverMessagesFn(ctx)
+	}
+}
+
+func (m *mockVMKeeper) UpdateRandomSeed(ctx sdk.Context, votes []abci.VoteInfo) {
+	if m.updateRandomSeedFn != nil {
+		m.updateRandomSeedFn(ctx, votes)
+	}
+}
+
 type (
 	lastBlockHeightDelegate func() int64
 	loggerDelegate          func() *slog.Logger
@@ -10,6 +10,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -28,9 +29,11 @@ func ProtoGnoAccount() std.Account {
 
 type GnoGenesisState struct {
 	Balances []Balance         `json:"balances"`
+	Vesting  []Vesting         `json:"vesting,omitempty"` // vesting schedules of balances
 	Txs      []TxWithMetadata  `json:"txs"`
 	Params   []Param           `json:"params"`
 	Auth     auth.GenesisState `json:"auth"`
+	Bank     bank.GenesisState `json:"bank"`
 }
 
 type TxWithMetadata struct {
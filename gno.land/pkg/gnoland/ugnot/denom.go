@@ -2,8 +2,15 @@ package ugnot
 
 import "strconv"
 
-// Denom is the denomination for ugnot, gno.land's native token.
-const Denom = "ugnot"
+const (
+	// Denom is the denomination for ugnot, gno.land's native token.
+	Denom = "ugnot"
+
+	// Display is the unit in which ugnot amounts are displayed: 1gnot is
+	// 10^Exponent ugnot.
+	Display  = "gnot"
+	Exponent = 6
+)
 
 // ValueString converts `value` to a string, appends "ugnot", and returns it.
 func ValueString(value int64) string {
@@ -0,0 +1,113 @@
+package gnoland
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Kinds of genesis vesting schedules
+const (
+	VestingContinuous = "continuous" // vests linearly, from the start to the end time
+	VestingDelayed    = "delayed"    // vests everything at the end time
+	VestingPeriodic   = "periodic"   // vests the amount of each period at its end
+)
+
+var (
+	ErrVestingEmptyAddress = errors.New("vesting address is empty")
+	ErrVestingEmptyAmount  = errors.New("vesting amount is empty")
+	ErrVestingInvalidKind  = errors.New("invalid vesting kind")
+)
+
+// Vesting locks the Amount of the genesis balance of Address, which unlocks
+// over time. Times are UNIX timestamps, in seconds.
+type Vesting struct {
+	Address   crypto.Address      `json:"address"`
+	Kind      string              `json:"kind"`
+	Amount    std.Coins           `json:"amount"`
+	StartTime int64               `json:"start_time"`
+	EndTime   int64               `json:"end_time"`
+	Periods   []std.VestingPeriod `json:"periods,omitempty"` // only for periodic vesting
+}
+
+// NewPeriodicVesting returns a periodic vesting of amount for address, in
+// installments vesting every period seconds, from start to end. The last
+// installment vests the remainder, at end. The period must be positive.
+func NewPeriodicVesting(address crypto.Address, amount std.Coins, start, end, period int64) Vesting {
+	var (
+		n       = (end - start + period - 1) / period // number of periods
+		periods = make([]std.VestingPeriod, 0, n)
+		left    = amount
+	)
+
+	for i := int64(0); i < n; i++ {
+		length := period
+		if i == n-1 {
+			length = end - start - period*(n-1)
+		}
+
+		installment := left
+		if i < n-1 {
+			installment = nil
+			for _, coin := range amount {
+				amt := coin.Amount.Quo(std.NewInt(n))
+				if amt.IsPositive() {
+					installment = append(installment, std.Coin{Denom: coin.Denom, Amount: amt})
+				}
+			}
+		}
+		left = left.Sub(installment)
+
+		periods = append(periods, std.VestingPeriod{Length: length, Amount: installment})
+	}
+
+	return Vesting{
+		Address:   address,
+		Kind:      VestingPeriodic,
+		Amount:    amount,
+		StartTime: start,
+		EndTime:   end,
+		Periods:   periods,
+	}
+}
+
+func (v Vesting) Verify() error {
+	if v.Address.IsZero() {
+		return ErrVestingEmptyAddress
+	}
+
+	if v.Amount.Len() == 0 {
+		return ErrVestingEmptyAmount
+	}
+
+	acc, err := v.NewAccount(std.BaseAccount{Address: v.Address})
+	if err != nil {
+		return err
+	}
+
+	return acc.Validate()
+}
+
+// NewAccount returns the vesting account locking the coins of base, with the
+// vesting schedule.
+func (v Vesting) NewAccount(base std.BaseAccount) (std.VestingAccount, error) {
+	switch v.Kind {
+	case VestingContinuous:
+		return std.NewContinuousVestingAccount(base, v.Amount, v.StartTime, v.EndTime), nil
+	case VestingDelayed:
+		return std.NewDelayedVestingAccount(base, v.Amount, v.EndTime), nil
+	case VestingPeriodic:
+		acc := std.NewPeriodicVestingAccount(base, v.StartTime, v.Periods)
+		if end := acc.GetEndTime(); end != v.EndTime {
+			return nil, fmt.Errorf("vesting periods end at %d, not at the end time %d", end, v.EndTime)
+		}
+		if !acc.OriginalVesting.IsEqual(v.Amount) {
+			return nil, fmt.Errorf("vesting periods total %s, not the amount %s", acc.OriginalVesting, v.Amount)
+		}
+		return acc, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrVestingInvalidKind, v.Kind)
+	}
+}
@@ -0,0 +1,69 @@
+package gnoland
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestVesting_Verify(t *testing.T) {
+	validAddress := crypto.MustAddressFromString("g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5")
+	amount := std.NewCoins(std.NewCoin("ugnot", 100))
+
+	tests := []struct {
+		name        string
+		vesting     Vesting
+		expectErr   bool
+		expectedErr error
+	}{
+		{"empty address", Vesting{Kind: VestingDelayed, Amount: amount, EndTime: 10}, true, ErrVestingEmptyAddress},
+		{"empty amount", Vesting{Address: validAddress, Kind: VestingDelayed, EndTime: 10}, true, ErrVestingEmptyAmount},
+		{"invalid kind", Vesting{Address: validAddress, Kind: "linear", Amount: amount, EndTime: 10}, true, ErrVestingInvalidKind},
+		{"end before start", Vesting{Address: validAddress, Kind: VestingContinuous, Amount: amount, StartTime: 10, EndTime: 5}, true, nil},
+		{"periodic end mismatch", Vesting{
+			Address: validAddress, Kind: VestingPeriodic, Amount: amount, StartTime: 0, EndTime: 20,
+			Periods: []std.VestingPeriod{{Length: 10, Amount: amount}},
+		}, true, nil},
+		{"periodic amount mismatch", Vesting{
+			Address: validAddress, Kind: VestingPeriodic, Amount: amount, StartTime: 0, EndTime: 10,
+			Periods: []std.VestingPeriod{{Length: 10, Amount: std.NewCoins(std.NewCoin("ugnot", 99))}},
+		}, true, nil},
+		{"valid continuous", Vesting{Address: validAddress, Kind: VestingContinuous, Amount: amount, StartTime: 5, EndTime: 10}, false, nil},
+		{"valid delayed", Vesting{Address: validAddress, Kind: VestingDelayed, Amount: amount, EndTime: 10}, false, nil},
+		{"valid periodic", NewPeriodicVesting(validAddress, amount, 0, 10, 3), false, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.vesting.Verify()
+			if !tc.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestNewPeriodicVesting(t *testing.T) {
+	address := crypto.MustAddressFromString("g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5")
+	amount := std.NewCoins(std.NewCoin("foo", 2), std.NewCoin("ugnot", 100))
+
+	v := NewPeriodicVesting(address, amount, 100, 200, 30)
+	require.NoError(t, v.Verify())
+
+	assert.Equal(t, []std.VestingPeriod{
+		{Length: 30, Amount: std.NewCoins(std.NewCoin("ugnot", 25))},
+		{Length: 30, Amount: std.NewCoins(std.NewCoin("ugnot", 25))},
+		{Length: 30, Amount: std.NewCoins(std.NewCoin("ugnot", 25))},
+		{Length: 10, Amount: std.NewCoins(std.NewCoin("foo", 2), std.NewCoin("ugnot", 25))},
+	}, v.Periods)
+}
@@ -125,5 +125,8 @@ func NewRouter(logger *slog.Logger, cfg *AppConfig) (http.Handler, error) {
 	// Handle status page
 	mux.Handle("/status.json", handlerStatusJSON(logger, client))
 
+	// Handle supply page
+	mux.Handle("/supply.json", handlerSupplyJSON(logger, client))
+
 	return mux, nil
 }
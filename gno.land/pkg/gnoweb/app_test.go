@@ -60,6 +60,8 @@ func TestRoutes(t *testing.T) {
 		{"/public/js/index.js", ok, ""},
 		{"/public/_chroma/style.css", ok, ""},
 		{"/public/imgs/gnoland.svg", ok, ""},
+		// Test supply
+		{"/supply.json", ok, `"display": "gnot"`},
 		// Test Toc
 		{"/", ok, `href="#learn-about-gnoland"`},
 	}
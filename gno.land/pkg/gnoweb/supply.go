@@ -0,0 +1,74 @@
+package gnoweb
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// supplyDenom is the total supply of a denom, with its metadata if any.
+type supplyDenom struct {
+	Denom    string `json:"denom"`
+	Amount   string `json:"amount"`
+	Display  string `json:"display,omitempty"`
+	Exponent uint32 `json:"exponent"`
+	Realm    string `json:"realm,omitempty"`
+}
+
+// handlerSupplyJSON serves the total supply of the denoms of the chain, for
+// explorers and other tools.
+func handlerSupplyJSON(logger *slog.Logger, cli *client.RPCClient) http.Handler {
+	query := func(qpath string, res any) error {
+		qres, err := cli.ABCIQuery(qpath, []byte{})
+		if err != nil {
+			return errors.Wrapf(err, "query %s", qpath)
+		}
+		if qres.Response.Error != nil {
+			return errors.Wrapf(qres.Response.Error, "query %s", qpath)
+		}
+		return amino.UnmarshalJSON(qres.Response.Data, res)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			supply std.Coins
+			denoms []bank.DenomMetadata
+		)
+		err := query("bank/supply", &supply)
+		if err == nil {
+			err = query("bank/denoms", &denoms)
+		}
+		if err != nil {
+			logger.Error("unable to query supply", "err", err)
+			http.Error(w, "unable to query supply", http.StatusInternalServerError)
+			return
+		}
+
+		metadata := make(map[string]bank.DenomMetadata, len(denoms))
+		for _, md := range denoms {
+			metadata[md.Denom] = md
+		}
+
+		ret := make([]supplyDenom, 0, len(supply))
+		for _, coin := range supply {
+			md := metadata[coin.Denom]
+			ret = append(ret, supplyDenom{
+				Denom:    coin.Denom,
+				Amount:   coin.Amount.String(),
+				Display:  md.Display,
+				Exponent: md.Exponent,
+				Realm:    md.Realm,
+			})
+		}
+
+		out, _ := json.MarshalIndent(ret, "", "  ")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(out)
+	})
+}
@@ -194,7 +194,7 @@ func GenerateTestingGenesisState(creator crypto.PrivKey, pkgs ...gnovm.MemPackag
 	for i, pkg := range pkgs {
 		// Create transaction
 		var tx std.Tx
-		tx.Fee = std.Fee{GasWanted: 1e6, GasFee: std.Coin{Amount: 1e6, Denom: "ugnot"}}
+		tx.Fee = std.Fee{GasWanted: 1e6, GasFee: std.Coin{Amount: std.NewInt(1e6), Denom: "ugnot"}}
 		tx.Msgs = []std.Msg{
 			vmm.MsgAddPackage{
 				Creator: creator.PubKey().Address(),
@@ -0,0 +1,380 @@
+package keyscli
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"gopkg.in/yaml.v3"
+)
+
+type MakeBatchCfg struct {
+	RootCfg *client.MakeTxCfg
+
+	File          string
+	GasAdjustment float64
+}
+
+func NewMakeBatchCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeBatchCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "batch",
+			ShortUsage: "batch [flags] <key-name or address>",
+			ShortHelp:  "executes the messages of a YAML file in a single tx",
+			LongHelp: `Composes a single tx from the list of messages of a YAML file, which are
+executed in order, all or nothing. Each message has a type and its fields:
+
+  msgs:
+    - type: addpkg        # pkgpath, pkgdir or files, deposit
+      pkgpath: gno.land/r/demo/foo
+      files: ["foo/*.gno"]
+    - type: call          # pkgpath, func, args, send
+      pkgpath: gno.land/r/demo/foo
+      func: SetOwner
+      args: ["g1..."]
+    - type: run           # pkgdir or files, send
+      files: ["script.gno"]
+    - type: send          # to, send
+      to: g1...
+      send: 1000ugnot
+
+The pkgdir and files paths, which may be glob patterns, are relative to the
+directory of the YAML file. Without -gas-wanted, the gas wanted is estimated by
+simulating the tx, which requires -broadcast.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeBatch(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeBatchCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.File,
+		"f",
+		"",
+		"path to the YAML file of messages (required)",
+	)
+
+	fs.Float64Var(
+		&c.GasAdjustment,
+		"gas-adjustment",
+		1.2,
+		"factor applied to the simulated gas used, when the gas wanted is estimated",
+	)
+}
+
+// batchFile is the YAML file of messages of maketx batch.
+type batchFile struct {
+	Msgs []batchMsg `yaml:"msgs"`
+}
+
+// batchMsg is a message of a batchFile; which fields are used depends on its
+// type.
+type batchMsg struct {
+	Type    string   `yaml:"type"`
+	PkgPath string   `yaml:"pkgpath"`
+	Func    string   `yaml:"func"`
+	Args    []string `yaml:"args"`
+	Send    string   `yaml:"send"`
+	To      string   `yaml:"to"`
+	Deposit string   `yaml:"deposit"`
+	PkgDir  string   `yaml:"pkgdir"`
+	Files   []string `yaml:"files"`
+}
+
+// These are the valid types of a batchMsg.
+const (
+	batchMsgCall   = "call"
+	batchMsgSend   = "send"
+	batchMsgRun    = "run"
+	batchMsgAddPkg = "addpkg"
+)
+
+func execMakeBatch(cfg *MakeBatchCfg, args []string, io commands.IO) error {
+	if cfg.File == "" {
+		return errors.New("f not specified")
+	}
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+	if cfg.RootCfg.GasWanted == 0 && !cfg.RootCfg.Broadcast {
+		return errors.New("gas-wanted not specified (it can only be estimated with --broadcast)")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.GasAdjustment < 1 {
+		return errors.New("gas-adjustment must be at least 1")
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := cfg.RootCfg.RootCfg.Keybase(io)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+	signer := info.GetAddress()
+
+	// parse gas wanted & fee.
+	gaswanted := cfg.RootCfg.GasWanted
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+
+	msgs, err := readBatchFile(cfg.File, signer)
+	if err != nil {
+		return err
+	}
+
+	// construct tx and marshal.
+	tx := std.Tx{
+		Msgs:       msgs,
+		Fee:        std.NewFee(gaswanted, gasfee),
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	cfg.RootCfg.GasAdjustment = cfg.GasAdjustment
+	return client.ExecMakeTx(cfg.RootCfg, args, tx, io)
+}
+
+// readBatchFile reads the YAML file of messages at path, and returns them as
+// messages of signer.
+func readBatchFile(path string, signer crypto.Address) ([]std.Msg, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %q: %w", path, err)
+	}
+
+	var file batchFile
+	dec := yaml.NewDecoder(bytes.NewReader(bz))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("could not parse %q: %w", path, err)
+	}
+	if len(file.Msgs) == 0 {
+		return nil, fmt.Errorf("no msgs in %q", path)
+	}
+
+	dir := filepath.Dir(path)
+	msgs := make([]std.Msg, 0, len(file.Msgs))
+	for i, bm := range file.Msgs {
+		msg, err := bm.toMsg(dir, signer)
+		if err != nil {
+			return nil, fmt.Errorf("msg #%d (%s): %w", i, bm.Type, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// toMsg returns the message of signer described by bm, reading the files of
+// its package relative to dir.
+func (bm batchMsg) toMsg(dir string, signer crypto.Address) (std.Msg, error) {
+	switch bm.Type {
+	case batchMsgCall:
+		if err := bm.checkFields("pkgpath", "func", "args", "send"); err != nil {
+			return nil, err
+		}
+		if bm.PkgPath == "" {
+			return nil, errors.New("pkgpath not specified")
+		}
+		if bm.Func == "" {
+			return nil, errors.New("func not specified")
+		}
+		send, err := std.ParseCoins(bm.Send)
+		if err != nil {
+			return nil, fmt.Errorf("parsing send coins: %w", err)
+		}
+		return vm.MsgCall{
+			Caller:  signer,
+			Send:    send,
+			PkgPath: bm.PkgPath,
+			Func:    bm.Func,
+			Args:    bm.Args,
+		}, nil
+
+	case batchMsgSend:
+		if err := bm.checkFields("to", "send"); err != nil {
+			return nil, err
+		}
+		if bm.To == "" {
+			return nil, errors.New("to not specified")
+		}
+		if bm.Send == "" {
+			return nil, errors.New("send not specified")
+		}
+		to, err := crypto.AddressFromBech32(bm.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to address: %w", err)
+		}
+		send, err := std.ParseCoins(bm.Send)
+		if err != nil {
+			return nil, fmt.Errorf("parsing send coins: %w", err)
+		}
+		return bank.MsgSend{
+			FromAddress: signer,
+			ToAddress:   to,
+			Amount:      send,
+		}, nil
+
+	case batchMsgRun:
+		if err := bm.checkFields("pkgdir", "files", "send"); err != nil {
+			return nil, err
+		}
+		send, err := std.ParseCoins(bm.Send)
+		if err != nil {
+			return nil, fmt.Errorf("parsing send coins: %w", err)
+		}
+		memPkg, err := bm.readPackage(dir, "")
+		if err != nil {
+			return nil, err
+		}
+		memPkg.Name = "main"
+		// Set to empty; this will be automatically set by the VM keeper.
+		memPkg.Path = ""
+		return vm.MsgRun{
+			Caller:  signer,
+			Send:    send,
+			Package: memPkg,
+		}, nil
+
+	case batchMsgAddPkg:
+		if err := bm.checkFields("pkgpath", "pkgdir", "files", "deposit"); err != nil {
+			return nil, err
+		}
+		if bm.PkgPath == "" {
+			return nil, errors.New("pkgpath not specified")
+		}
+		deposit, err := std.ParseCoins(bm.Deposit)
+		if err != nil {
+			return nil, fmt.Errorf("parsing deposit coins: %w", err)
+		}
+		memPkg, err := bm.readPackage(dir, bm.PkgPath)
+		if err != nil {
+			return nil, err
+		}
+		return vm.MsgAddPackage{
+			Creator: signer,
+			Package: memPkg,
+			Deposit: deposit,
+		}, nil
+
+	case "":
+		return nil, errors.New("type not specified")
+	default:
+		return nil, fmt.Errorf("invalid type %q", bm.Type)
+	}
+}
+
+// checkFields returns an error if a field of bm other than its type and the
+// allowed ones is set, as it would be ignored.
+func (bm batchMsg) checkFields(allowed ...string) error {
+	fields := []struct {
+		name string
+		set  bool
+	}{
+		{"pkgpath", bm.PkgPath != ""},
+		{"func", bm.Func != ""},
+		{"args", len(bm.Args) > 0},
+		{"send", bm.Send != ""},
+		{"to", bm.To != ""},
+		{"deposit", bm.Deposit != ""},
+		{"pkgdir", bm.PkgDir != ""},
+		{"files", len(bm.Files) > 0},
+	}
+	for _, field := range fields {
+		if field.set && !slices.Contains(allowed, field.name) {
+			return fmt.Errorf("unexpected field %q", field.name)
+		}
+	}
+	return nil
+}
+
+// readPackage reads the package of bm, from either its pkgdir or the files
+// matching its files patterns, relative to dir.
+func (bm batchMsg) readPackage(dir, pkgPath string) (*gnovm.MemPackage, error) {
+	var (
+		memPkg *gnovm.MemPackage
+		err    error
+	)
+	switch {
+	case bm.PkgDir != "" && len(bm.Files) > 0:
+		return nil, errors.New("pkgdir and files are mutually exclusive")
+	case bm.PkgDir != "":
+		memPkg, err = gno.ReadMemPackage(resolvePath(dir, bm.PkgDir), pkgPath)
+	case len(bm.Files) > 0:
+		var list []string
+		list, err = globFiles(dir, bm.Files)
+		if err == nil {
+			memPkg, err = gno.ReadMemPackageFromList(list, pkgPath)
+		}
+	default:
+		return nil, errors.New("pkgdir or files not specified")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading package: %w", err)
+	}
+	if memPkg.IsEmpty() {
+		return nil, errors.New("found an empty package")
+	}
+	return memPkg, nil
+}
+
+// globFiles returns the sorted list of the files matching patterns, relative
+// to dir. Each pattern must match at least one file.
+func globFiles(dir string, patterns []string) ([]string, error) {
+	seen := make(map[string]struct{})
+	var list []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(resolvePath(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files matching %q", pattern)
+		}
+		for _, match := range matches {
+			if _, ok := seen[match]; ok {
+				continue
+			}
+			seen[match] = struct{}{}
+			list = append(list, match)
+		}
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// resolvePath returns path relative to dir, unless it's absolute.
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
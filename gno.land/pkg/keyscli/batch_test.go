@@ -0,0 +1,331 @@
+package keyscli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fooGno  = "package foo\n\nfunc Foo() int { return 1 }\n"
+	barGno  = "package foo\n\nfunc Bar() int { return 2 }\n"
+	mainGno = "package main\n\nfunc main() { println(1) }\n"
+)
+
+// writeBatchDir writes the package files used by the batch files of the tests,
+// and the batch file yml, to a new directory. It returns the path of the
+// batch file.
+func writeBatchDir(t *testing.T, yml string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"foo/foo.gno":     fooGno,
+		"foo/bar.gno":     barGno,
+		"foo/README.md":   "# foo",
+		"script/main.gno": mainGno,
+		"msgs.yaml":       yml,
+	}
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+	}
+	return filepath.Join(dir, "msgs.yaml")
+}
+
+func TestReadBatchFile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		signer = crypto.AddressFromPreimage([]byte("signer"))
+		to     = crypto.AddressFromPreimage([]byte("to"))
+	)
+
+	fooPkg := func(files ...string) *gnovm.MemPackage {
+		bodies := map[string]string{
+			"README.md": "# foo",
+			"bar.gno":   barGno,
+			"foo.gno":   fooGno,
+		}
+		pkg := &gnovm.MemPackage{Name: "foo", Path: "gno.land/r/test/foo"}
+		for _, name := range files {
+			pkg.Files = append(pkg.Files, &gnovm.MemFile{Name: name, Body: bodies[name]})
+		}
+		return pkg
+	}
+	mainPkg := &gnovm.MemPackage{
+		Name:  "main",
+		Files: []*gnovm.MemFile{{Name: "main.gno", Body: mainGno}},
+	}
+
+	testCases := []struct {
+		name     string
+		yml      string
+		expected []std.Msg
+		errMsg   string
+	}{
+		{
+			name: "call",
+			yml: `
+msgs:
+  - type: call
+    pkgpath: gno.land/r/test/foo
+    func: Set
+    args: [42, "hello"]
+    send: 1000ugnot
+`,
+			expected: []std.Msg{vm.MsgCall{
+				Caller:  signer,
+				Send:    std.NewCoins(std.NewCoin("ugnot", 1000)),
+				PkgPath: "gno.land/r/test/foo",
+				Func:    "Set",
+				Args:    []string{"42", "hello"},
+			}},
+		},
+		{
+			name: "send",
+			yml: `
+msgs:
+  - type: send
+    to: ` + to.String() + `
+    send: 1000ugnot
+`,
+			expected: []std.Msg{bank.MsgSend{
+				FromAddress: signer,
+				ToAddress:   to,
+				Amount:      std.NewCoins(std.NewCoin("ugnot", 1000)),
+			}},
+		},
+		{
+			name: "run",
+			yml: `
+msgs:
+  - type: run
+    files: ["script/*.gno"]
+`,
+			expected: []std.Msg{vm.MsgRun{
+				Caller:  signer,
+				Package: mainPkg,
+			}},
+		},
+		{
+			name: "addpkg from dir",
+			yml: `
+msgs:
+  - type: addpkg
+    pkgpath: gno.land/r/test/foo
+    pkgdir: foo
+    deposit: 100ugnot
+`,
+			expected: []std.Msg{vm.MsgAddPackage{
+				Creator: signer,
+				Package: fooPkg("README.md", "bar.gno", "foo.gno"),
+				Deposit: std.NewCoins(std.NewCoin("ugnot", 100)),
+			}},
+		},
+		{
+			name: "addpkg from globs",
+			yml: `
+msgs:
+  - type: addpkg
+    pkgpath: gno.land/r/test/foo
+    files: ["foo/foo.gno", "foo/*.gno"]
+`,
+			expected: []std.Msg{vm.MsgAddPackage{
+				Creator: signer,
+				Package: fooPkg("bar.gno", "foo.gno"),
+			}},
+		},
+		{
+			name: "mixed msgs in order",
+			yml: `
+msgs:
+  - type: addpkg
+    pkgpath: gno.land/r/test/foo
+    files: ["foo/foo.gno"]
+  - type: call
+    pkgpath: gno.land/r/test/foo
+    func: Foo
+`,
+			expected: []std.Msg{
+				vm.MsgAddPackage{
+					Creator: signer,
+					Package: fooPkg("foo.gno"),
+				},
+				vm.MsgCall{
+					Caller:  signer,
+					PkgPath: "gno.land/r/test/foo",
+					Func:    "Foo",
+				},
+			},
+		},
+		{
+			name:   "malformed yaml",
+			yml:    "msgs: [",
+			errMsg: "could not parse",
+		},
+		{
+			name: "unknown field",
+			yml: `
+msgs:
+  - type: call
+    pkgpath: gno.land/r/test/foo
+    function: Foo
+`,
+			errMsg: "field function not found",
+		},
+		{
+			name:   "no msgs",
+			yml:    "msgs: []",
+			errMsg: "no msgs",
+		},
+		{
+			name: "missing type",
+			yml: `
+msgs:
+  - pkgpath: gno.land/r/test/foo
+`,
+			errMsg: "msg #0 (): type not specified",
+		},
+		{
+			name: "invalid type",
+			yml: `
+msgs:
+  - type: exec
+`,
+			errMsg: `msg #0 (exec): invalid type "exec"`,
+		},
+		{
+			name: "call without func",
+			yml: `
+msgs:
+  - type: call
+    pkgpath: gno.land/r/test/foo
+`,
+			errMsg: "msg #0 (call): func not specified",
+		},
+		{
+			name: "call with deposit",
+			yml: `
+msgs:
+  - type: call
+    pkgpath: gno.land/r/test/foo
+    func: Foo
+    deposit: 100ugnot
+`,
+			errMsg: `msg #0 (call): unexpected field "deposit"`,
+		},
+		{
+			name: "call with invalid send",
+			yml: `
+msgs:
+  - type: call
+    pkgpath: gno.land/r/test/foo
+    func: Foo
+    send: ugnot
+`,
+			errMsg: "parsing send coins",
+		},
+		{
+			name: "send without amount",
+			yml: `
+msgs:
+  - type: send
+    to: ` + to.String() + `
+`,
+			errMsg: "msg #0 (send): send not specified",
+		},
+		{
+			name: "send to invalid address",
+			yml: `
+msgs:
+  - type: send
+    to: g1invalid
+    send: 1000ugnot
+`,
+			errMsg: "invalid to address",
+		},
+		{
+			name: "run without files",
+			yml: `
+msgs:
+  - type: run
+`,
+			errMsg: "msg #0 (run): pkgdir or files not specified",
+		},
+		{
+			name: "addpkg without pkgpath",
+			yml: `
+msgs:
+  - type: addpkg
+    pkgdir: foo
+`,
+			errMsg: "msg #0 (addpkg): pkgpath not specified",
+		},
+		{
+			name: "addpkg with pkgdir and files",
+			yml: `
+msgs:
+  - type: addpkg
+    pkgpath: gno.land/r/test/foo
+    pkgdir: foo
+    files: ["foo/*.gno"]
+`,
+			errMsg: "pkgdir and files are mutually exclusive",
+		},
+		{
+			name: "addpkg without matching files",
+			yml: `
+msgs:
+  - type: addpkg
+    pkgpath: gno.land/r/test/foo
+    files: ["missing/*.gno"]
+`,
+			errMsg: `no files matching "missing/*.gno"`,
+		},
+		{
+			name: "error in a later msg",
+			yml: `
+msgs:
+  - type: call
+    pkgpath: gno.land/r/test/foo
+    func: Foo
+  - type: send
+    send: 1000ugnot
+`,
+			errMsg: "msg #1 (send): to not specified",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			msgs, err := readBatchFile(writeBatchDir(t, tc.yml), signer)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, msgs)
+		})
+	}
+}
+
+func TestReadBatchFile_Missing(t *testing.T) {
+	t.Parallel()
+
+	_, err := readBatchFile(filepath.Join(t.TempDir(), "msgs.yaml"), crypto.Address{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read")
+}
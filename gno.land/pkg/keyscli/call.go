@@ -5,9 +5,7 @@ import (
 	"flag"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
-	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
-	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -91,7 +89,7 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 
 	// read account pubkey.
 	nameOrBech32 := args[0]
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.RootCfg.Keybase(io)
 	if err != nil {
 		return err
 	}
@@ -130,13 +128,5 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 		Memo:       cfg.RootCfg.Memo,
 	}
 
-	if cfg.RootCfg.Broadcast {
-		err := client.ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
-		if err != nil {
-			return err
-		}
-	} else {
-		io.Println(string(amino.MustMarshalJSON(tx)))
-	}
-	return nil
+	return client.ExecMakeTx(cfg.RootCfg, args, tx, io)
 }
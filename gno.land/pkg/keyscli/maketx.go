@@ -35,11 +35,17 @@ func NewMakeTxCmd(rootCfg *client.BaseCfg, io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		client.NewMakeSendCmd(cfg, io),
+		client.NewMakeGrantCmd(cfg, io),
+		client.NewMakeRevokeCmd(cfg, io),
+		client.NewMakeCreateSessionCmd(cfg, io),
+		client.NewMakeRevokeSessionCmd(cfg, io),
 
 		// custom commands
 		NewMakeAddPkgCmd(cfg, io),
+		NewMakeUpgradePkgCmd(cfg, io),
 		NewMakeCallCmd(cfg, io),
 		NewMakeRunCmd(cfg, io),
+		NewMakeBatchCmd(cfg, io),
 	)
 
 	return cmd
@@ -36,9 +36,11 @@ func NewRootCmd(io commands.IO, base client.BaseOptions) *commands.Command {
 		client.NewImportCmd(cfg, io),
 		client.NewListCmd(cfg, io),
 		client.NewSignCmd(cfg, io),
+		client.NewMultisignCmd(cfg, io),
 		client.NewVerifyCmd(cfg, io),
 		client.NewQueryCmd(cfg, io),
 		client.NewBroadcastCmd(cfg, io),
+		client.NewTxCmd(cfg, io),
 
 		// Custom MakeTX command
 		NewMakeTxCmd(cfg, io),
@@ -1,8 +1,11 @@
 package vm
 
 import (
+	"strings"
+
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -36,26 +39,44 @@ func (bnk *SDKBanker) SendCoins(b32from, b32to crypto.Bech32Address, amt std.Coi
 	}
 }
 
-func (bnk *SDKBanker) TotalCoin(denom string) int64 {
-	panic("not yet implemented")
+func (bnk *SDKBanker) TotalCoin(denom string) std.Int {
+	return bnk.vmk.bank.GetSupply(bnk.ctx, denom).Amount
 }
 
-func (bnk *SDKBanker) IssueCoin(b32addr crypto.Bech32Address, denom string, amount int64) {
+func (bnk *SDKBanker) IssueCoin(b32addr crypto.Bech32Address, denom string, amount std.Int) {
 	addr := crypto.MustAddressFromString(string(b32addr))
-	_, err := bnk.vmk.bank.AddCoins(bnk.ctx, addr, std.Coins{std.Coin{Denom: denom, Amount: amount}})
+	err := bnk.vmk.bank.MintCoins(bnk.ctx, addr, std.Coins{std.Coin{Denom: denom, Amount: amount}})
 	if err != nil {
 		panic(err)
 	}
+
+	// Record the issuing realm of the denom the first time it is issued.
+	if _, ok := bnk.vmk.bank.GetDenomMetadata(bnk.ctx, denom); !ok {
+		if err := bnk.vmk.bank.SetDenomMetadata(bnk.ctx, realmDenomMetadata(denom)); err != nil {
+			panic(err)
+		}
+	}
 }
 
-func (bnk *SDKBanker) RemoveCoin(b32addr crypto.Bech32Address, denom string, amount int64) {
+func (bnk *SDKBanker) RemoveCoin(b32addr crypto.Bech32Address, denom string, amount std.Int) {
 	addr := crypto.MustAddressFromString(string(b32addr))
-	_, err := bnk.vmk.bank.SubtractCoins(bnk.ctx, addr, std.Coins{std.Coin{Denom: denom, Amount: amount}})
+	err := bnk.vmk.bank.BurnCoins(bnk.ctx, addr, std.Coins{std.Coin{Denom: denom, Amount: amount}})
 	if err != nil {
 		panic(err)
 	}
 }
 
+// realmDenomMetadata returns the default metadata of a denom issued by a
+// realm, which has the form "/<pkgpath>:<base denom>".
+func realmDenomMetadata(denom string) bank.DenomMetadata {
+	pkgPath, base, _ := strings.Cut(strings.TrimPrefix(denom, "/"), ":")
+	return bank.DenomMetadata{
+		Denom:   denom,
+		Display: base,
+		Realm:   pkgPath,
+	}
+}
+
 // ----------------------------------------
 // SDKParams
 
@@ -49,7 +49,7 @@ func _setupTestEnv(cacheStdlibs bool) testEnv {
 	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, &bft.Header{ChainID: "test-chain-id"}, log.NewNoopLogger())
 	prmk := paramsm.NewParamsKeeper(iavlCapKey, "params")
 	acck := authm.NewAccountKeeper(iavlCapKey, prmk, std.ProtoBaseAccount)
-	bank := bankm.NewBankKeeper(acck)
+	bank := bankm.NewBankKeeper(iavlCapKey, acck)
 
 	vmk := NewVMKeeper(baseCapKey, iavlCapKey, acck, bank, prmk)
 
@@ -20,9 +20,12 @@ func assertNoPlusPrefix(s string) {
 // These convert string representations of public-facing arguments to GNO types.
 // The limited set of input types available should map 1:1 to types supported
 // in FunctionSignature{}.
+// Primitive types and byte arrays and slices have their own representation;
+// other composite types (structs, maps, pointers, arrays and slices) are
+// decoded from JSON, following the rules of the encoding/json stdlib.
 // String representation of arg must be deterministic.
 // NOTE: very important that there is no malleability.
-func convertArgToGno(arg string, argT gno.Type) (tv gno.TypedValue) {
+func convertArgToGno(store gno.Store, arg string, argT gno.Type) (tv gno.TypedValue) {
 	tv.T = argT
 	switch bt := gno.BaseOf(argT).(type) {
 	case gno.PrimitiveType:
@@ -165,9 +168,8 @@ func convertArgToGno(arg string, argT gno.Type) (tv gno.TypedValue) {
 				Data: bz,
 			}
 			return
-		} else {
-			panic("unexpected array type in contract arg")
 		}
+		return convertJSONArgToGno(store, arg, argT)
 	case *gno.SliceType:
 		if bt.Elt == gno.Uint8Type {
 			bz, err := base64.StdEncoding.DecodeString(arg)
@@ -185,14 +187,29 @@ func convertArgToGno(arg string, argT gno.Type) (tv gno.TypedValue) {
 				Maxcap: len(bz),
 			}
 			return
-		} else {
-			panic("unexpected slice type in contract arg")
 		}
+		return convertJSONArgToGno(store, arg, argT)
+	case *gno.StructType, *gno.MapType, *gno.PointerType:
+		return convertJSONArgToGno(store, arg, argT)
 	default:
 		panic(fmt.Sprintf("unexpected type in contract arg: %v", argT))
 	}
 }
 
+func convertJSONArgToGno(store gno.Store, arg string, argT gno.Type) gno.TypedValue {
+	var alloc *gno.Allocator
+	if store != nil {
+		alloc = store.GetAllocator()
+	}
+	tv, err := gno.UnmarshalJSON(alloc, store, []byte(arg), argT)
+	if err != nil {
+		panic(fmt.Sprintf(
+			"error parsing %s %q: %v",
+			argT.String(), arg, err))
+	}
+	return tv
+}
+
 func convertFloat(value string, precision int) float64 {
 	assertNoPlusPrefix(value)
 	dec, _, err := apd.NewFromString(value)
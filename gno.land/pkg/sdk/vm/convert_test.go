@@ -31,7 +31,7 @@ func TestConvertEmptyNumbers(t *testing.T) {
 		testname := fmt.Sprintf("%v", tt.argT)
 		t.Run(testname, func(t *testing.T) {
 			run := func() {
-				_ = convertArgToGno("", tt.argT)
+				_ = convertArgToGno(nil, "", tt.argT)
 			}
 			assert.PanicsWithValue(t, tt.expectedErr, run)
 		})
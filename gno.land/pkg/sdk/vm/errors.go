@@ -25,6 +25,10 @@ type (
 		abciError
 		Errors []string `json:"errors"`
 	}
+	IncompatibleUpgradeError struct {
+		abciError
+		Errors []string `json:"errors"`
+	}
 )
 
 func (e InvalidPkgPathError) Error() string   { return "invalid package path" }
@@ -40,6 +44,13 @@ func (e TypeCheckError) Error() string {
 	return bld.String()
 }
 
+func (e IncompatibleUpgradeError) Error() string {
+	var bld strings.Builder
+	bld.WriteString("incompatible package upgrade:\n")
+	bld.WriteString(strings.Join(e.Errors, "\n"))
+	return bld.String()
+}
+
 func ErrPkgAlreadyExists(msg string) error {
 	return errors.Wrap(PkgExistError{}, msg)
 }
@@ -68,3 +79,12 @@ func ErrTypeCheck(err error) error {
 	}
 	return errors.NewWithData(tce).Stacktrace()
 }
+
+func ErrIncompatibleUpgrade(err error) error {
+	var iue IncompatibleUpgradeError
+	errs := multierr.Errors(err)
+	for _, err := range errs {
+		iue.Errors = append(iue.Errors, err.Error())
+	}
+	return errors.NewWithData(iue).Stacktrace()
+}
@@ -2,8 +2,10 @@ package vm
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -24,6 +26,8 @@ func (vh vmHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 	switch msg := msg.(type) {
 	case MsgAddPackage:
 		return vh.handleMsgAddPackage(ctx, msg)
+	case MsgUpgradePackage:
+		return vh.handleMsgUpgradePackage(ctx, msg)
 	case MsgCall:
 		return vh.handleMsgCall(ctx, msg)
 	case MsgRun:
@@ -43,6 +47,15 @@ func (vh vmHandler) handleMsgAddPackage(ctx sdk.Context, msg MsgAddPackage) sdk.
 	return sdk.Result{}
 }
 
+// Handle MsgUpgradePackage.
+func (vh vmHandler) handleMsgUpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) sdk.Result {
+	err := vh.vm.UpgradePackage(ctx, msg)
+	if err != nil {
+		return abciResult(err)
+	}
+	return sdk.Result{}
+}
+
 // Handle MsgCall.
 func (vh vmHandler) handleMsgCall(ctx sdk.Context, msg MsgCall) (res sdk.Result) {
 	resstr, err := vh.vm.Call(ctx, msg)
@@ -68,10 +81,14 @@ func (vh vmHandler) handleMsgRun(ctx sdk.Context, msg MsgRun) (res sdk.Result) {
 
 // query paths
 const (
-	QueryRender = "qrender"
-	QueryFuncs  = "qfuncs"
-	QueryEval   = "qeval"
-	QueryFile   = "qfile"
+	QueryRender      = "qrender"
+	QueryFuncs       = "qfuncs"
+	QueryEval        = "qeval"
+	QueryEvalJSON    = "qevaljson"
+	QueryFile        = "qfile"
+	QueryPkgVersions = "qversions"
+	QueryScheduled   = "qscheduled"
+	QueryMessages    = "qmessages"
 )
 
 func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) abci.ResponseQuery {
@@ -87,8 +104,16 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) abci.ResponseQ
 		res = vh.queryFuncs(ctx, req)
 	case QueryEval:
 		res = vh.queryEval(ctx, req)
+	case QueryEvalJSON:
+		res = vh.queryEvalJSON(ctx, req)
 	case QueryFile:
 		res = vh.queryFile(ctx, req)
+	case QueryPkgVersions:
+		res = vh.queryPkgVersions(ctx, req)
+	case QueryScheduled:
+		res = vh.queryScheduled(ctx, req)
+	case QueryMessages:
+		res = vh.queryMessages(ctx, req)
 	default:
 		return sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest(fmt.Sprintf(
@@ -146,6 +171,19 @@ func (vh vmHandler) queryEval(ctx sdk.Context, req abci.RequestQuery) (res abci.
 	return
 }
 
+// queryEvalJSON evaluates any expression in readonly mode and returns the
+// results as a JSON array.
+func (vh vmHandler) queryEvalJSON(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath, expr := parseQueryEvalData(string(req.Data))
+	result, err := vh.vm.QueryEvalJSON(ctx, pkgPath, expr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = []byte(result)
+	return
+}
+
 // parseQueryEval parses the input string of vm/qeval. It takes the first dot
 // after the first slash (if any) to separe the pkgPath and the expr.
 // For instance, in gno.land/r/realm.MyFunction(), gno.land/r/realm is the
@@ -183,6 +221,43 @@ func (vh vmHandler) queryFile(ctx sdk.Context, req abci.RequestQuery) (res abci.
 	return
 }
 
+// queryPkgVersions returns the number of stored versions of a package.
+// Previous versions can be queried with vm/qfile, as <pkgpath>[/<file>]@<version>.
+func (vh vmHandler) queryPkgVersions(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	n, err := vh.vm.QueryPkgVersions(ctx, pkgPath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = []byte(strconv.FormatUint(n, 10))
+	return
+}
+
+// queryScheduled returns the pending calls scheduled by a realm, as JSON.
+func (vh vmHandler) queryScheduled(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	calls, err := vh.vm.QueryScheduledCalls(ctx, pkgPath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = amino.MustMarshalJSON(calls)
+	return
+}
+
+// queryMessages returns the pending messages sent by a realm, as JSON.
+func (vh vmHandler) queryMessages(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	msgs, err := vh.vm.QueryMessages(ctx, pkgPath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = amino.MustMarshalJSON(msgs)
+	return
+}
+
 // ----------------------------------------
 // misc
 
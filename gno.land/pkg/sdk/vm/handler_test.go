@@ -182,6 +182,78 @@ func pvEcho(msg string) string { return "pvecho:"+msg }
 	}
 }
 
+func TestVmHandlerQuery_EvalJSON(t *testing.T) {
+	tt := []struct {
+		input              []byte
+		expectedResult     string
+		expectedErrorMatch string
+	}{
+		// valid queries
+		{input: []byte(`gno.land/r/hello.Get()`), expectedResult: `[{"name":"item","tags":["a","b"],"attrs":{"x":1,"y":2},"parent":null},true]`},
+		{input: []byte(`gno.land/r/hello.sl`), expectedResult: `[[1,2,3]]`},
+		{input: []byte(`gno.land/r/hello.println(1234)`), expectedResult: `[]`},
+
+		// errors
+		{input: []byte(`gno.land/r/hello.Get`), expectedErrorMatch: `^json: unsupported type: func`},
+		{input: []byte(`gno.land/r/hello.doesnotexist`), expectedErrorMatch: `name doesnotexist not declared`},
+	}
+
+	for _, tc := range tt {
+		name := string(tc.input)
+		t.Run(name, func(t *testing.T) {
+			env := setupTestEnv()
+			ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+			vmHandler := env.vmh
+
+			// Give "addr1" some gnots.
+			addr := crypto.AddressFromPreimage([]byte("addr1"))
+			acc := env.acck.NewAccountWithAddress(ctx, addr)
+			env.acck.SetAccount(ctx, acc)
+			env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+			// Create test package.
+			files := []*gnovm.MemFile{
+				{Name: "hello.gno", Body: `
+package hello
+
+type Item struct {
+	Name   string         ` + "`json:\"name\"`" + `
+	Tags   []string       ` + "`json:\"tags\"`" + `
+	Attrs  map[string]int ` + "`json:\"attrs\"`" + `
+	Parent *Item          ` + "`json:\"parent\"`" + `
+	hidden int
+}
+
+var item = &Item{Name: "item", Tags: []string{"a", "b"}, Attrs: map[string]int{"y": 2, "x": 1}}
+var sl = []int{1, 2, 3}
+
+func Get() (Item, bool) { return *item, true }
+`},
+			}
+			pkgPath := "gno.land/r/hello"
+			msg1 := NewMsgAddPackage(addr, pkgPath, files)
+			err := env.vmk.AddPackage(ctx, msg1)
+			assert.NoError(t, err)
+			env.vmk.CommitGnoTransactionStore(ctx)
+
+			req := abci.RequestQuery{
+				Path: "vm/qevaljson",
+				Data: tc.input,
+			}
+
+			res := vmHandler.Query(env.ctx, req)
+			if tc.expectedErrorMatch == "" {
+				assert.True(t, res.IsOK(), "should not have error")
+				assert.Equal(t, tc.expectedResult, string(res.Data))
+			} else {
+				assert.False(t, res.IsOK(), "should have an error")
+				errmsg := res.Error.Error()
+				assert.Regexp(t, tc.expectedErrorMatch, errmsg)
+			}
+		})
+	}
+}
+
 func TestVmHandlerQuery_Funcs(t *testing.T) {
 	tt := []struct {
 		input              []byte
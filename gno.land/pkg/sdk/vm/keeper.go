@@ -11,6 +11,7 @@ import (
 	"log/slog"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/gnolang/gno/gnovm"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/stdlibs"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -40,12 +42,17 @@ const (
 	maxAllocTx    = 500_000_000
 	maxAllocQuery = 1_500_000_000 // higher limit for queries
 	maxGasQuery   = 3_000_000_000 // same as max block gas
+
+	// DefaultObjectCacheSize is the default maximum size, in amino-encoded
+	// bytes, of the realm objects cached across transactions and blocks.
+	DefaultObjectCacheSize = 32 << 20
 )
 
 // vm.VMKeeperI defines a module interface that supports Gno
 // smart contracts programming (scripting).
 type VMKeeperI interface {
 	AddPackage(ctx sdk.Context, msg MsgAddPackage) error
+	UpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) error
 	Call(ctx sdk.Context, msg MsgCall) (res string, err error)
 	QueryEval(ctx sdk.Context, pkgPath string, expr string) (res string, err error)
 	Run(ctx sdk.Context, msg MsgRun) (res string, err error)
@@ -53,6 +60,9 @@ type VMKeeperI interface {
 	LoadStdlibCached(ctx sdk.Context, stdlibDir string)
 	MakeGnoTransactionStore(ctx sdk.Context) sdk.Context
 	CommitGnoTransactionStore(ctx sdk.Context)
+	RunScheduledCalls(ctx sdk.Context)
+	UpdateRandomSeed(ctx sdk.Context, votes []abci.VoteInfo)
+	DeliverMessages(ctx sdk.Context)
 }
 
 var _ VMKeeperI = &VMKeeper{}
@@ -62,6 +72,16 @@ type VMKeeper struct {
 	// Needs to be explicitly set, like in the case of gnodev.
 	Output io.Writer
 
+	// Maximum size of the object cache, see gno.Store.SetObjectCacheSize.
+	// Defaults to DefaultObjectCacheSize; must be set before Initialize.
+	ObjectCacheSize int64
+
+	// Bytecode forces running the compiled bytecode of the function bodies
+	// which have one, see gno.MachineOptions.Bytecode. On a chain, the
+	// bytecode engine is enabled for all the nodes at once by the vm.bytecode
+	// param instead, see useBytecode; this is meant for tests.
+	Bytecode bool
+
 	baseKey store.StoreKey
 	iavlKey store.StoreKey
 	acck    auth.AccountKeeper
@@ -86,6 +106,8 @@ func NewVMKeeper(
 		acck:    acck,
 		bank:    bank,
 		prmk:    prmk,
+
+		ObjectCacheSize: DefaultObjectCacheSize,
 	}
 
 	return vmk
@@ -104,6 +126,7 @@ func (vm *VMKeeper) Initialize(
 	alloc := gno.NewAllocator(maxAllocTx)
 	vm.gnoStore = gno.NewStore(alloc, baseStore, iavlStore)
 	vm.gnoStore.SetNativeResolver(stdlibs.NativeResolver)
+	vm.gnoStore.SetObjectCacheSize(vm.ObjectCacheSize)
 
 	if vm.gnoStore.NumMemPackages() > 0 {
 		// for now, all mem packages must be re-run after reboot.
@@ -213,10 +236,21 @@ func (vm *VMKeeper) newGnoTransactionStore(ctx sdk.Context) gno.TransactionStore
 	iavl := ctx.Store(vm.iavlKey)
 	gasMeter := ctx.GasMeter()
 
-	return vm.gnoStore.BeginTransaction(base, iavl, gasMeter)
+	txStore := vm.gnoStore.BeginTransaction(base, iavl, gasMeter)
+	if as := ctx.AccessSet(); as != nil {
+		// types and nodes are cached across transactions, outside of
+		// the multistore.
+		txStore.SetAccessRecorder(as)
+	}
+	return txStore
 }
 
 func (vm *VMKeeper) MakeGnoTransactionStore(ctx sdk.Context) sdk.Context {
+	if ctx.IsSpeculative() && vm.Output != nil {
+		// The output of speculative transactions is buffered, and only
+		// written if they are used; see WriteOutput.
+		ctx = ctx.WithValue(outputContextKey, new(bytes.Buffer))
+	}
 	return ctx.WithValue(gnoStoreContextKey, vm.newGnoTransactionStore(ctx))
 }
 
@@ -224,6 +258,26 @@ func (vm *VMKeeper) CommitGnoTransactionStore(ctx sdk.Context) {
 	vm.getGnoTransactionStore(ctx).Write()
 }
 
+type outputContextKeyType struct{}
+
+var outputContextKey outputContextKeyType
+
+// output returns the writer of the output of the machines of ctx.
+func (vm *VMKeeper) output(ctx sdk.Context) io.Writer {
+	if buf, ok := ctx.Value(outputContextKey).(*bytes.Buffer); ok {
+		return buf
+	}
+	return vm.Output
+}
+
+// WriteOutput writes the output buffered during the speculative execution of
+// the transaction of ctx, if any, to vm.Output.
+func (vm *VMKeeper) WriteOutput(ctx sdk.Context) {
+	if buf, ok := ctx.Value(outputContextKey).(*bytes.Buffer); ok {
+		buf.WriteTo(vm.Output)
+	}
+}
+
 func (vm *VMKeeper) getGnoTransactionStore(ctx sdk.Context) gno.TransactionStore {
 	txStore := ctx.Value(gnoStoreContextKey).(gno.TransactionStore)
 	txStore.ClearObjectCache()
@@ -282,11 +336,12 @@ func (vm *VMKeeper) checkNamespacePermission(ctx sdk.Context, creator crypto.Add
 	m := gno.NewMachineWithOptions(
 		gno.MachineOptions{
 			PkgPath:  "",
-			Output:   vm.Output,
+			Output:   vm.output(ctx),
 			Store:    store,
 			Context:  msgCtx,
 			Alloc:    store.GetAllocator(),
 			GasMeter: ctx.GasMeter(),
+			Bytecode: vm.useBytecode(ctx),
 		})
 	defer m.Release()
 
@@ -381,17 +436,21 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 		OriginPkgAddr:   pkgAddr.Bech32(),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
+		Randomness:      NewSDKRandomness(vm, ctx),
+		Messenger:       NewSDKMessenger(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 	// Parse and run the files, construct *PV.
 	m2 := gno.NewMachineWithOptions(
 		gno.MachineOptions{
 			PkgPath:  "",
-			Output:   vm.Output,
+			Output:   vm.output(ctx),
 			Store:    gnostore,
 			Alloc:    gnostore.GetAllocator(),
 			Context:  msgCtx,
 			GasMeter: ctx.GasMeter(),
+			Bytecode: vm.useBytecode(ctx),
 		})
 	defer m2.Release()
 	defer doRecover(m2, &err)
@@ -399,6 +458,7 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 
 	// Log the telemetry
 	logTelemetry(
+		gnostore,
 		m2.GasMeter.GasConsumed(),
 		m2.Cycles,
 		attribute.KeyValue{
@@ -410,6 +470,147 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	return nil
 }
 
+// upgradeAuthFunc is the function a realm must export to authorize its upgrades.
+const upgradeAuthFunc = "CanUpgrade"
+
+// UpgradePackage upgrades an existing realm to the given fileset, migrating
+// its state. The upgrade must be authorized by the realm itself, which must
+// export a func CanUpgrade(caller std.Address) bool.
+func (vm *VMKeeper) UpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) (err error) {
+	creator := msg.Creator
+	pkgPath := msg.Package.Path
+	memPkg := msg.Package
+	gnostore := vm.getGnoTransactionStore(ctx)
+	chainDomain := vm.getChainDomainParam(ctx)
+
+	// Validate arguments.
+	if creator.IsZero() {
+		return std.ErrInvalidAddress("missing creator address")
+	}
+	creatorAcc := vm.acck.GetAccount(ctx, creator)
+	if creatorAcc == nil {
+		return std.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", creator))
+	}
+	if err := msg.Package.Validate(); err != nil {
+		return ErrInvalidPkgPath(err.Error())
+	}
+	if !strings.HasPrefix(pkgPath, chainDomain+"/") {
+		return ErrInvalidPkgPath("invalid domain: " + pkgPath)
+	}
+	if !gno.IsRealmPath(pkgPath) || gno.ReGnoRunPath.MatchString(pkgPath) {
+		return ErrInvalidPkgPath("only realms can be upgraded: " + pkgPath)
+	}
+	oldMemPkg := gnostore.GetMemPackage(pkgPath)
+	if oldMemPkg == nil {
+		return ErrInvalidPkgPath("package does not exist: " + pkgPath)
+	}
+
+	// Validate Gno syntax and type check,
+	// then check the compatibility with the current version.
+	format := true
+	if err := gno.TypeCheckMemPackage(memPkg, gnostore, format); err != nil {
+		return ErrTypeCheck(err)
+	}
+	if err := gno.CheckMemPackageUpgrade(oldMemPkg, memPkg, gnostore); err != nil {
+		return ErrIncompatibleUpgrade(err)
+	}
+
+	// Parse and run the files, construct *PV.
+	pkgAddr := gno.DerivePkgAddr(pkgPath)
+	msgCtx := stdlibs.ExecContext{
+		ChainID:         ctx.ChainID(),
+		ChainDomain:     chainDomain,
+		Height:          ctx.BlockHeight(),
+		Timestamp:       ctx.BlockTime().Unix(),
+		OriginCaller:    creator.Bech32(),
+		OriginSendSpent: new(std.Coins),
+		OriginPkgAddr:   pkgAddr.Bech32(),
+		Banker:          NewSDKBanker(vm, ctx),
+		Params:          NewSDKParams(vm, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
+		Randomness:      NewSDKRandomness(vm, ctx),
+		Messenger:       NewSDKMessenger(vm, ctx),
+		EventLogger:     ctx.EventLogger(),
+	}
+	if err := vm.checkUpgradePermission(ctx, gnostore, msgCtx, creator, pkgPath); err != nil {
+		return err
+	}
+
+	m2 := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath:  "",
+			Output:   vm.output(ctx),
+			Store:    gnostore,
+			Alloc:    gnostore.GetAllocator(),
+			Context:  msgCtx,
+			GasMeter: ctx.GasMeter(),
+			Bytecode: vm.useBytecode(ctx),
+		})
+	defer m2.Release()
+	defer doRecover(m2, &err)
+	m2.UpgradeMemPackage(memPkg)
+
+	// Log the telemetry
+	logTelemetry(
+		gnostore,
+		m2.GasMeter.GasConsumed(),
+		m2.Cycles,
+		attribute.KeyValue{
+			Key:   "operation",
+			Value: attribute.StringValue("m_upgradepkg"),
+		},
+	)
+
+	return nil
+}
+
+// checkUpgradePermission calls the CanUpgrade(<creator>) hook of the realm
+// being upgraded.
+func (vm *VMKeeper) checkUpgradePermission(
+	ctx sdk.Context,
+	gnostore gno.Store,
+	msgCtx stdlibs.ExecContext,
+	creator crypto.Address,
+	pkgPath string,
+) (err error) {
+	pn := gnostore.GetBlockNode(gno.PackageNodeLocation(pkgPath)).(*gno.PackageNode)
+	if _, ok := pn.GetLocalIndex(upgradeAuthFunc); !ok {
+		return ErrUnauthorizedUser(fmt.Sprintf("%s does not declare %s", pkgPath, upgradeAuthFunc))
+	}
+
+	m := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath:  "",
+			Output:   vm.output(ctx),
+			Store:    gnostore,
+			Context:  msgCtx,
+			Alloc:    gnostore.GetAllocator(),
+			GasMeter: ctx.GasMeter(),
+			Bytecode: vm.useBytecode(ctx),
+		})
+	defer m.Release()
+	defer doRecover(m, &err)
+
+	// call $pkgPath.CanUpgrade("<creator>")
+	mpv := gno.NewPackageNode("main", "main", nil).NewPackage()
+	m.SetActivePackage(mpv)
+	m.RunDeclaration(gno.ImportD("pkg", pkgPath))
+	x := gno.Call(
+		gno.Sel(gno.Nx("pkg"), upgradeAuthFunc),
+		gno.Str(creator.String()),
+	)
+
+	ret := m.Eval(x)
+	if len(ret) != 1 || ret[0].T.Kind() != gno.BoolKind {
+		return ErrUnauthorizedUser(fmt.Sprintf("%s must return a bool", upgradeAuthFunc))
+	}
+	if !ret[0].GetBool() {
+		return ErrUnauthorizedUser(fmt.Sprintf("upgrade of %s not authorized for %s", pkgPath, creator))
+	}
+
+	return nil
+}
+
 // Call calls a public Gno function (for delivertx).
 func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	pkgPath := msg.PkgPath // to import
@@ -452,7 +653,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	}
 	for i, arg := range msg.Args {
 		argType := ft.Params[i].Type
-		atv := convertArgToGno(arg, argType)
+		atv := convertArgToGno(gnostore, arg, argType)
 		cx.Args[i] = &gno.ConstExpr{
 			TypedValue: atv,
 		}
@@ -472,17 +673,22 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		OriginPkgAddr:   pkgAddr.Bech32(),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
+		Randomness:      NewSDKRandomness(vm, ctx),
+		Messenger:       NewSDKMessenger(vm, ctx),
+		Message:         deliveredMessage(ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 	// Construct machine and evaluate.
 	m := gno.NewMachineWithOptions(
 		gno.MachineOptions{
 			PkgPath:  "",
-			Output:   vm.Output,
+			Output:   vm.output(ctx),
 			Store:    gnostore,
 			Context:  msgCtx,
 			Alloc:    gnostore.GetAllocator(),
 			GasMeter: ctx.GasMeter(),
+			Bytecode: vm.useBytecode(ctx),
 		})
 	defer m.Release()
 	m.SetActivePackage(mpv)
@@ -497,6 +703,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 
 	// Log the telemetry
 	logTelemetry(
+		gnostore,
 		m.GasMeter.GasConsumed(),
 		m.Cycles,
 		attribute.KeyValue{
@@ -604,6 +811,9 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		OriginPkgAddr:   pkgAddr.Bech32(),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
+		Randomness:      NewSDKRandomness(vm, ctx),
+		Messenger:       NewSDKMessenger(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 	}
 
@@ -613,8 +823,8 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 	// Run as self-executing closure to have own function for doRecover / m.Release defers.
 	pv := func() *gno.PackageValue {
 		// Parse and run the files, construct *PV.
-		if vm.Output != nil {
-			output = io.MultiWriter(buf, vm.Output)
+		if out := vm.output(ctx); out != nil {
+			output = io.MultiWriter(buf, out)
 		}
 		m := gno.NewMachineWithOptions(
 			gno.MachineOptions{
@@ -624,6 +834,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 				Alloc:    gnostore.GetAllocator(),
 				Context:  msgCtx,
 				GasMeter: ctx.GasMeter(),
+				Bytecode: vm.useBytecode(ctx),
 			})
 		// XXX MsgRun does not have pkgPath. How do we find it on chain?
 		defer m.Release()
@@ -645,6 +856,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 			Alloc:    gnostore.GetAllocator(),
 			Context:  msgCtx,
 			GasMeter: ctx.GasMeter(),
+			Bytecode: vm.useBytecode(ctx),
 		})
 	defer m2.Release()
 	m2.SetActivePackage(pv)
@@ -654,6 +866,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 
 	// Log the telemetry
 	logTelemetry(
+		gnostore,
 		m2.GasMeter.GasConsumed(),
 		m2.Cycles,
 		attribute.KeyValue{
@@ -727,7 +940,7 @@ func (vm *VMKeeper) QueryFuncs(ctx sdk.Context, pkgPath string) (fsigs FunctionS
 
 // QueryEval evaluates a gno expression (readonly, for ABCI queries).
 func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
-	rtvs, err := vm.queryEvalInternal(ctx, pkgPath, expr)
+	rtvs, _, err := vm.queryEvalInternal(ctx, pkgPath, expr)
 	if err != nil {
 		return "", err
 	}
@@ -744,7 +957,7 @@ func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res
 // QueryEvalString evaluates a gno expression (readonly, for ABCI queries).
 // The result is expected to be a single string (not a tuple).
 func (vm *VMKeeper) QueryEvalString(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
-	rtvs, err := vm.queryEvalInternal(ctx, pkgPath, expr)
+	rtvs, _, err := vm.queryEvalInternal(ctx, pkgPath, expr)
 	if err != nil {
 		return "", err
 	}
@@ -757,22 +970,46 @@ func (vm *VMKeeper) QueryEvalString(ctx sdk.Context, pkgPath string, expr string
 	return res, nil
 }
 
-func (vm *VMKeeper) queryEvalInternal(ctx sdk.Context, pkgPath string, expr string) (rtvs []gno.TypedValue, err error) {
+// QueryEvalJSON evaluates a gno expression (readonly, for ABCI queries).
+// The results are returned as a JSON array, encoded like the encoding/json
+// stdlib would.
+func (vm *VMKeeper) QueryEvalJSON(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
+	rtvs, gnostore, err := vm.queryEvalInternal(ctx, pkgPath, expr)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, rtv := range rtvs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		bz, err := gno.MarshalJSON(gnostore, rtv)
+		if err != nil {
+			return "", err
+		}
+		buf.Write(bz)
+	}
+	buf.WriteByte(']')
+	return buf.String(), nil
+}
+
+func (vm *VMKeeper) queryEvalInternal(ctx sdk.Context, pkgPath string, expr string) (rtvs []gno.TypedValue, gnostore gno.Store, err error) {
 	ctx = ctx.WithGasMeter(store.NewGasMeter(maxGasQuery))
 	alloc := gno.NewAllocator(maxAllocQuery)
-	gnostore := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+	gnostore = vm.newGnoTransactionStore(ctx) // throwaway (never committed)
 	pkgAddr := gno.DerivePkgAddr(pkgPath)
 	// Get Package.
 	pv := gnostore.GetPackage(pkgPath, false)
 	if pv == nil {
 		err = ErrInvalidPkgPath(fmt.Sprintf(
 			"package not found: %s", pkgPath))
-		return nil, err
+		return nil, nil, err
 	}
 	// Parse expression.
 	xx, err := gno.ParseExpr(expr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// Construct new machine.
 	chainDomain := vm.getChainDomainParam(ctx)
@@ -797,23 +1034,37 @@ func (vm *VMKeeper) queryEvalInternal(ctx sdk.Context, pkgPath string, expr stri
 			Context:  msgCtx,
 			Alloc:    alloc,
 			GasMeter: ctx.GasMeter(),
+			Bytecode: vm.useBytecode(ctx),
 		})
 	defer m.Release()
 	defer doRecoverQuery(m, &err)
-	return m.Eval(xx), err
+	return m.Eval(xx), gnostore, err
 }
 
 func (vm *VMKeeper) QueryFile(ctx sdk.Context, filepath string) (res string, err error) {
 	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+
+	// An optional @<version> suffix selects a previous version of an upgraded package.
+	filepath, version, err := splitQueryVersion(filepath)
+	if err != nil {
+		return "", err
+	}
+
 	dirpath, filename := gnovm.SplitFilepath(filepath)
+	memPkg := store.GetMemPackage(dirpath)
+	if memPkg != nil && version != 0 && version != store.NumMemPackageVersions(dirpath) {
+		memPkg = store.GetMemPackageVersion(dirpath, version)
+	}
 	if filename != "" {
-		memFile := store.GetMemFile(dirpath, filename)
+		var memFile *gnovm.MemFile
+		if memPkg != nil {
+			memFile = memPkg.GetFile(filename)
+		}
 		if memFile == nil {
 			return "", fmt.Errorf("file %q is not available", filepath) // TODO: XSS protection
 		}
 		return memFile.Body, nil
 	} else {
-		memPkg := store.GetMemPackage(dirpath)
 		if memPkg == nil {
 			return "", fmt.Errorf("package %q is not available", dirpath) // TODO: XSS protection
 		}
@@ -827,8 +1078,33 @@ func (vm *VMKeeper) QueryFile(ctx sdk.Context, filepath string) (res string, err
 	}
 }
 
+// QueryPkgVersions returns the number of stored versions of a package.
+func (vm *VMKeeper) QueryPkgVersions(ctx sdk.Context, pkgPath string) (uint64, error) {
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+	n := store.NumMemPackageVersions(pkgPath)
+	if n == 0 {
+		return 0, fmt.Errorf("package %q is not available", pkgPath) // TODO: XSS protection
+	}
+	return n, nil
+}
+
+// splitQueryVersion splits a trailing @<version> off the given path.
+// The returned version is 0 if no version is specified.
+func splitQueryVersion(path string) (string, uint64, error) {
+	at := strings.LastIndexByte(path, '@')
+	if at < 0 {
+		return path, 0, nil
+	}
+	version, err := strconv.ParseUint(path[at+1:], 10, 64)
+	if err != nil || version == 0 {
+		return "", 0, fmt.Errorf("invalid package version %q", path[at+1:])
+	}
+	return path[:at], version, nil
+}
+
 // logTelemetry logs the VM processing telemetry
 func logTelemetry(
+	gnostore gno.TransactionStore,
 	gasUsed int64,
 	cpuCycles int64,
 	attributes ...attribute.KeyValue,
@@ -857,4 +1133,17 @@ func logTelemetry(
 		gasUsed,
 		metric.WithAttributes(attributes...),
 	)
+
+	// Record the object cache hits and misses
+	hits, misses := gnostore.ObjectCacheStats()
+	metrics.VMObjectCacheHits.Add(
+		context.Background(),
+		hits,
+		metric.WithAttributes(attributes...),
+	)
+	metrics.VMObjectCacheMisses.Add(
+		context.Background(),
+		misses,
+		metric.WithAttributes(attributes...),
+	)
 }
@@ -3,8 +3,11 @@ package vm
 // TODO: move most of the logic in ROOT/gno.land/...
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -14,9 +17,15 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
 	"github.com/gnolang/gno/gnovm"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	authm "github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	bankm "github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/types"
@@ -337,6 +346,103 @@ func Echo(msg string) string {
 }
 
 // Using x/params from a realm.
+// Realms can issue and send amounts which don't fit in an int64.
+func TestVMKeeperRealmIssueBig(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	files := []*gnovm.MemFile{
+		{Name: "init.gno", Body: `
+package test
+
+import (
+	"math/big"
+	"std"
+)
+
+const denom = "/gno.land/r/test:wei"
+
+func Mint(amount string) string {
+	amt, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		panic("invalid amount")
+	}
+	banker := std.NewBanker(std.BankerTypeRealmIssue)
+	self := std.CurrentRealm().Address()
+	banker.IssueCoinBig(self, denom, amt)
+	amt.Add(amt, amt)
+	banker.IssueCoinBig(self, denom, amt)
+	banker.SendCoinsBig(self, std.OriginCaller(), std.BigCoins{std.NewBigCoin(denom, amt)})
+	return banker.GetCoinsBig(self).String()
+}`},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	msg2 := NewMsgCall(addr, nil, pkgPath, "Mint", []string{"1000000000000000000000000000000"})
+	res, err := env.vmk.Call(ctx, msg2)
+	require.NoError(t, err)
+	assert.Equal(t, `("1000000000000000000000000000000/gno.land/r/test:wei" string)`+"\n\n", res)
+
+	amt := env.bank.GetCoins(ctx, addr).AmountOf("/gno.land/r/test:wei")
+	assert.Equal(t, "2000000000000000000000000000000", amt.String())
+}
+
+// Coins issued and removed by realms are tracked in the total supply.
+func TestVMKeeperRealmIssueSupply(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	files := []*gnovm.MemFile{
+		{Name: "init.gno", Body: `
+package test
+
+import "std"
+
+const denom = "/gno.land/r/test:foo"
+
+func Mint(amount int64) int64 {
+	banker := std.NewBanker(std.BankerTypeRealmIssue)
+	banker.IssueCoin(std.OriginCaller(), denom, amount)
+	return banker.TotalCoin(denom)
+}
+
+func Burn(amount int64) int64 {
+	banker := std.NewBanker(std.BankerTypeRealmIssue)
+	banker.RemoveCoin(std.OriginCaller(), denom, amount)
+	return banker.TotalCoin(denom)
+}`},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Mint", []string{"100"}))
+	require.NoError(t, err)
+	assert.Equal(t, "(100 int64)\n\n", res)
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Burn", []string{"30"}))
+	require.NoError(t, err)
+	assert.Equal(t, "(70 int64)\n\n", res)
+
+	assert.Equal(t, std.NewCoin("/gno.land/r/test:foo", 70), env.bank.GetSupply(ctx, "/gno.land/r/test:foo"))
+	md, ok := env.bank.GetDenomMetadata(ctx, "/gno.land/r/test:foo")
+	require.True(t, ok)
+	assert.Equal(t, bankm.DenomMetadata{Denom: "/gno.land/r/test:foo", Display: "foo", Realm: pkgPath}, md)
+}
+
 func TestVMKeeperParams(t *testing.T) {
 	env := setupTestEnv()
 	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
@@ -553,6 +659,81 @@ func Echo(msg string) string {
 	)
 }
 
+func TestVMKeeperCallJSONArgs(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	files := []*gnovm.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+import "strings"
+
+type Order struct {
+	Items []string ` + "`json:\"items\"`" + `
+	Qty   map[string]int
+	Note  *string
+}
+
+var orders []*Order
+
+func Place(o *Order, ids []int) string {
+	orders = append(orders, o)
+	return strings.Join(o.Items, ",") + " " + *o.Note
+}
+
+func Total() int {
+	total := 0
+	for _, o := range orders {
+		for _, q := range o.Qty {
+			total += q
+		}
+	}
+	return total
+}`,
+		},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	msg2 := NewMsgCall(addr, nil, pkgPath, "Place", []string{
+		`{"items": ["a", "b"], "Qty": {"a": 2, "b": 3}, "Note": "asap"}`,
+		`[1, 2]`,
+	})
+	res, err := env.vmk.Call(ctx, msg2)
+	require.NoError(t, err)
+	assert.Equal(t, `("a,b asap" string)`+"\n\n", res)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	// The decoded order was persisted by the realm.
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Total", nil))
+	require.NoError(t, err)
+	assert.Equal(t, `(5 int)`+"\n\n", res)
+
+	// Invalid JSON is rejected.
+	msg3 := NewMsgCall(addr, nil, pkgPath, "Place", []string{`{"items": "a"}`, `[]`})
+	assert.PanicsWithValue(
+		t,
+		`error parsing *gno.land/r/test.Order "{\"items\": \"a\"}": json: cannot unmarshal string into Gno value of type []string`,
+		func() {
+			env.vmk.Call(ctx, msg3)
+		},
+	)
+}
+
 func TestVMKeeperReinitialize(t *testing.T) {
 	env := setupTestEnv()
 	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
@@ -596,6 +777,81 @@ func Echo(msg string) string {
 	assert.Equal(t, `("echo:hello world" string)`+"\n\n", res)
 }
 
+func TestVMKeeperObjectCache(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	files := []*gnovm.MemFile{
+		{Name: "init.gno", Body: `
+package test
+
+type Counter struct {
+	n int
+}
+
+var c = &Counter{}
+
+func Inc() int {
+	c.n++
+	return c.n
+}
+
+func Get() int {
+	return c.n
+}`},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	require.NoError(t, env.vmk.AddPackage(ctx, msg1))
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	type result struct {
+		res          string
+		gas          int64
+		hits, misses int64
+	}
+	call := func(fn string) result {
+		ctx := env.vmk.MakeGnoTransactionStore(env.ctx.WithGasMeter(types.NewInfiniteGasMeter()))
+		res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, fn, nil))
+		require.NoError(t, err)
+		env.vmk.CommitGnoTransactionStore(ctx)
+		hits, misses := env.vmk.getGnoTransactionStore(ctx).ObjectCacheStats()
+		return result{res, ctx.GasMeter().GasConsumed(), hits, misses}
+	}
+
+	// Objects read in a transaction are cached for the following ones,
+	// which consume the same amount of gas.
+	first := call("Get")
+	assert.NotZero(t, first.misses)
+	second := call("Get")
+	assert.Equal(t, first.res, second.res)
+	assert.Equal(t, first.gas, second.gas)
+	assert.NotZero(t, second.hits)
+	assert.Zero(t, second.misses)
+
+	// Written objects are invalidated.
+	assert.Equal(t, "(1 int)\n\n", call("Inc").res)
+	afterWrite := call("Get")
+	assert.Equal(t, "(1 int)\n\n", afterWrite.res)
+	assert.NotZero(t, afterWrite.misses)
+
+	// The gas doesn't depend on the cache.
+	cached := call("Get")
+	assert.Zero(t, cached.misses)
+	env.vmk.gnoStore.SetObjectCacheSize(0)
+	uncached := call("Get")
+	assert.Equal(t, cached.res, uncached.res)
+	assert.Equal(t, cached.gas, uncached.gas)
+	assert.Zero(t, uncached.hits+uncached.misses)
+}
+
 func Test_loadStdlibPackage(t *testing.T) {
 	mdb := memdb.NewMemDB()
 	cs := dbadapter.StoreConstructor(mdb, types.StoreOptions{})
@@ -608,3 +864,702 @@ func Test_loadStdlibPackage(t *testing.T) {
 		loadStdlibPackage("emptystdlib", "./testdata", gs)
 	})
 }
+
+func TestVMKeeperUpgradePackage(t *testing.T) {
+	env := setupTestEnv()
+
+	// Give "addr1" some gnots.
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	pkgPath := "gno.land/r/counter"
+	files := []*gnovm.MemFile{
+		{Name: "counter.gno", Body: `
+package counter
+
+import "std"
+
+type Item struct {
+	Name string
+}
+
+var (
+	count int
+	items []*Item
+)
+
+func Inc(name string) {
+	count++
+	items = append(items, &Item{Name: name})
+}
+
+func Get() int { return count }
+
+func CanUpgrade(caller std.Address) bool { return caller == "` + addr.String() + `" }`},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files)))
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Inc", []string{"a"}))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Inc", []string{"b"}))
+	require.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	// Upgrade the package, keeping its state.
+	upgraded := []*gnovm.MemFile{
+		{Name: "counter.gno", Body: `
+package counter
+
+import (
+	"std"
+	"strings"
+)
+
+type Item struct {
+	Name string
+}
+
+func (i *Item) Upper() string { return strings.ToUpper(i.Name) }
+
+var (
+	count int
+	items []*Item
+)
+
+func Inc(name string) {
+	count++
+	items = append(items, &Item{Name: name})
+}
+
+func Get() int { return count * 10 }
+
+func Names() string {
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Upper())
+	}
+	return strings.Join(names, ",")
+}
+
+func CanUpgrade(caller std.Address) bool { return caller == "` + addr.String() + `" }`},
+	}
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	require.NoError(t, env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, pkgPath, upgraded)))
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Get", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "(20 int)\n\n", res)
+
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Inc", []string{"c"}))
+	require.NoError(t, err)
+
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Names", nil))
+	require.NoError(t, err)
+	assert.Equal(t, `("A,B,C" string)`+"\n\n", res)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	// Both versions are queryable.
+	n, err := env.vmk.QueryPkgVersions(env.ctx, pkgPath)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), n)
+
+	body, err := env.vmk.QueryFile(env.ctx, pkgPath+"/counter.gno@1")
+	require.NoError(t, err)
+	assert.Contains(t, body, "func Get() int { return count }")
+
+	body, err = env.vmk.QueryFile(env.ctx, pkgPath+"/counter.gno")
+	require.NoError(t, err)
+	assert.Contains(t, body, "func Get() int { return count * 10 }")
+
+	// The upgraded package survives a restart.
+	env.vmk.gnoStore = nil
+	mcw := env.ctx.MultiStore().MultiCacheWrap()
+	env.vmk.Initialize(log.NewNoopLogger(), mcw)
+	mcw.MultiWrite()
+
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Names", nil))
+	require.NoError(t, err)
+	assert.Equal(t, `("A,B,C" string)`+"\n\n", res)
+}
+
+func TestVMKeeperUpgradePackage_Migration(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	pkgPath := "gno.land/r/counter"
+	files := []*gnovm.MemFile{
+		{Name: "counter.gno", Body: `
+package counter
+
+import "std"
+
+var count int
+
+func Inc() { count++ }
+
+func CanUpgrade(_ std.Address) bool { return true }`},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files)))
+	_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Inc", nil))
+	require.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	// Changing the type of count requires a migration.
+	upgraded := func(migrate string) []*gnovm.MemFile {
+		return []*gnovm.MemFile{
+			{Name: "counter.gno", Body: `
+package counter
+
+import "std"
+
+var count int64
+
+func Inc() { count++ }
+
+func Get() int64 { return count }
+
+func setCount(v int64) { count = v }
+
+func CanUpgrade(_ std.Address) bool { return true }
+` + migrate},
+		}
+	}
+
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	err = env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, pkgPath, upgraded("")))
+	var iue IncompatibleUpgradeError
+	assert.ErrorAs(t, err, &iue)
+
+	// The migration must receive the previous values.
+	err = env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, pkgPath, upgraded(`
+func migrate(count int64) {}`)))
+	assert.ErrorAs(t, err, &iue)
+
+	err = env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, pkgPath, upgraded(`
+func migrate(count int) { setCount(int64(count) * 100) }`)))
+	require.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Inc", nil))
+	require.NoError(t, err)
+
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Get", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "(101 int64)\n\n", res)
+}
+
+func TestVMKeeperUpgradePackage_Refused(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test packages.
+	const (
+		lockedPath = "gno.land/r/locked"
+		closedPath = "gno.land/r/closed"
+		echoPath   = "gno.land/r/echo"
+	)
+	echo := func(pkgPath, body string) []*gnovm.MemFile {
+		name := pkgPath[strings.LastIndexByte(pkgPath, '/')+1:]
+		if strings.Contains(body, "std.") {
+			body = "import \"std\"\n\n" + body
+		}
+		return []*gnovm.MemFile{
+			{Name: "echo.gno", Body: "package " + name + "\n\n" + body},
+		}
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, lockedPath, echo(lockedPath, `
+func Echo() string { return "v1" }`))))
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, closedPath, echo(closedPath, `
+func Echo() string { return "v1" }
+
+func CanUpgrade(_ std.Address) bool { return false }`))))
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, echoPath, echo(echoPath, `
+func Echo() string { return "v1" }
+
+func CanUpgrade(_ std.Address) bool { return true }`))))
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	tests := []struct {
+		name    string
+		msg     MsgUpgradePackage
+		errType error
+	}{
+		{
+			"missing package",
+			NewMsgUpgradePackage(addr, "gno.land/r/missing", echo("gno.land/r/missing", `
+func Echo() string { return "v2" }`)),
+			InvalidPkgPathError{},
+		},
+		{
+			"missing upgrade hook",
+			NewMsgUpgradePackage(addr, lockedPath, echo(lockedPath, `
+func Echo() string { return "v2" }
+
+func CanUpgrade(_ std.Address) bool { return true }`)),
+			UnauthorizedUserError{},
+		},
+		{
+			"upgrade not authorized",
+			NewMsgUpgradePackage(addr, closedPath, echo(closedPath, `
+func Echo() string { return "v2" }
+
+func CanUpgrade(_ std.Address) bool { return false }`)),
+			UnauthorizedUserError{},
+		},
+		{
+			"exported function removed",
+			NewMsgUpgradePackage(addr, echoPath, echo(echoPath, `
+func CanUpgrade(_ std.Address) bool { return true }`)),
+			IncompatibleUpgradeError{},
+		},
+		{
+			"exported function signature changed",
+			NewMsgUpgradePackage(addr, echoPath, echo(echoPath, `
+func Echo(msg string) string { return msg }
+
+func CanUpgrade(_ std.Address) bool { return true }`)),
+			IncompatibleUpgradeError{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+			err := env.vmk.UpgradePackage(ctx, tc.msg)
+			// IncompatibleUpgradeError is not comparable, so match on the error type.
+			target := reflect.New(reflect.TypeOf(tc.errType)).Interface()
+			assert.ErrorAs(t, err, target)
+
+			n, err := env.vmk.QueryPkgVersions(ctx, echoPath)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), n)
+		})
+	}
+}
+
+func TestVMKeeperScheduledCalls(t *testing.T) {
+	env := setupTestEnv()
+	atHeight := func(height int64) sdk.Context {
+		return env.ctx.WithBlockHeader(&bft.Header{ChainID: env.ctx.ChainID(), Height: height})
+	}
+	ctx := env.vmk.MakeGnoTransactionStore(atHeight(1))
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	pkgPath := "gno.land/r/ticker"
+	files := []*gnovm.MemFile{
+		{Name: "ticker.gno", Body: `
+package ticker
+
+import "std"
+
+var ticks int
+
+func Schedule(height int64, fn string) uint64 {
+	return std.ScheduleCallAtHeight(height, 2_000_000, fn, "2")
+}
+
+func ScheduleGas(height, maxGas int64) uint64 {
+	return std.ScheduleCallAtHeight(height, maxGas, "Tick", "2")
+}
+
+func Cancel(id uint64) { std.CancelScheduledCall(id) }
+
+func Tick(n int) {
+	if std.OriginCaller() != std.CurrentRealm().Address() {
+		panic("unexpected caller")
+	}
+	ticks += n
+}
+
+func Fail(n int) {
+	ticks += n
+	panic("fail")
+}
+
+func Ticks() int { return ticks }`},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files)))
+
+	// Fund the realm and schedule three calls, cancelling one.
+	send := std.MustParseCoins(ugnot.ValueString(10_000))
+	_, err := env.vmk.Call(ctx, NewMsgCall(addr, send, pkgPath, "Schedule", []string{"2", "Tick"}))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Schedule", []string{"2", "Fail"}))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Schedule", []string{"3", "Tick"}))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Cancel", []string{"3"}))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Schedule", []string{"1", "Tick"}))
+	assert.Error(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "ScheduleGas", []string{"2", "99999"}))
+	assert.ErrorContains(t, err, "max gas 99999 is below the scheduler minimum gas 100000")
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	// 2000 ugnot are prepaid for each of the pending calls.
+	pkgAddr := gnolang.DerivePkgAddr(pkgPath)
+	assert.Equal(t, std.MustParseCoins(ugnot.ValueString(6_000)), env.bank.GetCoins(ctx, pkgAddr))
+	calls, err := env.vmk.QueryScheduledCalls(ctx, pkgPath)
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+	assert.Equal(t, "Tick", calls[0].Func)
+	assert.Equal(t, "Fail", calls[1].Func)
+
+	// Nothing is due yet.
+	env.vmk.RunScheduledCalls(ctx)
+	calls, err = env.vmk.QueryScheduledCalls(ctx, pkgPath)
+	require.NoError(t, err)
+	assert.Len(t, calls, 2)
+
+	// Execute the calls, one per block.
+	env.vmk.prmk.SetInt64(ctx, schedulerMaxBlockCallsParamPath, 1)
+	ctx = atHeight(2)
+	env.vmk.RunScheduledCalls(ctx)
+	calls, err = env.vmk.QueryScheduledCalls(ctx, pkgPath)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, "Fail", calls[0].Func)
+
+	ctx = atHeight(3)
+	env.vmk.RunScheduledCalls(ctx)
+	calls, err = env.vmk.QueryScheduledCalls(ctx, pkgPath)
+	require.NoError(t, err)
+	assert.Empty(t, calls)
+
+	// The failed call was reverted, but both calls paid for their gas.
+	ctx = env.vmk.MakeGnoTransactionStore(ctx)
+	res, err := env.vmk.QueryEval(ctx, pkgPath, "Ticks()")
+	require.NoError(t, err)
+	assert.Equal(t, "(2 int)", res)
+
+	fees := env.bank.GetCoins(ctx, authm.FeeCollectorAddress())
+	assert.True(t, fees.IsAllPositive())
+	assert.Equal(t, std.MustParseCoins(ugnot.ValueString(10_000)), env.bank.GetCoins(ctx, pkgAddr).Add(fees))
+
+	var executed int
+	for _, evt := range ctx.EventLogger().Events() {
+		if gevt, ok := evt.(gnostd.GnoEvent); ok && gevt.Type == "ScheduledCallExecuted" {
+			executed++
+		}
+	}
+	assert.Equal(t, 2, executed)
+}
+
+func TestVMKeeperRandomSeed(t *testing.T) {
+	env := setupTestEnv()
+	atHeight := func(height int64) sdk.Context {
+		return env.ctx.WithBlockHeader(&bft.Header{ChainID: env.ctx.ChainID(), Height: height})
+	}
+	ctx := atHeight(1)
+	env.vmk.UpdateRandomSeed(ctx, nil)
+	ctx = env.vmk.MakeGnoTransactionStore(ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test package.
+	pkgPath := "gno.land/r/lottery"
+	files := []*gnovm.MemFile{
+		{Name: "lottery.gno", Body: `
+package lottery
+
+import (
+	"encoding/hex"
+	"std"
+)
+
+var height int64
+
+func Request() int64 {
+	height = std.RequestRandomSeed()
+	return height
+}
+
+func Draw() string {
+	seed, ok := std.RandomSeed(height)
+	if !ok {
+		return "pending"
+	}
+	return hex.EncodeToString(seed[:])
+}
+
+func DrawAt(h int64) { std.RandomSeed(h) }`},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files)))
+
+	// Request the seed of the next block.
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Request", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "(2 int64)\n\n", res)
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Draw", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "(\"pending\" string)\n\n", res)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	// The seed is derived from the randomness of the last commit, in the
+	// order of the validator addresses.
+	votes := []abci.VoteInfo{
+		{Address: crypto.AddressFromPreimage([]byte("val1")), Randomness: []byte("randomness1")},
+		{Address: crypto.AddressFromPreimage([]byte("val2"))},
+		{Address: crypto.AddressFromPreimage([]byte("val3")), Randomness: []byte("randomness3")},
+	}
+	if bytes.Compare(votes[0].Address[:], votes[2].Address[:]) < 0 {
+		votes[0], votes[2] = votes[2], votes[0]
+	}
+	ctx = atHeight(2)
+	env.vmk.UpdateRandomSeed(ctx, votes)
+
+	seed1 := sha256.Sum256([]byte{0, 0, 0, 0, 0, 0, 0, 1})
+	h := sha256.New()
+	h.Write(seed1[:])
+	h.Write([]byte{0, 0, 0, 0, 0, 0, 0, 2})
+	for _, i := range []int{2, 0} {
+		h.Write(votes[i].Address[:])
+		h.Write(votes[i].Randomness)
+	}
+	ctx = env.vmk.MakeGnoTransactionStore(ctx)
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Draw", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("(%q string)\n\n", fmt.Sprintf("%x", h.Sum(nil))), res)
+
+	// Only the requested seeds can be read, and are kept.
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "DrawAt", []string{"1"}))
+	assert.ErrorContains(t, err, "random seed of block 1 not requested by gno.land/r/lottery")
+	stor := ctx.Store(env.vmk.iavlKey)
+	assert.False(t, stor.Has(randomSeedKey(1)))
+	assert.True(t, stor.Has(randomSeedKey(2)))
+}
+
+func TestVMKeeperRealmMessages(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+	// Create test packages.
+	vaultPath, dexPath := "gno.land/r/vault", "gno.land/r/dex"
+	vaultFiles := []*gnovm.MemFile{
+		{Name: "vault.gno", Body: `
+package vault
+
+import (
+	"std"
+	"strings"
+)
+
+var received []string
+
+func Receive() {
+	msg, ok := std.CurrentMessage()
+	if !ok {
+		panic("not a message")
+	}
+	received = append(received, msg.Data)
+	if msg.Type == "fail" {
+		panic("rejected")
+	}
+	std.Emit("Received", "from", msg.From, "data", msg.Data)
+}
+
+func Received() string { return strings.Join(received, ",") }`},
+	}
+	dexFiles := []*gnovm.MemFile{
+		{Name: "dex.gno", Body: `
+package dex
+
+import (
+	"std"
+	"strings"
+)
+
+var bounces []string
+
+func Send(typ, data string) uint64 {
+	return std.SendMessageWithBounce("gno.land/r/vault", "Receive", typ, data, 5_000_000, "Bounce")
+}
+
+func SendGas(maxGas int64) uint64 {
+	return std.SendMessage("gno.land/r/vault", "Receive", "deposit", "", maxGas)
+}
+
+func Bounce() {
+	msg, ok := std.CurrentMessage()
+	if !ok || !msg.Bounced {
+		panic("not a bounce")
+	}
+	bounces = append(bounces, msg.From+" "+msg.Data+" "+msg.Error)
+}
+
+func Bounces() string { return strings.Join(bounces, ",") }`},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, vaultPath, vaultFiles)))
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, dexPath, dexFiles)))
+
+	// Fund the sending realm and send two messages.
+	send := std.MustParseCoins(ugnot.ValueString(30_000))
+	_, err := env.vmk.Call(ctx, NewMsgCall(addr, send, dexPath, "Send", []string{"deposit", "1"}))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, dexPath, "Send", []string{"fail", "2"}))
+	require.NoError(t, err)
+
+	// Handlers can't be called directly with a message.
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, vaultPath, "Receive", []string{}))
+	assert.ErrorContains(t, err, "not a message")
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, dexPath, "SendGas", []string{"99999"}))
+	assert.ErrorContains(t, err, "max gas 99999 is below the messages minimum gas 100000")
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	// 5000 ugnot are prepaid for each of the pending messages and their
+	// bounces.
+	dexAddr := gnolang.DerivePkgAddr(dexPath)
+	assert.Equal(t, std.MustParseCoins(ugnot.ValueString(10_000)), env.bank.GetCoins(ctx, dexAddr))
+	msgs, err := env.vmk.QueryMessages(ctx, dexPath)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, "deposit", msgs[0].Type)
+	assert.Equal(t, "fail", msgs[1].Type)
+
+	// Deliver the messages, one per block: the failed one bounces.
+	env.vmk.prmk.SetInt64(ctx, messagesMaxBlockMessagesParamPath, 1)
+	env.vmk.DeliverMessages(ctx)
+	msgs, err = env.vmk.QueryMessages(ctx, dexPath)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "fail", msgs[0].Type)
+
+	env.vmk.DeliverMessages(ctx)
+	msgs, err = env.vmk.QueryMessages(ctx, dexPath)
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+	msgs, err = env.vmk.QueryMessages(ctx, vaultPath)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.True(t, msgs[0].Bounced)
+	assert.Equal(t, dexPath, msgs[0].To)
+	assert.Equal(t, "Bounce", msgs[0].Handler)
+
+	// The failed delivery was reverted.
+	qctx := env.vmk.MakeGnoTransactionStore(ctx)
+	res, err := env.vmk.QueryEval(qctx, vaultPath, "Received()")
+	require.NoError(t, err)
+	assert.Equal(t, `("1" string)`, res)
+
+	// Deliver the bounced message.
+	env.vmk.DeliverMessages(ctx)
+	msgs, err = env.vmk.QueryMessages(ctx, vaultPath)
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	qctx = env.vmk.MakeGnoTransactionStore(ctx)
+	res, err = env.vmk.QueryEval(qctx, dexPath, "Bounces()")
+	require.NoError(t, err)
+	assert.Contains(t, res, "gno.land/r/vault 2 ")
+	assert.Contains(t, res, "rejected")
+
+	// All the deliveries paid for their gas, and the rest was refunded.
+	fees := env.bank.GetCoins(ctx, authm.FeeCollectorAddress())
+	assert.True(t, fees.IsAllPositive())
+	assert.Equal(t, std.MustParseCoins(ugnot.ValueString(30_000)), env.bank.GetCoins(ctx, dexAddr).Add(fees))
+	assert.True(t, env.bank.GetCoins(ctx, messengerEscrowAddress).IsZero())
+
+	counts := map[string]int{}
+	for _, evt := range ctx.EventLogger().Events() {
+		if gevt, ok := evt.(gnostd.GnoEvent); ok {
+			counts[gevt.Type]++
+		}
+	}
+	assert.Equal(t, map[string]int{
+		"MessageSent":      2,
+		"MessageDelivered": 3,
+		"MessageBounced":   1,
+		"Received":         1,
+	}, counts)
+}
+
+func TestVMKeeperBytecode(t *testing.T) {
+	t.Parallel()
+
+	const src = `package test
+
+func Fib(n int) int {
+	a, b := 0, 1
+	for i := 0; i < n; i++ {
+		a, b = b, a+b
+	}
+	return a
+}`
+
+	call := func(t *testing.T, bytecode, param bool) (res string, gas int64) {
+		t.Helper()
+
+		env := setupTestEnv()
+		env.vmk.Bytecode = bytecode
+		ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+		if param {
+			env.vmk.prmk.SetBool(ctx, bytecodeParamPath, true)
+		}
+		assert.Equal(t, bytecode || param, env.vmk.useBytecode(ctx))
+
+		addr := crypto.AddressFromPreimage([]byte("addr1"))
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bank.SetCoins(ctx, addr, std.MustParseCoins(coinsString))
+
+		files := []*gnovm.MemFile{{Name: "test.gno", Body: src}}
+		require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, "gno.land/r/test", files)))
+
+		ctx = ctx.WithGasMeter(types.NewInfiniteGasMeter())
+		res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, "gno.land/r/test", "Fib", []string{"50"}))
+		require.NoError(t, err)
+		return res, ctx.GasMeter().GasConsumed()
+	}
+
+	// Bytecode is off by default.
+	assert.False(t, setupTestEnv().vmk.Bytecode)
+
+	res, gas := call(t, false, false)
+	bres, bgas := call(t, true, false)
+	pres, pgas := call(t, false, true)
+	assert.Equal(t, "(12586269025 int)\n\n", res)
+	assert.Equal(t, res, bres)
+	assert.Equal(t, gas, bgas, "gas")
+	// The vm.bytecode param enables it chain-wide, without affecting the gas.
+	assert.Equal(t, res, pres)
+	assert.Equal(t, gas, pgas, "gas")
+}
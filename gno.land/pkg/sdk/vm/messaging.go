@@ -0,0 +1,323 @@
+package vm
+
+import (
+	"fmt"
+	"strconv"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs"
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// Realm messages pending delivery are kept in the iavl store, under the
+// following keys:
+//
+//	msg:ctr                     last assigned message ID
+//	msg:queue:<id>              the RealmMessage, in delivery order
+//	msg:pkg:<pkgpath>:<id>      messages sent by a realm
+//
+// Numbers are zero-padded, so that the queue iterates in delivery order.
+const (
+	msgCounterKey  = "msg:ctr"
+	msgQueuePrefix = "msg:queue:"
+	msgPkgPrefix   = "msg:pkg:"
+)
+
+// messengerEscrowAddress holds the gas deposits of the pending realm messages.
+var messengerEscrowAddress = crypto.AddressFromPreimage([]byte("vm/messenger"))
+
+// RealmMessage is a message from a realm to another, pending delivery in the
+// EndBlocker, sent through std.SendMessage.
+type RealmMessage struct {
+	ID            uint64    `json:"id" yaml:"id"`
+	From          string    `json:"from" yaml:"from"`
+	To            string    `json:"to" yaml:"to"`
+	Handler       string    `json:"handler" yaml:"handler"`
+	Type          string    `json:"type" yaml:"type"`
+	Data          string    `json:"data" yaml:"data"`
+	MaxGas        int64     `json:"max_gas" yaml:"max_gas"`
+	BounceHandler string    `json:"bounce_handler" yaml:"bounce_handler"`
+	Bounced       bool      `json:"bounced" yaml:"bounced"`
+	Error         string    `json:"error" yaml:"error"`
+	Deposit       std.Coins `json:"deposit" yaml:"deposit"`
+	BounceDeposit std.Coins `json:"bounce_deposit" yaml:"bounce_deposit"`
+}
+
+// payer returns the package path of the realm which prepaid the gas of the
+// message: its sender, or its recipient if it bounced.
+func (rm RealmMessage) payer() string {
+	if rm.Bounced {
+		return rm.To
+	}
+	return rm.From
+}
+
+func realmMessageKey(id uint64) []byte {
+	return []byte(fmt.Sprintf("%s%020d", msgQueuePrefix, id))
+}
+
+func realmMessagePkgKey(pkgPath string, id uint64) []byte {
+	return []byte(fmt.Sprintf("%s%s:%020d", msgPkgPrefix, pkgPath, id))
+}
+
+// deliveredMessageContextKey is the context key of the message being
+// delivered, exposed to its handler through std.CurrentMessage.
+type deliveredMessageContextKey struct{}
+
+func deliveredMessage(ctx sdk.Context) *stdlibs.RealmMessage {
+	msg, _ := ctx.Value(deliveredMessageContextKey{}).(*stdlibs.RealmMessage)
+	return msg
+}
+
+// ----------------------------------------
+// SDKMessenger
+
+type SDKMessenger struct {
+	vmk *VMKeeper
+	ctx sdk.Context
+}
+
+func NewSDKMessenger(vmk *VMKeeper, ctx sdk.Context) *SDKMessenger {
+	return &SDKMessenger{
+		vmk: vmk,
+		ctx: ctx,
+	}
+}
+
+func (msr *SDKMessenger) SendMessage(msg stdlibs.RealmMessage) (uint64, error) {
+	return msr.vmk.sendMessage(msr.ctx, msg)
+}
+
+// ----------------------------------------
+// VMKeeper
+
+func (vm *VMKeeper) sendMessage(ctx sdk.Context, msg stdlibs.RealmMessage) (uint64, error) {
+	maxBlockGas := vm.getMessagesMaxBlockGasParam(ctx)
+	if msg.MaxGas > maxBlockGas {
+		return 0, fmt.Errorf("max gas %d exceeds the messages block gas limit %d", msg.MaxGas, maxBlockGas)
+	}
+	minGas := vm.getMessagesMinGasParam(ctx)
+	if msg.MaxGas < minGas {
+		return 0, fmt.Errorf("max gas %d is below the messages minimum gas %d", msg.MaxGas, minGas)
+	}
+	gp, err := std.ParseGasPrice(vm.getMessagesGasPriceParam(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	// Prepay the gas from the realm's balance, including that of the
+	// bounce if there is a bounce handler.
+	deposit := scaleCoins(std.Coins{gp.Price}, msg.MaxGas, gp.Gas)
+	bounceDeposit := std.Coins{}
+	if msg.BounceHandler != "" {
+		bounceDeposit = deposit
+	}
+	pkgAddr := gno.DerivePkgAddr(msg.From)
+	if err := vm.bank.SendCoins(ctx, pkgAddr, messengerEscrowAddress, deposit.Add(bounceDeposit)); err != nil {
+		return 0, fmt.Errorf("unable to prepay message gas, %w", err)
+	}
+
+	rm := vm.queueMessage(ctx, RealmMessage{
+		From:          msg.From,
+		To:            msg.To,
+		Handler:       msg.Handler,
+		Type:          msg.Type,
+		Data:          msg.Data,
+		MaxGas:        msg.MaxGas,
+		BounceHandler: msg.BounceHandler,
+		Deposit:       deposit,
+		BounceDeposit: bounceDeposit,
+	})
+	ctx.EventLogger().EmitEvent(realmMessageEvent("MessageSent", rm,
+		"max_gas", strconv.FormatInt(rm.MaxGas, 10),
+		"deposit", rm.Deposit.Add(rm.BounceDeposit).String(),
+	))
+	return rm.ID, nil
+}
+
+// queueMessage assigns an ID to rm and queues it for delivery.
+func (vm *VMKeeper) queueMessage(ctx sdk.Context, rm RealmMessage) RealmMessage {
+	rm.ID = vm.lastMessageID(ctx) + 1
+	stor := ctx.Store(vm.iavlKey)
+	stor.Set([]byte(msgCounterKey), amino.MustMarshal(rm.ID))
+	stor.Set(realmMessageKey(rm.ID), amino.MustMarshal(rm))
+	stor.Set(realmMessagePkgKey(rm.From, rm.ID), []byte{1})
+	return rm
+}
+
+func (vm *VMKeeper) lastMessageID(ctx sdk.Context) (id uint64) {
+	if bz := ctx.Store(vm.iavlKey).Get([]byte(msgCounterKey)); bz != nil {
+		amino.MustUnmarshal(bz, &id)
+	}
+	return id
+}
+
+func (vm *VMKeeper) getRealmMessage(ctx sdk.Context, id uint64) (rm RealmMessage, ok bool) {
+	bz := ctx.Store(vm.iavlKey).Get(realmMessageKey(id))
+	if bz == nil {
+		return rm, false
+	}
+	amino.MustUnmarshal(bz, &rm)
+	return rm, true
+}
+
+func (vm *VMKeeper) deleteRealmMessage(ctx sdk.Context, rm RealmMessage) {
+	stor := ctx.Store(vm.iavlKey)
+	stor.Delete(realmMessageKey(rm.ID))
+	stor.Delete(realmMessagePkgKey(rm.From, rm.ID))
+}
+
+// QueryMessages returns the messages pending delivery sent by the realm at
+// pkgPath, ordered by ID. Bounced messages are sent by the realm which failed
+// to handle them.
+func (vm *VMKeeper) QueryMessages(ctx sdk.Context, pkgPath string) ([]RealmMessage, error) {
+	prefix := []byte(msgPkgPrefix + pkgPath + ":")
+	stor := ctx.Store(vm.iavlKey)
+	iter := stor.Iterator(prefix, types.PrefixEndBytes(prefix))
+	defer iter.Close()
+
+	msgs := []RealmMessage{}
+	for ; iter.Valid(); iter.Next() {
+		id, err := strconv.ParseUint(string(iter.Key()[len(prefix):]), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if rm, ok := vm.getRealmMessage(ctx, id); ok {
+			msgs = append(msgs, rm)
+		}
+	}
+	return msgs, nil
+}
+
+// pendingMessages returns the IDs of the first messages pending delivery, up
+// to limit, in the order they were sent.
+func (vm *VMKeeper) pendingMessages(ctx sdk.Context, limit int64) []uint64 {
+	prefix := []byte(msgQueuePrefix)
+	iter := ctx.Store(vm.iavlKey).Iterator(prefix, types.PrefixEndBytes(prefix))
+	defer iter.Close()
+
+	var ids []uint64
+	for ; iter.Valid() && int64(len(ids)) < limit; iter.Next() {
+		key := iter.Key()
+		id, err := strconv.ParseUint(string(key[len(prefix):]), 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid message queue key %q", key))
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// DeliverMessages delivers the pending realm messages, in the order they were
+// sent, until the messages block gas limit or the maximum number of messages
+// per block is reached. The messages sent during the delivery, and the
+// remaining ones, are left for the next blocks.
+//
+// Each message is delivered in its own cache context, by calling its handler
+// with the sending realm as the origin caller and a gas limit of its MaxGas;
+// the changes of the handler are discarded if it fails. The consumed gas, but
+// at least the messages minimum gas which accounts for the cost of the
+// delivery, is paid to the fee collector out of the message's deposit, and the
+// rest is refunded. If the delivery failed and the message has a bounce
+// handler, it is queued back to its sender, paid by its bounce deposit;
+// otherwise, the bounce deposit is refunded too.
+func (vm *VMKeeper) DeliverMessages(ctx sdk.Context) {
+	maxBlockGas := vm.getMessagesMaxBlockGasParam(ctx)
+	minGas := vm.getMessagesMinGasParam(ctx)
+	// Only the messages which may fit in the block are loaded.
+	limit := vm.getMessagesMaxBlockMessagesParam(ctx)
+	if minGas > 0 {
+		limit = min(limit, maxBlockGas/minGas)
+	}
+	remaining := maxBlockGas
+	for _, id := range vm.pendingMessages(ctx, limit) {
+		rm, ok := vm.getRealmMessage(ctx, id)
+		if !ok {
+			panic(fmt.Sprintf("realm message %d not found", id))
+		}
+		gasLimit := min(rm.MaxGas, maxBlockGas)
+		if max(gasLimit, minGas) > remaining {
+			break
+		}
+
+		delivered := &stdlibs.RealmMessage{
+			ID:      rm.ID,
+			From:    rm.From,
+			To:      rm.To,
+			Type:    rm.Type,
+			Data:    rm.Data,
+			Bounced: rm.Bounced,
+			Error:   rm.Error,
+		}
+		gasUsed, err := vm.runChainCall(ctx.WithValue(deliveredMessageContextKey{}, delivered), MsgCall{
+			Caller:  gno.DerivePkgAddr(rm.From),
+			PkgPath: rm.To,
+			Func:    rm.Handler,
+		}, gasLimit)
+		gasCharged := max(gasUsed, minGas)
+		remaining -= gasCharged
+
+		// Pay for the gas charged, out of the deposit.
+		fee := scaleCoins(rm.Deposit, min(gasCharged, rm.MaxGas), rm.MaxGas)
+		refund := rm.Deposit.Sub(fee)
+		if err := vm.bank.SendCoins(ctx, messengerEscrowAddress, auth.FeeCollectorAddress(), fee); err != nil {
+			panic(err)
+		}
+		vm.deleteRealmMessage(ctx, rm)
+
+		errMsg := ""
+		if err != nil {
+			errMsg = err.Error()
+		}
+		ctx.EventLogger().EmitEvent(realmMessageEvent("MessageDelivered", rm,
+			"gas_used", strconv.FormatInt(gasUsed, 10),
+			"fee", fee.String(),
+			"error", errMsg,
+		))
+
+		if err != nil && rm.BounceHandler != "" {
+			bounced := vm.queueMessage(ctx, RealmMessage{
+				From:    rm.To,
+				To:      rm.From,
+				Handler: rm.BounceHandler,
+				Type:    rm.Type,
+				Data:    rm.Data,
+				MaxGas:  rm.MaxGas,
+				Bounced: true,
+				Error:   errMsg,
+				Deposit: rm.BounceDeposit,
+			})
+			ctx.EventLogger().EmitEvent(realmMessageEvent("MessageBounced", bounced,
+				"message_id", strconv.FormatUint(rm.ID, 10),
+			))
+		} else {
+			refund = refund.Add(rm.BounceDeposit)
+		}
+		if err := vm.bank.SendCoins(ctx, messengerEscrowAddress, gno.DerivePkgAddr(rm.payer()), refund); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func realmMessageEvent(typ string, rm RealmMessage, attrs ...string) gnostd.GnoEvent {
+	evt := gnostd.GnoEvent{
+		Type:    typ,
+		PkgPath: rm.To,
+		Func:    rm.Handler,
+		Attributes: []gnostd.GnoEventAttribute{
+			{Key: "id", Value: strconv.FormatUint(rm.ID, 10)},
+			{Key: "from", Value: rm.From},
+			{Key: "type", Value: rm.Type},
+		},
+	}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		evt.Attributes = append(evt.Attributes, gnostd.GnoEventAttribute{Key: attrs[i], Value: attrs[i+1]})
+	}
+	return evt
+}
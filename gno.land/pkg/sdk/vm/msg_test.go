@@ -48,7 +48,7 @@ func TestMsgAddPackage_ValidateBasic(t *testing.T) {
 				},
 				Deposit: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: 1000,
+					Amount: std.NewInt(1000),
 				}},
 			},
 			expectErr: std.InvalidAddressError{},
@@ -64,7 +64,7 @@ func TestMsgAddPackage_ValidateBasic(t *testing.T) {
 				},
 				Deposit: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: 1000,
+					Amount: std.NewInt(1000),
 				}},
 			},
 			expectErr: InvalidPkgPathError{},
@@ -80,7 +80,7 @@ func TestMsgAddPackage_ValidateBasic(t *testing.T) {
 				},
 				Deposit: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: -1000, // invalid amount
+					Amount: std.NewInt(-1000), // invalid amount
 				}},
 			},
 			expectErr: std.InvalidCoinsError{},
@@ -101,6 +101,85 @@ func TestMsgAddPackage_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestMsgUpgradePackage_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	creator := crypto.AddressFromPreimage([]byte("addr1"))
+	pkgName := "test"
+	files := []*gnovm.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+		func Echo() string {return "hello world"}`,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		msg             MsgUpgradePackage
+		expectSignBytes string
+		expectErr       error
+	}{
+		{
+			name: "valid message",
+			msg:  NewMsgUpgradePackage(creator, "gno.land/r/namespace/test", files),
+			expectSignBytes: `{"creator":"g14ch5q26mhx3jk5cxl88t278nper264ces4m8nt",` +
+				`"package":{"files":[{"body":"package test\n\t\tfunc Echo() string {return \"hello world\"}",` +
+				`"name":"test.gno"}],"name":"test","path":"gno.land/r/namespace/test"}}`,
+			expectErr: nil,
+		},
+		{
+			name: "missing creator address",
+			msg: MsgUpgradePackage{
+				Creator: crypto.Address{},
+				Package: &gnovm.MemPackage{
+					Name:  pkgName,
+					Path:  "gno.land/r/namespace/test",
+					Files: files,
+				},
+			},
+			expectErr: std.InvalidAddressError{},
+		},
+		{
+			name: "missing package path",
+			msg: MsgUpgradePackage{
+				Creator: creator,
+				Package: &gnovm.MemPackage{
+					Name:  pkgName,
+					Path:  "",
+					Files: files,
+				},
+			},
+			expectErr: InvalidPkgPathError{},
+		},
+		{
+			name: "not a realm",
+			msg: MsgUpgradePackage{
+				Creator: creator,
+				Package: &gnovm.MemPackage{
+					Name:  pkgName,
+					Path:  "gno.land/p/namespace/test",
+					Files: files,
+				},
+			},
+			expectErr: InvalidPkgPathError{},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tc.msg.ValidateBasic(); err != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+			} else {
+				assert.Equal(t, tc.expectSignBytes, string(tc.msg.GetSignBytes()))
+			}
+		})
+	}
+}
+
 func TestMsgCall_ValidateBasic(t *testing.T) {
 	t.Parallel()
 
@@ -131,7 +210,7 @@ func TestMsgCall_ValidateBasic(t *testing.T) {
 				Args:    args,
 				Send: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: 1000,
+					Amount: std.NewInt(1000),
 				}},
 			},
 			expectErr: std.InvalidAddressError{},
@@ -145,7 +224,7 @@ func TestMsgCall_ValidateBasic(t *testing.T) {
 				Args:    args,
 				Send: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: 1000,
+					Amount: std.NewInt(1000),
 				}},
 			},
 			expectErr: InvalidPkgPathError{},
@@ -159,7 +238,7 @@ func TestMsgCall_ValidateBasic(t *testing.T) {
 				Args:    args,
 				Send: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: 1000,
+					Amount: std.NewInt(1000),
 				}},
 			},
 			expectErr: InvalidPkgPathError{},
@@ -173,7 +252,7 @@ func TestMsgCall_ValidateBasic(t *testing.T) {
 				Args:    args,
 				Send: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: 1000,
+					Amount: std.NewInt(1000),
 				}},
 			},
 			expectErr: InvalidExprError{},
@@ -234,7 +313,7 @@ func TestMsgRun_ValidateBasic(t *testing.T) {
 				},
 				Send: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: 1000,
+					Amount: std.NewInt(1000),
 				}},
 			},
 			expectErr: std.InvalidAddressError{},
@@ -250,7 +329,7 @@ func TestMsgRun_ValidateBasic(t *testing.T) {
 				},
 				Send: std.Coins{std.Coin{
 					Denom:  "ugnot",
-					Amount: 1000,
+					Amount: std.NewInt(1000),
 				}},
 			},
 			expectErr: InvalidPkgPathError{},
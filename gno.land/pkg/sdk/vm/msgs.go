@@ -79,6 +79,56 @@ func (msg MsgAddPackage) GetReceived() std.Coins {
 	return msg.Deposit
 }
 
+//----------------------------------------
+// MsgUpgradePackage
+
+// MsgUpgradePackage - upgrade an existing realm, migrating its state
+type MsgUpgradePackage struct {
+	Creator crypto.Address    `json:"creator" yaml:"creator"`
+	Package *gnovm.MemPackage `json:"package" yaml:"package"`
+}
+
+var _ std.Msg = MsgUpgradePackage{}
+
+// NewMsgUpgradePackage - upload the new version of a realm.
+func NewMsgUpgradePackage(creator crypto.Address, pkgPath string, files []*gnovm.MemFile) MsgUpgradePackage {
+	msg := NewMsgAddPackage(creator, pkgPath, files)
+	return MsgUpgradePackage{
+		Creator: msg.Creator,
+		Package: msg.Package,
+	}
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) Route() string { return RouterKey }
+
+// Implements Msg.
+func (msg MsgUpgradePackage) Type() string { return "upgrade_package" }
+
+// Implements Msg.
+func (msg MsgUpgradePackage) ValidateBasic() error {
+	if msg.Creator.IsZero() {
+		return std.ErrInvalidAddress("missing creator address")
+	}
+	if msg.Package == nil || msg.Package.Path == "" {
+		return ErrInvalidPkgPath("missing package path")
+	}
+	if !gno.IsRealmPath(msg.Package.Path) {
+		return ErrInvalidPkgPath("pkgpath must be of a realm")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Creator}
+}
+
 //----------------------------------------
 // MsgCall
 
@@ -141,6 +191,16 @@ func (msg MsgCall) GetReceived() std.Coins {
 	return msg.Send
 }
 
+// Implements feegrant.PkgPathMsg.
+func (msg MsgCall) GetPkgPath() string {
+	return msg.PkgPath
+}
+
+// Implements session.CallMsg.
+func (msg MsgCall) GetFunc() string {
+	return msg.Func
+}
+
 //----------------------------------------
 // MsgRun
 
@@ -17,6 +17,9 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	MsgCall{}, "m_call",
 	MsgRun{}, "m_run",
 	MsgAddPackage{}, "m_addpkg", // TODO rename both to MsgAddPkg?
+	MsgUpgradePackage{}, "m_upgradepkg",
+	ScheduledCall{}, "ScheduledCall",
+	RealmMessage{}, "RealmMessage",
 
 	// errors
 	InvalidPkgPathError{}, "InvalidPkgPathError",
@@ -26,4 +29,5 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	InvalidExprError{}, "InvalidExprError",
 	TypeCheckError{}, "TypeCheckError",
 	UnauthorizedUserError{}, "UnauthorizedUserError",
+	IncompatibleUpgradeError{}, "IncompatibleUpgradeError",
 ))
@@ -0,0 +1,205 @@
+package vm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	authm "github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	bankm "github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	paramsm "github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newParallelTestApp returns a BaseApp executing the messages of the VMKeeper
+// with parallelTxs workers, wired like the gno.land app, but without stdlibs
+// nor signatures. The account of caller is created with some coins.
+func newParallelTestApp(t *testing.T, parallelTxs int, caller crypto.Address) (*sdk.BaseApp, *bytes.Buffer) {
+	t.Helper()
+
+	db := memdb.NewMemDB()
+	mainKey := store.NewStoreKey("main")
+	baseKey := store.NewStoreKey("base")
+	app := sdk.NewBaseApp("vm", log.NewNoopLogger(), db, baseKey, mainKey)
+	app.MountStoreWithDB(mainKey, iavl.StoreConstructor, db)
+	app.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, db)
+
+	prmk := paramsm.NewParamsKeeper(mainKey, "vm")
+	acck := authm.NewAccountKeeper(mainKey, prmk, std.ProtoBaseAccount)
+	bank := bankm.NewBankKeeper(mainKey, acck)
+	vmk := NewVMKeeper(baseKey, mainKey, acck, bank, prmk)
+	var output bytes.Buffer
+	vmk.Output = &output
+	vmk.Bytecode = true
+
+	app.SetAnteHandler(func(ctx sdk.Context, tx std.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx = ctx.WithGasMeter(store.NewGasMeter(tx.Fee.GasWanted))
+		res.GasWanted = tx.Fee.GasWanted
+		return
+	})
+	app.SetBeginTxHook(func(ctx sdk.Context) sdk.Context {
+		return vmk.MakeGnoTransactionStore(ctx)
+	})
+	app.SetEndTxHook(func(ctx sdk.Context, result sdk.Result) {
+		vmk.WriteOutput(ctx)
+		if result.IsOK() {
+			vmk.CommitGnoTransactionStore(ctx)
+		}
+	})
+	if parallelTxs > 0 {
+		app.SetParallelTxs(parallelTxs, func(tx std.Tx) bool {
+			for _, msg := range tx.Msgs {
+				if _, ok := msg.(MsgCall); !ok {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	app.Router().AddRoute("vm", NewHandler(vmk))
+	app.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		acck.SetAccount(ctx, acck.NewAccountWithAddress(ctx, caller))
+		if err := bank.SetCoins(ctx, caller, std.MustParseCoins("10000000000ugnot")); err != nil {
+			panic(err)
+		}
+		return abci.ResponseInitChain{}
+	})
+
+	require.NoError(t, app.LoadLatestVersion())
+	ms := app.GetCacheMultiStore()
+	vmk.Initialize(log.NewNoopLogger(), ms)
+	ms.MultiWrite()
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain-id"})
+	return app, &output
+}
+
+// TestParallelTxs checks that executing the calls of a block in parallel, on
+// the shared caches of the gno store, leads to the same results as executing
+// them sequentially. Run it with -race.
+func TestParallelTxs(t *testing.T) {
+	t.Parallel()
+
+	caller := crypto.AddressFromPreimage([]byte("caller"))
+	const counter = `package counter
+
+type entry struct {
+	n    int
+	name string
+}
+
+var entries []*entry
+
+func Inc() int {
+	n := len(entries) + 1
+	entries = append(entries, &entry{n: n, name: "inc"})
+	println("inc", n)
+	return n
+}
+
+func Fail() { panic("fail") }`
+	const fib = `package fib
+
+func Fib(n int) int {
+	a, b := 0, 1
+	for i := 0; i < n; i++ {
+		a, b = b, a+b
+	}
+	return a
+}`
+	const proxy = `package proxy
+
+import (
+	"gno.land/p/demo/fib"
+	"gno.land/r/demo/counter"
+)
+
+var last int
+
+func Inc() int { return counter.Inc() }
+
+func Fib(n int) int {
+	last = fib.Fib(n)
+	return last
+}`
+
+	tx := func(msg std.Msg) []byte {
+		return amino.MustMarshal(std.Tx{
+			Msgs: []std.Msg{msg},
+			Fee:  std.Fee{GasWanted: 1e8, GasFee: std.NewCoin("ugnot", 1)},
+		})
+	}
+	addpkg := func(path, body string) []byte {
+		return tx(NewMsgAddPackage(caller, path, []*gnovm.MemFile{{Name: "pkg.gno", Body: body}}))
+	}
+	call := func(pkgPath, fn string, args ...string) []byte {
+		return tx(NewMsgCall(caller, nil, pkgPath, fn, args))
+	}
+	blocks := [][][]byte{
+		{
+			addpkg("gno.land/p/demo/fib", fib),
+			addpkg("gno.land/r/demo/counter", counter),
+			addpkg("gno.land/r/demo/counter2", counter),
+			addpkg("gno.land/r/demo/proxy", proxy),
+		},
+		{
+			call("gno.land/r/demo/counter", "Inc"),
+			call("gno.land/r/demo/counter2", "Inc"),
+			call("gno.land/r/demo/proxy", "Fib", "30"),
+			call("gno.land/r/demo/proxy", "Inc"), // conflicts with tx 0
+			call("gno.land/r/demo/counter2", "Fail"),
+			call("gno.land/r/demo/proxy", "Fib", "20"), // conflicts with tx 2
+			call("gno.land/r/demo/counter2", "Inc"),    // conflicts with tx 1
+		},
+		{
+			call("gno.land/r/demo/proxy", "Fib", "10"),
+			call("gno.land/r/demo/counter2", "Inc"),
+			addpkg("gno.land/r/demo/counter3", counter),
+			call("gno.land/r/demo/counter3", "Inc"), // after an addpkg
+			call("gno.land/r/demo/counter", "Inc"),
+		},
+	}
+
+	seq, seqOutput := newParallelTestApp(t, 0, caller)
+	par, parOutput := newParallelTestApp(t, 4, caller)
+	for i, txs := range blocks {
+		height := int64(i + 1)
+		var (
+			responses [2][]abci.ResponseDeliverTx
+			hashes    [2][]byte
+		)
+		for j, app := range []*sdk.BaseApp{seq, par} {
+			app.BeginBlock(abci.RequestBeginBlock{
+				Header: &bft.Header{ChainID: "test-chain-id", Height: height},
+				Txs:    txs,
+			})
+			for _, tx := range txs {
+				responses[j] = append(responses[j], app.DeliverTx(abci.RequestDeliverTx{Tx: tx}))
+			}
+			app.EndBlock(abci.RequestEndBlock{})
+			hashes[j] = app.Commit().Data
+		}
+		for k, resp := range responses[0] {
+			if height == 2 && k == 4 {
+				require.NotNil(t, resp.Error, "block %d, tx %d", height, k)
+			} else {
+				require.Nil(t, resp.Error, "block %d, tx %d: %s", height, k, resp.Log)
+			}
+		}
+		require.Equal(t, responses[0], responses[1], "block %d", height)
+		require.Equal(t, hashes[0], hashes[1], "block %d", height)
+	}
+	assert.Equal(t, seqOutput.String(), parOutput.String())
+	assert.Contains(t, seqOutput.String(), "inc 3\n")
+}
@@ -1,10 +1,24 @@
 package vm
 
-import "github.com/gnolang/gno/tm2/pkg/sdk"
+import (
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
 
 const (
 	sysUsersPkgParamPath = "gno.land/r/sys/params.sys.users_pkgpath.string"
 	chainDomainParamPath = "gno.land/r/sys/params.chain_domain.string"
+	bytecodeParamPath    = "gno.land/r/sys/params.vm.bytecode.bool"
+
+	schedulerMaxBlockGasParamPath   = "gno.land/r/sys/params.scheduler.max_block_gas.int64"
+	schedulerMaxBlockCallsParamPath = "gno.land/r/sys/params.scheduler.max_block_calls.int64"
+	schedulerMinGasParamPath        = "gno.land/r/sys/params.scheduler.min_gas.int64"
+	schedulerGasPriceParamPath      = "gno.land/r/sys/params.scheduler.gas_price.string"
+
+	messagesMaxBlockGasParamPath      = "gno.land/r/sys/params.messages.max_block_gas.int64"
+	messagesMaxBlockMessagesParamPath = "gno.land/r/sys/params.messages.max_block_messages.int64"
+	messagesMinGasParamPath           = "gno.land/r/sys/params.messages.min_gas.int64"
+	messagesGasPriceParamPath         = "gno.land/r/sys/params.messages.gas_price.string"
 )
 
 func (vm *VMKeeper) getChainDomainParam(ctx sdk.Context) string {
@@ -18,3 +32,64 @@ func (vm *VMKeeper) getSysUsersPkgParam(ctx sdk.Context) string {
 	vm.prmk.GetString(ctx, sysUsersPkgParamPath, &sysUsersPkg)
 	return sysUsersPkg
 }
+
+// useBytecode reports whether the machines run the compiled bytecode of the
+// functions which have one. The engine is enabled chain-wide by the vm.bytecode
+// param, so that all the nodes switch at the same height. The param is read
+// off the gas meter, as the engine doesn't change the gas used.
+func (vm *VMKeeper) useBytecode(ctx sdk.Context) bool {
+	if vm.Bytecode {
+		return true
+	}
+	bytecode := false // default
+	vm.prmk.GetBool(ctx.WithGasMeter(store.NewInfiniteGasMeter()), bytecodeParamPath, &bytecode)
+	return bytecode
+}
+
+func (vm *VMKeeper) getSchedulerMaxBlockGasParam(ctx sdk.Context) int64 {
+	maxBlockGas := int64(10_000_000) // default
+	vm.prmk.GetInt64(ctx, schedulerMaxBlockGasParamPath, &maxBlockGas)
+	return maxBlockGas
+}
+
+func (vm *VMKeeper) getSchedulerMaxBlockCallsParam(ctx sdk.Context) int64 {
+	maxBlockCalls := int64(100) // default
+	vm.prmk.GetInt64(ctx, schedulerMaxBlockCallsParamPath, &maxBlockCalls)
+	return maxBlockCalls
+}
+
+func (vm *VMKeeper) getSchedulerMinGasParam(ctx sdk.Context) int64 {
+	minGas := int64(100_000) // default
+	vm.prmk.GetInt64(ctx, schedulerMinGasParamPath, &minGas)
+	return minGas
+}
+
+func (vm *VMKeeper) getSchedulerGasPriceParam(ctx sdk.Context) string {
+	gasPrice := "1ugnot/1000gas" // default
+	vm.prmk.GetString(ctx, schedulerGasPriceParamPath, &gasPrice)
+	return gasPrice
+}
+
+func (vm *VMKeeper) getMessagesMaxBlockGasParam(ctx sdk.Context) int64 {
+	maxBlockGas := int64(10_000_000) // default
+	vm.prmk.GetInt64(ctx, messagesMaxBlockGasParamPath, &maxBlockGas)
+	return maxBlockGas
+}
+
+func (vm *VMKeeper) getMessagesMaxBlockMessagesParam(ctx sdk.Context) int64 {
+	maxBlockMessages := int64(100) // default
+	vm.prmk.GetInt64(ctx, messagesMaxBlockMessagesParamPath, &maxBlockMessages)
+	return maxBlockMessages
+}
+
+func (vm *VMKeeper) getMessagesMinGasParam(ctx sdk.Context) int64 {
+	minGas := int64(100_000) // default
+	vm.prmk.GetInt64(ctx, messagesMinGasParamPath, &minGas)
+	return minGas
+}
+
+func (vm *VMKeeper) getMessagesGasPriceParam(ctx sdk.Context) string {
+	gasPrice := "1ugnot/1000gas" // default
+	vm.prmk.GetString(ctx, messagesGasPriceParamPath, &gasPrice)
+	return gasPrice
+}
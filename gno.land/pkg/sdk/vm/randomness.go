@@ -0,0 +1,122 @@
+package vm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// Random seeds are kept in the iavl store, under the following keys:
+//
+//	rand:last                       seed of the last block
+//	rand:seed:<height>              seed of a block requested by a realm
+//	rand:req:<height>:<pkgpath>     request of a realm for the seed of a block
+//
+// Only the seeds requested by realms are kept.
+const (
+	randLastKey       = "rand:last"
+	randSeedPrefix    = "rand:seed:"
+	randRequestPrefix = "rand:req:"
+)
+
+func randomSeedKey(height int64) []byte {
+	return []byte(fmt.Sprintf("%s%020d", randSeedPrefix, height))
+}
+
+func randomRequestKey(height int64, pkgPath string) []byte {
+	return []byte(fmt.Sprintf("%s%020d:%s", randRequestPrefix, height, pkgPath))
+}
+
+// ----------------------------------------
+// SDKRandomness
+
+type SDKRandomness struct {
+	vmk *VMKeeper
+	ctx sdk.Context
+}
+
+func NewSDKRandomness(vmk *VMKeeper, ctx sdk.Context) *SDKRandomness {
+	return &SDKRandomness{
+		vmk: vmk,
+		ctx: ctx,
+	}
+}
+
+func (rnd *SDKRandomness) RequestSeed(pkgPath string, height int64) error {
+	if height <= rnd.ctx.BlockHeight() {
+		return fmt.Errorf("random seed of block %d is already known", height)
+	}
+	rnd.ctx.Store(rnd.vmk.iavlKey).Set(randomRequestKey(height, pkgPath), []byte{1})
+	return nil
+}
+
+func (rnd *SDKRandomness) Seed(pkgPath string, height int64) ([32]byte, error) {
+	var seed [32]byte
+	stor := rnd.ctx.Store(rnd.vmk.iavlKey)
+	if !stor.Has(randomRequestKey(height, pkgPath)) {
+		return seed, fmt.Errorf("random seed of block %d not requested by %s", height, pkgPath)
+	}
+	bz := stor.Get(randomSeedKey(height))
+	if len(bz) != len(seed) {
+		return seed, fmt.Errorf("random seed of block %d not found", height)
+	}
+	copy(seed[:], bz)
+	return seed, nil
+}
+
+// ----------------------------------------
+// VMKeeper
+
+// UpdateRandomSeed derives the random seed of the current block from the
+// seed of the previous block and the randomness of the validators in the
+// last commit, and keeps it if it was requested by a realm. It must be
+// called at the beginning of every block.
+//
+// The randomness of a validator is the output of a VRF on the height and hash
+// of the last block, which can't be computed without its private key, nor
+// before that block is proposed (see types.RandomnessBytes). The seed
+// is thus unpredictable as long as one of the validators in the last commit
+// is honest. A validator can't withhold its randomness while precommitting
+// the block, as such precommits are rejected (see types.Vote.VerifyRandomness);
+// however, the proposer, which chooses the precommits of the last commit
+// beyond the required +2/3, can still bias the seed by leaving some out.
+func (vm *VMKeeper) UpdateRandomSeed(ctx sdk.Context, votes []abci.VoteInfo) {
+	stor := ctx.Store(vm.iavlKey)
+
+	contributions := make([]abci.VoteInfo, 0, len(votes))
+	for _, vote := range votes {
+		if len(vote.Randomness) > 0 {
+			contributions = append(contributions, vote)
+		}
+	}
+	sort.Slice(contributions, func(i, j int) bool {
+		return bytes.Compare(contributions[i].Address[:], contributions[j].Address[:]) < 0
+	})
+
+	// seed = SHA-256(last seed || height || (address || randomness)...)
+	h := sha256.New()
+	h.Write(stor.Get([]byte(randLastKey)))
+	var height [8]byte
+	binary.BigEndian.PutUint64(height[:], uint64(ctx.BlockHeight()))
+	h.Write(height[:])
+	for _, vote := range contributions {
+		h.Write(vote.Address[:])
+		h.Write(vote.Randomness)
+	}
+	seed := h.Sum(nil)
+
+	stor.Set([]byte(randLastKey), seed)
+	prefix := []byte(fmt.Sprintf("%s%020d:", randRequestPrefix, ctx.BlockHeight()))
+	iter := stor.Iterator(prefix, types.PrefixEndBytes(prefix))
+	requested := iter.Valid()
+	iter.Close()
+	if requested {
+		stor.Set(randomSeedKey(ctx.BlockHeight()), seed)
+	}
+}
@@ -0,0 +1,340 @@
+package vm
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs"
+	gnostd "github.com/gnolang/gno/gnovm/stdlibs/std"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// Scheduled calls are kept in the iavl store, under the following keys:
+//
+//	sched:ctr                         last assigned call ID
+//	sched:call:<id>                   the ScheduledCall
+//	sched:h:<height>:<id>             height queue
+//	sched:t:<timestamp>:<id>          time queue
+//	sched:pkg:<pkgpath>:<id>          calls scheduled by a realm
+//
+// Numbers are zero-padded, so that the queues iterate in execution order.
+const (
+	schedCounterKey   = "sched:ctr"
+	schedCallPrefix   = "sched:call:"
+	schedHeightPrefix = "sched:h:"
+	schedTimePrefix   = "sched:t:"
+	schedPkgPrefix    = "sched:pkg:"
+)
+
+// schedulerEscrowAddress holds the gas deposits of the pending scheduled calls.
+var schedulerEscrowAddress = crypto.AddressFromPreimage([]byte("vm/scheduler"))
+
+// ScheduledCall is a realm call pending execution in the EndBlocker, scheduled
+// through std.ScheduleCallAtHeight or std.ScheduleCallAtTime.
+type ScheduledCall struct {
+	ID        uint64    `json:"id" yaml:"id"`
+	PkgPath   string    `json:"pkg_path" yaml:"pkg_path"`
+	Func      string    `json:"func" yaml:"func"`
+	Args      []string  `json:"args" yaml:"args"`
+	Height    int64     `json:"height" yaml:"height"`
+	Timestamp int64     `json:"timestamp" yaml:"timestamp"` // seconds
+	MaxGas    int64     `json:"max_gas" yaml:"max_gas"`
+	Deposit   std.Coins `json:"deposit" yaml:"deposit"`
+}
+
+func (sc ScheduledCall) queueKey() []byte {
+	if sc.Height != 0 {
+		return []byte(fmt.Sprintf("%s%020d:%020d", schedHeightPrefix, sc.Height, sc.ID))
+	}
+	return []byte(fmt.Sprintf("%s%020d:%020d", schedTimePrefix, sc.Timestamp, sc.ID))
+}
+
+func scheduledCallKey(id uint64) []byte {
+	return []byte(fmt.Sprintf("%s%020d", schedCallPrefix, id))
+}
+
+func scheduledPkgKey(pkgPath string, id uint64) []byte {
+	return []byte(fmt.Sprintf("%s%s:%020d", schedPkgPrefix, pkgPath, id))
+}
+
+// ----------------------------------------
+// SDKScheduler
+
+type SDKScheduler struct {
+	vmk *VMKeeper
+	ctx sdk.Context
+}
+
+func NewSDKScheduler(vmk *VMKeeper, ctx sdk.Context) *SDKScheduler {
+	return &SDKScheduler{
+		vmk: vmk,
+		ctx: ctx,
+	}
+}
+
+func (sch *SDKScheduler) ScheduleCall(call stdlibs.ScheduledCall) (uint64, error) {
+	return sch.vmk.scheduleCall(sch.ctx, call)
+}
+
+func (sch *SDKScheduler) CancelCall(pkgPath string, id uint64) error {
+	return sch.vmk.cancelScheduledCall(sch.ctx, pkgPath, id)
+}
+
+// ----------------------------------------
+// VMKeeper
+
+func (vm *VMKeeper) scheduleCall(ctx sdk.Context, call stdlibs.ScheduledCall) (uint64, error) {
+	maxBlockGas := vm.getSchedulerMaxBlockGasParam(ctx)
+	if call.MaxGas > maxBlockGas {
+		return 0, fmt.Errorf("max gas %d exceeds the scheduler block gas limit %d", call.MaxGas, maxBlockGas)
+	}
+	minGas := vm.getSchedulerMinGasParam(ctx)
+	if call.MaxGas < minGas {
+		return 0, fmt.Errorf("max gas %d is below the scheduler minimum gas %d", call.MaxGas, minGas)
+	}
+	gp, err := std.ParseGasPrice(vm.getSchedulerGasPriceParam(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	// Prepay the gas from the realm's balance.
+	deposit := scaleCoins(std.Coins{gp.Price}, call.MaxGas, gp.Gas)
+	pkgAddr := gno.DerivePkgAddr(call.PkgPath)
+	if err := vm.bank.SendCoins(ctx, pkgAddr, schedulerEscrowAddress, deposit); err != nil {
+		return 0, fmt.Errorf("unable to prepay scheduled call gas, %w", err)
+	}
+
+	stor := ctx.Store(vm.iavlKey)
+	var id uint64
+	if bz := stor.Get([]byte(schedCounterKey)); bz != nil {
+		amino.MustUnmarshal(bz, &id)
+	}
+	id++
+	stor.Set([]byte(schedCounterKey), amino.MustMarshal(id))
+
+	sc := ScheduledCall{
+		ID:        id,
+		PkgPath:   call.PkgPath,
+		Func:      call.Func,
+		Args:      call.Args,
+		Height:    call.Height,
+		Timestamp: call.Timestamp,
+		MaxGas:    call.MaxGas,
+		Deposit:   deposit,
+	}
+	stor.Set(scheduledCallKey(id), amino.MustMarshal(sc))
+	stor.Set(sc.queueKey(), []byte{1})
+	stor.Set(scheduledPkgKey(sc.PkgPath, id), []byte{1})
+
+	ctx.EventLogger().EmitEvent(scheduledCallEvent("ScheduledCall", sc,
+		"height", strconv.FormatInt(sc.Height, 10),
+		"timestamp", strconv.FormatInt(sc.Timestamp, 10),
+		"max_gas", strconv.FormatInt(sc.MaxGas, 10),
+		"deposit", sc.Deposit.String(),
+	))
+	return id, nil
+}
+
+func (vm *VMKeeper) cancelScheduledCall(ctx sdk.Context, pkgPath string, id uint64) error {
+	sc, ok := vm.getScheduledCall(ctx, id)
+	if !ok || sc.PkgPath != pkgPath {
+		return fmt.Errorf("scheduled call %d not found", id)
+	}
+
+	pkgAddr := gno.DerivePkgAddr(sc.PkgPath)
+	if err := vm.bank.SendCoins(ctx, schedulerEscrowAddress, pkgAddr, sc.Deposit); err != nil {
+		return err
+	}
+	vm.deleteScheduledCall(ctx, sc)
+
+	ctx.EventLogger().EmitEvent(scheduledCallEvent("ScheduledCallCancelled", sc,
+		"refund", sc.Deposit.String(),
+	))
+	return nil
+}
+
+func (vm *VMKeeper) getScheduledCall(ctx sdk.Context, id uint64) (sc ScheduledCall, ok bool) {
+	bz := ctx.Store(vm.iavlKey).Get(scheduledCallKey(id))
+	if bz == nil {
+		return sc, false
+	}
+	amino.MustUnmarshal(bz, &sc)
+	return sc, true
+}
+
+func (vm *VMKeeper) deleteScheduledCall(ctx sdk.Context, sc ScheduledCall) {
+	stor := ctx.Store(vm.iavlKey)
+	stor.Delete(scheduledCallKey(sc.ID))
+	stor.Delete(sc.queueKey())
+	stor.Delete(scheduledPkgKey(sc.PkgPath, sc.ID))
+}
+
+// QueryScheduledCalls returns the calls pending execution scheduled by the
+// realm at pkgPath, ordered by ID.
+func (vm *VMKeeper) QueryScheduledCalls(ctx sdk.Context, pkgPath string) ([]ScheduledCall, error) {
+	prefix := []byte(schedPkgPrefix + pkgPath + ":")
+	stor := ctx.Store(vm.iavlKey)
+	iter := stor.Iterator(prefix, types.PrefixEndBytes(prefix))
+	defer iter.Close()
+
+	calls := []ScheduledCall{}
+	for ; iter.Valid(); iter.Next() {
+		id, err := strconv.ParseUint(string(iter.Key()[len(prefix):]), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if sc, ok := vm.getScheduledCall(ctx, id); ok {
+			calls = append(calls, sc)
+		}
+	}
+	return calls, nil
+}
+
+// dueScheduledCalls returns the IDs of the calls due at the current block
+// height and time, in the order they were scheduled.
+func (vm *VMKeeper) dueScheduledCalls(ctx sdk.Context) []uint64 {
+	stor := ctx.Store(vm.iavlKey)
+	var ids []uint64
+	collect := func(prefix string, until int64) {
+		end := []byte(fmt.Sprintf("%s%020d;", prefix, until))
+		iter := stor.Iterator([]byte(prefix), end)
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			key := iter.Key()
+			id, err := strconv.ParseUint(string(key[len(key)-20:]), 10, 64)
+			if err != nil {
+				panic(fmt.Sprintf("invalid scheduler queue key %q", key))
+			}
+			ids = append(ids, id)
+		}
+	}
+	collect(schedHeightPrefix, ctx.BlockHeight())
+	collect(schedTimePrefix, ctx.BlockTime().Unix())
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// RunScheduledCalls executes the scheduled calls which are due, in the order
+// they were scheduled, until the scheduler block gas limit or the maximum
+// number of calls per block is reached. The remaining calls are left for the
+// next blocks.
+//
+// Each call is executed in its own cache context, with the realm as the origin
+// caller and a gas limit of its MaxGas; its changes are discarded if it fails.
+// The consumed gas, but at least the scheduler minimum gas which accounts for
+// the cost of running the call, is paid to the fee collector out of the call's
+// deposit, and the rest of the deposit is refunded to the realm.
+func (vm *VMKeeper) RunScheduledCalls(ctx sdk.Context) {
+	maxBlockGas := vm.getSchedulerMaxBlockGasParam(ctx)
+	maxBlockCalls := vm.getSchedulerMaxBlockCallsParam(ctx)
+	minGas := vm.getSchedulerMinGasParam(ctx)
+	remaining := maxBlockGas
+	for i, id := range vm.dueScheduledCalls(ctx) {
+		if int64(i) >= maxBlockCalls {
+			break
+		}
+		sc, ok := vm.getScheduledCall(ctx, id)
+		if !ok {
+			panic(fmt.Sprintf("scheduled call %d not found", id))
+		}
+		gasLimit := min(sc.MaxGas, maxBlockGas)
+		if max(gasLimit, minGas) > remaining {
+			break
+		}
+
+		gasUsed, err := vm.runChainCall(ctx, MsgCall{
+			Caller:  gno.DerivePkgAddr(sc.PkgPath),
+			PkgPath: sc.PkgPath,
+			Func:    sc.Func,
+			Args:    sc.Args,
+		}, gasLimit)
+		gasCharged := max(gasUsed, minGas)
+		remaining -= gasCharged
+
+		// Settle the deposit, proportionally to the gas charged.
+		fee := scaleCoins(sc.Deposit, min(gasCharged, sc.MaxGas), sc.MaxGas)
+		refund := sc.Deposit.Sub(fee)
+		if err := vm.bank.SendCoins(ctx, schedulerEscrowAddress, auth.FeeCollectorAddress(), fee); err != nil {
+			panic(err)
+		}
+		if err := vm.bank.SendCoins(ctx, schedulerEscrowAddress, gno.DerivePkgAddr(sc.PkgPath), refund); err != nil {
+			panic(err)
+		}
+		vm.deleteScheduledCall(ctx, sc)
+
+		errMsg := ""
+		if err != nil {
+			errMsg = err.Error()
+		}
+		ctx.EventLogger().EmitEvent(scheduledCallEvent("ScheduledCallExecuted", sc,
+			"gas_used", strconv.FormatInt(gasUsed, 10),
+			"fee", fee.String(),
+			"error", errMsg,
+		))
+	}
+}
+
+// runChainCall executes a call made by the chain itself, in its own cache
+// context and with its own gas meter. Its changes are discarded if it fails.
+func (vm *VMKeeper) runChainCall(ctx sdk.Context, msg MsgCall, gasLimit int64) (gasUsed int64, err error) {
+	cctx, writeCache := ctx.CacheContext()
+	gasMeter := store.NewGasMeter(gasLimit)
+	cctx = vm.MakeGnoTransactionStore(cctx.WithGasMeter(gasMeter))
+
+	defer func() {
+		if r := recover(); r != nil {
+			if rerr, ok := r.(error); ok {
+				err = rerr
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+		gasUsed = gasMeter.GasConsumedToLimit()
+		if err == nil {
+			vm.CommitGnoTransactionStore(cctx)
+			writeCache()
+			ctx.EventLogger().EmitEvents(cctx.EventLogger().Events())
+		}
+	}()
+
+	_, err = vm.Call(cctx, msg)
+	return
+}
+
+func scheduledCallEvent(typ string, sc ScheduledCall, attrs ...string) gnostd.GnoEvent {
+	evt := gnostd.GnoEvent{
+		Type:    typ,
+		PkgPath: sc.PkgPath,
+		Func:    sc.Func,
+		Attributes: []gnostd.GnoEventAttribute{
+			{Key: "id", Value: strconv.FormatUint(sc.ID, 10)},
+		},
+	}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		evt.Attributes = append(evt.Attributes, gnostd.GnoEventAttribute{Key: attrs[i], Value: attrs[i+1]})
+	}
+	return evt
+}
+
+// scaleCoins returns coins * num / den, rounded up.
+func scaleCoins(coins std.Coins, num, den int64) std.Coins {
+	res := std.Coins{}
+	for _, coin := range coins {
+		amt := new(big.Int).Mul(coin.Amount.BigInt(), big.NewInt(num))
+		amt.Add(amt, big.NewInt(den-1))
+		amt.Quo(amt, big.NewInt(den))
+		if amt.Sign() > 0 {
+			res = append(res, std.NewCoinFromInt(coin.Denom, std.NewIntFromBigInt(amt)))
+		}
+	}
+	return res
+}
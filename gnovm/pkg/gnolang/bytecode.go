@@ -0,0 +1,798 @@
+package gnolang
+
+import (
+	"errors"
+)
+
+/*
+Bytecode compilation.
+
+After preprocessing, function declarations whose bodies only use a simple
+subset of the language are compiled into a register-based bytecode, which is
+run by a single OpCallBytecode in place of the OpBody/OpExec/OpEval state
+machine. The bytecode is kept in the store along with the BlockNodes, by
+the location of its function declaration. The supported subset is meant for
+tight computational code:
+
+  - all names declared in the function (parameters, results, and the names
+    of nested blocks) are of boolean, numeric or string kind;
+  - names are local to the function, or package-level variables of boolean,
+    numeric or string kind; captures and heap-escaping names are not
+    supported;
+  - statements are assignments (=, :=, op=), inc/dec, var declarations,
+    if, for (with unlabeled break and continue), blocks and return;
+  - expressions are names, constants, unary and binary operators (with a
+    constant divisor for / and %), and conversions.
+
+Anything else, notably calls, makes the function run on the AST interpreter
+as before.
+
+Local names live in registers. Package-level variables are not: they are read
+and written through the package block on every access, with the same realm
+bookkeeping (DidUpdate) and readonly checks as the AST interpreter's ops, so
+that realm functions updating counters, totals or flags of their package
+compile too.
+
+Every instruction carries the number of CPU cycles that the AST interpreter
+would charge for the ops it stands for, and blocks are accounted with the
+allocator exactly as the AST interpreter does, so that gas usage (including
+the point at which a transaction runs out of gas) does not depend on the
+engine. The values themselves are computed by the same helpers (addAssign,
+isLss, ConvertTo, ...) as the corresponding ops.
+*/
+
+// bcOp is a bytecode instruction opcode.
+type bcOp uint8
+
+const (
+	bcInvalid         bcOp = iota
+	bcNop                  // charge cycles only
+	bcStmt                 // set the statement reported in stacktraces
+	bcBlock                // enter a block: clear regs[a:a+b], allocate c names
+	bcExpand               // expand the last block by c names
+	bcMove                 // regs[a] = regs[b]
+	bcAssign               // regs[a] = regs[b], converting untyped values
+	bcValueDecl            // regs[a] = regs[b] (or default if b < 0) as typ
+	bcConvert              // regs[a] = typ(regs[b]); c != 0 for shift amounts
+	bcBinary               // regs[a] = regs[b] <mop> regs[c]
+	bcUnary                // regs[a] = <mop> regs[b]
+	bcLand                 // regs[a] = regs[a] && regs[b]
+	bcLor                  // regs[a] = regs[a] || regs[b]
+	bcOpAssign             // regs[a] <mop>= regs[b]
+	bcInc                  // regs[a]++
+	bcDec                  // regs[a]--
+	bcJump                 // goto a
+	bcJumpIfFalse          // if !regs[a] goto b
+	bcJumpIfTrue           // if regs[a] goto b
+	bcReturn               // return regs[a:a+b]
+	bcReturnFromBlock      // return (named results)
+	bcLoadGlobal           // regs[a] = *path
+	bcStoreGlobal          // *path = regs[b]
+	bcOpAssignGlobal       // *path <mop>= regs[b]
+	bcIncGlobal            // (*path)++
+	bcDecGlobal            // (*path)--
+)
+
+// bcInstr is a bytecode instruction.
+type bcInstr struct {
+	op     bcOp
+	mop    Op    // machine op of unary, binary and op-assign instructions
+	cycles int64 // cycles charged before executing the instruction
+	a      int
+	b      int
+	c      int
+	typ    Type      // type of bcConvert and bcValueDecl
+	body   Body      // body of the statement of bcStmt
+	path   ValuePath // path of a package-level variable, from the function block
+}
+
+// bcConst is a constant value, loaded into its register on each call.
+type bcConst struct {
+	reg int
+	tv  TypedValue
+}
+
+// bcFunc is the bytecode of a function declaration.
+//
+// Registers are laid out as the values of the function block, followed by
+// the values of nested blocks, temporaries and constants.
+type bcFunc struct {
+	code    []bcInstr
+	consts  []bcConst
+	numRegs int
+}
+
+var errBytecodeUnsupported = errors.New("unsupported by bytecode")
+
+// bcLoop records the branches of a for loop, patched once its body is
+// compiled.
+type bcLoop struct {
+	breaks    []int
+	continues []int
+}
+
+type bcCompiler struct {
+	bcFunc
+	store   Store
+	fd      *FuncDecl
+	pending int64     // cycles to charge with the next instruction
+	blocks  []int     // base register of each enclosing block
+	loops   []*bcLoop // enclosing for loops
+	trace   bool      // whether statements are reported in stacktraces
+}
+
+// compileFuncDecls compiles the function declarations of n, a *FileNode or
+// *FuncDecl, and sets their bytecode in store, along with their BlockNodes.
+func compileFuncDecls(store Store, n Node) {
+	if store == nil {
+		return
+	}
+	switch n := n.(type) {
+	case *FileNode:
+		for _, d := range n.Decls {
+			if fd, ok := d.(*FuncDecl); ok {
+				compileFuncDecls(store, fd)
+			}
+		}
+	case *FuncDecl:
+		// declarations outside of a file have no location to be found by.
+		if loc := n.GetLocation(); !loc.IsZero() {
+			store.setBytecode(loc, compileFuncDecl(store, n))
+		}
+	}
+}
+
+// compileFuncDecl returns the bytecode of fd, or nil if its body uses a
+// construct which is unsupported by bytecode.
+func compileFuncDecl(store Store, fd *FuncDecl) (bc *bcFunc) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errBytecodeUnsupported { //nolint:errorlint
+				panic(r)
+			}
+			bc = nil
+		}
+	}()
+
+	c := &bcCompiler{store: store, fd: fd, trace: true}
+	c.checkBlock(&fd.StaticBlock)
+	c.pushBlock(int(fd.GetNumNames()))
+	c.body(fd.Body)
+	if len(fd.Type.Results) == 0 {
+		// final OpExec of the implicit *ReturnStmt.
+		c.pending += OpCPUExec + OpCPUReturnFromBlock
+		c.emit(bcInstr{op: bcReturnFromBlock})
+	} else {
+		// missing return; unreachable.
+		c.emit(bcInstr{op: bcInvalid})
+	}
+	return &c.bcFunc
+}
+
+// unsupported aborts the compilation of the current function.
+func (c *bcCompiler) unsupported() {
+	panic(errBytecodeUnsupported)
+}
+
+// isBytecodeType returns whether t can be held in a register.
+func isBytecodeType(t Type) bool {
+	if t == nil {
+		return false
+	}
+	if _, ok := t.(*NativeType); ok {
+		return false
+	}
+	switch t.Kind() {
+	case BoolKind, StringKind,
+		IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind,
+		UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind,
+		Float32Kind, Float64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNumericKind returns whether k is an integer or floating point kind.
+func isNumericKind(k Kind) bool {
+	switch k {
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind,
+		UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind,
+		Float32Kind, Float64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *bcCompiler) checkBlock(sb *StaticBlock) {
+	if int(sb.NumNames) != len(sb.Types) {
+		c.unsupported()
+	}
+	for _, t := range sb.Types {
+		if !isBytecodeType(t) {
+			c.unsupported()
+		}
+	}
+}
+
+// emit appends in to the code, charging it with the pending cycles, and
+// returns its address.
+func (c *bcCompiler) emit(in bcInstr) int {
+	in.cycles = c.pending
+	c.pending = 0
+	c.code = append(c.code, in)
+	return len(c.code) - 1
+}
+
+// label returns the address of the next instruction, to be used as a jump
+// target. Pending cycles are charged before it.
+func (c *bcCompiler) label() int {
+	if c.pending != 0 {
+		c.emit(bcInstr{op: bcNop})
+	}
+	return len(c.code)
+}
+
+// patch sets the target of the jump at addr.
+func (c *bcCompiler) patch(addr, target int) {
+	in := &c.code[addr]
+	switch in.op {
+	case bcJump:
+		in.a = target
+	case bcJumpIfFalse, bcJumpIfTrue:
+		in.b = target
+	default:
+		panic("should not happen")
+	}
+}
+
+func (c *bcCompiler) newRegs(n int) int {
+	base := c.numRegs
+	c.numRegs += n
+	return base
+}
+
+func (c *bcCompiler) pushBlock(n int) int {
+	base := c.newRegs(n)
+	c.blocks = append(c.blocks, base)
+	return base
+}
+
+func (c *bcCompiler) popBlock() {
+	c.blocks = c.blocks[:len(c.blocks)-1]
+}
+
+// enterBlock emits the creation of the block of bn, whose names and those
+// of its faux child blocks take size registers.
+func (c *bcCompiler) enterBlock(bn BlockNode, size int) {
+	base := c.pushBlock(size)
+	c.emit(bcInstr{op: bcBlock, a: base, b: size, c: int(bn.GetNumNames())})
+}
+
+// nameReg returns the register of the local name nx.
+func (c *bcCompiler) nameReg(nx *NameExpr) int {
+	reg, global := c.nameRef(nx)
+	if global {
+		c.unsupported()
+	}
+	return reg
+}
+
+// globalPath returns the path of nx from the function block, if it is a
+// package-level variable of a bytecode type; it aborts the compilation if it
+// is any other name from outside the function.
+func (c *bcCompiler) globalPath(nx *NameExpr) ValuePath {
+	path := nx.Path
+	path.Depth -= uint8(len(c.blocks) - 1)
+	if nx.Type != NameExprTypeNormal {
+		c.unsupported()
+	}
+	bn := c.fd.GetBlockNodeForPath(c.store, path)
+	if _, ok := bn.(*PackageNode); !ok {
+		c.unsupported()
+	}
+	if !isBytecodeType(bn.GetStaticBlock().Types[path.Index]) {
+		c.unsupported()
+	}
+	return path
+}
+
+// nameRef returns the register of nx if it is a local name, or whether it is
+// a package-level variable, see globalPath.
+func (c *bcCompiler) nameRef(nx *NameExpr) (reg int, global bool) {
+	switch nx.Type {
+	case NameExprTypeNormal, NameExprTypeDefine:
+	default:
+		c.unsupported()
+	}
+	path := nx.Path
+	if path.Type != VPBlock || nx.Name == blankIdentifier || path.Depth == 0 {
+		c.unsupported()
+	}
+	if int(path.Depth) > len(c.blocks) {
+		return -1, true
+	}
+	d := len(c.blocks) - int(path.Depth)
+	reg = c.blocks[d] + int(path.Index)
+	if d+1 < len(c.blocks) && reg >= c.blocks[d+1] {
+		panic("should not happen")
+	}
+	return reg, false
+}
+
+func (c *bcCompiler) constReg(tv TypedValue) int {
+	if !isBytecodeType(tv.T) {
+		c.unsupported()
+	}
+	reg := c.newRegs(1)
+	c.consts = append(c.consts, bcConst{reg: reg, tv: tv})
+	return reg
+}
+
+// tempRegs copies the values of regs to fresh registers, so that they are not
+// overwritten by a parallel assignment.
+func (c *bcCompiler) tempRegs(regs []int) []int {
+	if len(regs) < 2 {
+		return regs
+	}
+	base := c.newRegs(len(regs))
+	for i, reg := range regs {
+		c.emit(bcInstr{op: bcMove, a: base + i, b: reg})
+		regs[i] = base + i
+	}
+	return regs
+}
+
+// ----------------------------------------
+// statements
+
+// body compiles the statements of a function, if or block body, each of
+// which is dispatched by an OpBody.
+func (c *bcCompiler) body(body Body) {
+	for i, s := range body {
+		c.pending += OpCPUBody
+		if c.trace {
+			c.emit(bcInstr{op: bcStmt, a: i + 1, body: body})
+		}
+		c.stmt(s)
+	}
+	c.pending += OpCPUBody
+}
+
+func (c *bcCompiler) stmt(s Stmt) {
+	switch s := s.(type) {
+	case *AssignStmt:
+		c.assignStmt(s)
+	case *IncDecStmt:
+		nx, ok := s.X.(*NameExpr)
+		if !ok {
+			c.unsupported()
+		}
+		reg, global := c.nameRef(nx)
+		var in bcInstr
+		if global {
+			in.path = c.globalPath(nx)
+		}
+		switch s.Op {
+		case INC:
+			c.pending += OpCPUInc
+			in.op = bcInc
+			if global {
+				in.op = bcIncGlobal
+			}
+		case DEC:
+			c.pending += OpCPUDec
+			in.op = bcDec
+			if global {
+				in.op = bcDecGlobal
+			}
+		default:
+			c.unsupported()
+		}
+		in.a = reg
+		c.emit(in)
+	case *DeclStmt:
+		for _, d := range s.Body {
+			vd, ok := d.(*ValueDecl)
+			if !ok || vd.Const {
+				c.unsupported()
+			}
+			c.valueDecl(vd)
+		}
+	case *IfStmt:
+		c.ifStmt(s)
+	case *ForStmt:
+		c.forStmt(s)
+	case *BlockStmt:
+		c.checkBlock(&s.StaticBlock)
+		c.enterBlock(s, int(s.GetNumNames()))
+		c.body(s.Body)
+		c.pending += OpCPUPopBlock
+		c.popBlock()
+	case *BranchStmt:
+		if s.Label != "" || len(c.loops) == 0 {
+			c.unsupported()
+		}
+		loop := c.loops[len(c.loops)-1]
+		switch s.Op {
+		case BREAK:
+			loop.breaks = append(loop.breaks, c.emit(bcInstr{op: bcJump}))
+		case CONTINUE:
+			loop.continues = append(loop.continues, c.emit(bcInstr{op: bcJump}))
+		default:
+			c.unsupported()
+		}
+	case *ReturnStmt:
+		if s.Results == nil {
+			c.pending += OpCPUReturnFromBlock
+			c.emit(bcInstr{op: bcReturnFromBlock})
+			return
+		}
+		regs := make([]int, len(s.Results))
+		for i, rx := range s.Results {
+			regs[i] = c.expr(rx)
+		}
+		base := c.newRegs(len(regs))
+		for i, reg := range regs {
+			c.emit(bcInstr{op: bcMove, a: base + i, b: reg})
+		}
+		c.pending += OpCPUReturn
+		c.emit(bcInstr{op: bcReturn, a: base, b: len(regs)})
+	case *EmptyStmt:
+	default:
+		c.unsupported()
+	}
+}
+
+func (c *bcCompiler) assignStmt(s *AssignStmt) {
+	var mop Op
+	switch s.Op {
+	case ASSIGN, DEFINE:
+		if len(s.Lhs) != len(s.Rhs) {
+			c.unsupported()
+		}
+		regs := make([]int, len(s.Rhs))
+		for i, rx := range s.Rhs {
+			regs[i] = c.expr(rx)
+		}
+		regs = c.tempRegs(regs)
+		lhs := make([]bcInstr, len(s.Lhs))
+		for i, lx := range s.Lhs {
+			nx, ok := lx.(*NameExpr)
+			if !ok {
+				c.unsupported()
+			}
+			if s.Op == DEFINE {
+				lhs[i] = bcInstr{op: bcAssign, a: c.nameReg(nx), b: regs[i]}
+			} else if reg, global := c.nameRef(nx); global {
+				lhs[i] = bcInstr{op: bcStoreGlobal, b: regs[i], path: c.globalPath(nx)}
+			} else {
+				lhs[i] = bcInstr{op: bcAssign, a: reg, b: regs[i]}
+			}
+		}
+		if s.Op == DEFINE {
+			c.pending += OpCPUDefine
+			for i := range lhs {
+				c.emit(lhs[i])
+			}
+		} else {
+			c.pending += OpCPUAssign
+			for i := len(lhs) - 1; 0 <= i; i-- {
+				c.emit(lhs[i])
+			}
+		}
+		return
+	case ADD_ASSIGN:
+		mop = OpAddAssign
+		c.pending += OpCPUAddAssign
+	case SUB_ASSIGN:
+		mop = OpSubAssign
+		c.pending += OpCPUSubAssign
+	case MUL_ASSIGN:
+		mop = OpMulAssign
+		c.pending += OpCPUMulAssign
+	case QUO_ASSIGN:
+		mop = OpQuoAssign
+		c.pending += OpCPUQuoAssign
+	case REM_ASSIGN:
+		mop = OpRemAssign
+		c.pending += OpCPURemAssign
+	case BAND_ASSIGN:
+		mop = OpBandAssign
+		c.pending += OpCPUBandAssign
+	case BOR_ASSIGN:
+		mop = OpBorAssign
+		c.pending += OpCPUBorAssign
+	case XOR_ASSIGN:
+		mop = OpXorAssign
+		c.pending += OpCPUXorAssign
+	case SHL_ASSIGN:
+		mop = OpShlAssign
+		c.pending += OpCPUShlAssign
+	case SHR_ASSIGN:
+		mop = OpShrAssign
+		c.pending += OpCPUShrAssign
+	case BAND_NOT_ASSIGN:
+		mop = OpBandnAssign
+		c.pending += OpCPUBandnAssign
+	default:
+		c.unsupported()
+	}
+	if len(s.Lhs) != 1 || len(s.Rhs) != 1 {
+		c.unsupported()
+	}
+	if (mop == OpQuoAssign || mop == OpRemAssign) && !isNonZeroConst(s.Rhs[0]) {
+		// division by zero must be recoverable.
+		c.unsupported()
+	}
+	nx, ok := s.Lhs[0].(*NameExpr)
+	if !ok {
+		c.unsupported()
+	}
+	reg := c.expr(s.Rhs[0])
+	if lreg, global := c.nameRef(nx); global {
+		c.emit(bcInstr{op: bcOpAssignGlobal, mop: mop, b: reg, path: c.globalPath(nx)})
+	} else {
+		c.emit(bcInstr{op: bcOpAssign, mop: mop, a: lreg, b: reg})
+	}
+}
+
+func (c *bcCompiler) valueDecl(vd *ValueDecl) {
+	c.pending += OpCPUExec
+	var regs []int
+	if vd.Values != nil {
+		if len(vd.Values) != len(vd.NameExprs) {
+			c.unsupported()
+		}
+		regs = make([]int, len(vd.Values))
+		for i, vx := range vd.Values {
+			regs[i] = c.expr(vx)
+		}
+		regs = c.tempRegs(regs)
+	}
+	var nt Type
+	if vd.Type != nil {
+		ctx, ok := vd.Type.(*constTypeExpr)
+		if !ok {
+			c.unsupported()
+		}
+		c.pending += OpCPUEval
+		nt = ctx.Type
+	}
+	c.pending += OpCPUValueDecl
+	for i := range vd.NameExprs {
+		src := -1
+		if regs != nil {
+			src = regs[i]
+		}
+		reg := c.nameReg(&vd.NameExprs[i])
+		c.emit(bcInstr{op: bcValueDecl, a: reg, b: src, typ: nt})
+	}
+}
+
+func (c *bcCompiler) ifStmt(s *IfStmt) {
+	c.checkBlock(&s.StaticBlock)
+	c.checkBlock(&s.Then.StaticBlock)
+	c.checkBlock(&s.Else.StaticBlock)
+	size := max(s.GetNumNames(), s.Then.GetNumNames(), s.Else.GetNumNames())
+	c.enterBlock(s, int(size))
+	if s.Init != nil {
+		c.pending += OpCPUExec
+		c.stmt(s.Init)
+	}
+	cond := c.expr(s.Cond)
+	c.pending += OpCPUIfCond
+	jelse := c.emit(bcInstr{op: bcJumpIfFalse, a: cond})
+	c.ifCase(s, &s.Then)
+	jend := c.emit(bcInstr{op: bcJump})
+	c.patch(jelse, c.label())
+	c.ifCase(s, &s.Else)
+	c.patch(jend, c.label())
+	c.popBlock()
+}
+
+func (c *bcCompiler) ifCase(s *IfStmt, ic *IfCaseStmt) {
+	if len(ic.Body) != 0 {
+		if nn := ic.GetNumNames(); nn > s.GetNumNames() {
+			c.emit(bcInstr{op: bcExpand, c: int(nn - s.GetNumNames())})
+		}
+		c.body(ic.Body)
+	}
+	c.pending += OpCPUPopBlock
+}
+
+func (c *bcCompiler) forStmt(s *ForStmt) {
+	c.checkBlock(&s.StaticBlock)
+	c.enterBlock(s, int(s.GetNumNames()))
+	// Statements of loops are not reported in stacktraces,
+	// which show the statement of the enclosing body instead.
+	trace := c.trace
+	c.trace = false
+	if s.Init != nil {
+		c.pending += OpCPUExec
+		c.stmt(s.Init)
+	}
+	top := c.label()
+	jexit := -1
+	if s.Cond != nil {
+		cond := c.expr(s.Cond)
+		c.pending += OpCPUForLoop
+		jexit = c.emit(bcInstr{op: bcJumpIfFalse, a: cond})
+	} else {
+		c.pending += OpCPUForLoop
+	}
+	loop := &bcLoop{}
+	c.loops = append(c.loops, loop)
+	for i, bs := range s.Body {
+		if i > 0 {
+			c.pending += OpCPUForLoop
+		}
+		c.stmt(bs)
+	}
+	c.loops = c.loops[:len(c.loops)-1]
+	cont := c.label()
+	if len(s.Body) != 0 {
+		c.pending += OpCPUForLoop
+	}
+	if s.Post != nil {
+		c.stmt(s.Post)
+	}
+	c.emit(bcInstr{op: bcJump, a: top})
+	exit := c.label()
+	if jexit >= 0 {
+		c.patch(jexit, exit)
+	}
+	for _, addr := range loop.breaks {
+		c.patch(addr, exit)
+	}
+	for _, addr := range loop.continues {
+		c.patch(addr, cont)
+	}
+	c.trace = trace
+	c.popBlock()
+}
+
+// ----------------------------------------
+// expressions
+
+// expr compiles the evaluation of x, and returns the register holding its
+// value. The register must not be modified.
+func (c *bcCompiler) expr(x Expr) int {
+	c.pending += OpCPUEval
+	switch x := x.(type) {
+	case *NameExpr:
+		reg, global := c.nameRef(x)
+		if global {
+			reg = c.newRegs(1)
+			c.emit(bcInstr{op: bcLoadGlobal, a: reg, path: c.globalPath(x)})
+		}
+		return reg
+	case *ConstExpr:
+		return c.constReg(x.TypedValue)
+	case *BinaryExpr:
+		switch x.Op {
+		case LAND, LOR:
+			dst := c.newRegs(1)
+			lreg := c.expr(x.Left)
+			c.pending += OpCPUBinary1
+			c.emit(bcInstr{op: bcMove, a: dst, b: lreg})
+			var jend int
+			if x.Op == LAND {
+				jend = c.emit(bcInstr{op: bcJumpIfFalse, a: dst})
+			} else {
+				jend = c.emit(bcInstr{op: bcJumpIfTrue, a: dst})
+			}
+			rreg := c.expr(x.Right)
+			if x.Op == LAND {
+				c.pending += OpCPULand
+				c.emit(bcInstr{op: bcLand, a: dst, b: rreg})
+			} else {
+				c.pending += OpCPULor
+				c.emit(bcInstr{op: bcLor, a: dst, b: rreg})
+			}
+			c.patch(jend, c.label())
+			return dst
+		case QUO, REM:
+			if !isNonZeroConst(x.Right) {
+				// division by zero must be recoverable.
+				c.unsupported()
+			}
+		}
+		mop := word2BinaryOp(x.Op)
+		lreg := c.expr(x.Left)
+		rreg := c.expr(x.Right)
+		dst := c.newRegs(1)
+		c.pending += binaryOpCPU(mop)
+		c.emit(bcInstr{op: bcBinary, mop: mop, a: dst, b: lreg, c: rreg})
+		return dst
+	case *UnaryExpr:
+		var cycles int64
+		mop := word2UnaryOp(x.Op)
+		switch mop {
+		case OpUpos:
+			cycles = OpCPUUpos
+		case OpUneg:
+			cycles = OpCPUUneg
+		case OpUnot:
+			cycles = OpCPUUnot
+		case OpUxor:
+			cycles = OpCPUUxor
+		default:
+			c.unsupported()
+		}
+		reg := c.expr(x.X)
+		dst := c.newRegs(1)
+		c.pending += cycles
+		c.emit(bcInstr{op: bcUnary, mop: mop, a: dst, b: reg})
+		return dst
+	case *CallExpr:
+		// only conversions are supported.
+		ctx, ok := x.Func.(*constTypeExpr)
+		if !ok || len(x.Args) != 1 || x.Varg || !isBytecodeType(ctx.Type) {
+			c.unsupported()
+		}
+		c.pending += OpCPUEval // evaluate type
+		reg := c.expr(x.Args[0])
+		shift := 0
+		if x.GetAttribute(ATTR_SHIFT_RHS) == true {
+			shift = 1
+		}
+		dst := c.newRegs(1)
+		c.pending += OpCPUPrecall + OpCPUConvert
+		c.emit(bcInstr{op: bcConvert, a: dst, b: reg, c: shift, typ: ctx.Type})
+		return dst
+	default:
+		c.unsupported()
+		return -1
+	}
+}
+
+// isNonZeroConst returns whether x is a numeric constant other than zero.
+func isNonZeroConst(x Expr) bool {
+	cx, ok := x.(*ConstExpr)
+	return ok && isBytecodeType(cx.T) && isNumericKind(cx.T.Kind()) &&
+		cx.TypedValue.Sign() != 0
+}
+
+// binaryOpCPU returns the cycles charged for the binary op.
+func binaryOpCPU(op Op) int64 {
+	switch op {
+	case OpEql:
+		return OpCPUEql
+	case OpNeq:
+		return OpCPUNeq
+	case OpLss:
+		return OpCPULss
+	case OpLeq:
+		return OpCPULeq
+	case OpGtr:
+		return OpCPUGtr
+	case OpGeq:
+		return OpCPUGeq
+	case OpAdd:
+		return OpCPUAdd
+	case OpSub:
+		return OpCPUSub
+	case OpMul:
+		return OpCPUMul
+	case OpQuo:
+		return OpCPUQuo
+	case OpRem:
+		return OpCPURem
+	case OpBor:
+		return OpCPUBor
+	case OpXor:
+		return OpCPUXor
+	case OpShl:
+		return OpCPUShl
+	case OpShr:
+		return OpCPUShr
+	case OpBand:
+		return OpCPUBand
+	case OpBandn:
+		return OpCPUBandn
+	default:
+		panic("should not happen")
+	}
+}
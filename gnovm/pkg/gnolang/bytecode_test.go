@@ -0,0 +1,237 @@
+package gnolang
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bytecodeTestSource = `package test
+
+func fib(n int) int {
+	a, b := 0, 1
+	for i := 0; i < n; i++ {
+		a, b = b, a+b
+	}
+	return a
+}
+
+func collatz(n uint64) (steps int) {
+	for n != 1 {
+		if n%2 == 0 {
+			n /= 2
+		} else {
+			n = 3*n + 1
+		}
+		steps++
+	}
+	return
+}
+
+func primes(max int) int {
+	count := 0
+	for i := 2; i <= max; i++ {
+		isPrime := true
+		for j := 2; j*j <= i; j++ {
+			for k := j * j; k <= i; k += j {
+				if k == i {
+					isPrime = false
+					break
+				}
+			}
+			if !isPrime {
+				break
+			}
+		}
+		if !isPrime {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func bits(x uint32) (uint32, int8, bool) {
+	var y uint32 = x
+	y <<= 3
+	y ^= ^x
+	y &^= 0xff
+	y |= x >> 2
+	s := int8(-int(x&0x7f)) >> 1
+	return y, s, y > x && (s < 0 || x == 0)
+}
+
+func mix(f float64, n int, s string) (string, float64) {
+	var out string
+	for k := 0; k < n; k++ {
+		if k > 0 {
+			out += "-"
+		}
+		{
+			c := s
+			out += c
+		}
+		f = f*1.5 - float64(k)
+	}
+	return out, f / 3
+}
+
+var (
+	total int
+	hits  uint8
+	label = "sum"
+	done  bool
+)
+
+func accumulate(n int) string {
+	for i := 0; i < n; i++ {
+		total += i
+		hits++
+	}
+	hits--
+	done, total = total > 10, total*2
+	label = label + "!"
+	return label
+}
+
+func main() {
+	println(fib(50))
+	println(collatz(27))
+	println(primes(200))
+	println(bits(12345))
+	println(mix(2.5, 4, "ab"))
+	println(accumulate(10), total, hits, done)
+}
+
+func withCall(n int) int {
+	return fib(n)
+}
+`
+
+func runBytecodeTest(t *testing.T, bytecode bool) (output string, cycles, allocs int64, fn *FileNode, store Store) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath:  "test",
+		Output:   &buf,
+		Alloc:    NewAllocator(math.MaxInt64),
+		Bytecode: bytecode,
+	})
+	defer m.Release()
+
+	fn = MustParseFile("main.go", bytecodeTestSource)
+	m.RunFiles(fn)
+	m.RunMain()
+	_, allocs = m.Alloc.Status()
+	return buf.String(), m.Cycles, allocs, fn, m.Store
+}
+
+func TestBytecode(t *testing.T) {
+	t.Parallel()
+
+	output, cycles, allocs, fn, store := runBytecodeTest(t, false)
+	boutput, bcycles, ballocs, _, _ := runBytecodeTest(t, true)
+
+	assert.Equal(t, "12586269025\n111\n46\n4294856206 -29 true\nab-ab-ab-ab 1.46875\nsum! 90 9 true\n", output)
+	assert.Equal(t, output, boutput)
+	assert.Equal(t, cycles, bcycles, "cycles")
+	assert.Equal(t, allocs, ballocs, "allocations")
+
+	compiled := map[Name]bool{}
+	for _, d := range fn.Decls {
+		if fd, ok := d.(*FuncDecl); ok {
+			compiled[fd.Name] = store.getBytecode(fd.GetLocation()) != nil
+		}
+	}
+	require.Equal(t, map[Name]bool{
+		"fib":        true,
+		"collatz":    true,
+		"primes":     true,
+		"bits":       true,
+		"mix":        true,
+		"accumulate": true,
+		"main":       false,
+		"withCall":   false,
+	}, compiled)
+}
+
+func TestBytecodeStacktrace(t *testing.T) {
+	t.Parallel()
+
+	const src = `package test
+
+func shift(x, n int) int {
+	y := x
+	if x > 0 {
+		y++
+		y = y << n
+	}
+	return y
+}
+
+func main() {
+	println(shift(1, 2))
+	println(shift(1, -2))
+}
+`
+	run := func(bytecode bool) (output, err, stacktrace string) {
+		var buf bytes.Buffer
+		m := NewMachineWithOptions(MachineOptions{
+			PkgPath:  "test",
+			Output:   &buf,
+			Bytecode: bytecode,
+		})
+		defer m.Release()
+		defer func() {
+			r := recover()
+			require.NotNil(t, r)
+			output, err = buf.String(), r.(string)
+			stacktrace = m.Stacktrace().String()
+		}()
+		m.RunFiles(MustParseFile("main.go", src))
+		m.RunMain()
+		return
+	}
+
+	output, err, stacktrace := run(false)
+	boutput, berr, bstacktrace := run(true)
+	assert.Equal(t, "8\n", output)
+	assert.Equal(t, "runtime error: negative shift amount: (-2 int)", err)
+	assert.Contains(t, stacktrace, "main.go:7")
+	assert.Equal(t, output, boutput)
+	assert.Equal(t, err, berr)
+	assert.Equal(t, stacktrace, bstacktrace)
+}
+
+func TestBytecodeTransaction(t *testing.T) {
+	t.Parallel()
+
+	const src = `package test
+
+func double(x int) int {
+	return x * 2
+}
+`
+	store := NewStore(nil, nil, nil)
+	tx := store.BeginTransaction(nil, nil, nil)
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath: "gno.land/p/demo/test",
+		Store:   tx,
+	})
+	defer m.Release()
+	fn := MustParseFile("double.gno", src)
+	m.RunFiles(fn)
+
+	// the bytecode is only set in the parent store along with the BlockNodes.
+	loc := fn.Decls[0].(*FuncDecl).GetLocation()
+	require.NotNil(t, tx.getBytecode(loc))
+	assert.Nil(t, store.getBytecode(loc))
+	assert.Nil(t, store.GetBlockNodeSafe(loc))
+	tx.Write()
+	assert.NotNil(t, store.getBytecode(loc))
+	assert.NotNil(t, store.GetBlockNodeSafe(loc))
+}
@@ -16,7 +16,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var withSync = flag.Bool("update-golden-tests", false, "rewrite tests updating Realm: and Output: with new values where changed")
+var (
+	withSync     = flag.Bool("update-golden-tests", false, "rewrite tests updating Realm: and Output: with new values where changed")
+	withBytecode = flag.Bool("bytecode", false, "also run tests on the bytecode engine, checking that results, cycles and allocations match")
+)
 
 type nopReader struct{}
 
@@ -32,6 +35,8 @@ func (nopReader) Read(p []byte) (int, error) { return 0, io.EOF }
 //		go test -run TestFiles/addr0b
 //	fix a specific test:
 //		go test -run TestFiles/'^bin1.gno' -short -v -update-golden-tests .
+//	check that the bytecode engine matches the AST interpreter:
+//		go test -run TestFiles -bytecode .
 func TestFiles(t *testing.T) {
 	t.Parallel()
 
@@ -40,10 +45,11 @@ func TestFiles(t *testing.T) {
 
 	newOpts := func() *test.TestOptions {
 		o := &test.TestOptions{
-			RootDir: rootDir,
-			Output:  io.Discard,
-			Error:   io.Discard,
-			Sync:    *withSync,
+			RootDir:  rootDir,
+			Output:   io.Discard,
+			Error:    io.Discard,
+			Sync:     *withSync,
+			Bytecode: *withBytecode,
 		}
 		o.BaseStore, o.TestStore = test.StoreWithOptions(
 			rootDir, nopReader{}, o.WriterForStore(), io.Discard,
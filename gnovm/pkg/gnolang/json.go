@@ -0,0 +1,982 @@
+package gnolang
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// This file implements the JSON encoding of Gno values, following the rules
+// of Go's encoding/json package. It backs the encoding/json standard library,
+// the JSON variant of the VM's eval query and the decoding of composite
+// MsgCall arguments.
+//
+// The output is deterministic: map keys are sorted, floats are formatted as
+// in Go, and no method of the encoded values (such as MarshalJSON) is called.
+
+// jsonMaxDepth bounds the nesting of encoded values, so that cyclic
+// structures built with pointers fail rather than recursing forever.
+const jsonMaxDepth = 1000
+
+var (
+	errJSONMaxDepth = errors.New("json: exceeded max depth (possible cycle)")
+
+	// Types used when decoding into an interface value.
+	gJSONAnyType    = &InterfaceType{}
+	gJSONArrayType  = &SliceType{Elt: gJSONAnyType}
+	gJSONObjectType = &MapType{Key: StringType, Value: gJSONAnyType}
+)
+
+// JSONUnsupportedTypeError is returned when encoding or decoding a value of
+// a type that has no JSON representation, such as a func or a chan.
+type JSONUnsupportedTypeError struct {
+	Type Type
+}
+
+func (e *JSONUnsupportedTypeError) Error() string {
+	return "json: unsupported type: " + e.Type.String()
+}
+
+// JSONUnmarshalTypeError is returned when a JSON value cannot be decoded
+// into the Gno type at the same position.
+type JSONUnmarshalTypeError struct {
+	Value string // description of the JSON value, e.g. "string"
+	Type  Type
+}
+
+func (e *JSONUnmarshalTypeError) Error() string {
+	return "json: cannot unmarshal " + e.Value + " into Gno value of type " + e.Type.String()
+}
+
+// ----------------------------------------
+// Encoding
+
+// MarshalJSON returns the JSON encoding of tv.
+func MarshalJSON(store Store, tv TypedValue) ([]byte, error) {
+	return MarshalJSONMetered(store, tv, nil)
+}
+
+// MarshalJSONMetered is like MarshalJSON, but calls charge, if not nil, with
+// the size of the output as it is written, before encoding each value. The
+// output may be much larger than tv, as pointers to the same value are
+// encoded each time; charge can abort the encoding by panicking.
+func MarshalJSONMetered(store Store, tv TypedValue, charge func(size int64)) ([]byte, error) {
+	buf := &jsonEncodeState{charge: charge}
+	if err := jsonEncode(buf, store, tv, 0); err != nil {
+		return nil, err
+	}
+	buf.meter()
+	return buf.Bytes(), nil
+}
+
+// jsonEncodeState is the output of the encoding.
+type jsonEncodeState struct {
+	bytes.Buffer
+	charge  func(size int64)
+	charged int // size of the output already charged
+}
+
+// meter charges the output written since the last call.
+func (e *jsonEncodeState) meter() {
+	if e.charge != nil && e.Len() > e.charged {
+		e.charge(int64(e.Len() - e.charged))
+		e.charged = e.Len()
+	}
+}
+
+func jsonEncode(buf *jsonEncodeState, store Store, tv TypedValue, depth int) error {
+	if depth > jsonMaxDepth {
+		return errJSONMaxDepth
+	}
+	buf.meter()
+	if tv.T == nil || tv.T.Kind() == InterfaceKind { // nil interface
+		buf.WriteString("null")
+		return nil
+	}
+	fillValueTV(store, &tv)
+	switch bt := baseOf(tv.T).(type) {
+	case PrimitiveType:
+		return jsonEncodePrimitive(buf, tv)
+	case *PointerType:
+		if tv.V == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		return jsonEncode(buf, store, tv.V.(PointerValue).Deref(), depth+1)
+	case *ArrayType:
+		av := tv.V.(*ArrayValue)
+		if av.Data != nil {
+			return jsonEncodeBytesList(buf, av.Data)
+		}
+		return jsonEncodeList(buf, store, av.List, depth)
+	case *SliceType:
+		if tv.V == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		sv := tv.V.(*SliceValue)
+		av := sv.GetBase(store)
+		if bt.Elt.Kind() == Uint8Kind {
+			var data []byte
+			if av.Data != nil {
+				data = av.Data[sv.Offset : sv.Offset+sv.Length]
+			} else {
+				data = make([]byte, sv.Length)
+				for i := range data {
+					data[i] = av.List[sv.Offset+i].GetUint8()
+				}
+			}
+			jsonEncodeString(buf, base64.StdEncoding.EncodeToString(data))
+			return nil
+		}
+		return jsonEncodeList(buf, store, av.List[sv.Offset:sv.Offset+sv.Length], depth)
+	case *MapType:
+		if tv.V == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		return jsonEncodeMap(buf, store, tv.V.(*MapValue), depth)
+	case *StructType:
+		return jsonEncodeStruct(buf, store, bt, tv.V.(*StructValue), depth)
+	default:
+		return &JSONUnsupportedTypeError{Type: tv.T}
+	}
+}
+
+func jsonEncodePrimitive(buf *jsonEncodeState, tv TypedValue) error {
+	var v any
+	switch tv.T.Kind() {
+	case BoolKind:
+		v = tv.GetBool()
+	case StringKind:
+		jsonEncodeString(buf, tv.GetString())
+		return nil
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		buf.WriteString(strconv.FormatInt(jsonGetInt(tv), 10))
+		return nil
+	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		buf.WriteString(strconv.FormatUint(jsonGetUint(tv), 10))
+		return nil
+	case Float32Kind:
+		v = math.Float32frombits(tv.GetFloat32())
+	case Float64Kind:
+		v = math.Float64frombits(tv.GetFloat64())
+	default:
+		return &JSONUnsupportedTypeError{Type: tv.T}
+	}
+	// Go's encoder gives the canonical representation of bools and floats,
+	// and rejects NaN and infinities.
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(bz)
+	return nil
+}
+
+func jsonEncodeString(buf *jsonEncodeState, s string) {
+	bz, _ := json.Marshal(s) // never fails for strings
+	buf.Write(bz)
+}
+
+func jsonEncodeBytesList(buf *jsonEncodeState, data []byte) error {
+	buf.WriteByte('[')
+	for i, b := range data {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.Itoa(int(b)))
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func jsonEncodeList(buf *jsonEncodeState, store Store, list []TypedValue, depth int) error {
+	buf.WriteByte('[')
+	for i := range list {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := jsonEncode(buf, store, list[i], depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func jsonEncodeMap(buf *jsonEncodeState, store Store, mv *MapValue, depth int) error {
+	type entry struct {
+		key   string
+		value TypedValue
+	}
+	entries := make([]entry, 0, mv.GetLength())
+	for item := mv.List.Head; item != nil; item = item.Next {
+		key, err := jsonMapKey(item.Key)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{key, item.Value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	buf.WriteByte('{')
+	for i, e := range entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		jsonEncodeString(buf, e.key)
+		buf.WriteByte(':')
+		if err := jsonEncode(buf, store, e.value, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// jsonMapKey returns the object key for a map key; like in Go, only
+// string and integer keys are supported.
+func jsonMapKey(key TypedValue) (string, error) {
+	switch key.T.Kind() {
+	case StringKind:
+		return key.GetString(), nil
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		return strconv.FormatInt(jsonGetInt(key), 10), nil
+	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		return strconv.FormatUint(jsonGetUint(key), 10), nil
+	default:
+		return "", &JSONUnsupportedTypeError{Type: key.T}
+	}
+}
+
+func jsonEncodeStruct(buf *jsonEncodeState, store Store, st *StructType, sv *StructValue, depth int) error {
+	buf.WriteByte('{')
+	first := true
+	for _, f := range jsonStructFields(st) {
+		fv, ok := jsonFieldValue(store, sv, f.index)
+		if !ok || (f.omitEmpty && jsonIsEmpty(fv)) {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		jsonEncodeString(buf, f.name)
+		buf.WriteByte(':')
+		if err := jsonEncode(buf, store, fv, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// jsonFieldValue follows the index path of a (possibly promoted) field. It
+// returns false if the path goes through a nil embedded pointer.
+func jsonFieldValue(store Store, sv *StructValue, index []int) (TypedValue, bool) {
+	for i, idx := range index {
+		fv := *fillValueTV(store, &sv.Fields[idx])
+		if i == len(index)-1 {
+			return fv, true
+		}
+		if fv.T.Kind() == PointerKind {
+			if fv.V == nil {
+				return TypedValue{}, false
+			}
+			fv = fv.V.(PointerValue).Deref()
+			fillValueTV(store, &fv)
+		}
+		sv = fv.V.(*StructValue)
+	}
+	panic("should not happen")
+}
+
+func jsonIsEmpty(tv TypedValue) bool {
+	if tv.T == nil || tv.T.Kind() == InterfaceKind {
+		return true
+	}
+	switch tv.T.Kind() {
+	case BoolKind:
+		return !tv.GetBool()
+	case StringKind:
+		return tv.GetString() == ""
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		return jsonGetInt(tv) == 0
+	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		return jsonGetUint(tv) == 0
+	case Float32Kind:
+		return math.Float32frombits(tv.GetFloat32()) == 0
+	case Float64Kind:
+		return math.Float64frombits(tv.GetFloat64()) == 0
+	case ArrayKind:
+		return baseOf(tv.T).(*ArrayType).Len == 0
+	case SliceKind:
+		return tv.V == nil || tv.V.(*SliceValue).GetLength() == 0
+	case MapKind:
+		return tv.V == nil || tv.V.(*MapValue).GetLength() == 0
+	case PointerKind:
+		return tv.V == nil
+	default:
+		return false
+	}
+}
+
+func jsonGetInt(tv TypedValue) int64 {
+	switch tv.T.Kind() {
+	case IntKind:
+		return tv.GetInt()
+	case Int8Kind:
+		return int64(tv.GetInt8())
+	case Int16Kind:
+		return int64(tv.GetInt16())
+	case Int32Kind:
+		return int64(tv.GetInt32())
+	case Int64Kind:
+		return tv.GetInt64()
+	default:
+		panic("should not happen")
+	}
+}
+
+func jsonGetUint(tv TypedValue) uint64 {
+	switch tv.T.Kind() {
+	case UintKind:
+		return tv.GetUint()
+	case Uint8Kind:
+		return uint64(tv.GetUint8())
+	case Uint16Kind:
+		return uint64(tv.GetUint16())
+	case Uint32Kind:
+		return uint64(tv.GetUint32())
+	case Uint64Kind:
+		return tv.GetUint64()
+	default:
+		panic("should not happen")
+	}
+}
+
+// ----------------------------------------
+// Struct fields
+
+// jsonField is a struct field as seen by the JSON codec, possibly promoted
+// from an embedded struct.
+type jsonField struct {
+	name      string
+	tagged    bool
+	omitEmpty bool
+	index     []int // path of field indexes through embedded structs
+	typ       Type
+}
+
+// jsonStructFields returns the encoded fields of st, in order, applying the
+// same tag and embedding rules as Go's encoding/json.
+func jsonStructFields(st *StructType) []jsonField {
+	var fields []jsonField
+	type level struct {
+		st    *StructType
+		index []int
+	}
+	current := []level{}
+	next := []level{{st: st}}
+	visited := map[*StructType]bool{}
+	for len(next) > 0 {
+		current, next = next, nil
+		for _, lv := range current {
+			if visited[lv.st] {
+				continue
+			}
+			visited[lv.st] = true
+			for i, ft := range lv.st.Fields {
+				tag := reflect.StructTag(ft.Tag).Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(append([]int(nil), lv.index...), i)
+				if ft.Embedded && name == "" {
+					et := ft.Type
+					if pt, ok := baseOf(et).(*PointerType); ok {
+						et = pt.Elt
+					}
+					if est, ok := baseOf(et).(*StructType); ok {
+						next = append(next, level{st: est, index: index})
+						continue
+					}
+				}
+				if !isUpper(string(ft.Name)) {
+					continue
+				}
+				f := jsonField{
+					name:      name,
+					tagged:    name != "",
+					omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+					index:     index,
+					typ:       ft.Type,
+				}
+				if f.name == "" {
+					f.name = string(ft.Name)
+				}
+				fields = append(fields, f)
+			}
+		}
+	}
+
+	// Resolve name conflicts: the shallowest field wins, preferring a
+	// tagged one; fields that cannot be told apart are all dropped.
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if len(fields[i].index) != len(fields[j].index) {
+			return len(fields[i].index) < len(fields[j].index)
+		}
+		return fields[i].tagged && !fields[j].tagged
+	})
+	out := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		group := fields[i:j]
+		if len(group) == 1 || len(group[1].index) > len(group[0].index) ||
+			(group[0].tagged && !group[1].tagged) {
+			out = append(out, group[0])
+		}
+		i = j
+	}
+	// Restore declaration order.
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].index, out[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return out
+}
+
+// ----------------------------------------
+// Decoding
+
+// UnmarshalJSON decodes the JSON-encoded data into a new value of type t.
+func UnmarshalJSON(alloc *Allocator, store Store, data []byte, t Type) (TypedValue, error) {
+	v, err := jsonParse(data)
+	if err != nil {
+		return TypedValue{}, err
+	}
+	d := jsonDecoder{alloc: alloc, store: store}
+	return d.decode(v, t)
+}
+
+// UnmarshalJSONInto decodes the JSON-encoded data into the value pointed to
+// by ptr, which must be a non-nil pointer. As in Go, existing values are
+// reused where possible: JSON objects are merged into structs and non-nil
+// maps, non-nil pointers (also when held in an interface) are decoded into,
+// and null leaves non-nullable values untouched. Updates go through the
+// realm, so that they are persisted like regular assignments.
+func UnmarshalJSONInto(alloc *Allocator, store Store, rlm *Realm, data []byte, ptr TypedValue) error {
+	if ptr.T == nil || ptr.T.Kind() == InterfaceKind {
+		return errors.New("json: Unmarshal(nil)")
+	}
+	pt, ok := baseOf(ptr.T).(*PointerType)
+	if !ok {
+		return fmt.Errorf("json: Unmarshal(non-pointer %s)", ptr.T.String())
+	}
+	if ptr.V == nil {
+		return fmt.Errorf("json: Unmarshal(nil %s)", ptr.T.String())
+	}
+	v, err := jsonParse(data)
+	if err != nil {
+		return err
+	}
+	d := jsonDecoder{alloc: alloc, store: store, rlm: rlm}
+	return d.decodeInto(ptr.V.(PointerValue), pt.Elt, v)
+}
+
+// jsonParse parses data into the generic representation of Go's decoder,
+// keeping numbers as json.Number.
+func jsonParse(data []byte) (any, error) {
+	// Validate first, so that trailing data is rejected too.
+	var raw json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type jsonDecoder struct {
+	alloc *Allocator
+	store Store
+	rlm   *Realm
+}
+
+// decodeInto decodes v into the existing value of type t pointed to by ptr.
+func (d *jsonDecoder) decodeInto(ptr PointerValue, t Type, v any) error {
+	cur := fillValueTV(d.store, ptr.TV)
+	switch bt := baseOf(t).(type) {
+	case *InterfaceType:
+		if v != nil && cur.T != nil && cur.T.Kind() == PointerKind && cur.V != nil {
+			return d.decodeInto(cur.V.(PointerValue), baseOf(cur.T).(*PointerType).Elt, v)
+		}
+	case *PointerType:
+		if v != nil && cur.V != nil {
+			return d.decodeInto(cur.V.(PointerValue), bt.Elt, v)
+		}
+	case *MapType:
+		if obj, ok := v.(map[string]any); ok && cur.V != nil {
+			mv := cur.V.(*MapValue)
+			for _, k := range jsonSortedKeys(obj) {
+				ktv, err := d.decodeMapKey(k, t, bt)
+				if err != nil {
+					return err
+				}
+				vtv, err := d.decode(obj[k], bt.Value)
+				if err != nil {
+					return err
+				}
+				d.assign(mv.GetPointerForKey(d.alloc, d.store, &ktv), vtv)
+			}
+			return nil
+		}
+	case *StructType:
+		if obj, ok := v.(map[string]any); ok {
+			return d.decodeStructInto(cur.V.(*StructValue), bt, obj)
+		}
+	}
+	if v == nil && !jsonIsNullable(t) {
+		return nil // null has no effect, as in Go.
+	}
+	tv, err := d.decode(v, t)
+	if err != nil {
+		return err
+	}
+	d.assign(ptr, tv)
+	return nil
+}
+
+func (d *jsonDecoder) decodeStructInto(sv *StructValue, st *StructType, obj map[string]any) error {
+	fields := jsonStructFields(st)
+	for _, k := range jsonSortedKeys(obj) {
+		f := jsonLookupField(fields, k)
+		if f == nil {
+			continue // unknown fields are ignored, as in Go.
+		}
+		// Walk down to the struct holding the field, allocating
+		// nil embedded pointers on the way.
+		parent := sv
+		for _, idx := range f.index[:len(f.index)-1] {
+			fp := parent.GetPointerToInt(d.store, idx)
+			if pt, ok := baseOf(fp.TV.T).(*PointerType); ok {
+				if fp.TV.V == nil {
+					d.assign(fp, d.newPointer(fp.TV.T, defaultTypedValue(d.alloc, pt.Elt)))
+				}
+				fp = fp.TV.V.(PointerValue)
+			}
+			parent = fillValueTV(d.store, fp.TV).V.(*StructValue)
+		}
+		fp := parent.GetPointerToInt(d.store, f.index[len(f.index)-1])
+		if err := d.decodeInto(fp, f.typ, obj[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *jsonDecoder) assign(ptr PointerValue, tv TypedValue) {
+	ptr.Assign2(d.alloc, d.store, d.rlm, tv, false)
+}
+
+func jsonIsNullable(t Type) bool {
+	switch t.Kind() {
+	case PointerKind, InterfaceKind, SliceKind, MapKind:
+		return true
+	default:
+		return false
+	}
+}
+
+// decode decodes v into a new value of type t.
+func (d *jsonDecoder) decode(v any, t Type) (TypedValue, error) {
+	switch bt := baseOf(t).(type) {
+	case *InterfaceType:
+		if v == nil {
+			return TypedValue{}, nil
+		}
+		if !bt.IsEmptyInterface() {
+			return TypedValue{}, &JSONUnmarshalTypeError{Value: jsonKindOf(v), Type: t}
+		}
+		return d.decodeAny(v)
+	case PrimitiveType:
+		if v == nil {
+			return defaultTypedValue(d.alloc, t), nil
+		}
+		return d.decodePrimitive(v, t)
+	case *PointerType:
+		if v == nil {
+			return TypedValue{T: t}, nil
+		}
+		etv, err := d.decode(v, bt.Elt)
+		if err != nil {
+			return TypedValue{}, err
+		}
+		return d.newPointer(t, etv), nil
+	case *SliceType:
+		switch cv := v.(type) {
+		case nil:
+			return TypedValue{T: t}, nil
+		case string:
+			if bt.Elt.Kind() != Uint8Kind {
+				break
+			}
+			bz, err := base64.StdEncoding.DecodeString(cv)
+			if err != nil {
+				return TypedValue{}, fmt.Errorf("json: %w", err)
+			}
+			return TypedValue{T: t, V: d.alloc.NewSliceFromData(bz)}, nil
+		case []any:
+			if bt.Elt.Kind() == Uint8Kind {
+				bz, err := d.decodeBytes(cv, bt.Elt, len(cv))
+				if err != nil {
+					return TypedValue{}, err
+				}
+				return TypedValue{T: t, V: d.alloc.NewSliceFromData(bz)}, nil
+			}
+			list, err := d.decodeList(cv, bt.Elt, len(cv))
+			if err != nil {
+				return TypedValue{}, err
+			}
+			return TypedValue{T: t, V: d.alloc.NewSliceFromList(list)}, nil
+		}
+	case *ArrayType:
+		tv := defaultTypedValue(d.alloc, t)
+		switch cv := v.(type) {
+		case nil:
+			return tv, nil
+		case []any:
+			av := tv.V.(*ArrayValue)
+			if av.Data != nil {
+				bz, err := d.decodeBytes(cv, bt.Elt, bt.Len)
+				if err != nil {
+					return TypedValue{}, err
+				}
+				copy(av.Data, bz)
+				return tv, nil
+			}
+			list, err := d.decodeList(cv, bt.Elt, bt.Len)
+			if err != nil {
+				return TypedValue{}, err
+			}
+			copy(av.List, list)
+			return tv, nil
+		}
+	case *MapType:
+		switch cv := v.(type) {
+		case nil:
+			return TypedValue{T: t}, nil
+		case map[string]any:
+			return d.decodeMap(cv, t, bt)
+		}
+	case *StructType:
+		tv := defaultTypedValue(d.alloc, t)
+		switch cv := v.(type) {
+		case nil:
+			return tv, nil
+		case map[string]any:
+			if err := d.decodeStruct(cv, bt, tv.V.(*StructValue)); err != nil {
+				return TypedValue{}, err
+			}
+			return tv, nil
+		}
+	default:
+		return TypedValue{}, &JSONUnsupportedTypeError{Type: t}
+	}
+	return TypedValue{}, &JSONUnmarshalTypeError{Value: jsonKindOf(v), Type: t}
+}
+
+func (d *jsonDecoder) decodePrimitive(v any, t Type) (tv TypedValue, err error) {
+	tv.T = t
+	kind := t.Kind()
+	switch cv := v.(type) {
+	case bool:
+		if kind == BoolKind {
+			tv.SetBool(cv)
+			return tv, nil
+		}
+	case string:
+		if kind == StringKind {
+			tv.V = d.alloc.NewString(cv)
+			return tv, nil
+		}
+	case json.Number:
+		if err := jsonSetNumber(&tv, string(cv)); err != nil {
+			return TypedValue{}, err
+		}
+		return tv, nil
+	}
+	return TypedValue{}, &JSONUnmarshalTypeError{Value: jsonKindOf(v), Type: t}
+}
+
+// jsonSetNumber parses the JSON number s into tv, according to the kind of
+// tv.T.
+func jsonSetNumber(tv *TypedValue, s string) error {
+	errRange := func() error {
+		return &JSONUnmarshalTypeError{Value: "number " + s, Type: tv.T}
+	}
+	switch kind := tv.T.Kind(); kind {
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		n, err := strconv.ParseInt(s, 10, jsonIntBits(kind))
+		if err != nil {
+			return errRange()
+		}
+		switch kind {
+		case IntKind:
+			tv.SetInt(n)
+		case Int8Kind:
+			tv.SetInt8(int8(n))
+		case Int16Kind:
+			tv.SetInt16(int16(n))
+		case Int32Kind:
+			tv.SetInt32(int32(n))
+		case Int64Kind:
+			tv.SetInt64(n)
+		}
+	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		n, err := strconv.ParseUint(s, 10, jsonIntBits(kind))
+		if err != nil {
+			return errRange()
+		}
+		switch kind {
+		case UintKind:
+			tv.SetUint(n)
+		case Uint8Kind:
+			tv.SetUint8(uint8(n))
+		case Uint16Kind:
+			tv.SetUint16(uint16(n))
+		case Uint32Kind:
+			tv.SetUint32(uint32(n))
+		case Uint64Kind:
+			tv.SetUint64(n)
+		}
+	case Float32Kind:
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return errRange()
+		}
+		tv.SetFloat32(math.Float32bits(float32(f)))
+	case Float64Kind:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errRange()
+		}
+		tv.SetFloat64(math.Float64bits(f))
+	default:
+		return errRange()
+	}
+	return nil
+}
+
+func jsonIntBits(kind Kind) int {
+	switch kind {
+	case Int8Kind, Uint8Kind:
+		return 8
+	case Int16Kind, Uint16Kind:
+		return 16
+	case Int32Kind, Uint32Kind:
+		return 32
+	default:
+		return 64
+	}
+}
+
+// decodeAny decodes v into the dynamic value Go would store in an any:
+// bool, float64, string, []any or map[string]any.
+func (d *jsonDecoder) decodeAny(v any) (TypedValue, error) {
+	switch cv := v.(type) {
+	case nil:
+		return TypedValue{}, nil
+	case bool:
+		return d.decodePrimitive(cv, BoolType)
+	case string:
+		return d.decodePrimitive(cv, StringType)
+	case json.Number:
+		return d.decodePrimitive(cv, Float64Type)
+	case []any:
+		return d.decode(cv, gJSONArrayType)
+	case map[string]any:
+		return d.decode(cv, gJSONObjectType)
+	default:
+		panic("should not happen")
+	}
+}
+
+// decodeBytes decodes a JSON array of numbers into n bytes; extra elements
+// are ignored, as in Go.
+func (d *jsonDecoder) decodeBytes(vs []any, et Type, n int) ([]byte, error) {
+	bz := make([]byte, n)
+	for i := 0; i < n && i < len(vs); i++ {
+		etv, err := d.decode(vs[i], et)
+		if err != nil {
+			return nil, err
+		}
+		bz[i] = etv.GetUint8()
+	}
+	return bz, nil
+}
+
+// decodeList decodes a JSON array into n values of type et. Missing
+// elements are zero and extra ones are ignored, as in Go.
+func (d *jsonDecoder) decodeList(vs []any, et Type, n int) ([]TypedValue, error) {
+	list := make([]TypedValue, n)
+	for i := range list {
+		if i >= len(vs) {
+			list[i] = defaultTypedValue(d.alloc, et)
+			continue
+		}
+		etv, err := d.decode(vs[i], et)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = etv
+	}
+	return list, nil
+}
+
+func (d *jsonDecoder) decodeMap(obj map[string]any, t Type, mt *MapType) (TypedValue, error) {
+	mv := d.alloc.NewMap(len(obj))
+	// Insert in key order, so that the map's iteration order only
+	// depends on its contents.
+	for _, k := range jsonSortedKeys(obj) {
+		ktv, err := d.decodeMapKey(k, t, mt)
+		if err != nil {
+			return TypedValue{}, err
+		}
+		vtv, err := d.decode(obj[k], mt.Value)
+		if err != nil {
+			return TypedValue{}, err
+		}
+		ptr := mv.GetPointerForKey(d.alloc, d.store, &ktv)
+		*ptr.TV = vtv
+	}
+	return TypedValue{T: t, V: mv}, nil
+}
+
+func (d *jsonDecoder) decodeMapKey(k string, t Type, mt *MapType) (TypedValue, error) {
+	ktv := TypedValue{T: mt.Key}
+	switch mt.Key.Kind() {
+	case StringKind:
+		ktv.V = d.alloc.NewString(k)
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind,
+		UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		if err := jsonSetNumber(&ktv, k); err != nil {
+			return TypedValue{}, &JSONUnmarshalTypeError{Value: "number " + k, Type: mt.Key}
+		}
+	default:
+		return TypedValue{}, &JSONUnsupportedTypeError{Type: t}
+	}
+	return ktv, nil
+}
+
+func (d *jsonDecoder) decodeStruct(obj map[string]any, st *StructType, sv *StructValue) error {
+	fields := jsonStructFields(st)
+	for _, k := range jsonSortedKeys(obj) {
+		f := jsonLookupField(fields, k)
+		if f == nil {
+			continue // unknown fields are ignored, as in Go.
+		}
+		ftv, err := d.decode(obj[k], f.typ)
+		if err != nil {
+			return err
+		}
+		// Walk down to the struct holding the field, allocating
+		// nil embedded pointers on the way.
+		parent := sv
+		for _, idx := range f.index[:len(f.index)-1] {
+			etv := &parent.Fields[idx]
+			if pt, ok := baseOf(etv.T).(*PointerType); ok {
+				if etv.V == nil {
+					*etv = d.newPointer(etv.T, defaultTypedValue(d.alloc, pt.Elt))
+				}
+				etv = etv.V.(PointerValue).TV
+			}
+			parent = etv.V.(*StructValue)
+		}
+		parent.Fields[f.index[len(f.index)-1]] = ftv
+	}
+	return nil
+}
+
+// jsonLookupField finds the field for key, preferring an exact match and
+// falling back to a case-insensitive one, as in Go.
+func jsonLookupField(fields []jsonField, key string) *jsonField {
+	for i := range fields {
+		if fields[i].name == key {
+			return &fields[i]
+		}
+	}
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, key) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func (d *jsonDecoder) newPointer(t Type, etv TypedValue) TypedValue {
+	d.alloc.AllocatePointer()
+	hi := d.alloc.NewHeapItem(etv)
+	return TypedValue{
+		T: t,
+		V: PointerValue{
+			TV:    &hi.Value,
+			Base:  hi,
+			Index: 0,
+		},
+	}
+}
+
+func jsonSortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonKindOf describes a decoded JSON value for error messages.
+func jsonKindOf(v any) string {
+	switch cv := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case string:
+		return "string"
+	case json.Number:
+		return "number " + string(cv)
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		panic("should not happen")
+	}
+}
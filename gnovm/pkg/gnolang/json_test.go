@@ -0,0 +1,55 @@
+package gnolang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSONMetered(t *testing.T) {
+	t.Parallel()
+
+	const n = 1000
+	list := make([]TypedValue, n)
+	for i := range list {
+		list[i] = typedString(strings.Repeat("x", 10))
+	}
+	tv := TypedValue{
+		T: &ArrayType{Len: n, Elt: StringType},
+		V: &ArrayValue{List: list},
+	}
+
+	t.Run("charges the output", func(t *testing.T) {
+		t.Parallel()
+
+		var charged int64
+		bz, err := MarshalJSONMetered(nil, tv, func(size int64) {
+			charged += size
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(bz)), charged)
+
+		unmetered, err := MarshalJSON(nil, tv)
+		require.NoError(t, err)
+		assert.Equal(t, unmetered, bz)
+	})
+
+	t.Run("aborts while encoding", func(t *testing.T) {
+		t.Parallel()
+
+		const limit = 100
+		var charged int64
+		assert.PanicsWithValue(t, "out of gas", func() {
+			MarshalJSONMetered(nil, tv, func(size int64) {
+				charged += size
+				if charged > limit {
+					panic("out of gas")
+				}
+			})
+		})
+		// at most one more value was encoded after the limit.
+		assert.Less(t, charged, int64(limit+20))
+	})
+}
@@ -47,6 +47,7 @@ type Machine struct {
 	Store            Store
 	Context          interface{}
 	GasMeter         store.GasMeter
+	Bytecode         bool
 	// PanicScope is incremented each time a panic occurs and is reset to
 	// zero when it is recovered.
 	PanicScope uint
@@ -87,6 +88,7 @@ type MachineOptions struct {
 	Alloc            *Allocator // or see MaxAllocBytes.
 	MaxAllocBytes    int64      // or 0 for no limit.
 	GasMeter         store.GasMeter
+	Bytecode         bool // run compiled function bodies; see bytecode.go.
 }
 
 // the machine constructor gets spammed
@@ -146,6 +148,7 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	mm.Store = store
 	mm.Context = context
 	mm.GasMeter = vmGasMeter
+	mm.Bytecode = opts.Bytecode
 	mm.Debugger.enabled = opts.Debug
 	mm.Debugger.in = opts.Input
 	mm.Debugger.out = output
@@ -199,14 +202,48 @@ func (m *Machine) SetActivePackage(pv *PackageValue) {
 // Upon restart, preprocess all MemPackage and save blocknodes.
 // This is a temporary measure until we optimize/make-lazy.
 //
+// Packages are preprocessed in the order they were added, after their
+// dependencies: the new version of an upgraded realm may import packages
+// that were added after it.
+//
 // NOTE: package paths not beginning with gno.land will be allowed to override,
 // to support cases of stdlibs processed through [RunMemPackagesWithOverrides].
 func (m *Machine) PreprocessAllFilesAndSaveBlockNodes() {
 	ch := m.Store.IterMemPackage()
+	var paths []string
+	memPkgs := make(map[string]*gnovm.MemPackage)
 	for memPkg := range ch {
+		if _, exists := memPkgs[memPkg.Path]; !exists {
+			paths = append(paths, memPkg.Path)
+		}
+		memPkgs[memPkg.Path] = memPkg
+	}
+	done := make(map[string]bool, len(paths))
+	var preprocess func(path string)
+	preprocess = func(path string) {
+		if done[path] {
+			return
+		}
+		done[path] = true
+		memPkg := memPkgs[path]
 		fset := ParseMemPackage(memPkg)
+		for _, fn := range fset.Files {
+			for _, d := range fn.Decls {
+				if id, ok := d.(*ImportDecl); ok {
+					if _, exists := memPkgs[id.PkgPath]; exists {
+						preprocess(id.PkgPath)
+					}
+				}
+			}
+		}
 		pn := NewPackageNode(Name(memPkg.Name), memPkg.Path, fset)
 		m.Store.SetBlockNode(pn)
+		// Keep the package block layout of upgraded realms.
+		if IsRealmPath(memPkg.Path) {
+			if rlm := m.Store.GetPackageRealm(memPkg.Path); rlm != nil && rlm.Names != nil {
+				predefineNames(pn, fset, rlm.Names)
+			}
+		}
 		PredefineFileSet(m.Store, pn, fset)
 		for _, fn := range fset.Files {
 			// Save Types to m.Store (while preprocessing).
@@ -226,6 +263,9 @@ func (m *Machine) PreprocessAllFilesAndSaveBlockNodes() {
 			// TODO ensure the files are the same.
 		}
 	}
+	for _, path := range paths {
+		preprocess(path)
+	}
 }
 
 //----------------------------------------
@@ -914,6 +954,7 @@ const (
 	OpPopFrameAndReset    Op = 0x15 // pop frame and reset.
 	OpPanic1              Op = 0x16 // pop exception and pop call frames.
 	OpPanic2              Op = 0x17 // pop call frames.
+	OpCallBytecode        Op = 0x18 // call body is bytecode
 
 	/* Unary & binary operators */
 	OpUpos  Op = 0x20 // + (unary)
@@ -1026,6 +1067,15 @@ func (m *Machine) incrCPU(cycles int64) {
 	m.Cycles += cycles
 }
 
+// IncrCPU accounts for cycles spent outside of the machine's ops; it is used
+// by native functions whose cost depends on the size of their input. Cycles
+// are in the same unit as the OpCPU* costs of the ops below, and are charged
+// as gas like them, at GasFactorCPU per cycle: natives price their work
+// relative to the ops.
+func (m *Machine) IncrCPU(cycles int64) {
+	m.incrCPU(cycles)
+}
+
 const (
 	// CPU cycles
 	/* Control operators */
@@ -1233,6 +1283,9 @@ func (m *Machine) Run() {
 		case OpPanic2:
 			m.incrCPU(OpCPUPanic2)
 			m.doOpPanic2()
+		case OpCallBytecode:
+			// cycles are charged by each instruction.
+			m.doOpCallBytecode()
 		case OpCallDeferNativeBody:
 			m.incrCPU(OpCPUCallDeferNativeBody)
 			m.doOpCallDeferNativeBody()
@@ -0,0 +1,160 @@
+package gnolang
+
+import (
+	"bytes"
+	"container/list"
+	"slices"
+	"sync"
+)
+
+// objectCache is a bounded LRU cache of decoded objects, shared by all the
+// transactions of a store and thus kept across blocks.
+//
+// Entries are keyed by object ID, but are only returned if the hash of the
+// cached object matches the one stored alongside the object's bytes in the
+// backend store. The backend store is still read, and the gas and allocation
+// charged as if the object was decoded, so that a hit is indistinguishable
+// from a miss to the transaction: the cache only saves the amino decoding.
+// This makes it correct with any backend store, including those of failed
+// transactions, queries at any height and speculative executions.
+//
+// Cached objects are never handed out; a hit returns a copy, which the
+// transaction is free to fill and modify.
+type objectCache struct {
+	mu      sync.Mutex
+	maxSize int64                      // limit of the sum of entry sizes.
+	size    int64                      // sum of entry sizes.
+	entries map[ObjectID]*list.Element // *objectCacheEntry.
+	queue   *list.List                 // LRU queue, least recently used first.
+}
+
+type objectCacheEntry struct {
+	oid    ObjectID
+	hash   []byte
+	object Object // as decoded, before filling its types.
+	size   int64  // length of its amino bytes.
+}
+
+func newObjectCache(maxSize int64) *objectCache {
+	return &objectCache{
+		maxSize: maxSize,
+		entries: make(map[ObjectID]*list.Element),
+		queue:   list.New(),
+	}
+}
+
+// get returns a copy of the cached object with the given id and hash, or nil.
+func (oc *objectCache) get(oid ObjectID, hash []byte) Object {
+	oc.mu.Lock()
+	elem, ok := oc.entries[oid]
+	if !ok || !bytes.Equal(elem.Value.(*objectCacheEntry).hash, hash) {
+		oc.mu.Unlock()
+		return nil
+	}
+	oc.queue.MoveToBack(elem)
+	oo := elem.Value.(*objectCacheEntry).object
+	oc.mu.Unlock()
+
+	// cached objects are never modified, so they can be copied concurrently.
+	return copyObject(oo)
+}
+
+// add caches a copy of the decoded object oo. It must be called before
+// filling its types.
+func (oc *objectCache) add(oid ObjectID, hash []byte, oo Object, size int64) {
+	if size > oc.maxSize || !isCacheableObject(oo) {
+		return
+	}
+	cpy, ok := tryCopyObject(oo)
+	if !ok {
+		return
+	}
+
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	oc.removeLocked(oid)
+	entry := &objectCacheEntry{
+		oid:    oid,
+		hash:   slices.Clone(hash),
+		object: cpy,
+		size:   size,
+	}
+	oc.entries[oid] = oc.queue.PushBack(entry)
+	oc.size += size
+	for oc.size > oc.maxSize {
+		oc.removeLocked(oc.queue.Front().Value.(*objectCacheEntry).oid)
+	}
+}
+
+// remove evicts the object with the given id, if cached.
+func (oc *objectCache) remove(oid ObjectID) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	oc.removeLocked(oid)
+}
+
+func (oc *objectCache) removeLocked(oid ObjectID) {
+	if elem, ok := oc.entries[oid]; ok {
+		oc.size -= oc.queue.Remove(elem).(*objectCacheEntry).size
+		delete(oc.entries, oid)
+	}
+}
+
+// copyObject copies a decoded object, whose children are all references.
+func copyObject(oo Object) Object {
+	cpy := copyValueWithRefs(oo).(Object)
+	if pv, ok := cpy.(*PackageValue); ok {
+		pv.FNames = slices.Clone(pv.FNames)
+	}
+	return cpy
+}
+
+// tryCopyObject is like copyObject, but returns false rather than panicking
+// on objects that can't be copied, which are then not cached; whether an
+// object is cached must not change the outcome of a transaction.
+func tryCopyObject(oo Object) (cpy Object, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			cpy, ok = nil, false
+		}
+	}()
+	return copyObject(oo), true
+}
+
+// isCacheableObject returns false for the objects holding closures with
+// captured variables, as the captured values are persisted inline and
+// would be shared with the copies.
+func isCacheableObject(oo Object) bool {
+	var tvs []TypedValue
+	switch cv := oo.(type) {
+	case *ArrayValue:
+		tvs = cv.List
+	case *StructValue:
+		tvs = cv.Fields
+	case *BoundMethodValue:
+		return (cv.Func == nil || len(cv.Func.Captures) == 0) && !hasCaptures(cv.Receiver)
+	case *MapValue:
+		for cur := cv.List.Head; cur != nil; cur = cur.Next {
+			if hasCaptures(cur.Key) || hasCaptures(cur.Value) {
+				return false
+			}
+		}
+	case *Block:
+		tvs = cv.Values
+	case *HeapItemValue:
+		return !hasCaptures(cv.Value)
+	}
+	for _, tv := range tvs {
+		if hasCaptures(tv) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasCaptures(tv TypedValue) bool {
+	fv, ok := tv.V.(*FuncValue)
+	return ok && len(fv.Captures) > 0
+}
@@ -0,0 +1,248 @@
+package gnolang
+
+// doOpCallBytecode runs the bytecode of the function called by the last call
+// frame, whose block was pushed by doOpCall. See bytecode.go.
+func (m *Machine) doOpCallBytecode() {
+	fr := m.LastFrame()
+	bc := m.Store.getBytecode(fr.Func.GetSource(m.Store).GetLocation())
+	b := m.LastBlock()
+	bs := b.GetBodyStmt()
+
+	regs := make([]TypedValue, bc.numRegs)
+	copy(regs, b.Values)
+	for _, c := range bc.consts {
+		regs[c.reg] = c.tv
+	}
+	code := bc.code
+	for pc := 0; ; {
+		in := &code[pc]
+		pc++
+		if in.cycles != 0 {
+			m.incrCPU(in.cycles)
+		}
+		switch in.op {
+		case bcNop:
+		case bcStmt:
+			bs.Body = in.body
+			bs.NextBodyIndex = in.a
+		case bcBlock:
+			m.Alloc.AllocateBlock(int64(in.c))
+			clear(regs[in.a : in.a+in.b])
+		case bcExpand:
+			m.Alloc.AllocateBlockItems(int64(in.c))
+		case bcMove:
+			regs[in.a] = regs[in.b]
+		case bcAssign:
+			regs[in.a].Assign(m.Alloc, regs[in.b], true)
+		case bcValueDecl:
+			var tv TypedValue
+			if in.b < 0 {
+				tv = TypedValue{T: in.typ, V: defaultValue(m.Alloc, in.typ)}
+			} else {
+				tv = regs[in.b]
+			}
+			declValueType(&tv, in.typ, false)
+			regs[in.a].Assign(m.Alloc, tv, false)
+		case bcConvert:
+			xv := regs[in.b]
+			if in.c != 0 {
+				xv.AssertNonNegative("runtime error: negative shift amount")
+			}
+			ConvertTo(m.Alloc, m.Store, &xv, in.typ, false)
+			regs[in.a] = xv
+		case bcBinary:
+			lv := &regs[in.a]
+			*lv = regs[in.b]
+			rv := regs[in.c]
+			m.bcBinary(in.mop, lv, &rv)
+		case bcUnary:
+			xv := &regs[in.a]
+			*xv = regs[in.b]
+			switch in.mop {
+			case OpUpos:
+			case OpUneg:
+				unegAssign(xv)
+			case OpUnot:
+				unotAssign(xv)
+			case OpUxor:
+				uxorAssign(xv)
+			default:
+				panic("should not happen")
+			}
+		case bcLand:
+			lv, rv := &regs[in.a], regs[in.b]
+			if isUntyped(lv.T) {
+				lv.T = rv.T
+			}
+			lv.SetBool(lv.GetBool() && rv.GetBool())
+		case bcLor:
+			lv, rv := &regs[in.a], regs[in.b]
+			if isUntyped(lv.T) {
+				lv.T = rv.T
+			}
+			lv.SetBool(lv.GetBool() || rv.GetBool())
+		case bcOpAssign:
+			rv := regs[in.b]
+			m.bcOpAssign(in.mop, &regs[in.a], &rv)
+		case bcInc:
+			incAssign(&regs[in.a])
+		case bcDec:
+			decAssign(&regs[in.a])
+		case bcJump:
+			pc = in.a
+		case bcJumpIfFalse:
+			if !regs[in.a].GetBool() {
+				pc = in.b
+			}
+		case bcJumpIfTrue:
+			if regs[in.a].GetBool() {
+				pc = in.b
+			}
+		case bcReturn:
+			for _, tv := range regs[in.a : in.a+in.b] {
+				m.PushValue(tv)
+			}
+			m.doOpReturn()
+			return
+		case bcReturnFromBlock:
+			copy(b.Values, regs)
+			m.doOpReturnFromBlock()
+			return
+		case bcLoadGlobal:
+			regs[in.a] = b.GetPointerTo(m.Store, in.path).Deref()
+		case bcStoreGlobal:
+			pv := b.GetPointerTo(m.Store, in.path)
+			m.bcCheckReadOnly(pv)
+			pv.Assign2(m.Alloc, m.Store, m.Realm, regs[in.b], true)
+		case bcOpAssignGlobal:
+			pv := b.GetPointerTo(m.Store, in.path)
+			m.bcCheckReadOnly(pv)
+			rv := regs[in.b]
+			m.bcOpAssign(in.mop, pv.TV, &rv)
+			if pv.Base != nil {
+				m.Realm.DidUpdate(pv.Base.(Object), nil, nil)
+			}
+		case bcIncGlobal:
+			pv := b.GetPointerTo(m.Store, in.path)
+			incAssign(pv.TV)
+			if m.Realm != nil && pv.Base != nil {
+				m.Realm.DidUpdate(pv.Base.(Object), nil, nil)
+			}
+		case bcDecGlobal:
+			pv := b.GetPointerTo(m.Store, in.path)
+			decAssign(pv.TV)
+			if m.Realm != nil && pv.Base != nil {
+				m.Realm.DidUpdate(pv.Base.(Object), nil, nil)
+			}
+		default:
+			panic("should not happen")
+		}
+	}
+}
+
+// bcCheckReadOnly panics if pv points into a persisted object while the
+// machine is readonly, like the assignment ops.
+func (m *Machine) bcCheckReadOnly(pv PointerValue) {
+	if m.ReadOnly {
+		if oo, ok := pv.Base.(Object); ok {
+			if oo.GetIsReal() {
+				panic("readonly violation")
+			}
+		}
+	}
+}
+
+// bcBinary sets lv to lv <op> rv, like the binary op.
+func (m *Machine) bcBinary(op Op, lv, rv *TypedValue) {
+	var res bool
+	switch op {
+	case OpEql:
+		res = isEql(m.Store, lv, rv)
+	case OpNeq:
+		res = !isEql(m.Store, lv, rv)
+	case OpLss:
+		res = isLss(lv, rv)
+	case OpLeq:
+		res = isLeq(lv, rv)
+	case OpGtr:
+		res = isGtr(lv, rv)
+	case OpGeq:
+		res = isGeq(lv, rv)
+	case OpAdd:
+		addAssign(m.Alloc, lv, rv)
+		return
+	case OpSub:
+		subAssign(lv, rv)
+		return
+	case OpMul:
+		mulAssign(lv, rv)
+		return
+	case OpQuo:
+		if err := quoAssign(lv, rv); err != nil {
+			panic(err)
+		}
+		return
+	case OpRem:
+		if err := remAssign(lv, rv); err != nil {
+			panic(err)
+		}
+		return
+	case OpBor:
+		borAssign(lv, rv)
+		return
+	case OpXor:
+		xorAssign(lv, rv)
+		return
+	case OpShl:
+		shlAssign(m, lv, rv)
+		return
+	case OpShr:
+		shrAssign(m, lv, rv)
+		return
+	case OpBand:
+		bandAssign(lv, rv)
+		return
+	case OpBandn:
+		bandnAssign(lv, rv)
+		return
+	default:
+		panic("should not happen")
+	}
+	lv.T = UntypedBoolType
+	lv.V = nil
+	lv.SetBool(res)
+}
+
+// bcOpAssign sets lv to lv <op> rv, like the op-assign op.
+func (m *Machine) bcOpAssign(op Op, lv, rv *TypedValue) {
+	switch op {
+	case OpAddAssign:
+		addAssign(m.Alloc, lv, rv)
+	case OpSubAssign:
+		subAssign(lv, rv)
+	case OpMulAssign:
+		mulAssign(lv, rv)
+	case OpQuoAssign:
+		if err := quoAssign(lv, rv); err != nil {
+			panic(err)
+		}
+	case OpRemAssign:
+		if err := remAssign(lv, rv); err != nil {
+			panic(err)
+		}
+	case OpBandAssign:
+		bandAssign(lv, rv)
+	case OpBorAssign:
+		borAssign(lv, rv)
+	case OpXorAssign:
+		xorAssign(lv, rv)
+	case OpShlAssign:
+		shlAssign(m, lv, rv)
+	case OpShrAssign:
+		shrAssign(m, lv, rv)
+	case OpBandnAssign:
+		bandnAssign(lv, rv)
+	default:
+		panic("should not happen")
+	}
+}
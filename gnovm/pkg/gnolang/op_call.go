@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	bm "github.com/gnolang/gno/gnovm/pkg/benchops"
 )
 
 func (m *Machine) doOpPrecall() {
@@ -84,13 +86,16 @@ func (m *Machine) doOpCall() {
 	}
 	if fv.nativeBody == nil {
 		fbody := fv.GetBodyFromSource(m.Store)
+		bc := m.bytecodeOf(fv)
 		if len(ft.Results) == 0 {
-			// Push final empty *ReturnStmt;
-			// TODO: transform in preprocessor instead to return only
-			// when necessary.
-			// NOTE: m.PushOp(OpReturn) doesn't handle defers.
-			m.PushStmt(gReturnStmt)
-			m.PushOp(OpExec)
+			if bc == nil {
+				// Push final empty *ReturnStmt;
+				// TODO: transform in preprocessor instead to return only
+				// when necessary.
+				// NOTE: m.PushOp(OpReturn) doesn't handle defers.
+				m.PushStmt(gReturnStmt)
+				m.PushOp(OpExec)
+			}
 		} else {
 			// Initialize return variables with default value.
 			numParams := len(ft.Params)
@@ -107,7 +112,11 @@ func (m *Machine) doOpCall() {
 			BodyLen:       len(fbody),
 			NextBodyIndex: -2,
 		}
-		m.PushOp(OpBody)
+		if bc != nil {
+			m.PushOp(OpCallBytecode)
+		} else {
+			m.PushOp(OpBody)
+		}
 		m.PushStmt(b.GetBodyStmt())
 	} else {
 		// No return exprs and no defers, safe to skip OpEval.
@@ -187,6 +196,18 @@ func (m *Machine) doOpCall() {
 	}
 }
 
+// bytecodeOf returns the bytecode of fv, if it should run as bytecode.
+func (m *Machine) bytecodeOf(fv *FuncValue) *bcFunc {
+	if !m.Bytecode || m.Debugger.enabled || bm.OpsEnabled {
+		return nil
+	}
+	fd, ok := fv.GetSource(m.Store).(*FuncDecl)
+	if !ok {
+		return nil
+	}
+	return m.Store.getBytecode(fd.GetLocation())
+}
+
 func (m *Machine) doOpCallNativeBody() {
 	m.LastFrame().Func.nativeBody(m)
 }
@@ -29,38 +29,45 @@ func (m *Machine) doOpValueDecl() {
 		} else {
 			tv = rvs[i]
 		}
-		if nt != nil {
-			if nt.Kind() == InterfaceKind {
-				if isUntyped(tv.T) {
-					ConvertUntypedTo(&tv, nil)
-				} else {
-					// keep type as is.
-				}
+		declValueType(&tv, nt, s.Const)
+		nx := &s.NameExprs[i]
+		ptr := lb.GetPointerToMaybeHeapDefine(m.Store, nx)
+		ptr.Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+	}
+}
+
+// declValueType gives tv, the value of a value declaration, the declared
+// type nt. If nt is nil, untyped values other than constants get their
+// default type.
+func declValueType(tv *TypedValue, nt Type, isConst bool) {
+	if nt != nil {
+		if nt.Kind() == InterfaceKind {
+			if isUntyped(tv.T) {
+				ConvertUntypedTo(tv, nil)
 			} else {
-				if isUntyped(tv.T) {
-					ConvertUntypedTo(&tv, nt)
-				} else {
-					if debug {
-						if nt.TypeID() != tv.T.TypeID() &&
-							baseOf(nt).TypeID() != tv.T.TypeID() {
-							panic(fmt.Sprintf(
-								"type mismatch: %s vs %s",
-								nt.TypeID(),
-								tv.T.TypeID(),
-							))
-						}
+				// keep type as is.
+			}
+		} else {
+			if isUntyped(tv.T) {
+				ConvertUntypedTo(tv, nt)
+			} else {
+				if debug {
+					if nt.TypeID() != tv.T.TypeID() &&
+						baseOf(nt).TypeID() != tv.T.TypeID() {
+						panic(fmt.Sprintf(
+							"type mismatch: %s vs %s",
+							nt.TypeID(),
+							tv.T.TypeID(),
+						))
 					}
-					tv.T = nt
 				}
+				tv.T = nt
 			}
-		} else if s.Const {
-			// leave untyped as is.
-		} else if isUntyped(tv.T) {
-			ConvertUntypedTo(&tv, nil)
 		}
-		nx := &s.NameExprs[i]
-		ptr := lb.GetPointerToMaybeHeapDefine(m.Store, nx)
-		ptr.Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+	} else if isConst {
+		// leave untyped as is.
+	} else if isUntyped(tv.T) {
+		ConvertUntypedTo(tv, nil)
 	}
 }
 
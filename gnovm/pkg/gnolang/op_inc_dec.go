@@ -28,6 +28,43 @@ func (m *Machine) doOpInc() {
 		}
 	}
 
+	incAssign(lv)
+
+	// Mark dirty in realm.
+	if m.Realm != nil && pv.Base != nil {
+		m.Realm.DidUpdate(pv.Base.(Object), nil, nil)
+	}
+}
+
+func (m *Machine) doOpDec() {
+	s := m.PopStmt().(*IncDecStmt)
+
+	// Get result ptr depending on lhs.
+	pv := m.PopAsPointer(s.X)
+	lv := pv.TV
+
+	// Switch on the base type.  NOTE: this is faster
+	// than computing the kind of kv.T.  TODO: consider
+	// optimizing away this switch by implementing a
+	// general SetAnyInt(n int64) function that handles
+	// bounds checking.  NOTE: no need to set .V to nil,
+	// as the type should be the same, and thus .V is
+	// expected to be nil.
+	if debug {
+		if lv.V != nil {
+			panic("expected lv.V to be nil for primitive type for OpDec")
+		}
+	}
+	decAssign(lv)
+
+	// Mark dirty in realm.
+	if m.Realm != nil && pv.Base != nil {
+		m.Realm.DidUpdate(pv.Base.(Object), nil, nil)
+	}
+}
+
+// incAssign increments lv by one.
+func incAssign(lv *TypedValue) {
 	// here we can't just switch on the value type
 	// because it could be a type alias
 	// type num int
@@ -75,32 +112,10 @@ func (m *Machine) doOpInc() {
 	default:
 		panic(fmt.Sprintf("unexpected type %s in inc/dec operation", lv.T))
 	}
-
-	// Mark dirty in realm.
-	if m.Realm != nil && pv.Base != nil {
-		m.Realm.DidUpdate(pv.Base.(Object), nil, nil)
-	}
 }
 
-func (m *Machine) doOpDec() {
-	s := m.PopStmt().(*IncDecStmt)
-
-	// Get result ptr depending on lhs.
-	pv := m.PopAsPointer(s.X)
-	lv := pv.TV
-
-	// Switch on the base type.  NOTE: this is faster
-	// than computing the kind of kv.T.  TODO: consider
-	// optimizing away this switch by implementing a
-	// general SetAnyInt(n int64) function that handles
-	// bounds checking.  NOTE: no need to set .V to nil,
-	// as the type should be the same, and thus .V is
-	// expected to be nil.
-	if debug {
-		if lv.V != nil {
-			panic("expected lv.V to be nil for primitive type for OpDec")
-		}
-	}
+// decAssign decrements lv by one.
+func decAssign(lv *TypedValue) {
 	switch baseOf(lv.T) {
 	case IntType:
 		lv.SetInt(lv.GetInt() - 1)
@@ -145,9 +160,4 @@ func (m *Machine) doOpDec() {
 	default:
 		panic(fmt.Sprintf("unexpected type %s in inc/dec operation", lv.T))
 	}
-
-	// Mark dirty in realm.
-	if m.Realm != nil && pv.Base != nil {
-		m.Realm.DidUpdate(pv.Base.(Object), nil, nil)
-	}
 }
@@ -23,6 +23,35 @@ func (m *Machine) doOpUneg() {
 	}
 	xv := m.PeekValue(1)
 
+	unegAssign(xv)
+}
+
+func (m *Machine) doOpUnot() {
+	ux := m.PopExpr().(*UnaryExpr)
+	if debug {
+		debug.Printf("doOpUnot(%v)\n", ux)
+	}
+	xv := m.PeekValue(1)
+
+	unotAssign(xv)
+}
+
+func (m *Machine) doOpUxor() {
+	ux := m.PopExpr().(*UnaryExpr)
+	if debug {
+		debug.Printf("doOpUxor(%v)\n", ux)
+	}
+	xv := m.PeekValue(1)
+
+	uxorAssign(xv)
+}
+
+func (m *Machine) doOpUrecv() {
+	panic("not yet implemented")
+}
+
+// unegAssign applies unary - to xv in place.
+func unegAssign(xv *TypedValue) {
 	// Switch on the base type.
 	// NOTE: this is faster than computing the kind of kv.T.
 	switch baseOf(xv.T) {
@@ -66,13 +95,8 @@ func (m *Machine) doOpUneg() {
 	}
 }
 
-func (m *Machine) doOpUnot() {
-	ux := m.PopExpr().(*UnaryExpr)
-	if debug {
-		debug.Printf("doOpUnot(%v)\n", ux)
-	}
-	xv := m.PeekValue(1)
-
+// unotAssign applies unary ! to xv in place.
+func unotAssign(xv *TypedValue) {
 	// Switch on the base type.
 	switch baseOf(xv.T) {
 	case BoolType, UntypedBoolType:
@@ -83,13 +107,8 @@ func (m *Machine) doOpUnot() {
 	}
 }
 
-func (m *Machine) doOpUxor() {
-	ux := m.PopExpr().(*UnaryExpr)
-	if debug {
-		debug.Printf("doOpUxor(%v)\n", ux)
-	}
-	xv := m.PeekValue(1)
-
+// uxorAssign applies unary ^ to xv in place.
+func uxorAssign(xv *TypedValue) {
 	// Switch on the base type.
 	switch baseOf(xv.T) {
 	case IntType:
@@ -120,7 +139,3 @@ func (m *Machine) doOpUxor() {
 			baseOf(xv.T)))
 	}
 }
-
-func (m *Machine) doOpUrecv() {
-	panic("not yet implemented")
-}
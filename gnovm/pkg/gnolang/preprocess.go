@@ -438,6 +438,8 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 		findLoopUses1(ctx, bn)
 		findLoopUses2(ctx, bn)
 	}
+	// Compile function bodies to bytecode, now that loop uses are known.
+	compileFuncDecls(store, n)
 	return n
 }
 
@@ -2430,6 +2432,13 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					tid := DeclaredTypeID(lastpn.PkgPath, n.Name)
 					exists := false
 					if dt := store.GetTypeSafe(tid); dt != nil {
+						// Special case: the type is being
+						// redeclared by an upgrade of the package,
+						// which may add struct fields to it, see
+						// [Machine.UpgradeMemPackage].
+						if dt := dt.(*DeclaredType); dt.upgrading != nil {
+							dt.upgradeBase(baseOf(tmp))
+						}
 						dst = dt.(*DeclaredType)
 						last.GetValueRef(store, n.Name, true).SetType(dst)
 						exists = true
@@ -2453,6 +2462,9 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					panic(fmt.Sprintf("unexpected type declaration type %v",
 						reflect.TypeOf(dst)))
 				}
+				// The declared type may have been replaced by
+				// the one in the store, above.
+				dst = last.GetValueRef(store, n.Name, true).GetType()
 				// We need to replace all references of the new
 				// Type with old Type, including in attributes.
 				n.Type.SetAttribute(ATTR_TYPE_VALUE, dst)
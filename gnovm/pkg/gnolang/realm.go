@@ -115,6 +115,11 @@ type Realm struct {
 	Path string
 	Time uint64
 
+	// Names is the layout of the package block of an upgraded realm,
+	// which is kept across upgrades. It is nil for realms that were
+	// never upgraded. See [Machine.UpgradeMemPackage].
+	Names []Name
+
 	newCreated []Object
 	newEscaped []Object
 	newDeleted []Object
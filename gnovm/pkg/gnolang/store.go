@@ -53,6 +53,10 @@ type Store interface {
 	GetBlockNodeSafe(Location) BlockNode
 	SetBlockNode(BlockNode)
 
+	// bytecode of function declarations, set along with their BlockNodes.
+	getBytecode(Location) *bcFunc
+	setBytecode(Location, *bcFunc)
+
 	// UNSTABLE
 	Go2GnoType(rt reflect.Type) Type
 	GetAllocator() *Allocator
@@ -61,10 +65,17 @@ type Store interface {
 	// loads BlockNodes and Types onto the store for persistence
 	// version 1.
 	AddMemPackage(memPkg *gnovm.MemPackage)
+	// Replaces the mempackage of an upgraded package, keeping the
+	// previous versions available through GetMemPackageVersion.
+	UpgradeMemPackage(memPkg *gnovm.MemPackage)
 	GetMemPackage(path string) *gnovm.MemPackage
+	GetMemPackageVersion(path string, version uint64) *gnovm.MemPackage
+	NumMemPackageVersions(path string) uint64
+	SetUpgradedType(dt *DeclaredType, base Type, methods []TypedValue)
 	GetMemFile(path string, name string) *gnovm.MemFile
 	IterMemPackage() <-chan *gnovm.MemPackage
 	ClearObjectCache()                                    // run before processing a message
+	SetObjectCacheSize(size int64)                        // bounds the cross-transaction object cache; 0 disables it
 	SetNativeResolver(NativeResolver)                     // for "new" natives XXX
 	GetNative(pkgPath string, name Name) func(m *Machine) // for "new" natives XXX
 	SetLogStoreOps(enabled bool)
@@ -82,6 +93,25 @@ type TransactionStore interface {
 	// Write commits the current buffered transaction data to the underlying store.
 	// It also clears the current buffer of the transaction.
 	Write()
+
+	// SetAccessRecorder sets the recorder of the objects, types and block
+	// nodes accessed in the transaction, or unsets it if ar is nil.
+	SetAccessRecorder(ar AccessRecorder)
+
+	// ObjectCacheStats returns the number of objects loaded from the
+	// backend store which were found in, or missing from, the object cache
+	// since the previous call.
+	ObjectCacheStats() (hits, misses int64)
+}
+
+// AccessRecorder records the keys of the objects, realms, types and block
+// nodes read and written through a transaction store. As types and block
+// nodes are cached across transactions, this is the only way to know which
+// ones a transaction depends on, for instance when executing transactions
+// in parallel.
+type AccessRecorder interface {
+	AddRead(key string)
+	AddWrite(key string)
 }
 
 // Gas consumption descriptors.
@@ -131,10 +161,23 @@ type defaultStore struct {
 	iavlStore store.Store // for escaped object hashes
 
 	// transaction-scoped
-	cacheObjects map[ObjectID]Object            // this is a real cache, reset with every transaction.
-	cacheTypes   txlog.Map[TypeID, Type]        // this re-uses the parent store's.
-	cacheNodes   txlog.Map[Location, BlockNode] // until BlockNode persistence is implemented, this is an actual store.
-	alloc        *Allocator                     // for accounting for cached items
+	cacheObjects  map[ObjectID]Object            // this is a real cache, reset with every transaction.
+	cacheTypes    txlog.Map[TypeID, Type]        // this re-uses the parent store's.
+	cacheNodes    txlog.Map[Location, BlockNode] // until BlockNode persistence is implemented, this is an actual store.
+	cacheBytecode txlog.Map[Location, *bcFunc]   // bytecode of the FuncDecls of cacheNodes; same as cacheNodes.
+	alloc         *Allocator                     // for accounting for cached items
+
+	// decoded objects, shared across transactions, optional.
+	// types and block nodes don't need one, as all of them are kept in
+	// cacheTypes and cacheNodes, which are committed upon Write().
+	objCache        *objectCache
+	objCacheWritten map[ObjectID]struct{} // evicted upon Write().
+	objCacheHits    int64
+	objCacheMisses  int64
+
+	// declared types of upgraded packages; the shared
+	// *DeclaredType is only updated upon Write().
+	upgradedTypes []upgradedType
 
 	// store configuration; cannot be modified in a transaction
 	pkgGetter        PackageGetter         // non-realm packages
@@ -148,6 +191,9 @@ type defaultStore struct {
 	// gas
 	gasMeter  store.GasMeter
 	gasConfig GasConfig
+
+	// access recorder, optional.
+	access AccessRecorder
 }
 
 func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore {
@@ -157,9 +203,10 @@ func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore
 		alloc:     alloc,
 
 		// cacheObjects is set; objects in the store will be copied over for any transaction.
-		cacheObjects: make(map[ObjectID]Object),
-		cacheTypes:   txlog.GoMap[TypeID, Type](map[TypeID]Type{}),
-		cacheNodes:   txlog.GoMap[Location, BlockNode](map[Location]BlockNode{}),
+		cacheObjects:  make(map[ObjectID]Object),
+		cacheTypes:    txlog.GoMap[TypeID, Type](map[TypeID]Type{}),
+		cacheNodes:    txlog.GoMap[Location, BlockNode](map[Location]BlockNode{}),
+		cacheBytecode: txlog.GoMap[Location, *bcFunc](map[Location]*bcFunc{}),
 
 		// store configuration
 		pkgGetter:        nil,
@@ -185,10 +232,15 @@ func (ds *defaultStore) BeginTransaction(baseStore, iavlStore store.Store, gasMe
 		iavlStore: iavlStore,
 
 		// transaction-scoped
-		cacheObjects: make(map[ObjectID]Object),
-		cacheTypes:   txlog.Wrap(ds.cacheTypes),
-		cacheNodes:   txlog.Wrap(ds.cacheNodes),
-		alloc:        ds.alloc.Fork().Reset(),
+		cacheObjects:  make(map[ObjectID]Object),
+		cacheTypes:    txlog.Wrap(ds.cacheTypes),
+		cacheNodes:    txlog.Wrap(ds.cacheNodes),
+		cacheBytecode: txlog.Wrap(ds.cacheBytecode),
+		alloc:         ds.alloc.Fork().Reset(),
+
+		// object cache
+		objCache:        ds.objCache,
+		objCacheWritten: make(map[ObjectID]struct{}),
 
 		// store configuration
 		pkgGetter:        ds.pkgGetter,
@@ -210,9 +262,49 @@ func (ds *defaultStore) BeginTransaction(baseStore, iavlStore store.Store, gasMe
 
 type transactionStore struct{ *defaultStore }
 
+func (t transactionStore) SetAccessRecorder(ar AccessRecorder) {
+	t.access = ar
+}
+
+func (t transactionStore) ObjectCacheStats() (hits, misses int64) {
+	hits, misses = t.objCacheHits, t.objCacheMisses
+	t.objCacheHits, t.objCacheMisses = 0, 0
+	return
+}
+
 func (t transactionStore) Write() {
 	t.cacheTypes.(txlog.MapCommitter[TypeID, Type]).Commit()
 	t.cacheNodes.(txlog.MapCommitter[Location, BlockNode]).Commit()
+	t.cacheBytecode.(txlog.MapCommitter[Location, *bcFunc]).Commit()
+	if t.objCache != nil {
+		for oid := range t.objCacheWritten {
+			t.objCache.remove(oid)
+		}
+	}
+	clear(t.objCacheWritten)
+	for _, ut := range t.upgradedTypes {
+		ut.dt.Base = ut.base
+		ut.dt.Methods = ut.methods
+	}
+	t.upgradedTypes = nil
+}
+
+type upgradedType struct {
+	dt      *DeclaredType
+	base    Type
+	methods []TypedValue
+}
+
+// SetUpgradedType persists dt with the given base and methods. As the
+// *DeclaredType is shared with the parent store, and likely referenced by other
+// packages, its base and methods are only replaced once the transaction is
+// written.
+func (t transactionStore) SetUpgradedType(dt *DeclaredType, base Type, methods []TypedValue) {
+	oldBase, oldMethods := dt.Base, dt.Methods
+	dt.Base, dt.Methods = base, methods
+	t.SetType(dt)
+	dt.Base, dt.Methods = oldBase, oldMethods
+	t.upgradedTypes = append(t.upgradedTypes, upgradedType{dt: dt, base: base, methods: methods})
 }
 
 func (transactionStore) SetPackageGetter(pg PackageGetter) {
@@ -231,6 +323,10 @@ func (transactionStore) SetNativeResolver(ns NativeResolver) {
 	panic("SetNativeResolver may not be called in a transaction store")
 }
 
+func (transactionStore) SetObjectCacheSize(size int64) {
+	panic("SetObjectCacheSize may not be called in a transaction store")
+}
+
 // CopyCachesFromStore allows to copy a store's internal object, type and
 // BlockNode cache into the dst store.
 // This is mostly useful for testing, where many stores have to be initialized.
@@ -252,6 +348,9 @@ func CopyFromCachedStore(destStore, cachedStore Store, cachedBase, cachedIavl st
 	for k, v := range ss.cacheNodes.Iterate() {
 		ds.cacheNodes.Set(k, v)
 	}
+	for k, v := range ss.cacheBytecode.Iterate() {
+		ds.cacheBytecode.Set(k, v)
+	}
 }
 
 func (ds *defaultStore) GetAllocator() *Allocator {
@@ -349,6 +448,9 @@ func (ds *defaultStore) GetPackageRealm(pkgPath string) (rlm *Realm) {
 	}
 	oid := ObjectIDFromPkgPath(pkgPath)
 	key := backendRealmKey(oid)
+	if ds.access != nil {
+		ds.access.AddRead(key)
+	}
 	bz := ds.baseStore.Get([]byte(key))
 	if bz == nil {
 		return nil
@@ -382,6 +484,9 @@ func (ds *defaultStore) SetPackageRealm(rlm *Realm) {
 	}
 	oid := ObjectIDFromPkgPath(rlm.Path)
 	key := backendRealmKey(oid)
+	if ds.access != nil {
+		ds.access.AddWrite(key)
+	}
 	bz := amino.MustMarshal(rlm)
 	gas := overflow.Mul64p(ds.gasConfig.GasSetPackageRealm, store.Gas(len(bz)))
 	ds.consumeGas(gas, GasSetPackageRealmDesc)
@@ -442,6 +547,9 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 		}()
 	}
 	key := backendObjectKey(oid)
+	if ds.access != nil {
+		ds.access.AddRead(key)
+	}
 	hashbz := ds.baseStore.Get([]byte(key))
 	if hashbz != nil {
 		size = len(hashbz)
@@ -451,7 +559,20 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 		ds.alloc.AllocateAmino(int64(len(bz)))
 		gas := overflow.Mul64p(ds.gasConfig.GasGetObject, store.Gas(len(bz)))
 		ds.consumeGas(gas, GasGetObjectDesc)
-		amino.MustUnmarshal(bz, &oo)
+		if ds.objCache != nil {
+			oo = ds.objCache.get(oid, hash)
+		}
+		if oo != nil {
+			ds.objCacheHits++
+		} else {
+			amino.MustUnmarshal(bz, &oo)
+			if ds.objCache != nil {
+				ds.objCacheMisses++
+				if _, written := ds.objCacheWritten[oid]; !written {
+					ds.objCache.add(oid, hash, oo, int64(len(bz)))
+				}
+			}
+		}
 		if debug {
 			if oo.GetObjectID() != oid {
 				panic(fmt.Sprintf("unexpected object id: expected %v but got %v",
@@ -481,6 +602,10 @@ func (ds *defaultStore) SetObject(oo Object) {
 		}()
 	}
 	oid := oo.GetObjectID()
+	if ds.access != nil {
+		ds.access.AddWrite(backendObjectKey(oid))
+	}
+	ds.invalidateCachedObject(oid)
 	// replace children/fields with Ref.
 	o2 := copyValueWithRefs(oo)
 	// marshal to binary.
@@ -550,6 +675,10 @@ func (ds *defaultStore) DelObject(oo Object) {
 	}
 	ds.consumeGas(ds.gasConfig.GasDeleteObject, GasDeleteObjectDesc)
 	oid := oo.GetObjectID()
+	if ds.access != nil {
+		ds.access.AddWrite(backendObjectKey(oid))
+	}
+	ds.invalidateCachedObject(oid)
 	// delete from cache.
 	delete(ds.cacheObjects, oid)
 	// delete from backend.
@@ -564,6 +693,19 @@ func (ds *defaultStore) DelObject(oo Object) {
 	}
 }
 
+// invalidateCachedObject evicts a written object from the object cache, once
+// the transaction is written if in a transaction store.
+func (ds *defaultStore) invalidateCachedObject(oid ObjectID) {
+	if ds.objCache == nil {
+		return
+	}
+	if ds.objCacheWritten != nil {
+		ds.objCacheWritten[oid] = struct{}{}
+	} else {
+		ds.objCache.remove(oid)
+	}
+}
+
 // NOTE: not used quite yet.
 // NOTE: The implementation matches that of GetObject() in anticipation of what
 // the persistent type system might work like.
@@ -581,6 +723,9 @@ func (ds *defaultStore) GetTypeSafe(tid TypeID) Type {
 		defer bm.ResumeOpCode()
 	}
 
+	if ds.access != nil {
+		ds.access.AddRead(backendTypeKey(tid))
+	}
 	// check cache.
 	if tt, exists := ds.cacheTypes.Get(tid); exists {
 		return tt
@@ -619,6 +764,9 @@ func (ds *defaultStore) SetCacheType(tt Type) {
 			// already set.
 		}
 	} else {
+		if ds.access != nil {
+			ds.access.AddWrite(backendTypeKey(tid))
+		}
 		ds.cacheTypes.Set(tid, tt)
 	}
 }
@@ -645,6 +793,9 @@ func (ds *defaultStore) SetType(tt Type) {
 			return
 		}
 	}
+	if ds.access != nil {
+		ds.access.AddWrite(backendTypeKey(tid))
+	}
 	// save type to backend.
 	if ds.baseStore != nil {
 		key := backendTypeKey(tid)
@@ -681,6 +832,9 @@ func (ds *defaultStore) GetBlockNodeSafe(loc Location) BlockNode {
 			bm.StopStore(size)
 		}()
 	}
+	if ds.access != nil {
+		ds.access.AddRead(backendNodeKey(loc))
+	}
 	// check cache.
 	if bn, exists := ds.cacheNodes.Get(loc); exists {
 		return bn
@@ -711,6 +865,9 @@ func (ds *defaultStore) SetBlockNode(bn BlockNode) {
 	if loc.IsZero() {
 		panic("unexpected zero location in blocknode")
 	}
+	if ds.access != nil {
+		ds.access.AddWrite(backendNodeKey(loc))
+	}
 	// save node to backend.
 	if ds.baseStore != nil {
 		// TODO: implement copyValueWithRefs() for Nodes.
@@ -723,6 +880,29 @@ func (ds *defaultStore) SetBlockNode(bn BlockNode) {
 	// XXX
 }
 
+// getBytecode returns the bytecode of the function declaration at loc, or nil
+// if it has none.
+//
+// Accesses are not recorded: the bytecode is only set along with the
+// BlockNode of its package, and a function is only called through an object
+// of that package.
+func (ds *defaultStore) getBytecode(loc Location) *bcFunc {
+	bc, _ := ds.cacheBytecode.Get(loc)
+	return bc
+}
+
+// setBytecode sets the bytecode of the function declaration at loc, or
+// removes it if bc is nil, as for a function which is no longer supported
+// after an upgrade. Like BlockNodes, bytecode is not persisted to the backend
+// yet, and is compiled again when packages are re-preprocessed upon restart.
+func (ds *defaultStore) setBytecode(loc Location, bc *bcFunc) {
+	if bc == nil {
+		ds.cacheBytecode.Delete(loc)
+		return
+	}
+	ds.cacheBytecode.Set(loc, bc)
+}
+
 func (ds *defaultStore) NumMemPackages() int64 {
 	ctrkey := []byte(backendPackageIndexCtrKey())
 	ctrbz := ds.baseStore.Get(ctrkey)
@@ -836,6 +1016,82 @@ func (ds *defaultStore) GetMemFile(path string, name string) *gnovm.MemFile {
 	return memFile
 }
 
+// UpgradeMemPackage replaces the mempackage stored at memPkg.Path, which
+// must exist, archiving the previous version. Unlike AddMemPackage, the
+// package index is left untouched: upon restart, the package is
+// preprocessed with its latest version.
+func (ds *defaultStore) UpgradeMemPackage(memPkg *gnovm.MemPackage) {
+	if bm.OpsEnabled {
+		bm.PauseOpCode()
+		defer bm.ResumeOpCode()
+	}
+	memPkg.Validate() // NOTE: duplicate validation.
+	pathkey := []byte(backendPackagePathKey(memPkg.Path))
+	oldbz := ds.iavlStore.Get(pathkey)
+	if oldbz == nil {
+		panic(fmt.Sprintf("cannot upgrade missing package %q", memPkg.Path))
+	}
+	// archive the current version.
+	version := ds.NumMemPackageVersions(memPkg.Path)
+	ds.iavlStore.Set([]byte(backendPackageVersionKey(memPkg.Path, version)), oldbz)
+	ds.iavlStore.Set(
+		[]byte(backendPackageVersionCtrKey(memPkg.Path)),
+		[]byte(strconv.FormatUint(version+1, 10)))
+	// store the new version.
+	bz := amino.MustMarshal(memPkg)
+	gas := overflow.Mul64p(ds.gasConfig.GasAddMemPackage, store.Gas(len(bz)+len(oldbz)))
+	ds.consumeGas(gas, GasAddMemPackageDesc)
+	ds.iavlStore.Set(pathkey, bz)
+}
+
+// GetMemPackageVersion retrieves the given version of the MemPackage at the
+// given path, the first version being 1. It returns nil if the package or the
+// version could not be found.
+func (ds *defaultStore) GetMemPackageVersion(path string, version uint64) *gnovm.MemPackage {
+	numVersions := ds.NumMemPackageVersions(path)
+	switch {
+	case version == 0 || version > numVersions:
+		return nil
+	case version == numVersions:
+		return ds.GetMemPackage(path)
+	}
+	bz := ds.iavlStore.Get([]byte(backendPackageVersionKey(path, version)))
+	if bz == nil {
+		panic(fmt.Sprintf("missing version %d of package %q", version, path))
+	}
+	gas := overflow.Mul64p(ds.gasConfig.GasGetMemPackage, store.Gas(len(bz)))
+	ds.consumeGas(gas, GasGetMemPackageDesc)
+
+	var memPkg *gnovm.MemPackage
+	amino.MustUnmarshal(bz, &memPkg)
+	return memPkg
+}
+
+// NumMemPackageVersions returns the number of versions of the MemPackage at
+// the given path; 1 for packages which were never upgraded, 0 for packages
+// which do not exist.
+func (ds *defaultStore) NumMemPackageVersions(path string) uint64 {
+	ctrbz := ds.iavlStore.Get([]byte(backendPackageVersionCtrKey(path)))
+	if ctrbz == nil {
+		if !ds.iavlStore.Has([]byte(backendPackagePathKey(path))) {
+			return 0
+		}
+		return 1
+	}
+	ctr, err := strconv.ParseUint(string(ctrbz), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	return ctr
+}
+
+// SetUpgradedType persists dt with the given base and methods.
+// See [transactionStore.SetUpgradedType].
+func (ds *defaultStore) SetUpgradedType(dt *DeclaredType, base Type, methods []TypedValue) {
+	dt.Base, dt.Methods = base, methods
+	ds.SetType(dt)
+}
+
 func (ds *defaultStore) IterMemPackage() <-chan *gnovm.MemPackage {
 	ctrkey := []byte(backendPackageIndexCtrKey())
 	ctrbz := ds.baseStore.Get(ctrkey)
@@ -881,6 +1137,17 @@ func (ds *defaultStore) ClearObjectCache() {
 	ds.SetCachePackage(Uverse())
 }
 
+// SetObjectCacheSize sets the maximum size, in amino-encoded bytes, of the
+// decoded objects cached across transactions. Objects are still read from the
+// backend store, and charged for, on a hit. A size of 0 disables the cache.
+func (ds *defaultStore) SetObjectCacheSize(size int64) {
+	if size > 0 {
+		ds.objCache = newObjectCache(size)
+	} else {
+		ds.objCache = nil
+	}
+}
+
 func (ds *defaultStore) SetNativeResolver(ns NativeResolver) {
 	ds.nativeResolver = ns
 }
@@ -1017,6 +1284,14 @@ func backendPackagePathKey(path string) string {
 	return "pkg:" + path
 }
 
+func backendPackageVersionCtrKey(path string) string {
+	return "pkgver:" + path + ":counter"
+}
+
+func backendPackageVersionKey(path string, version uint64) string {
+	return fmt.Sprintf("pkgver:%s:%020d", path, version)
+}
+
 // ----------------------------------------
 // builtin types and packages
 
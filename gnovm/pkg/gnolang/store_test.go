@@ -2,6 +2,7 @@ package gnolang
 
 import (
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/gnolang/gno/gnovm"
@@ -54,6 +55,216 @@ func TestTransactionStore(t *testing.T) {
 	assert.Equal(t, txSt.GetType("hello.A"), helloA)
 }
 
+type testAccessRecorder struct {
+	reads, writes map[string]bool
+}
+
+func newTestAccessRecorder() *testAccessRecorder {
+	return &testAccessRecorder{reads: map[string]bool{}, writes: map[string]bool{}}
+}
+
+func (ar *testAccessRecorder) AddRead(key string)  { ar.reads[key] = true }
+func (ar *testAccessRecorder) AddWrite(key string) { ar.writes[key] = true }
+
+func TestTransactionStore_accessRecorder(t *testing.T) {
+	db := memdb.NewMemDB()
+	tm2Store := dbadapter.StoreConstructor(db, storetypes.StoreOptions{})
+	st := NewStore(nil, tm2Store, tm2Store)
+
+	// Add the package in a first transaction.
+	wrapped := tm2Store.CacheWrap()
+	txSt := st.BeginTransaction(wrapped, wrapped, nil)
+	ar := newTestAccessRecorder()
+	txSt.SetAccessRecorder(ar)
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath: "gno.land/r/hello",
+		Store:   txSt,
+		Output:  io.Discard,
+	})
+	m.RunMemPackage(&gnovm.MemPackage{
+		Name: "hello",
+		Path: "gno.land/r/hello",
+		Files: []*gnovm.MemFile{
+			{Name: "hello.gno", Body: "package hello; var x A; type A struct{ n int }; func Inc() { x.n++ }"},
+		},
+	}, true)
+	m.Release()
+	txSt.Write()
+	wrapped.Write()
+
+	pkgNodeKey := backendNodeKey(PackageNodeLocation("gno.land/r/hello"))
+	pkgKey := backendObjectKey(ObjectIDFromPkgPath("gno.land/r/hello"))
+	assert.True(t, ar.writes[pkgNodeKey])
+	assert.True(t, ar.writes[pkgKey])
+	assert.True(t, ar.writes[backendTypeKey("gno.land/r/hello.A")])
+
+	// Call Inc in a second one, reading what the first one wrote.
+	wrapped = tm2Store.CacheWrap()
+	txSt = st.BeginTransaction(wrapped, wrapped, nil)
+	ar = newTestAccessRecorder()
+	txSt.SetAccessRecorder(ar)
+	m = NewMachineWithOptions(MachineOptions{
+		PkgPath: "gno.land/r/hello",
+		Store:   txSt,
+		Output:  io.Discard,
+	})
+	pv := txSt.GetPackage("gno.land/r/hello", false)
+	m.SetActivePackage(pv)
+	m.Eval(Call(Nx("Inc")))
+	pv.GetRealm().FinalizeRealmTransaction(false, txSt)
+	m.Release()
+
+	assert.True(t, ar.reads[pkgNodeKey])
+	assert.True(t, ar.reads[pkgKey])
+	assert.True(t, ar.reads[backendTypeKey("gno.land/r/hello.A")])
+	var objectWrites int
+	for key := range ar.writes {
+		if strings.HasPrefix(key, "oid:") {
+			objectWrites++
+		}
+	}
+	assert.Equal(t, 1, objectWrites, "x")
+	assert.False(t, ar.writes[pkgNodeKey])
+
+	// Without a recorder, nothing is recorded.
+	txSt.SetAccessRecorder(nil)
+	nreads := len(ar.reads)
+	txSt.GetBlockNodeSafe(PackageNodeLocation("fmt"))
+	assert.Equal(t, nreads, len(ar.reads))
+}
+
+func TestTransactionStore_objectCache(t *testing.T) {
+	db := memdb.NewMemDB()
+	tm2Store := dbadapter.StoreConstructor(db, storetypes.StoreOptions{})
+	iavlStore := dbadapter.StoreConstructor(memdb.NewMemDB(), storetypes.StoreOptions{})
+	st := NewStore(nil, tm2Store, iavlStore)
+	st.SetObjectCacheSize(1 << 20)
+
+	const pkgPath = "gno.land/r/hello"
+	wrapped, wrappedIavl := tm2Store.CacheWrap(), iavlStore.CacheWrap()
+	txSt := st.BeginTransaction(wrapped, wrappedIavl, nil)
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath: pkgPath,
+		Store:   txSt,
+		Output:  io.Discard,
+	})
+	m.RunMemPackage(&gnovm.MemPackage{
+		Name: "hello",
+		Path: pkgPath,
+		Files: []*gnovm.MemFile{
+			{Name: "hello.gno", Body: "package hello; var x A; type A struct{ n int }; func Inc() int { x.n++; return x.n }; func Get() int { return x.n }"},
+		},
+	}, true)
+	m.Release()
+	txSt.Write()
+	wrapped.Write()
+	wrappedIavl.Write()
+
+	type result struct {
+		res          string
+		gas          int64
+		hits, misses int64
+	}
+	call := func(fn Name, write bool) result {
+		wrapped, wrappedIavl := tm2Store.CacheWrap(), iavlStore.CacheWrap()
+		gasMeter := storetypes.NewInfiniteGasMeter()
+		txSt := st.BeginTransaction(wrapped, wrappedIavl, gasMeter)
+		m := NewMachineWithOptions(MachineOptions{
+			PkgPath: pkgPath,
+			Store:   txSt,
+			Output:  io.Discard,
+		})
+		defer m.Release()
+		pv := txSt.GetPackage(pkgPath, false)
+		m.SetActivePackage(pv)
+		res := m.Eval(Call(Nx(fn)))
+		pv.GetRealm().FinalizeRealmTransaction(false, txSt)
+		if write {
+			txSt.Write()
+			wrapped.Write()
+			wrappedIavl.Write()
+		}
+		hits, misses := txSt.ObjectCacheStats()
+		return result{res[0].String(), gasMeter.GasConsumed(), hits, misses}
+	}
+
+	// The first read misses, the following ones hit and cost as much gas.
+	first := call("Get", false)
+	assert.Equal(t, "(0 int)", first.res)
+	assert.Zero(t, first.hits)
+	assert.NotZero(t, first.misses)
+	second := call("Get", false)
+	assert.Equal(t, first.res, second.res)
+	assert.Equal(t, first.gas, second.gas)
+	assert.Equal(t, first.misses, second.hits)
+	assert.Zero(t, second.misses)
+
+	// Modifying the copies of cached objects doesn't modify the cache,
+	// so a rolled back transaction leaves no trace.
+	assert.Equal(t, "(1 int)", call("Inc", false).res)
+	afterRollback := call("Get", false)
+	assert.Equal(t, second, afterRollback)
+
+	// Written objects are evicted, and read again from the store.
+	assert.Equal(t, "(1 int)", call("Inc", true).res)
+	afterWrite := call("Get", false)
+	assert.Equal(t, "(1 int)", afterWrite.res)
+	assert.NotZero(t, afterWrite.misses)
+	assert.Equal(t, first.misses, afterWrite.hits+afterWrite.misses)
+	assert.Equal(t, "(2 int)", call("Inc", true).res)
+
+	// Without the cache, the same gas is consumed.
+	st.SetObjectCacheSize(0)
+	uncached := call("Get", false)
+	assert.Equal(t, "(2 int)", uncached.res)
+	assert.Zero(t, uncached.hits+uncached.misses)
+	st.SetObjectCacheSize(1 << 20)
+	assert.Equal(t, uncached.gas, call("Get", false).gas)
+	assert.Equal(t, uncached.gas, call("Get", false).gas)
+}
+
+func TestObjectCache_eviction(t *testing.T) {
+	oc := newObjectCache(10)
+	object := func(i uint64) (ObjectID, Object) {
+		oid := ObjectID{NewTime: i}
+		return oid, &StructValue{ObjectInfo: ObjectInfo{ID: oid}, Fields: []TypedValue{}}
+	}
+	hash := []byte("hash")
+
+	for i := uint64(1); i <= 3; i++ {
+		oid, oo := object(i)
+		oc.add(oid, hash, oo, 4)
+	}
+	// the least recently used object was evicted.
+	oid1, _ := object(1)
+	oid2, _ := object(2)
+	oid3, oo3 := object(3)
+	assert.Nil(t, oc.get(oid1, hash))
+	assert.Equal(t, int64(8), oc.size)
+
+	// a hit is a copy, and requires the same hash.
+	assert.NotNil(t, oc.get(oid2, hash))
+	assert.Nil(t, oc.get(oid2, []byte("other")))
+	cpy := oc.get(oid3, hash)
+	assert.Equal(t, oo3, cpy)
+	assert.NotSame(t, oo3, cpy)
+
+	// oid2 is now the least recently used.
+	oid4, oo4 := object(4)
+	oc.add(oid4, hash, oo4, 4)
+	assert.Nil(t, oc.get(oid2, hash))
+	assert.NotNil(t, oc.get(oid3, hash))
+
+	// objects larger than the cache are not cached.
+	oid5, oo5 := object(5)
+	oc.add(oid5, hash, oo5, 11)
+	assert.Nil(t, oc.get(oid5, hash))
+
+	oc.remove(oid3)
+	assert.Nil(t, oc.get(oid3, hash))
+	assert.Equal(t, int64(4), oc.size)
+}
+
 func TestTransactionStore_blockedMethods(t *testing.T) {
 	// These methods should panic as they modify store settings, which should
 	// only be changed in the root store.
@@ -1427,8 +1427,9 @@ type DeclaredType struct {
 	Base    Type         // not a DeclaredType
 	Methods []TypedValue // {T:*FuncType,V:*FuncValue}...
 
-	typeid TypeID
-	sealed bool // for ensuring correctness with recursive types.
+	typeid    TypeID
+	sealed    bool          // for ensuring correctness with recursive types.
+	upgrading map[Name]bool // methods to be redefined by a package upgrade.
 }
 
 // returns an unsealed *DeclaredType.
@@ -1530,6 +1531,22 @@ func (dt *DeclaredType) TryDefineMethod(fv *FuncValue) bool {
 			continue
 		}
 
+		// Special case: the method is being redefined by an
+		// upgrade of the package, see [Machine.UpgradeMemPackage].
+		// Keep the method index, so that preprocessed selectors
+		// of dependent packages still resolve to it.
+		if dt.upgrading[name] {
+			if fv.Type.TypeID() != ofv.Type.TypeID() {
+				return false
+			}
+			dt.Methods[i] = TypedValue{
+				T: tv.T, // keep old type.
+				V: fv,
+			}
+			delete(dt.upgrading, name)
+			return true
+		}
+
 		// Do not allow redeclaring (override) a method.
 		// In the future we may allow this, just like we
 		// allow package-level function overrides.
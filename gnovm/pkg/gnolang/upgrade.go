@@ -0,0 +1,747 @@
+package gnolang
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm"
+	"go.uber.org/multierr"
+)
+
+// Realm upgrades.
+//
+// A realm is upgraded in place: its *PackageValue, package block and all the
+// objects reachable from its package-level variables are kept, while the
+// source, the file blocks, and the functions and methods are replaced.
+//
+// Upgrades must be backwards compatible with the values persisted in the
+// realm, and with the packages importing it:
+//
+//   - declared types cannot be removed, nor change their underlying type,
+//     except for unexported struct types, which may be extended with new
+//     fields after the existing ones;
+//   - methods cannot be removed, nor change their signature;
+//   - exported functions, constants and variables cannot be removed, nor
+//     change their type (or value, for constants).
+//
+// Unexported package-level variables may be removed or change type, as long as
+// the new version declares a migration function, which receives their previous
+// values as parameters of the same name and type:
+//
+//	func migrate(oldVar1 OldType1, oldVar2 OldType2) { ... }
+//
+// The migration function must also be declared when struct types are extended:
+// their values reachable from the package-level variables are rewritten with
+// the zero value of the new fields, which the migration function may then set.
+//
+// The migration function, if declared, is run once after the upgrade; init
+// functions are not run. The remaining package-level variables keep their
+// values; their initializers in the new version are evaluated, and discarded.
+//
+// NOTE: function values persisted in the realm state (ie. closures, or
+// functions stored in variables) keep referring to the source they were
+// created from. They should be replaced by the migration function.
+// Values of an extended struct type cannot be used as map keys, and must not
+// be stored by other realms.
+
+// upgradeMigrateFunc is the name of the migration function of an upgrade.
+const upgradeMigrateFunc = "migrate"
+
+// CheckMemPackageUpgrade checks that newPkg can replace oldPkg, the version of
+// the package currently stored, according to the rules of realm upgrades.
+// To retrieve dependencies, it uses getter.
+// Both packages are expected to be valid, and to type check.
+func CheckMemPackageUpgrade(oldPkg, newPkg *gnovm.MemPackage, getter MemPackageGetter) error {
+	if oldPkg.Path != newPkg.Path {
+		return fmt.Errorf("package path cannot change from %q to %q", oldPkg.Path, newPkg.Path)
+	}
+	if oldPkg.Name != newPkg.Name {
+		return fmt.Errorf("package name cannot change from %q to %q", oldPkg.Name, newPkg.Name)
+	}
+
+	oldTypes, err := typeCheckUpgradeVersion(oldPkg, getter)
+	if err != nil {
+		return fmt.Errorf("unable to type check the current version: %w", err)
+	}
+	newTypes, err := typeCheckUpgradeVersion(newPkg, getter)
+	if err != nil {
+		return fmt.Errorf("unable to type check the new version: %w", err)
+	}
+
+	var (
+		errs     error
+		dropped  []*types.Var // variables to be migrated.
+		extended []string     // struct types to be migrated.
+
+		oldScope = oldTypes.Scope()
+		newScope = newTypes.Scope()
+	)
+	for _, name := range oldScope.Names() {
+		switch oldObj := oldScope.Lookup(name).(type) {
+		case *types.TypeName:
+			ext, err := checkUpgradeTypeName(oldObj, newScope.Lookup(name))
+			if ext {
+				extended = append(extended, name)
+			}
+			errs = multierr.Append(errs, err)
+		case *types.Var:
+			newVar, ok := newScope.Lookup(name).(*types.Var)
+			if ok && upgradeTypeString(oldObj.Type()) == upgradeTypeString(newVar.Type()) {
+				continue
+			}
+			if oldObj.Exported() {
+				errs = multierr.Append(errs, fmt.Errorf(
+					"exported variable %s cannot be removed or change type", name))
+				continue
+			}
+			dropped = append(dropped, oldObj)
+		case *types.Const:
+			if !oldObj.Exported() {
+				continue
+			}
+			newConst, ok := newScope.Lookup(name).(*types.Const)
+			if !ok ||
+				upgradeTypeString(oldObj.Type()) != upgradeTypeString(newConst.Type()) ||
+				!constant.Compare(oldObj.Val(), token.EQL, newConst.Val()) {
+				errs = multierr.Append(errs, fmt.Errorf(
+					"exported constant %s cannot be removed or change type or value", name))
+			}
+		case *types.Func:
+			if !oldObj.Exported() {
+				continue
+			}
+			newFunc, ok := newScope.Lookup(name).(*types.Func)
+			if !ok || upgradeTypeString(oldObj.Type()) != upgradeTypeString(newFunc.Type()) {
+				errs = multierr.Append(errs, fmt.Errorf(
+					"exported function %s cannot be removed or change signature", name))
+			}
+		}
+	}
+	return multierr.Append(errs, checkUpgradeMigration(newScope, dropped, extended))
+}
+
+// typeCheckUpgradeVersion type checks a version of an upgraded package.
+func typeCheckUpgradeVersion(mpkg *gnovm.MemPackage, getter MemPackageGetter) (*types.Package, error) {
+	var errs error
+	imp := &gnoImporter{
+		getter: getter,
+		cache:  map[string]gnoImporterResult{},
+		cfg: &types.Config{
+			Error: func(err error) {
+				errs = multierr.Append(errs, err)
+			},
+		},
+	}
+	imp.cfg.Importer = imp
+
+	pkg, err := imp.parseCheckMemPackage(mpkg, false)
+	if errs != nil {
+		return nil, errs
+	}
+	return pkg, err
+}
+
+// upgradeTypeString returns the string representation of t used to compare
+// the types of two versions of a package, which are type checked separately.
+func upgradeTypeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		return pkg.Path()
+	})
+}
+
+// checkUpgradeTypeName checks the new version of a type declaration. It
+// returns whether the type is a struct type extended with new fields.
+func checkUpgradeTypeName(oldType *types.TypeName, newObj types.Object) (extended bool, err error) {
+	name := oldType.Name()
+	newType, ok := newObj.(*types.TypeName)
+	if !ok {
+		return false, fmt.Errorf("type %s cannot be removed", name)
+	}
+	if oldType.IsAlias() != newType.IsAlias() {
+		return false, fmt.Errorf("type %s cannot change between an alias and a declared type", name)
+	}
+	if oldType.IsAlias() {
+		if upgradeTypeString(oldType.Type()) != upgradeTypeString(newType.Type()) {
+			return false, fmt.Errorf("type alias %s cannot change", name)
+		}
+		return false, nil
+	}
+
+	oldNamed, newNamed := oldType.Type().(*types.Named), newType.Type().(*types.Named)
+	if upgradeTypeString(oldNamed.Underlying()) != upgradeTypeString(newNamed.Underlying()) {
+		if err := checkUpgradeStructFields(oldType, newNamed); err != nil {
+			return false, err
+		}
+		extended = true
+	}
+
+	for i := 0; i < oldNamed.NumMethods(); i++ {
+		oldMethod := oldNamed.Method(i)
+		var newMethod *types.Func
+		for j := 0; j < newNamed.NumMethods(); j++ {
+			if newNamed.Method(j).Name() == oldMethod.Name() {
+				newMethod = newNamed.Method(j)
+				break
+			}
+		}
+		if newMethod == nil ||
+			upgradeTypeString(oldMethod.Type()) != upgradeTypeString(newMethod.Type()) ||
+			upgradeTypeString(oldMethod.Type().(*types.Signature).Recv().Type()) !=
+				upgradeTypeString(newMethod.Type().(*types.Signature).Recv().Type()) {
+			err = multierr.Append(err, fmt.Errorf(
+				"method %s.%s cannot be removed or change signature", name, oldMethod.Name()))
+		}
+	}
+	return extended, err
+}
+
+// checkUpgradeStructFields checks that the new version of a declared type,
+// whose underlying type changes, only adds fields to an unexported struct type.
+func checkUpgradeStructFields(oldType *types.TypeName, newNamed *types.Named) error {
+	name := oldType.Name()
+	if oldType.Exported() {
+		return fmt.Errorf("exported type %s cannot change its underlying type", name)
+	}
+	oldStruct, ok := oldType.Type().Underlying().(*types.Struct)
+	if !ok {
+		return fmt.Errorf("type %s cannot change its underlying type", name)
+	}
+	newStruct, ok := newNamed.Underlying().(*types.Struct)
+	if !ok || newStruct.NumFields() < oldStruct.NumFields() {
+		return fmt.Errorf("type %s can only change by adding struct fields", name)
+	}
+	for i := 0; i < oldStruct.NumFields(); i++ {
+		oldField, newField := oldStruct.Field(i), newStruct.Field(i)
+		if oldField.Name() != newField.Name() ||
+			oldField.Embedded() != newField.Embedded() ||
+			oldStruct.Tag(i) != newStruct.Tag(i) ||
+			upgradeTypeString(oldField.Type()) != upgradeTypeString(newField.Type()) {
+			return fmt.Errorf("type %s can only change by adding struct fields after the existing ones", name)
+		}
+	}
+	for i := oldStruct.NumFields(); i < newStruct.NumFields(); i++ {
+		if upgradeTypeRefers(newStruct.Field(i).Type(), newNamed) {
+			return fmt.Errorf("new field %s of type %s cannot refer to %s", newStruct.Field(i).Name(), name, name)
+		}
+	}
+	return nil
+}
+
+// upgradeTypeRefers returns whether t refers to the declared type named,
+// other than through another declared type.
+func upgradeTypeRefers(t types.Type, named *types.Named) bool {
+	switch t := t.(type) {
+	case *types.Named:
+		return t.Obj() == named.Obj()
+	case *types.Pointer:
+		return upgradeTypeRefers(t.Elem(), named)
+	case *types.Slice:
+		return upgradeTypeRefers(t.Elem(), named)
+	case *types.Array:
+		return upgradeTypeRefers(t.Elem(), named)
+	case *types.Chan:
+		return upgradeTypeRefers(t.Elem(), named)
+	case *types.Map:
+		return upgradeTypeRefers(t.Key(), named) || upgradeTypeRefers(t.Elem(), named)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if upgradeTypeRefers(t.Field(i).Type(), named) {
+				return true
+			}
+		}
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			if upgradeTypeRefers(t.At(i).Type(), named) {
+				return true
+			}
+		}
+	case *types.Signature:
+		return upgradeTypeRefers(t.Params(), named) || upgradeTypeRefers(t.Results(), named)
+	case *types.Interface:
+		for i := 0; i < t.NumExplicitMethods(); i++ {
+			if upgradeTypeRefers(t.ExplicitMethod(i).Type(), named) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkUpgradeMigration checks that the migration function of the new version
+// takes exactly the dropped variables as parameters, and that it is declared
+// if struct types are extended.
+func checkUpgradeMigration(newScope *types.Scope, dropped []*types.Var, extended []string) error {
+	obj := newScope.Lookup(upgradeMigrateFunc)
+	if obj == nil {
+		var errs error
+		if len(dropped) > 0 {
+			names := make([]string, len(dropped))
+			for i, v := range dropped {
+				names[i] = v.Name()
+			}
+			errs = multierr.Append(errs, fmt.Errorf(
+				"variables %s are removed or change type, and must be migrated by declaring func %s(%s)",
+				strings.Join(names, ", "), upgradeMigrateFunc, formatUpgradeParams(dropped)))
+		}
+		if len(extended) > 0 {
+			errs = multierr.Append(errs, fmt.Errorf(
+				"types %s are extended, and must be migrated by declaring func %s(%s)",
+				strings.Join(extended, ", "), upgradeMigrateFunc, formatUpgradeParams(dropped)))
+		}
+		return errs
+	}
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return fmt.Errorf("%s must be a function", upgradeMigrateFunc)
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Results().Len() > 0 || sig.Variadic() || !sameUpgradeParams(sig.Params(), dropped) {
+		return fmt.Errorf(
+			"migration function must be declared as func %s(%s)",
+			upgradeMigrateFunc, formatUpgradeParams(dropped))
+	}
+	return nil
+}
+
+func sameUpgradeParams(params *types.Tuple, dropped []*types.Var) bool {
+	if params.Len() != len(dropped) {
+		return false
+	}
+	for i := 0; i < params.Len(); i++ {
+		param := params.At(i)
+		idx := slices.IndexFunc(dropped, func(v *types.Var) bool {
+			return v.Name() == param.Name()
+		})
+		if idx < 0 || upgradeTypeString(dropped[idx].Type()) != upgradeTypeString(param.Type()) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatUpgradeParams(vars []*types.Var) string {
+	params := make([]string, len(vars))
+	for i, v := range vars {
+		params[i] = v.Name() + " " + types.TypeString(v.Type(), types.RelativeTo(v.Pkg()))
+	}
+	return strings.Join(params, ", ")
+}
+
+//----------------------------------------
+// Machine
+
+// UpgradeMemPackage upgrades the realm at memPkg.Path, which must exist, to the
+// new version of its source, migrating its state, and saves the new mempackage
+// to the store. The new version must have been checked with
+// [CheckMemPackageUpgrade]; the checks done here are only a safeguard.
+func (m *Machine) UpgradeMemPackage(memPkg *gnovm.MemPackage) {
+	// parse files.
+	files := ParseMemPackage(memPkg)
+	if err := checkDuplicates(files); err != nil {
+		panic(fmt.Errorf("upgrading package %q: %w", memPkg.Path, err))
+	}
+	pv := m.Store.GetPackage(memPkg.Path, false)
+	if pv == nil || !pv.IsRealm() {
+		panic(fmt.Sprintf("cannot upgrade %q: not an existing realm", memPkg.Path))
+	}
+	if string(pv.PkgName) != memPkg.Name {
+		panic(fmt.Sprintf("cannot upgrade %q: package name cannot change from %s to %s",
+			memPkg.Path, pv.PkgName, memPkg.Name))
+	}
+	rlm := pv.GetRealm()
+	ob := pv.GetBlock(m.Store)
+	opn := ob.GetSource(m.Store).(*PackageNode)
+
+	// Snapshot the current version.
+	oldNames := slices.Clone(opn.GetBlockNames())
+	oldVars := fileSetVarNames(opn.FileSet)
+	oldValues := make(map[Name]TypedValue, len(oldVars))
+	oldStaticTypes := make(map[Name]Type, len(oldVars))
+	for i, n := range oldNames {
+		if oldVars[n] {
+			oldValues[n] = *ob.GetPointerToInt(m.Store, i).TV
+			oldStaticTypes[n] = opn.Types[i]
+		}
+	}
+	oldFNames, oldFBlocks := pv.FNames, pv.FBlocks
+
+	// Methods of the declared types are redefined in place,
+	// see [DeclaredType.TryDefineMethod], and struct types may be
+	// extended, see [DeclaredType.upgradeBase].
+	var (
+		oldTypes   []*DeclaredType
+		oldBases   []Type
+		oldMethods [][]TypedValue
+	)
+	for _, fn := range opn.FileSet.Files {
+		for _, d := range fn.Decls {
+			if td, ok := d.(*TypeDecl); ok && !td.IsAlias {
+				dt := m.Store.GetType(DeclaredTypeID(pv.PkgPath, td.Name)).(*DeclaredType)
+				oldTypes = append(oldTypes, dt)
+				oldBases = append(oldBases, dt.Base)
+				oldMethods = append(oldMethods, slices.Clone(dt.Methods))
+			}
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			for i, dt := range oldTypes {
+				dt.Base = oldBases[i]
+				dt.Methods = oldMethods[i]
+				dt.upgrading = nil
+			}
+			pv.Block, pv.FNames, pv.FBlocks, pv.fBlocksMap = ob, oldFNames, oldFBlocks, nil
+			panic(r)
+		}
+	}()
+	for _, dt := range oldTypes {
+		dt.upgrading = make(map[Name]bool, len(dt.Methods))
+		for _, mtv := range dt.Methods {
+			dt.upgrading[mtv.V.(*FuncValue).Name] = true
+		}
+	}
+
+	// Run the new version on a new package node and block, keeping the
+	// layout of the package block, as the preprocessed selectors of
+	// dependent packages refer to its indices.
+	// As the new block is not real, the new values are only attached to
+	// the realm once the migration is done.
+	pn := NewPackageNode(Name(memPkg.Name), memPkg.Path, &FileSet{})
+	predefineNames(pn, files, oldNames)
+	nb := &Block{Source: pn}
+	pv.Block = nb
+	pv.FNames, pv.FBlocks, pv.fBlocksMap = nil, nil, make(map[Name]*Block)
+	m.Store.SetBlockNode(pn)
+	m.SetActivePackage(pv)
+	m.runFileDecls(files.Files...)
+
+	extended := make(map[*DeclaredType]int)
+	for i, dt := range oldTypes {
+		if len(dt.upgrading) > 0 {
+			panic(fmt.Sprintf("cannot upgrade %q: methods of %s cannot be removed",
+				memPkg.Path, dt.Name))
+		}
+		dt.upgrading = nil
+		if dt.Base != oldBases[i] {
+			extended[dt] = len(oldBases[i].(*StructType).Fields)
+		}
+	}
+
+	// Keep the variables of the same name and type, collect the others.
+	newVars := fileSetVarNames(files)
+	carried := make(map[int]bool, len(oldVars))
+	dropped := make(map[Name]TypedValue)
+	for i, n := range oldNames {
+		if !oldVars[n] {
+			continue
+		}
+		ost, nst := oldStaticTypes[n], pn.Types[i]
+		if newVars[n] && ost != nil && nst != nil && ost.TypeID() == nst.TypeID() {
+			nb.Values[i] = oldValues[n]
+			carried[i] = true
+		} else {
+			dropped[n] = oldValues[n]
+		}
+	}
+	if len(extended) > 0 {
+		roots := make([]*TypedValue, 0, len(oldValues))
+		for _, n := range oldNames {
+			if tv, ok := oldValues[n]; ok {
+				roots = append(roots, &tv)
+			}
+		}
+		u := &structUpgrader{
+			store:    m.Store,
+			alloc:    m.Alloc,
+			rlm:      rlm,
+			extended: extended,
+			visited:  make(map[Object]bool),
+		}
+		u.upgrade(roots)
+	}
+	m.runMigration(files, dropped, len(extended) > 0)
+
+	// Attach the new version to the realm.
+	for _, fb := range pv.FBlocks {
+		fb.(*Block).Parent = ob
+	}
+	ob.Source = pn
+	ob.Values = nb.Values
+	pv.Block = ob
+	rlm.MarkDirty(pv)
+	rlm.MarkDirty(ob)
+	for _, fb := range pv.FBlocks {
+		rlm.DidUpdate(pv, nil, fb.(*Block))
+	}
+	for i := range ob.Values {
+		if carried[i] {
+			continue
+		}
+		if oo := ob.Values[i].GetFirstObject(m.Store); oo != nil {
+			rlm.DidUpdate(ob, nil, oo)
+		}
+	}
+	for _, tv := range dropped {
+		if oo := tv.GetFirstObject(m.Store); oo != nil {
+			rlm.DidUpdate(ob, oo, nil)
+		}
+	}
+	// NOTE: the previous file blocks, and function values, are not
+	// released; the imported packages referenced by the file blocks
+	// must not be released.
+	rlm.Names = slices.Clone(pn.GetBlockNames())
+	rlm.FinalizeRealmTransaction(m.ReadOnly, m.Store)
+	m.Store.SetPackageRealm(rlm)
+
+	// Save declared types.
+	for _, tv := range ob.Values {
+		tvv, ok := tv.V.(TypeValue)
+		if !ok {
+			continue
+		}
+		dt, ok := tvv.Type.(*DeclaredType)
+		if !ok || dt.PkgPath != pv.PkgPath {
+			continue
+		}
+		if idx := slices.Index(oldTypes, dt); idx >= 0 {
+			base, methods := dt.Base, dt.Methods
+			dt.Base, dt.Methods = oldBases[idx], oldMethods[idx]
+			m.Store.SetUpgradedType(dt, base, methods)
+		} else {
+			m.Store.SetType(dt)
+		}
+	}
+
+	// Save the new version of the mempackage.
+	m.Store.UpgradeMemPackage(memPkg)
+}
+
+// runMigration runs the migration function of an upgraded package, if any,
+// passing it the values of the variables which were dropped. The migration
+// function is required if variables were dropped, or struct types extended.
+func (m *Machine) runMigration(files *FileSet, dropped map[Name]TypedValue, extended bool) {
+	var migrate *FuncDecl
+	for _, fn := range files.Files {
+		for _, d := range fn.Decls {
+			if fd, ok := d.(*FuncDecl); ok && !fd.IsMethod && fd.Name == upgradeMigrateFunc {
+				migrate = fd
+			}
+		}
+	}
+	if migrate == nil {
+		if len(dropped) > 0 || extended {
+			panic(fmt.Sprintf("cannot upgrade %q: variables and types must be migrated by func %s",
+				m.Package.PkgPath, upgradeMigrateFunc))
+		}
+		return
+	}
+	if len(migrate.Type.Params) != len(dropped) {
+		panic(fmt.Sprintf("cannot upgrade %q: invalid parameters of func %s",
+			m.Package.PkgPath, upgradeMigrateFunc))
+	}
+	args := make([]interface{}, len(migrate.Type.Params))
+	for i, param := range migrate.Type.Params {
+		tv, ok := dropped[param.Name]
+		if !ok {
+			panic(fmt.Sprintf("cannot upgrade %q: invalid parameter %s of func %s",
+				m.Package.PkgPath, param.Name, upgradeMigrateFunc))
+		}
+		args[i] = &ConstExpr{TypedValue: tv}
+	}
+	m.Eval(Call(Nx(upgradeMigrateFunc), args...))
+}
+
+// upgradeBase replaces the base of dt, a type being redeclared by an upgrade
+// of its package, with its new base. Only struct types may change, by adding
+// fields after the existing ones.
+func (dt *DeclaredType) upgradeBase(base Type) {
+	if base.TypeID() == dt.Base.TypeID() {
+		return
+	}
+	ost, ok := dt.Base.(*StructType)
+	if !ok {
+		panic(fmt.Sprintf("cannot upgrade %q: type %s cannot change its underlying type",
+			dt.PkgPath, dt.Name))
+	}
+	nst, ok := base.(*StructType)
+	if !ok || len(nst.Fields) < len(ost.Fields) {
+		panic(fmt.Sprintf("cannot upgrade %q: type %s can only change by adding struct fields",
+			dt.PkgPath, dt.Name))
+	}
+	for i, f := range ost.Fields {
+		nf := nst.Fields[i]
+		if f.Name != nf.Name || f.Embedded != nf.Embedded || f.Tag != nf.Tag ||
+			f.Type.TypeID() != nf.Type.TypeID() {
+			panic(fmt.Sprintf("cannot upgrade %q: type %s can only change by adding struct fields after the existing ones",
+				dt.PkgPath, dt.Name))
+		}
+	}
+	// Keep the existing fields, as their types may refer to dt; the new
+	// fields refer to the type predefined by the preprocessor instead,
+	// which is why [CheckMemPackageUpgrade] refuses such fields.
+	fields := make([]FieldType, 0, len(nst.Fields))
+	fields = append(fields, ost.Fields...)
+	fields = append(fields, nst.Fields[len(ost.Fields):]...)
+	dt.Base = &StructType{PkgPath: ost.PkgPath, Fields: fields}
+}
+
+// structUpgrader rewrites the values of the struct types extended by an
+// upgrade, reachable from the package-level variables of the realm, to the
+// new layout of their type.
+type structUpgrader struct {
+	store    Store
+	alloc    *Allocator
+	rlm      *Realm
+	extended map[*DeclaredType]int // number of fields of the previous version.
+	visited  map[Object]bool
+	stack    []*TypedValue
+}
+
+func (u *structUpgrader) upgrade(roots []*TypedValue) {
+	u.stack = roots
+	for len(u.stack) > 0 {
+		tv := u.stack[len(u.stack)-1]
+		u.stack = u.stack[:len(u.stack)-1]
+		u.upgradeValue(tv)
+	}
+}
+
+func (u *structUpgrader) upgradeValue(tv *TypedValue) {
+	if tv.T == nil {
+		return
+	}
+	if _, ok := tv.T.(*PackageType); ok {
+		return // other packages are not walked.
+	}
+	fillValueTV(u.store, tv)
+	switch cv := tv.V.(type) {
+	case *StructValue:
+		if dt, ok := tv.T.(*DeclaredType); ok {
+			u.upgradeStruct(dt, cv)
+		}
+		u.pushObject(cv)
+	case PointerValue:
+		u.pushObject(cv.Base)
+	case *SliceValue:
+		if base := cv.GetBase(u.store); base != nil {
+			u.pushObject(base)
+		}
+	case *FuncValue:
+		for i := range cv.Captures {
+			u.stack = append(u.stack, &cv.Captures[i])
+		}
+	case Object:
+		u.pushObject(cv)
+	}
+}
+
+// pushObject pushes the values held by oo, if not yet visited.
+func (u *structUpgrader) pushObject(v Value) {
+	oo, ok := v.(Object)
+	if !ok || u.visited[oo] {
+		return
+	}
+	u.visited[oo] = true
+	switch cv := oo.(type) {
+	case *ArrayValue:
+		for i := range cv.List {
+			u.stack = append(u.stack, &cv.List[i])
+		}
+	case *StructValue:
+		for i := range cv.Fields {
+			u.stack = append(u.stack, &cv.Fields[i])
+		}
+	case *MapValue:
+		for cur := cv.List.Head; cur != nil; cur = cur.Next {
+			if u.isExtended(cur.Key.T) {
+				panic(fmt.Sprintf("cannot upgrade %q: extended type %s is used as a map key",
+					u.rlm.Path, cur.Key.T.String()))
+			}
+			u.stack = append(u.stack, &cur.Value)
+		}
+	case *HeapItemValue:
+		u.stack = append(u.stack, &cv.Value)
+	case *BoundMethodValue:
+		u.stack = append(u.stack, &cv.Receiver)
+	}
+}
+
+// upgradeStruct appends the zero value of the new fields of dt to sv.
+func (u *structUpgrader) upgradeStruct(dt *DeclaredType, sv *StructValue) {
+	n, ok := u.extended[dt]
+	if !ok || len(sv.Fields) != n {
+		return // not extended, or already upgraded.
+	}
+	if sv.GetIsReal() && sv.GetObjectID().PkgID != u.rlm.ID {
+		panic(fmt.Sprintf("cannot upgrade %q: a value of extended type %s is stored by another realm",
+			u.rlm.Path, dt.Name))
+	}
+	fields := dt.Base.(*StructType).Fields[n:]
+	u.alloc.AllocateStructFields(int64(len(fields)))
+	for _, f := range fields {
+		ftv := defaultTypedValue(u.alloc, f.Type)
+		sv.Fields = append(sv.Fields, ftv)
+		u.rlm.DidUpdate(sv, nil, ftv.GetFirstObject(u.store))
+	}
+}
+
+// isExtended returns whether values of type t hold values of an extended
+// struct type, other than through pointers.
+func (u *structUpgrader) isExtended(t Type) bool {
+	switch ct := t.(type) {
+	case *DeclaredType:
+		if _, ok := u.extended[ct]; ok {
+			return true
+		}
+		return u.isExtended(ct.Base)
+	case *StructType:
+		for _, f := range ct.Fields {
+			if u.isExtended(f.Type) {
+				return true
+			}
+		}
+	case *ArrayType:
+		return u.isExtended(ct.Elt)
+	}
+	return false
+}
+
+// fileSetVarNames returns the names of the package-level variables of fset.
+func fileSetVarNames(fset *FileSet) map[Name]bool {
+	names := make(map[Name]bool)
+	for _, fn := range fset.Files {
+		for _, d := range fn.Decls {
+			if vd, ok := d.(*ValueDecl); ok && !vd.Const {
+				for _, nx := range vd.NameExprs {
+					names[nx.Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// predefineNames reserves the given names in the package block of pn, in
+// order, so that an upgraded package keeps the block layout of its previous
+// versions. The const status of the names is taken from their declaration in
+// fset; names which are no longer declared are left undefined.
+func predefineNames(pn *PackageNode, fset *FileSet, names []Name) {
+	consts := make(map[Name]bool)
+	for _, fn := range fset.Files {
+		for _, d := range fn.Decls {
+			if vd, ok := d.(*ValueDecl); ok && vd.Const {
+				for _, nx := range vd.NameExprs {
+					consts[nx.Name] = true
+				}
+			}
+		}
+	}
+	for _, n := range names {
+		pn.Predefine(consts[n], n)
+	}
+}
@@ -0,0 +1,592 @@
+package gnolang
+
+import (
+	"io"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	stypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const upgradeTestPath = "gno.land/r/test/counter"
+
+type upgradeTestGetter map[string]*gnovm.MemPackage
+
+func (g upgradeTestGetter) GetMemPackage(path string) *gnovm.MemPackage {
+	return g[path]
+}
+
+func upgradeTestPackage(body string) *gnovm.MemPackage {
+	return &gnovm.MemPackage{
+		Name: "counter",
+		Path: upgradeTestPath,
+		Files: []*gnovm.MemFile{
+			{Name: "counter.gno", Body: "package counter\n" + body},
+		},
+	}
+}
+
+func TestCheckMemPackageUpgrade(t *testing.T) {
+	t.Parallel()
+
+	oldPkg := upgradeTestPackage(`
+type Item struct{ Name string }
+
+func (i *Item) String() string { return i.Name }
+
+const Max = 10
+
+var (
+	Items []*Item
+	count int
+)
+
+func Count() int { return count }
+`)
+
+	tests := []struct {
+		name  string
+		body  string
+		valid bool
+	}{
+		{
+			"same version",
+			oldPkg.Files[0].Body[len("package counter\n"):],
+			true,
+		},
+		{
+			"declarations added",
+			`
+type Item struct{ Name string }
+
+func (i *Item) String() string { return i.Name }
+
+func (i *Item) Len() int { return len(i.Name) }
+
+const Max = 10
+
+var (
+	Items []*Item
+	count int
+	total int
+)
+
+func Count() int { return count + total }
+`,
+			true,
+		},
+		{
+			"type changed",
+			`
+type Item struct{ Name, Desc string }
+
+func (i *Item) String() string { return i.Name }
+
+const Max = 10
+
+var (
+	Items []*Item
+	count int
+)
+
+func Count() int { return count }
+`,
+			false,
+		},
+		{
+			"method removed",
+			`
+type Item struct{ Name string }
+
+const Max = 10
+
+var (
+	Items []*Item
+	count int
+)
+
+func Count() int { return count }
+`,
+			false,
+		},
+		{
+			"constant changed",
+			`
+type Item struct{ Name string }
+
+func (i *Item) String() string { return i.Name }
+
+const Max = 20
+
+var (
+	Items []*Item
+	count int
+)
+
+func Count() int { return count }
+`,
+			false,
+		},
+		{
+			"unexported variable changed without migration",
+			`
+type Item struct{ Name string }
+
+func (i *Item) String() string { return i.Name }
+
+const Max = 10
+
+var (
+	Items []*Item
+	count int64
+)
+
+func Count() int { return int(count) }
+`,
+			false,
+		},
+		{
+			"unexported variable changed with migration",
+			`
+type Item struct{ Name string }
+
+func (i *Item) String() string { return i.Name }
+
+const Max = 10
+
+var (
+	Items []*Item
+	count int64
+)
+
+func Count() int { return int(count) }
+
+func migrate(count int) { setCount(int64(count)) }
+
+func setCount(c int64) { count = c }
+`,
+			true,
+		},
+		{
+			"invalid migration",
+			`
+type Item struct{ Name string }
+
+func (i *Item) String() string { return i.Name }
+
+const Max = 10
+
+var (
+	Items []*Item
+	count int64
+)
+
+func Count() int { return int(count) }
+
+func migrate(count string) {}
+`,
+			false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckMemPackageUpgrade(oldPkg, upgradeTestPackage(tc.body), upgradeTestGetter{})
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMachine_UpgradeMemPackage(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	baseStore := dbadapter.StoreConstructor(db, stypes.StoreOptions{})
+	iavlStore := iavl.StoreConstructor(db, stypes.StoreOptions{})
+	store := NewStore(nil, baseStore, iavlStore)
+
+	// eval calls fn of the test realm, from a main package
+	eval := func(store Store, fn string) string {
+		pv := store.GetPackage(upgradeTestPath, false)
+		mpn := NewPackageNode("main", "main", nil)
+		mpn.Define("pkg", TypedValue{T: &PackageType{}, V: pv})
+
+		m := NewMachineWithOptions(MachineOptions{Store: store, Output: io.Discard})
+		defer m.Release()
+
+		m.SetActivePackage(mpn.NewPackage())
+		res := m.Eval(Call(Sel(Nx("pkg"), fn)))
+		if len(res) == 0 {
+			return ""
+		}
+		return res[0].String()
+	}
+
+	m := NewMachineWithOptions(MachineOptions{PkgPath: upgradeTestPath, Store: store, Output: io.Discard})
+	m.RunMemPackage(upgradeTestPackage(`
+type Item struct{ Name string }
+
+func (i *Item) Label() string { return "item:" + i.Name }
+
+var (
+	items []*Item
+	count int
+)
+
+func Add() {
+	count++
+	items = append(items, &Item{Name: "a"})
+}
+
+func Count() int { return count }
+
+func Labels() string {
+	s := ""
+	for _, item := range items {
+		s += item.Label() + ";"
+	}
+	return s
+}
+`), true)
+	m.Release()
+
+	eval(store, "Add")
+	eval(store, "Add")
+	require.Equal(t, "(2 int)", eval(store, "Count"))
+
+	// Upgrade, changing the methods and migrating count.
+	m = NewMachineWithOptions(MachineOptions{Store: store, Output: io.Discard})
+	m.UpgradeMemPackage(upgradeTestPackage(`
+type Item struct{ Name string }
+
+func (i *Item) Label() string { return "label:" + i.Name }
+
+var (
+	items []*Item
+	count int64
+)
+
+func Add() {
+	count++
+	items = append(items, &Item{Name: "b"})
+}
+
+func Count() int64 { return count }
+
+func Labels() string {
+	s := ""
+	for _, item := range items {
+		s += item.Label() + ";"
+	}
+	return s
+}
+
+func migrate(count int) { setCount(int64(count) * 10) }
+
+func setCount(c int64) { count = c }
+`))
+	m.Release()
+
+	eval(store, "Add")
+	assert.Equal(t, "(21 int64)", eval(store, "Count"))
+	assert.Equal(t, `("label:a;label:a;label:b;" string)`, eval(store, "Labels"))
+
+	// Both versions of the source are kept.
+	assert.Equal(t, uint64(2), store.NumMemPackageVersions(upgradeTestPath))
+	assert.Contains(t, store.GetMemPackageVersion(upgradeTestPath, 1).Files[0].Body, `"item:"`)
+	assert.Contains(t, store.GetMemPackage(upgradeTestPath).Files[0].Body, `"label:"`)
+
+	// The upgraded realm is restored on restart.
+	restarted := NewStore(nil, baseStore, iavlStore)
+	m = NewMachineWithOptions(MachineOptions{Store: restarted, Output: io.Discard})
+	m.PreprocessAllFilesAndSaveBlockNodes()
+	m.Release()
+
+	assert.Equal(t, "(21 int64)", eval(restarted, "Count"))
+	assert.Equal(t, `("label:a;label:a;label:b;" string)`, eval(restarted, "Labels"))
+}
+
+func TestCheckMemPackageUpgrade_StructFields(t *testing.T) {
+	t.Parallel()
+
+	oldPkg := upgradeTestPackage(`
+type entry struct {
+	name string
+	next *entry
+}
+
+type Entry struct{ Name string }
+
+var entries []*entry
+`)
+
+	tests := []struct {
+		name  string
+		body  string
+		valid bool
+	}{
+		{
+			"fields added with migration",
+			`
+type entry struct {
+	name  string
+	next  *entry
+	count int
+}
+
+type Entry struct{ Name string }
+
+var entries []*entry
+
+func migrate() {}
+`,
+			true,
+		},
+		{
+			"fields added without migration",
+			`
+type entry struct {
+	name  string
+	next  *entry
+	count int
+}
+
+type Entry struct{ Name string }
+
+var entries []*entry
+`,
+			false,
+		},
+		{
+			"field inserted",
+			`
+type entry struct {
+	count int
+	name  string
+	next  *entry
+}
+
+type Entry struct{ Name string }
+
+var entries []*entry
+
+func migrate() {}
+`,
+			false,
+		},
+		{
+			"field removed",
+			`
+type entry struct {
+	name string
+}
+
+type Entry struct{ Name string }
+
+var entries []*entry
+
+func migrate() {}
+`,
+			false,
+		},
+		{
+			"field changed",
+			`
+type entry struct {
+	name []byte
+	next *entry
+}
+
+type Entry struct{ Name string }
+
+var entries []*entry
+
+func migrate() {}
+`,
+			false,
+		},
+		{
+			"new field referring to the type",
+			`
+type entry struct {
+	name string
+	next *entry
+	prev *entry
+}
+
+type Entry struct{ Name string }
+
+var entries []*entry
+
+func migrate() {}
+`,
+			false,
+		},
+		{
+			"exported type extended",
+			`
+type entry struct {
+	name string
+	next *entry
+}
+
+type Entry struct{ Name, Desc string }
+
+var entries []*entry
+
+func migrate() {}
+`,
+			false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckMemPackageUpgrade(oldPkg, upgradeTestPackage(tc.body), upgradeTestGetter{})
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMachine_UpgradeMemPackage_StructFields(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	baseStore := dbadapter.StoreConstructor(db, stypes.StoreOptions{})
+	iavlStore := iavl.StoreConstructor(db, stypes.StoreOptions{})
+	store := NewStore(nil, baseStore, iavlStore)
+
+	eval := func(store Store, fn string, args ...interface{}) string {
+		pv := store.GetPackage(upgradeTestPath, false)
+		mpn := NewPackageNode("main", "main", nil)
+		mpn.Define("pkg", TypedValue{T: &PackageType{}, V: pv})
+
+		m := NewMachineWithOptions(MachineOptions{Store: store, Output: io.Discard})
+		defer m.Release()
+
+		m.SetActivePackage(mpn.NewPackage())
+		res := m.Eval(Call(Sel(Nx("pkg"), fn), args...))
+		if len(res) == 0 {
+			return ""
+		}
+		return res[0].String()
+	}
+
+	m := NewMachineWithOptions(MachineOptions{PkgPath: upgradeTestPath, Store: store, Output: io.Discard})
+	m.RunMemPackage(upgradeTestPackage(`
+type entry struct {
+	name string
+	next *entry
+}
+
+var (
+	entries []*entry
+	byName  = map[string]entry{}
+	last    interface{}
+)
+
+func Add(name string) {
+	e := &entry{name: name}
+	if len(entries) > 0 {
+		e.next = entries[len(entries)-1]
+	}
+	entries = append(entries, e)
+	byName[name] = *e
+	last = *e
+}
+`), true)
+	m.Release()
+
+	eval(store, "Add", Str("a"))
+	eval(store, "Add", Str("b"))
+
+	// Upgrade, adding fields to entry, set by the migration.
+	m = NewMachineWithOptions(MachineOptions{Store: store, Output: io.Discard})
+	m.UpgradeMemPackage(upgradeTestPackage(`
+type entry struct {
+	name  string
+	next  *entry
+	size  int
+	attrs struct{ hidden bool }
+}
+
+var (
+	entries []*entry
+	byName  = map[string]entry{}
+	last    interface{}
+)
+
+func Add(name string) {
+	e := &entry{name: name, size: len(name)}
+	if len(entries) > 0 {
+		e.next = entries[len(entries)-1]
+	}
+	entries = append(entries, e)
+	byName[name] = *e
+}
+
+func Dump() string {
+	s := ""
+	for _, e := range entries {
+		s += e.name + ":" + itoa(e.size)
+		if e.next != nil {
+			s += "<" + e.next.name + ":" + itoa(e.next.size)
+		}
+		if e.attrs.hidden {
+			s += "!"
+		}
+		s += ";"
+	}
+	for _, name := range []string{"a", "b", "cc"} {
+		e := byName[name]
+		s += e.name + ":" + itoa(e.size) + ";"
+	}
+	e := last.(entry)
+	return s + e.name + ":" + itoa(e.size)
+}
+
+func itoa(n int) string {
+	if n < 10 {
+		return string(rune('0' + n))
+	}
+	return itoa(n/10) + itoa(n%10)
+}
+
+func migrate() {
+	for _, e := range entries {
+		e.size = len(e.name) * 10
+	}
+	entries[0].attrs.hidden = true
+}
+`))
+	m.Release()
+
+	eval(store, "Add", Str("cc"))
+	const dump = `("a:10!;b:10<a:10;cc:2<b:10;a:0;b:0;cc:2;b:0" string)`
+	assert.Equal(t, dump, eval(store, "Dump"))
+
+	// The extended type is restored on restart.
+	restarted := NewStore(nil, baseStore, iavlStore)
+	m = NewMachineWithOptions(MachineOptions{Store: restarted, Output: io.Discard})
+	m.PreprocessAllFilesAndSaveBlockNodes()
+	m.Release()
+
+	assert.Equal(t, dump, eval(restarted, "Dump"))
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"regexp"
 	"runtime/debug"
 	"strconv"
@@ -46,28 +47,41 @@ func (opts *TestOptions) runFiletest(filename string, source []byte) (string, er
 	if err != nil {
 		return "", err
 	}
-	ctx := Context(
-		pkgPath,
-		coins,
-	)
 	maxAllocRaw := dirs.FirstDefault(DirectiveMaxAlloc, "0")
 	maxAlloc, err := strconv.ParseInt(maxAllocRaw, 10, 64)
 	if err != nil {
 		return "", fmt.Errorf("could not parse MAXALLOC directive: %w", err)
 	}
+	if opts.Bytecode && maxAlloc == 0 {
+		// Allocations are compared between engines.
+		maxAlloc = math.MaxInt64
+	}
 
 	// Create machine for execution and run test
-	cw := opts.BaseStore.CacheWrap()
-	m := gno.NewMachineWithOptions(gno.MachineOptions{
-		Output:        &opts.outWriter,
-		Store:         opts.TestStore.BeginTransaction(cw, cw, nil),
-		Context:       ctx,
-		MaxAllocBytes: maxAlloc,
-		Debug:         opts.Debug,
-	})
+	newMachine := func(bytecode bool) *gno.Machine {
+		cw := opts.BaseStore.CacheWrap()
+		return gno.NewMachineWithOptions(gno.MachineOptions{
+			Output:        &opts.outWriter,
+			Store:         opts.TestStore.BeginTransaction(cw, cw, nil),
+			Context:       Context(pkgPath, coins),
+			MaxAllocBytes: maxAlloc,
+			Debug:         opts.Debug,
+			Bytecode:      bytecode,
+		})
+	}
+	m := newMachine(false)
 	defer m.Release()
 	result := opts.runTest(m, pkgPath, filename, source)
 
+	if opts.Bytecode {
+		bm := newMachine(true)
+		defer bm.Release()
+		bresult := opts.runTest(bm, pkgPath, filename, source)
+		if err := compareEngines(m, result, bm, bresult); err != nil {
+			return "", err
+		}
+	}
+
 	// updated tells whether the directives have been updated, and as such
 	// a new generated filetest should be returned.
 	// returnErr is used as the return value, and may be a MultiError if
@@ -155,6 +169,29 @@ func (opts *TestOptions) runFiletest(filename string, source []byte) (string, er
 	return "", returnErr
 }
 
+// compareEngines returns an error if running a filetest on the bytecode
+// engine, with bm, led to a different result than with the AST interpreter.
+func compareEngines(m *gno.Machine, result runResult, bm *gno.Machine, bresult runResult) error {
+	var err error
+	compare := func(what string, ast, bytecode string) {
+		if ast != bytecode {
+			err = multierr.Append(err, fmt.Errorf("bytecode %s diff:\n%s", what, unifiedDiff(ast, bytecode)))
+		}
+	}
+	compare("output", result.Output, bresult.Output)
+	compare("error", result.Error, bresult.Error)
+	compare("stacktrace", result.GnoStacktrace, bresult.GnoStacktrace)
+	if m.Cycles != bm.Cycles {
+		err = multierr.Append(err, fmt.Errorf("bytecode cycles: want %d, got %d", m.Cycles, bm.Cycles))
+	}
+	_, bytes := m.Alloc.Status()
+	_, bbytes := bm.Alloc.Status()
+	if bytes != bbytes {
+		err = multierr.Append(err, fmt.Errorf("bytecode allocations: want %d, got %d", bytes, bbytes))
+	}
+	return err
+}
+
 func unifiedDiff(wanted, actual string) string {
 	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
 		A:        difflib.SplitLines(wanted),
@@ -1,12 +1,10 @@
 package test
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"go/token"
 	"io"
-	"math/big"
 	"os"
 	"path/filepath"
 	"runtime/debug"
@@ -126,11 +124,6 @@ func StoreWithOptions(
 			pkg.DefineGoNativeValue("Fprintf", fmt.Fprintf)
 			pkg.DefineGoNativeValue("Fprint", fmt.Fprint)
 			return pkg, pkg.NewPackage()
-		case "encoding/json":
-			pkg := gno.NewPackageNode("json", pkgPath, nil)
-			pkg.DefineGoNativeValue("Unmarshal", json.Unmarshal)
-			pkg.DefineGoNativeValue("Marshal", json.Marshal)
-			return pkg, pkg.NewPackage()
 		case "os_test":
 			pkg := gno.NewPackageNode("os_test", pkgPath, nil)
 			pkg.DefineNative("Sleep",
@@ -156,10 +149,6 @@ func StoreWithOptions(
 				},
 			)
 			return pkg, pkg.NewPackage()
-		case "math/big":
-			pkg := gno.NewPackageNode("big", pkgPath, nil)
-			pkg.DefineGoNativeValue("NewInt", big.NewInt)
-			return pkg, pkg.NewPackage()
 		}
 
 		// Load normal stdlib.
@@ -3,6 +3,7 @@ package test
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -61,6 +62,9 @@ func Context(pkgPath string, send std.Coins) *teststd.TestExecContext {
 		OriginSendSpent: new(std.Coins),
 		Banker:          banker,
 		Params:          newTestParams(),
+		Scheduler:       newTestScheduler(),
+		Randomness:      newTestRandomness(),
+		Messenger:       newTestMessenger(),
 		EventLogger:     sdk.NewEventLogger(),
 	}
 	return &teststd.TestExecContext{
@@ -94,6 +98,80 @@ func (tp *testParams) SetInt64(key string, val int64)   { /* noop */ }
 func (tp *testParams) SetUint64(key string, val uint64) { /* noop */ }
 func (tp *testParams) SetString(key string, val string) { /* noop */ }
 
+// ----------------------------------------
+// testScheduler
+
+// testScheduler records scheduled calls, without ever executing them.
+type testScheduler struct {
+	lastID uint64
+	calls  map[uint64]stdlibs.ScheduledCall
+}
+
+func newTestScheduler() *testScheduler {
+	return &testScheduler{calls: make(map[uint64]stdlibs.ScheduledCall)}
+}
+
+func (ts *testScheduler) ScheduleCall(call stdlibs.ScheduledCall) (uint64, error) {
+	ts.lastID++
+	ts.calls[ts.lastID] = call
+	return ts.lastID, nil
+}
+
+func (ts *testScheduler) CancelCall(pkgPath string, id uint64) error {
+	call, ok := ts.calls[id]
+	if !ok || call.PkgPath != pkgPath {
+		return fmt.Errorf("scheduled call %d not found", id)
+	}
+	delete(ts.calls, id)
+	return nil
+}
+
+// ----------------------------------------
+// testRandomness
+
+// testRandomness records requested seeds. The seed of a block is the SHA-256
+// of its height, so that tests are reproducible.
+type testRandomness struct {
+	requested map[string]map[int64]bool // pkgPath -> height
+}
+
+func newTestRandomness() *testRandomness {
+	return &testRandomness{requested: make(map[string]map[int64]bool)}
+}
+
+func (tr *testRandomness) RequestSeed(pkgPath string, height int64) error {
+	if tr.requested[pkgPath] == nil {
+		tr.requested[pkgPath] = make(map[int64]bool)
+	}
+	tr.requested[pkgPath][height] = true
+	return nil
+}
+
+func (tr *testRandomness) Seed(pkgPath string, height int64) ([32]byte, error) {
+	if !tr.requested[pkgPath][height] {
+		return [32]byte{}, fmt.Errorf("random seed of block %d not requested by %s", height, pkgPath)
+	}
+	return sha256.Sum256([]byte(strconv.FormatInt(height, 10))), nil
+}
+
+// ----------------------------------------
+// testMessenger
+
+// testMessenger records sent messages, without ever delivering them.
+type testMessenger struct {
+	messages []stdlibs.RealmMessage
+}
+
+func newTestMessenger() *testMessenger {
+	return &testMessenger{}
+}
+
+func (tm *testMessenger) SendMessage(msg stdlibs.RealmMessage) (uint64, error) {
+	msg.ID = uint64(len(tm.messages) + 1)
+	tm.messages = append(tm.messages, msg)
+	return msg.ID, nil
+}
+
 // ----------------------------------------
 // main test function
 
@@ -128,6 +206,9 @@ type TestOptions struct {
 	FuzzName string
 	// Fuzz iters
 	FuzzIters int
+	// Run filetests on both the AST interpreter and the bytecode engine,
+	// and compare their results, cycles and allocations.
+	Bytecode bool
 
 	filetestBuffer bytes.Buffer
 	outWriter      proxyWriter
@@ -0,0 +1,34 @@
+package hmac
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// Cost of an HMAC, see [gno.Machine.IncrCPU]. The key and the message are
+// hashed twice, with the inner and outer padding.
+const (
+	cpuSumBase    = 1500
+	cpuSumPerByte = 3
+)
+
+func X_sha256(m *gno.Machine, key, message []byte) (res [32]byte) {
+	sum(m, sha256.New, key, message, res[:0])
+	return res
+}
+
+func X_sha512(m *gno.Machine, key, message []byte) (res [64]byte) {
+	sum(m, sha512.New, key, message, res[:0])
+	return res
+}
+
+func sum(m *gno.Machine, h func() hash.Hash, key, message, dst []byte) {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(key)+len(message)))
+	mac := hmac.New(h, key)
+	mac.Write(message)
+	mac.Sum(dst)
+}
@@ -0,0 +1,26 @@
+package keccak
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"golang.org/x/crypto/sha3"
+)
+
+// Cost of a sum, see [gno.Machine.IncrCPU]; the Keccak-f permutation runs
+// once per 136-byte block of data.
+const (
+	cpuSumBase    = 500
+	cpuSumPerByte = 4
+)
+
+func X_sum256(m *gno.Machine, data []byte) (res [32]byte) {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	h.Sum(res[:0])
+	return res
+}
+
+func X_sha3Sum256(m *gno.Machine, data []byte) [32]byte {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	return sha3.Sum256(data)
+}
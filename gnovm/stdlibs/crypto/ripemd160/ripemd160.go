@@ -0,0 +1,21 @@
+package ripemd160
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"golang.org/x/crypto/ripemd160" //nolint:gosec
+)
+
+// Cost of a sum, see [gno.Machine.IncrCPU]; RIPEMD-160 is slower per byte
+// than the SHA-2 hashes.
+const (
+	cpuSumBase    = 500
+	cpuSumPerByte = 5
+)
+
+func X_sum(m *gno.Machine, data []byte) (res [20]byte) {
+	m.IncrCPU(cpuSumBase + cpuSumPerByte*int64(len(data)))
+	h := ripemd160.New()
+	h.Write(data)
+	h.Sum(res[:0])
+	return res
+}
@@ -0,0 +1,70 @@
+package secp256k1
+
+import (
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// Costs of the natives, see [gno.Machine.IncrCPU]. They are fixed, as the
+// inputs have fixed sizes; recovering a public key takes an extra point
+// decompression over verifying a signature.
+const (
+	cpuVerify         = 60_000
+	cpuRecoverPubKey  = 70_000
+	cpuCompressPubKey = 5_000
+)
+
+func X_verify(m *gno.Machine, pubKey, hash, signature []byte) bool {
+	m.IncrCPU(cpuVerify)
+	if len(hash) != 32 || len(signature) != 64 {
+		return false
+	}
+	pub, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) {
+		return false // overflow
+	}
+	// Reject malleable signatures, like tm2's secp256k1 keys.
+	if s.IsOverHalfOrder() {
+		return false
+	}
+	return ecdsa.NewSignature(&r, &s).Verify(hash, pub)
+}
+
+func X_recoverPubKey(m *gno.Machine, hash, signature []byte) ([]byte, bool) {
+	m.IncrCPU(cpuRecoverPubKey)
+	if len(hash) != 32 || len(signature) != 65 {
+		return nil, false
+	}
+	v := signature[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, false
+	}
+
+	// RecoverCompact expects the recovery code first, offset by 27.
+	compact := make([]byte, 65)
+	compact[0] = 27 + v
+	copy(compact[1:], signature[:64])
+	pub, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, false
+	}
+	return pub.SerializeUncompressed(), true
+}
+
+func X_compressPubKey(m *gno.Machine, pubKey []byte) ([]byte, bool) {
+	m.IncrCPU(cpuCompressPubKey)
+	pub, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return nil, false
+	}
+	return pub.SerializeCompressed(), true
+}
@@ -0,0 +1,108 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// Costs of the natives, see [gno.Machine.IncrCPU]. Encoding and decoding are
+// charged per byte of JSON.
+const (
+	cpuBase        = 300
+	cpuPerByte     = 10
+	cpuScanPerByte = 2
+)
+
+func X_marshal(m *gno.Machine, v gno.TypedValue) ([]byte, string) {
+	// The output is charged as it is written, as it may be much larger than
+	// v itself.
+	m.IncrCPU(cpuBase)
+	bz, err := gno.MarshalJSONMetered(m.Store, v, func(size int64) {
+		m.IncrCPU(cpuPerByte * size)
+	})
+	if err != nil {
+		return nil, err.Error()
+	}
+	return bz, ""
+}
+
+func X_unmarshal(m *gno.Machine, data []byte, v gno.TypedValue) string {
+	m.IncrCPU(cpuBase + cpuPerByte*int64(len(data)))
+	if err := gno.UnmarshalJSONInto(m.Alloc, m.Store, m.Realm, data, v); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func X_valid(m *gno.Machine, data []byte) bool {
+	m.IncrCPU(cpuBase + cpuScanPerByte*int64(len(data)))
+	return json.Valid(data)
+}
+
+func X_indentJSON(m *gno.Machine, src []byte, prefix, indent string) ([]byte, string) {
+	// The output is charged before indenting, from its size computed in a
+	// first scan of src, as it may be much larger than src.
+	m.IncrCPU(cpuBase + cpuScanPerByte*int64(len(src)))
+	m.IncrCPU(cpuScanPerByte * indentedSize(src, prefix, indent))
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, src, prefix, indent); err != nil {
+		return nil, err.Error()
+	}
+	return buf.Bytes(), ""
+}
+
+func X_compactJSON(m *gno.Machine, src []byte) ([]byte, string) {
+	m.IncrCPU(cpuBase + cpuScanPerByte*int64(len(src)))
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, src); err != nil {
+		return nil, err.Error()
+	}
+	return buf.Bytes(), ""
+}
+
+// indentedSize returns an upper bound of the size of src indented by
+// json.Indent with prefix and indent: each line it starts is prefixed with
+// prefix and the indentation of its depth.
+func indentedSize(src []byte, prefix, indent string) int64 {
+	var (
+		size     = int64(len(src))
+		depth    int64
+		inString bool
+		escaped  bool
+	)
+	newline := func() {
+		size += 1 + int64(len(prefix)) + depth*int64(len(indent))
+	}
+	for _, c := range src {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			newline()
+		case '}', ']':
+			if depth > 0 {
+				depth--
+			}
+			newline()
+		case ',':
+			newline()
+		case ':':
+			size++ // space after the colon
+		}
+	}
+	return size
+}
@@ -0,0 +1,42 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndentedSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		src            string
+		prefix, indent string
+	}{
+		{`1`, "", "  "},
+		{`"a,b:{[c]}\""`, "", "  "},
+		{`{"a":1,"b":[1,2,3]}`, "", "  "},
+		{`{"a":1,"b":[1,2,3]}`, ">", "\t"},
+		{` { "a" : [ { "b" : { } } ] } `, "", "    "},
+		{`[[[[[[[[[[1]]]]]]]]]]`, "//", "        "},
+		{`[{},[],{"a":[]}]`, "", "  "},
+	}
+	for _, tc := range tests {
+		t.Run(tc.src, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, json.Indent(&buf, []byte(tc.src), tc.prefix, tc.indent))
+			assert.GreaterOrEqual(t, indentedSize([]byte(tc.src), tc.prefix, tc.indent), int64(buf.Len()))
+		})
+	}
+
+	// the bound is exact without empty objects, arrays and spaces.
+	src := []byte(`{"a":[1,{"b":"c"}],"d":2}`)
+	var buf bytes.Buffer
+	require.NoError(t, json.Indent(&buf, src, "> ", "\t"))
+	assert.Equal(t, int64(buf.Len()), indentedSize(src, "> ", "\t"))
+}
@@ -0,0 +1,204 @@
+package big
+
+import (
+	"math/big"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// Costs of the natives, see [gno.Machine.IncrCPU]. Operands are measured in
+// 64-bit words: linear operations are charged per word of their operands,
+// multiplications and divisions per product of the operand sizes.
+const (
+	cpuBase       = 100
+	cpuPerWord    = 10
+	cpuPerWordSq  = 5
+	cpuPerDigitSq = 1
+)
+
+// maxExpBits is the maximum bit length of the result of Exp without modulus.
+const maxExpBits = 1 << 16
+
+func toBig(neg bool, abs []byte) *big.Int {
+	z := new(big.Int).SetBytes(abs)
+	if neg {
+		z.Neg(z)
+	}
+	return z
+}
+
+func fromBig(z *big.Int) (bool, []byte) {
+	if z.Sign() == 0 {
+		return false, nil
+	}
+	return z.Sign() < 0, z.Bytes()
+}
+
+func words(abs []byte) int64 {
+	return int64(len(abs)+7) / 8
+}
+
+func linearCost(xabs, yabs []byte) int64 {
+	return cpuBase + cpuPerWord*(words(xabs)+words(yabs))
+}
+
+func quadraticCost(xabs, yabs []byte) int64 {
+	wx, wy := words(xabs), words(yabs)
+	return cpuBase + cpuPerWord*(wx+wy) + cpuPerWordSq*wx*wy
+}
+
+func binary(xneg bool, xabs []byte, yneg bool, yabs []byte, op func(z, x, y *big.Int) *big.Int) (bool, []byte) {
+	x, y := toBig(xneg, xabs), toBig(yneg, yabs)
+	return fromBig(op(new(big.Int), x, y))
+}
+
+func X_intAdd(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(linearCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Add)
+}
+
+func X_intSub(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(linearCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Sub)
+}
+
+func X_intMul(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(quadraticCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Mul)
+}
+
+func X_intQuo(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(quadraticCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Quo)
+}
+
+func X_intRem(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(quadraticCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Rem)
+}
+
+func X_intDiv(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(quadraticCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Div)
+}
+
+func X_intMod(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(quadraticCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Mod)
+}
+
+func X_intAnd(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(linearCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).And)
+}
+
+func X_intOr(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(linearCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Or)
+}
+
+func X_intXor(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(linearCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).Xor)
+}
+
+func X_intAndNot(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte) (bool, []byte) {
+	m.IncrCPU(linearCost(xabs, yabs))
+	return binary(xneg, xabs, yneg, yabs, (*big.Int).AndNot)
+}
+
+func X_intLsh(m *gno.Machine, neg bool, abs []byte, n uint) (bool, []byte) {
+	m.IncrCPU(cpuBase + cpuPerWord*(words(abs)+int64(n/64)))
+	x := toBig(neg, abs)
+	return fromBig(x.Lsh(x, n))
+}
+
+func X_intRsh(m *gno.Machine, neg bool, abs []byte, n uint) (bool, []byte) {
+	m.IncrCPU(cpuBase + cpuPerWord*words(abs))
+	x := toBig(neg, abs)
+	return fromBig(x.Rsh(x, n))
+}
+
+// X_intExp computes x**y mod |m|, or x**y if m is zero. It returns false if
+// the result without modulus would exceed maxExpBits bits, or if y is negative
+// and x has no inverse modulo m.
+func X_intExp(m *gno.Machine, xneg bool, xabs []byte, yneg bool, yabs []byte, mneg bool, mabs []byte) (bool, []byte, bool) {
+	x, y, mod := toBig(xneg, xabs), toBig(yneg, yabs), toBig(mneg, mabs)
+	if mod.Sign() != 0 {
+		wm := words(mabs)
+		m.IncrCPU(cpuBase + 2*int64(y.BitLen())*cpuPerWordSq*wm*wm)
+		z := new(big.Int).Exp(x, y, mod)
+		if z == nil {
+			return false, nil, false
+		}
+		neg, abs := fromBig(z)
+		return neg, abs, true
+	}
+	if y.Sign() > 0 && x.CmpAbs(big.NewInt(1)) > 0 {
+		if !y.IsInt64() || y.Int64() > maxExpBits || y.Int64()*int64(x.BitLen()-1) > maxExpBits {
+			m.IncrCPU(cpuBase)
+			return false, nil, false
+		}
+		wz := (y.Int64()*int64(x.BitLen()) + 63) / 64
+		m.IncrCPU(cpuBase + 2*cpuPerWordSq*wz*wz)
+	} else {
+		m.IncrCPU(cpuBase)
+	}
+	neg, abs := fromBig(new(big.Int).Exp(x, y, nil))
+	return neg, abs, true
+}
+
+func X_intSqrt(m *gno.Machine, abs []byte) []byte {
+	w := words(abs)
+	m.IncrCPU(cpuBase + 4*cpuPerWordSq*w*w)
+	x := toBig(false, abs)
+	_, res := fromBig(x.Sqrt(x))
+	return res
+}
+
+// X_intGCD returns the greatest common divisor d of a and b, and the
+// cofactors x and y such that d = a*x + b*y.
+func X_intGCD(m *gno.Machine, aneg bool, aabs []byte, bneg bool, babs []byte) (dabs []byte, xneg bool, xabs []byte, yneg bool, yabs []byte) {
+	m.IncrCPU(2 * quadraticCost(aabs, babs))
+	a, b := toBig(aneg, aabs), toBig(bneg, babs)
+	var x, y big.Int
+	d := new(big.Int).GCD(&x, &y, a, b)
+	_, dabs = fromBig(d)
+	xneg, xabs = fromBig(&x)
+	yneg, yabs = fromBig(&y)
+	return
+}
+
+func X_intModInverse(m *gno.Machine, gneg bool, gabs []byte, nneg bool, nabs []byte) (bool, []byte, bool) {
+	m.IncrCPU(2 * quadraticCost(gabs, nabs))
+	g, n := toBig(gneg, gabs), toBig(nneg, nabs)
+	z := new(big.Int).ModInverse(g, n)
+	if z == nil {
+		return false, nil, false
+	}
+	neg, abs := fromBig(z)
+	return neg, abs, true
+}
+
+func X_intProbablyPrime(m *gno.Machine, neg bool, abs []byte, n int) bool {
+	w := words(abs)
+	m.IncrCPU(cpuBase + int64(n+1)*int64(len(abs)*8)*cpuPerWordSq*w*w)
+	return toBig(neg, abs).ProbablyPrime(n)
+}
+
+func X_intText(m *gno.Machine, neg bool, abs []byte, base int) string {
+	w := words(abs)
+	m.IncrCPU(cpuBase + cpuPerWordSq*w*w)
+	return toBig(neg, abs).Text(base)
+}
+
+// X_intSetString parses s in the given base, like (*big.Int).SetString.
+func X_intSetString(m *gno.Machine, s string, base int) (bool, []byte, bool) {
+	m.IncrCPU(cpuBase + cpuPerDigitSq*int64(len(s))*int64(len(s))/16)
+	z, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return false, nil, false
+	}
+	neg, abs := fromBig(z)
+	return neg, abs, true
+}
@@ -16,9 +16,9 @@ import (
 type BankerInterface interface {
 	GetCoins(addr crypto.Bech32Address) (dst std.Coins)
 	SendCoins(from, to crypto.Bech32Address, amt std.Coins)
-	TotalCoin(denom string) int64
-	IssueCoin(addr crypto.Bech32Address, denom string, amount int64)
-	RemoveCoin(addr crypto.Bech32Address, denom string, amount int64)
+	TotalCoin(denom string) std.Int
+	IssueCoin(addr crypto.Bech32Address, denom string, amount std.Int)
+	RemoveCoin(addr crypto.Bech32Address, denom string, amount std.Int)
 }
 
 const (
@@ -32,16 +32,23 @@ const (
 	btRealmIssue
 )
 
-func X_bankerGetCoins(m *gno.Machine, bt uint8, addr string) (denoms []string, amounts []int64) {
+// Amounts are passed to and from Gno as decimal strings, as they may not fit
+// in an int64.
+
+func X_bankerGetCoins(m *gno.Machine, bt uint8, addr string) (denoms []string, amounts []string) {
 	coins := GetContext(m).Banker.GetCoins(crypto.Bech32Address(addr))
 	return ExpandCoins(coins)
 }
 
-func X_bankerSendCoins(m *gno.Machine, bt uint8, fromS, toS string, denoms []string, amounts []int64) {
+func X_bankerSendCoins(m *gno.Machine, bt uint8, fromS, toS string, denoms []string, amounts []string) {
 	// bt != BankerTypeReadonly (checked in gno)
 
 	ctx := GetContext(m)
-	amt := CompactCoins(denoms, amounts)
+	amt, err := CompactCoins(denoms, amounts)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+		return
+	}
 	from, to := crypto.Bech32Address(fromS), crypto.Bech32Address(toS)
 
 	switch bt {
@@ -65,14 +72,24 @@ func X_bankerSendCoins(m *gno.Machine, bt uint8, fromS, toS string, denoms []str
 	}
 }
 
-func X_bankerTotalCoin(m *gno.Machine, bt uint8, denom string) int64 {
-	return GetContext(m).Banker.TotalCoin(denom)
+func X_bankerTotalCoin(m *gno.Machine, bt uint8, denom string) string {
+	return GetContext(m).Banker.TotalCoin(denom).String()
 }
 
-func X_bankerIssueCoin(m *gno.Machine, bt uint8, addr string, denom string, amount int64) {
-	GetContext(m).Banker.IssueCoin(crypto.Bech32Address(addr), denom, amount)
+func X_bankerIssueCoin(m *gno.Machine, bt uint8, addr string, denom string, amount string) {
+	amt, err := std.ParseInt(amount)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+		return
+	}
+	GetContext(m).Banker.IssueCoin(crypto.Bech32Address(addr), denom, amt)
 }
 
-func X_bankerRemoveCoin(m *gno.Machine, bt uint8, addr string, denom string, amount int64) {
-	GetContext(m).Banker.RemoveCoin(crypto.Bech32Address(addr), denom, amount)
+func X_bankerRemoveCoin(m *gno.Machine, bt uint8, addr string, denom string, amount string) {
+	amt, err := std.ParseInt(amount)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+		return
+	}
+	GetContext(m).Banker.RemoveCoin(crypto.Bech32Address(addr), denom, amt)
 }
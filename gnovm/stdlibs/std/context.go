@@ -19,6 +19,10 @@ type ExecContext struct {
 	OriginSendSpent *std.Coins // mutable
 	Banker          BankerInterface
 	Params          ParamsInterface
+	Scheduler       SchedulerInterface
+	Randomness      RandomnessInterface
+	Messenger       MessengerInterface
+	Message         *RealmMessage // message being delivered, if any
 	EventLogger     *sdk.EventLogger
 }
 
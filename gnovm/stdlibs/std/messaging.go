@@ -0,0 +1,93 @@
+package std
+
+import (
+	"errors"
+	"fmt"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// MessengerInterface is the interface through which Gno is capable of
+// sending messages to other realms, delivered by the blockchain after the
+// sending transaction.
+type MessengerInterface interface {
+	// SendMessage queues the message for delivery, returning its ID.
+	SendMessage(msg RealmMessage) (uint64, error)
+}
+
+// RealmMessage is a message from a realm to a handler function of another
+// realm. When a message bounces, it is sent back to the bounce handler of its
+// sender, with From and To swapped.
+type RealmMessage struct {
+	ID            uint64
+	From          string
+	To            string
+	Handler       string
+	Type          string
+	Data          string
+	MaxGas        int64
+	BounceHandler string
+	Bounced       bool
+	Error         string // delivery error of a bounced message
+}
+
+func X_sendMessage(m *gno.Machine, to, handler, msgType, data string, maxGas int64, bounceHandler string) uint64 {
+	ctx := GetContext(m)
+	_, pkgPath := currentRealm(m)
+	msg := RealmMessage{
+		From:          pkgPath,
+		To:            to,
+		Handler:       handler,
+		Type:          msgType,
+		Data:          data,
+		MaxGas:        maxGas,
+		BounceHandler: bounceHandler,
+	}
+	if err := checkRealmMessage(m, ctx, msg); err != nil {
+		m.Panic(typedString(err.Error()))
+		return 0
+	}
+
+	id, err := ctx.Messenger.SendMessage(msg)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+		return 0
+	}
+	return id
+}
+
+func X_currentMessage(m *gno.Machine) (id uint64, from, to, msgType, data string, bounced bool, errMsg string, ok bool) {
+	ctx := GetContext(m)
+	_, pkgPath := currentRealm(m)
+	// Only the recipient of the message sees it, and not the realms it calls.
+	msg := ctx.Message
+	if msg == nil || msg.To != pkgPath {
+		return
+	}
+	return msg.ID, msg.From, msg.To, msg.Type, msg.Data, msg.Bounced, msg.Error, true
+}
+
+var errMessengerUnsupported = errors.New("realm messages are not supported")
+
+// checkRealmMessage validates a message to be sent in the given context.
+func checkRealmMessage(m *gno.Machine, ctx ExecContext, msg RealmMessage) error {
+	switch {
+	case !gno.IsRealmPath(msg.From):
+		return errors.New("only realms can send messages")
+	case ctx.Messenger == nil:
+		return errMessengerUnsupported
+	case !gno.IsRealmPath(msg.To):
+		return fmt.Errorf("%s is not a realm", msg.To)
+	case msg.MaxGas <= 0:
+		return errors.New("max gas must be positive")
+	case m.Store.GetPackage(msg.To, false) == nil:
+		return fmt.Errorf("realm %s not found", msg.To)
+	}
+	if err := checkScheduledFunc(m, msg.To, msg.Handler, 0); err != nil {
+		return err
+	}
+	if msg.BounceHandler != "" {
+		return checkScheduledFunc(m, msg.From, msg.BounceHandler, 0)
+	}
+	return nil
+}
@@ -66,7 +66,7 @@ func findPreviousFunctionName(m *gno.Machine, targetIndex int) string {
 	panic("function name not found")
 }
 
-func X_originSend(m *gno.Machine) (denoms []string, amounts []int64) {
+func X_originSend(m *gno.Machine) (denoms []string, amounts []string) {
 	os := GetContext(m).OriginSend
 	return ExpandCoins(os)
 }
@@ -154,20 +154,27 @@ func typedString(s string) gno.TypedValue {
 	return tv
 }
 
-func ExpandCoins(c std.Coins) (denoms []string, amounts []int64) {
+// ExpandCoins splits c into denominations and decimal amounts, to be passed
+// to Gno.
+func ExpandCoins(c std.Coins) (denoms []string, amounts []string) {
 	denoms = make([]string, len(c))
-	amounts = make([]int64, len(c))
+	amounts = make([]string, len(c))
 	for i, coin := range c {
 		denoms[i] = coin.Denom
-		amounts[i] = coin.Amount
+		amounts[i] = coin.Amount.String()
 	}
 	return denoms, amounts
 }
 
-func CompactCoins(denoms []string, amounts []int64) std.Coins {
+// CompactCoins is the inverse of ExpandCoins.
+func CompactCoins(denoms []string, amounts []string) (std.Coins, error) {
 	coins := make(std.Coins, len(denoms))
 	for i := range coins {
-		coins[i] = std.Coin{Denom: denoms[i], Amount: amounts[i]}
+		amt, err := std.ParseInt(amounts[i])
+		if err != nil {
+			return nil, err
+		}
+		coins[i] = std.Coin{Denom: denoms[i], Amount: amt}
 	}
-	return coins
+	return coins, nil
 }
@@ -0,0 +1,68 @@
+package std
+
+import (
+	"errors"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// RandomnessInterface is the interface through which Gno is capable of
+// reading the random seeds of the blocks, derived from the randomness
+// contributed by the validators.
+type RandomnessInterface interface {
+	// RequestSeed records that the realm at pkgPath will read the seed of
+	// the block at height.
+	RequestSeed(pkgPath string, height int64) error
+	// Seed returns the seed of the block at height, which must have started
+	// and must have been requested by the realm at pkgPath.
+	Seed(pkgPath string, height int64) ([32]byte, error)
+}
+
+func X_requestRandomSeed(m *gno.Machine) int64 {
+	ctx := GetContext(m)
+	_, pkgPath := currentRealm(m)
+	if err := checkRandomness(ctx, pkgPath); err != nil {
+		m.Panic(typedString(err.Error()))
+		return 0
+	}
+
+	height := ctx.Height + 1
+	if err := ctx.Randomness.RequestSeed(pkgPath, height); err != nil {
+		m.Panic(typedString(err.Error()))
+		return 0
+	}
+	return height
+}
+
+func X_randomSeed(m *gno.Machine, height int64) ([32]byte, bool) {
+	ctx := GetContext(m)
+	_, pkgPath := currentRealm(m)
+	if err := checkRandomness(ctx, pkgPath); err != nil {
+		m.Panic(typedString(err.Error()))
+		return [32]byte{}, false
+	}
+	if height > ctx.Height {
+		return [32]byte{}, false
+	}
+
+	seed, err := ctx.Randomness.Seed(pkgPath, height)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+		return [32]byte{}, false
+	}
+	return seed, true
+}
+
+// checkRandomness verifies that the realm at pkgPath can use random seeds in
+// the given context.
+func checkRandomness(ctx ExecContext, pkgPath string) error {
+	switch {
+	case !gno.IsRealmPath(pkgPath):
+		return errors.New("only realms can use random seeds")
+	case ctx.Randomness == nil:
+		return errRandomnessUnsupported
+	}
+	return nil
+}
+
+var errRandomnessUnsupported = errors.New("random seeds are not supported")
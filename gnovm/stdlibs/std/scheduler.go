@@ -0,0 +1,103 @@
+package std
+
+import (
+	"errors"
+	"fmt"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// SchedulerInterface is the interface through which Gno is capable of
+// scheduling calls to be executed by the blockchain in a future block.
+type SchedulerInterface interface {
+	// ScheduleCall schedules the call, returning its ID.
+	ScheduleCall(call ScheduledCall) (uint64, error)
+	// CancelCall cancels the call with the given ID, which must have been
+	// scheduled by the realm at pkgPath.
+	CancelCall(pkgPath string, id uint64) error
+}
+
+// ScheduledCall is a call to a realm's function to be executed at a given
+// block height or time. Only one of Height and Timestamp is set.
+type ScheduledCall struct {
+	PkgPath   string
+	Func      string
+	Args      []string
+	Height    int64
+	Timestamp int64 // seconds
+	MaxGas    int64
+}
+
+func X_scheduleCall(m *gno.Machine, height, timestamp, maxGas int64, fn string, args []string) uint64 {
+	ctx := GetContext(m)
+	_, pkgPath := currentRealm(m)
+	call := ScheduledCall{
+		PkgPath:   pkgPath,
+		Func:      fn,
+		Args:      args,
+		Height:    height,
+		Timestamp: timestamp,
+		MaxGas:    maxGas,
+	}
+	if err := checkScheduledCall(m, ctx, call); err != nil {
+		m.Panic(typedString(err.Error()))
+		return 0
+	}
+
+	id, err := ctx.Scheduler.ScheduleCall(call)
+	if err != nil {
+		m.Panic(typedString(err.Error()))
+		return 0
+	}
+	return id
+}
+
+func X_cancelScheduledCall(m *gno.Machine, id uint64) {
+	ctx := GetContext(m)
+	_, pkgPath := currentRealm(m)
+	if ctx.Scheduler == nil {
+		m.Panic(typedString(errSchedulerUnsupported.Error()))
+		return
+	}
+	if err := ctx.Scheduler.CancelCall(pkgPath, id); err != nil {
+		m.Panic(typedString(err.Error()))
+	}
+}
+
+var errSchedulerUnsupported = errors.New("scheduled calls are not supported")
+
+// checkScheduledCall validates a call to be scheduled in the given context.
+func checkScheduledCall(m *gno.Machine, ctx ExecContext, call ScheduledCall) error {
+	switch {
+	case !gno.IsRealmPath(call.PkgPath):
+		return errors.New("only realms can schedule calls")
+	case ctx.Scheduler == nil:
+		return errSchedulerUnsupported
+	case (call.Height == 0) == (call.Timestamp == 0):
+		return errors.New("exactly one of height and timestamp must be set")
+	case call.Height != 0 && call.Height <= ctx.Height:
+		return fmt.Errorf("height %d is not in the future", call.Height)
+	case call.Timestamp != 0 && call.Timestamp <= ctx.Timestamp:
+		return fmt.Errorf("timestamp %d is not in the future", call.Timestamp)
+	case call.MaxGas <= 0:
+		return errors.New("max gas must be positive")
+	}
+	return checkScheduledFunc(m, call.PkgPath, call.Func, len(call.Args))
+}
+
+// checkScheduledFunc verifies that fn is a non-variadic top-level function
+// of the package at pkgPath, taking nargs arguments.
+func checkScheduledFunc(m *gno.Machine, pkgPath, fn string, nargs int) error {
+	pn := m.Store.GetBlockNode(gno.PackageNodeLocation(pkgPath)).(*gno.PackageNode)
+	if _, ok := pn.GetLocalIndex(gno.Name(fn)); !ok {
+		return fmt.Errorf("function %s not declared in %s", fn, pkgPath)
+	}
+	ft, ok := pn.GetStaticTypeOf(m.Store, gno.Name(fn)).(*gno.FuncType)
+	if !ok {
+		return fmt.Errorf("%s is not a function", fn)
+	}
+	if ft.HasVarg() || len(ft.Params) != nargs {
+		return fmt.Errorf("function %s takes %d arguments, got %d", fn, len(ft.Params), nargs)
+	}
+	return nil
+}
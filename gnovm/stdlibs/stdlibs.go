@@ -7,7 +7,11 @@ import (
 	libsstd "github.com/gnolang/gno/gnovm/stdlibs/std"
 )
 
-type ExecContext = libsstd.ExecContext
+type (
+	ExecContext   = libsstd.ExecContext
+	ScheduledCall = libsstd.ScheduledCall
+	RealmMessage  = libsstd.RealmMessage
+)
 
 func GetContext(m *gno.Machine) ExecContext {
 	return libsstd.GetContext(m)
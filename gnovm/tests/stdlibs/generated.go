@@ -170,9 +170,9 @@ var nativeFuncs = [...]NativeFunc{
 		"testSetOriginSend",
 		[]gno.FieldTypeExpr{
 			{Name: gno.N("p0"), Type: gno.X("[]string")},
-			{Name: gno.N("p1"), Type: gno.X("[]int64")},
+			{Name: gno.N("p1"), Type: gno.X("[]string")},
 			{Name: gno.N("p2"), Type: gno.X("[]string")},
-			{Name: gno.N("p3"), Type: gno.X("[]int64")},
+			{Name: gno.N("p3"), Type: gno.X("[]string")},
 		},
 		[]gno.FieldTypeExpr{},
 		true,
@@ -181,11 +181,11 @@ var nativeFuncs = [...]NativeFunc{
 			var (
 				p0  []string
 				rp0 = reflect.ValueOf(&p0).Elem()
-				p1  []int64
+				p1  []string
 				rp1 = reflect.ValueOf(&p1).Elem()
 				p2  []string
 				rp2 = reflect.ValueOf(&p2).Elem()
-				p3  []int64
+				p3  []string
 				rp3 = reflect.ValueOf(&p3).Elem()
 			)
 
@@ -205,7 +205,7 @@ var nativeFuncs = [...]NativeFunc{
 		[]gno.FieldTypeExpr{
 			{Name: gno.N("p0"), Type: gno.X("string")},
 			{Name: gno.N("p1"), Type: gno.X("[]string")},
-			{Name: gno.N("p2"), Type: gno.X("[]int64")},
+			{Name: gno.N("p2"), Type: gno.X("[]string")},
 		},
 		[]gno.FieldTypeExpr{},
 		true,
@@ -216,7 +216,7 @@ var nativeFuncs = [...]NativeFunc{
 				rp0 = reflect.ValueOf(&p0).Elem()
 				p1  []string
 				rp1 = reflect.ValueOf(&p1).Elem()
-				p2  []int64
+				p2  []string
 				rp2 = reflect.ValueOf(&p2).Elem()
 			)
 
@@ -169,12 +169,19 @@ func X_isRealm(m *gno.Machine, pkgPath string) bool {
 }
 
 func X_testSetOriginSend(m *gno.Machine,
-	sentDenom []string, sentAmt []int64,
-	spentDenom []string, spentAmt []int64,
+	sentDenom []string, sentAmt []string,
+	spentDenom []string, spentAmt []string,
 ) {
 	ctx := m.Context.(*TestExecContext)
-	ctx.OriginSend = std.CompactCoins(sentDenom, sentAmt)
-	spent := std.CompactCoins(spentDenom, spentAmt)
+	sent, err := std.CompactCoins(sentDenom, sentAmt)
+	if err != nil {
+		panic(err)
+	}
+	spent, err := std.CompactCoins(spentDenom, spentAmt)
+	if err != nil {
+		panic(err)
+	}
+	ctx.OriginSend = sent
 	ctx.OriginSendSpent = &spent
 	m.Context = ctx
 }
@@ -215,28 +222,35 @@ func (tb *TestBanker) SendCoins(from, to crypto.Bech32Address, amt tm2std.Coins)
 }
 
 // TotalCoin implements the Banker interface.
-func (tb *TestBanker) TotalCoin(denom string) int64 {
-	panic("not yet implemented")
+func (tb *TestBanker) TotalCoin(denom string) tm2std.Int {
+	var total tm2std.Int
+	for _, coins := range tb.CoinTable {
+		total = total.Add(coins.AmountOf(denom))
+	}
+	return total
 }
 
 // IssueCoin implements the Banker interface.
-func (tb *TestBanker) IssueCoin(addr crypto.Bech32Address, denom string, amt int64) {
+func (tb *TestBanker) IssueCoin(addr crypto.Bech32Address, denom string, amt tm2std.Int) {
 	coins, _ := tb.CoinTable[addr]
 	sum := coins.Add(tm2std.Coins{{Denom: denom, Amount: amt}})
 	tb.CoinTable[addr] = sum
 }
 
 // RemoveCoin implements the Banker interface.
-func (tb *TestBanker) RemoveCoin(addr crypto.Bech32Address, denom string, amt int64) {
+func (tb *TestBanker) RemoveCoin(addr crypto.Bech32Address, denom string, amt tm2std.Int) {
 	coins, _ := tb.CoinTable[addr]
 	rest := coins.Sub(tm2std.Coins{{Denom: denom, Amount: amt}})
 	tb.CoinTable[addr] = rest
 }
 
-func X_testIssueCoins(m *gno.Machine, addr string, denom []string, amt []int64) {
+func X_testIssueCoins(m *gno.Machine, addr string, denom []string, amt []string) {
 	ctx := m.Context.(*TestExecContext)
-	banker := ctx.Banker
-	for i := range denom {
-		banker.IssueCoin(crypto.Bech32Address(addr), denom[i], amt[i])
+	coins, err := std.CompactCoins(denom, amt)
+	if err != nil {
+		panic(err)
+	}
+	for _, coin := range coins {
+		ctx.Banker.IssueCoin(crypto.Bech32Address(addr), coin.Denom, coin.Amount)
 	}
 }
@@ -5,6 +5,7 @@ go 1.23.6
 require github.com/gnolang/gno v0.0.0-00010101000000-000000000000
 
 require (
+	filippo.io/edwards25519 v1.1.0 // indirect
 	github.com/btcsuite/btcd/btcec/v2 v2.3.4 // indirect
 	github.com/btcsuite/btcd/btcutil v1.1.6 // indirect
 	github.com/cenkalti/backoff/v4 v4.3.0 // indirect
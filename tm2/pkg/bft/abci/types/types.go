@@ -61,6 +61,7 @@ type RequestBeginBlock struct {
 	Hash           []byte
 	Header         Header
 	LastCommitInfo *LastCommitInfo
+	Txs            [][]byte // txs of the block, in the order they are delivered
 	// Violations     []Violation
 }
 
@@ -275,6 +276,7 @@ type VoteInfo struct {
 	Address         crypto.Address
 	Power           int64
 	SignedLastBlock bool
+	Randomness      []byte // VRF output of the precommit for the last block, if any.
 }
 
 /*
@@ -183,13 +183,13 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.PeerConn, msgBytes []by
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		bcR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
-		bcR.Switch.StopPeerForError(src, err)
+		bcR.Switch.ReportMisbehavior(src, err)
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		bcR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		bcR.Switch.StopPeerForError(src, err)
+		bcR.Switch.ReportMisbehavior(src, err)
 		return
 	}
 
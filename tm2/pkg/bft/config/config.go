@@ -30,6 +30,8 @@ var (
 	errInvalidPrivValidatorStatePath     = errors.New("invalid private validator state file path")
 	errInvalidABCIMechanism              = errors.New("invalid ABCI mechanism")
 	errInvalidPrivValidatorListenAddress = errors.New("invalid PrivValidator listen address")
+	errPrivValidatorLeaseWithoutState    = errors.New("private validator lease file set without a sign state file")
+	errInvalidPrivValidatorLeaseTTL      = errors.New("invalid private validator lease TTL")
 	errInvalidProfListenAddress          = errors.New("invalid profiling server listen address")
 	errInvalidNodeKeyPath                = errors.New("invalid p2p node key path")
 )
@@ -263,6 +265,7 @@ var (
 	defaultConfigPath       = filepath.Join(DefaultConfigDir, DefaultConfigFileName)
 	defaultPrivValKeyPath   = filepath.Join(DefaultSecretsDir, defaultPrivValKeyName)
 	defaultPrivValStatePath = filepath.Join(DefaultSecretsDir, defaultPrivValStateName)
+	defaultPrivValLeaseTTL  = 15 * time.Second
 	defaultNodeKeyPath      = filepath.Join(DefaultSecretsDir, defaultNodeKeyName)
 )
 
@@ -327,6 +330,18 @@ type BaseConfig struct {
 	// connections from an external PrivValidator process
 	PrivValidatorListenAddr string `toml:"priv_validator_laddr" comment:"TCP or UNIX socket address for Tendermint to listen on for\n connections from an external PrivValidator process"`
 
+	// Path to the JSON file containing the double-sign protection high-water mark
+	// of the local validator, kept independently of the node state. Disabled if empty
+	PrivValidatorSignState string `toml:"priv_validator_sign_state_file" comment:"Path to the JSON file containing the double-sign protection high-water mark\n of the local validator, kept independently of the node state. Disabled if empty"`
+
+	// Path to the JSON file holding the signing lease shared by an active / standby
+	// pair of validator nodes, on storage shared along with the sign state file.
+	// The local validator only signs while holding the lease. Disabled if empty
+	PrivValidatorLease string `toml:"priv_validator_lease_file" comment:"Path to the JSON file holding the signing lease shared by an active / standby\n pair of validator nodes, on storage shared along with the sign state file.\n The local validator only signs while holding the lease. Disabled if empty"`
+
+	// Duration of the signing lease, renewed while the validator signs
+	PrivValidatorLeaseTTL time.Duration `toml:"priv_validator_lease_ttl" comment:"Duration of the signing lease, renewed while the validator signs"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `toml:"node_key_file" comment:"Path to the JSON file containing the private key to use for node authentication in the p2p protocol"`
 
@@ -340,16 +355,17 @@ type BaseConfig struct {
 // DefaultBaseConfig returns a default base configuration for a Tendermint node
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               SocketABCI,
-		ProfListenAddress:  "",
-		FastSyncMode:       true,
-		DBBackend:          db.GoLevelDBBackend.String(),
-		DBPath:             DefaultDBDir,
+		PrivValidatorKey:      defaultPrivValKeyPath,
+		PrivValidatorState:    defaultPrivValStatePath,
+		PrivValidatorLeaseTTL: defaultPrivValLeaseTTL,
+		NodeKey:               defaultNodeKeyPath,
+		Moniker:               defaultMoniker,
+		ProxyApp:              "tcp://127.0.0.1:26658",
+		ABCI:                  SocketABCI,
+		ProfListenAddress:     "",
+		FastSyncMode:          true,
+		DBBackend:             db.GoLevelDBBackend.String(),
+		DBPath:                DefaultDBDir,
 	}
 }
 
@@ -377,6 +393,27 @@ func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return filepath.Join(cfg.RootDir, cfg.PrivValidatorState)
 }
 
+// PrivValidatorSignStateFile returns the full path to the sign state file,
+// or an empty path if the double-sign protection is disabled
+func (cfg BaseConfig) PrivValidatorSignStateFile() string {
+	return cfg.rootPath(cfg.PrivValidatorSignState)
+}
+
+// PrivValidatorLeaseFile returns the full path to the signing lease file,
+// or an empty path if the signing lease is disabled
+func (cfg BaseConfig) PrivValidatorLeaseFile() string {
+	return cfg.rootPath(cfg.PrivValidatorLease)
+}
+
+// rootPath returns the full path of an optional, possibly absolute, path
+func (cfg BaseConfig) rootPath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(cfg.RootDir, path)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return filepath.Join(cfg.RootDir, cfg.NodeKey)
@@ -440,6 +477,17 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return errInvalidPrivValidatorListenAddress
 	}
 
+	// Verify the signing lease is backed by a shared sign state
+	if cfg.PrivValidatorLease != "" {
+		if cfg.PrivValidatorSignState == "" {
+			return errPrivValidatorLeaseWithoutState
+		}
+
+		if cfg.PrivValidatorLeaseTTL <= 0 {
+			return errInvalidPrivValidatorLeaseTTL
+		}
+	}
+
 	// Verify the p2p private key exists
 	if cfg.NodeKey == "" {
 		return errInvalidNodeKeyPath
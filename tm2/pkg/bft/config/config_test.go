@@ -158,6 +158,26 @@ func TestConfig_ValidateBaseConfig(t *testing.T) {
 		assert.ErrorIs(t, c.BaseConfig.ValidateBasic(), errInvalidPrivValidatorListenAddress)
 	})
 
+	t.Run("priv validator lease without sign state", func(t *testing.T) {
+		t.Parallel()
+
+		c := DefaultConfig()
+		c.PrivValidatorLease = "lease.json"
+
+		assert.ErrorIs(t, c.BaseConfig.ValidateBasic(), errPrivValidatorLeaseWithoutState)
+	})
+
+	t.Run("invalid priv validator lease TTL", func(t *testing.T) {
+		t.Parallel()
+
+		c := DefaultConfig()
+		c.PrivValidatorSignState = "sign_state.json"
+		c.PrivValidatorLease = "lease.json"
+		c.PrivValidatorLeaseTTL = 0
+
+		assert.ErrorIs(t, c.BaseConfig.ValidateBasic(), errInvalidPrivValidatorLeaseTTL)
+	})
+
 	t.Run("node key path not set", func(t *testing.T) {
 		t.Parallel()
 
@@ -210,3 +230,29 @@ func TestConfig_DBDir(t *testing.T) {
 		assert.Equal(t, filepath.Join(c.RootDir, c.DBPath), c.DBDir())
 	})
 }
+
+func TestConfig_PrivValidatorLeaseFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lease disabled", func(t *testing.T) {
+		t.Parallel()
+
+		c := DefaultConfig()
+		c.RootDir = "/root"
+
+		assert.Empty(t, c.PrivValidatorLeaseFile())
+		assert.Empty(t, c.PrivValidatorSignStateFile())
+	})
+
+	t.Run("shared absolute paths", func(t *testing.T) {
+		t.Parallel()
+
+		c := DefaultConfig()
+		c.RootDir = "/root"
+		c.PrivValidatorSignState = "/shared/sign_state.json"
+		c.PrivValidatorLease = "/shared/lease.json"
+
+		assert.Equal(t, c.PrivValidatorSignState, c.PrivValidatorSignStateFile())
+		assert.Equal(t, c.PrivValidatorLease, c.PrivValidatorLeaseFile())
+	})
+}
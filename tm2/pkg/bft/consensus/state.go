@@ -582,6 +582,9 @@ func (cs *ConsensusState) newStep() {
 	if cs.evsw != nil {
 		cs.evsw.FireEvent(cs.EventNewRoundStep())
 	}
+
+	// Log the telemetry
+	cs.logStepTelemetry()
 }
 
 // -----------------------------------------
@@ -1793,6 +1796,16 @@ func (cs *ConsensusState) logTelemetry(block *types.Block) {
 	metrics.BlockSizeBytes.Record(context.Background(), int64(block.Size()))
 }
 
+// logStepTelemetry logs the consensus round step telemetry
+func (cs *ConsensusState) logStepTelemetry() {
+	if !telemetry.MetricsEnabled() {
+		return
+	}
+
+	metrics.ConsensusHeight.Record(context.Background(), cs.Height)
+	metrics.ConsensusRound.Record(context.Background(), int64(cs.Round))
+}
+
 // ---------------------------------------------------------
 
 func CompareHRS(h1 int64, r1 int, s1 cstypes.RoundStepType, h2 int64, r2 int, s2 cstypes.RoundStepType) int {
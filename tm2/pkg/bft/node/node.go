@@ -14,8 +14,10 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	p2pCfg "github.com/gnolang/gno/tm2/pkg/p2p/config"
 	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
 	"github.com/gnolang/gno/tm2/pkg/p2p/discovery"
+	"github.com/gnolang/gno/tm2/pkg/p2p/manager"
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 	"github.com/rs/cors"
 
@@ -111,9 +113,11 @@ func DefaultNewNode(
 		return nil, err
 	}
 
-	// Get privValKeys.
-	newPrivValKey := config.PrivValidatorKeyFile()
-	newPrivValState := config.PrivValidatorStateFile()
+	// Get the local validator, guarded by the double-sign protection if configured
+	privValidator, err := newLocalPrivValidator(config, nodeKey.ID().String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the private validator, %w", err)
+	}
 
 	// Get app client creator.
 	appClientCreator := proxy.DefaultClientCreator(
@@ -124,7 +128,7 @@ func DefaultNewNode(
 	)
 
 	return NewNode(config,
-		privval.LoadOrGenFilePV(newPrivValKey, newPrivValState),
+		privValidator,
 		nodeKey,
 		appClientCreator,
 		DefaultGenesisDocProviderFunc(genesisFile),
@@ -134,6 +138,31 @@ func DefaultNewNode(
 	)
 }
 
+// newLocalPrivValidator loads (or generates) the local validator key. If a sign
+// state file is configured, the validator is wrapped with double-sign protection,
+// and only signs while holding the signing lease, if a lease file is configured.
+// The node ID is used as the lease holder
+func newLocalPrivValidator(config *cfg.Config, holder string) (types.PrivValidator, error) {
+	filePV := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+
+	signStatePath := config.PrivValidatorSignStateFile()
+	if signStatePath == "" {
+		return filePV, nil
+	}
+
+	var opts []privval.GuardedOption
+
+	if leasePath := config.PrivValidatorLeaseFile(); leasePath != "" {
+		opts = append(opts, privval.WithSigningLease(
+			privval.NewFileLeaseStore(leasePath),
+			holder,
+			config.PrivValidatorLeaseTTL,
+		))
+	}
+
+	return privval.NewGuardedPrivValidator(filePV, privval.NewFileSignStateStore(signStatePath), opts...)
+}
+
 // Option sets a parameter for the node.
 type Option func(*Node)
 
@@ -150,7 +179,7 @@ type Node struct {
 	privValidator types.PrivValidator // local node's validator key
 
 	// network
-	transport        *p2p.MultiplexTransport
+	transport        p2p.NetworkTransport
 	sw               *p2p.MultiplexSwitch // p2p connections
 	discoveryReactor *discovery.Reactor   // discovery reactor
 	nodeInfo         p2pTypes.NodeInfo
@@ -334,6 +363,33 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
+// createTransport creates the p2p transport selected in the config
+func createTransport(
+	p2pConfig *p2pCfg.P2PConfig,
+	nodeInfo p2pTypes.NodeInfo,
+	nodeKey *p2pTypes.NodeKey,
+	logger *slog.Logger,
+) (p2p.NetworkTransport, error) {
+	mConfig := conn.MConfigFromP2P(p2pConfig)
+
+	switch p2pConfig.Transport {
+	case p2pCfg.TransportQUIC:
+		return p2p.NewQUICTransport(
+			nodeInfo,
+			*nodeKey,
+			mConfig,
+			logger.With("transport", "quic"),
+		)
+	default:
+		return p2p.NewMultiplexTransport(
+			nodeInfo,
+			*nodeKey,
+			mConfig,
+			logger.With("transport", "multiplex"),
+		), nil
+	}
+}
+
 type nodeReactor struct {
 	name    string
 	reactor p2p.Reactor
@@ -469,13 +525,11 @@ func NewNode(config *cfg.Config,
 
 	p2pLogger := logger.With("module", p2pModuleName)
 
-	// Setup the multiplex transport, used by the P2P switch
-	transport := p2p.NewMultiplexTransport(
-		nodeInfo,
-		*nodeKey,
-		conn.MConfigFromP2P(config.P2P),
-		p2pLogger.With("transport", "multiplex"),
-	)
+	// Setup the transport, used by the P2P switch
+	transport, err := createTransport(config.P2P, nodeInfo, nodeKey, p2pLogger)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create p2p transport")
+	}
 
 	var discoveryReactor *discovery.Reactor
 
@@ -506,8 +560,20 @@ func NewNode(config *cfg.Config,
 		p2pLogger.Error("invalid private peer ID", "err", err)
 	}
 
+	// Set up the peer manager, with the persisted address book
+	peerManager := manager.New(
+		manager.WithBanThreshold(config.P2P.BanThreshold),
+		manager.WithBanDuration(config.P2P.BanDuration),
+		manager.WithAddressBook(config.P2P.AddrBookFile()),
+	)
+
+	if err := peerManager.Load(); err != nil {
+		return nil, fmt.Errorf("unable to load p2p address book, %w", err)
+	}
+
 	// Prepare the misc switch options
 	opts := []p2p.SwitchOption{
+		p2p.WithPeerManager(peerManager),
 		p2p.WithPersistentPeers(peerAddrs),
 		p2p.WithPrivatePeers(privatePeerIDs),
 		p2p.WithMaxInboundPeers(config.P2P.MaxNumInboundPeers),
@@ -683,6 +749,13 @@ func (n *Node) OnStop() {
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		pvsc.Stop()
 	}
+
+	// let a standby validator take over right away
+	if gpv, ok := n.privValidator.(*privval.GuardedPrivValidator); ok {
+		if err := gpv.ReleaseLease(); err != nil {
+			n.Logger.Error("unable to release the signing lease", "err", err)
+		}
+	}
 }
 
 // Ready signals that the node is ready by returning a blocking channel. This channel is closed when the node receives its first block.
@@ -699,6 +772,7 @@ func (n *Node) configureRPC() {
 	rpccore.SetMempool(n.mempool)
 	rpccore.SetP2PPeers(n.sw)
 	rpccore.SetP2PTransport(n)
+	rpccore.SetP2PBans(n.sw)
 	pubKey := n.privValidator.GetPubKey()
 	rpccore.SetPubKey(pubKey)
 	rpccore.SetGenesisDoc(n.genesisDoc)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
@@ -69,6 +70,44 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodeGuardedPrivValidator(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_guarded_priv_val_test")
+	defer os.RemoveAll(config.RootDir)
+
+	sharedDir := t.TempDir()
+	config.BaseConfig.PrivValidatorSignState = filepath.Join(sharedDir, "sign_state.json")
+	config.BaseConfig.PrivValidatorLease = filepath.Join(sharedDir, "lease.json")
+
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewNoopLogger())
+	require.NoError(t, err)
+	require.IsType(t, &privval.GuardedPrivValidator{}, n.PrivValidator())
+	require.NoError(t, n.Start())
+
+	// wait for the node to produce a block
+	blocksSub := events.SubscribeToEvent(n.EventSwitch(), "node_test", types.EventNewBlock{})
+	select {
+	case <-blocksSub:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the node to produce a block")
+	}
+
+	// the lease is held by the node, so a standby can't take it over
+	leases := privval.NewFileLeaseStore(config.PrivValidatorLeaseFile())
+	_, err = leases.Acquire("standby", time.Now(), time.Second)
+	assert.ErrorIs(t, err, privval.ErrLeaseHeld)
+
+	// the high-water mark was saved
+	state, err := privval.NewFileSignStateStore(config.PrivValidatorSignStateFile()).Load()
+	require.NoError(t, err)
+	assert.Positive(t, state.Height)
+
+	// stopping the node releases the lease
+	require.NoError(t, n.Stop())
+
+	_, err = leases.Acquire("standby", time.Now(), time.Second)
+	assert.NoError(t, err)
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
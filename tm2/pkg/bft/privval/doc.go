@@ -15,5 +15,18 @@ SignerListenerEndpoint takes a listener, which determines the type of connection
 # SignerDialerEndpoint
 
 SignerDialerEndpoint is a simple wrapper around a net.Conn. It's used by both IPCVal and TCPVal.
+
+# GuardedPrivValidator
+
+GuardedPrivValidator wraps any PrivValidator (usually the one served by a SignerServer) with double-sign protection
+that doesn't rely on the node. It keeps a height/round/step high-water mark in a SignStateStore,
+which is durably saved before any signature is handed out, and refuses regressions and conflicting sign requests.
+
+For high availability, an active and a standby signer can share the same SignStateStore and a LeaseStore
+(ex. FileSignStateStore and FileLeaseStore on shared storage). Only the lease holder signs;
+the standby takes over once the lease expires, and reloads the high-water mark before signing.
+
+A node signing with its local key is guarded this way when its priv_validator_sign_state_file
+(and priv_validator_lease_file) options are set.
 */
 package privval
@@ -21,6 +21,14 @@ var (
 	ErrWriteTimeout = fmt.Errorf("endpoint write timed out")
 )
 
+// Double-sign protection errors.
+var (
+	ErrConflictingSignature = fmt.Errorf("conflicting sign request for an already signed height/round/step")
+	ErrSignStateRegression  = fmt.Errorf("sign request regresses the signed height/round/step")
+	ErrLeaseHeld            = fmt.Errorf("signing lease is held")
+	ErrLeaseNotHeld         = fmt.Errorf("signing lease is not held")
+)
+
 // RemoteSignerError allows (remote) validators to include meaningful error descriptions in their reply.
 type RemoteSignerError struct {
 	// TODO(ismail): create an enum of known errors
@@ -290,6 +290,10 @@ func (pv *FilePV) signVote(chainID string, vote *types.Vote) error {
 		return err
 	}
 
+	// The randomness is deterministic, and part of the sign bytes.
+	if err := vote.SignRandomness(chainID, pv.Key.PrivKey); err != nil {
+		return err
+	}
 	signBytes := vote.SignBytes(chainID)
 
 	// We might crash before writing to the wal,
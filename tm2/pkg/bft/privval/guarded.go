@@ -0,0 +1,309 @@
+package privval
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// GuardedOption is a GuardedPrivValidator configuration option
+type GuardedOption func(*GuardedPrivValidator)
+
+// WithSigningLease makes the signer sign only while holding the
+// lease in the given store, under the given holder ID.
+// The lease is renewed on sign requests, and granted for the given TTL
+func WithSigningLease(store LeaseStore, holder string, ttl time.Duration) GuardedOption {
+	return func(g *GuardedPrivValidator) {
+		g.lease = &signingLease{
+			store:  store,
+			holder: holder,
+			ttl:    ttl,
+		}
+	}
+}
+
+// signingLease is the lease state of a single signer instance
+type signingLease struct {
+	store  LeaseStore
+	holder string
+	ttl    time.Duration
+
+	expires time.Time // zero if the lease is not held
+}
+
+// margin is the part of the lease TTL the signer gives up,
+// to tolerate clock drift between the signer instances
+func (l *signingLease) margin() time.Duration {
+	return l.ttl / 3
+}
+
+// GuardedPrivValidator wraps a PrivValidator with double-sign protection
+// that is independent of the node. Every signature is checked against a
+// height/round/step (HRS) high-water mark, and the mark is durably saved
+// before the signature is handed out. Regressions, and conflicting requests
+// for an already signed HRS, are refused.
+//
+// When configured with a signing lease, the wrapper only signs while holding
+// the lease, allowing an active / standby pair of signers that share
+// the same SignStateStore and LeaseStore
+type GuardedPrivValidator struct {
+	mux sync.Mutex
+
+	privVal types.PrivValidator
+	store   SignStateStore
+	lease   *signingLease
+
+	state FilePVLastSignState // the in-memory copy of the high-water mark
+	now   func() time.Time
+}
+
+// NewGuardedPrivValidator creates a new guarded signer around the given
+// PrivValidator, loading the current high-water mark from the store
+func NewGuardedPrivValidator(
+	privVal types.PrivValidator,
+	store SignStateStore,
+	opts ...GuardedOption,
+) (*GuardedPrivValidator, error) {
+	g := &GuardedPrivValidator{
+		privVal: privVal,
+		store:   store,
+		now:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	state, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load sign state, %w", err)
+	}
+
+	g.state = state
+
+	return g, nil
+}
+
+// GetPubKey returns the public key of the wrapped signer
+func (g *GuardedPrivValidator) GetPubKey() crypto.PubKey {
+	return g.privVal.GetPubKey()
+}
+
+// SignVote signs the vote, if it doesn't conflict with the high-water mark
+func (g *GuardedPrivValidator) SignVote(chainID string, vote *types.Vote) error {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+
+	if err := g.ensureLease(); err != nil {
+		return err
+	}
+
+	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+
+	sameHRS, err := g.checkHRS(height, round, step)
+	if err != nil {
+		return err
+	}
+
+	// The vote was already signed for this HRS (ex. the node crashed
+	// before persisting it). Hand out the same signature, if possible
+	if sameHRS {
+		// The randomness of a vote only depends on its height,
+		// so it's the one of the last signed vote
+		vote.Randomness = lastVoteRandomness(g.state.SignBytes)
+		signBytes := vote.SignBytes(chainID)
+
+		if bytes.Equal(signBytes, g.state.SignBytes) {
+			vote.Signature = g.state.Signature
+
+			return nil
+		}
+
+		if timestamp, ok := checkVotesOnlyDifferByTimestamp(g.state.SignBytes, signBytes); ok {
+			vote.Timestamp = timestamp
+			vote.Signature = g.state.Signature
+
+			return nil
+		}
+
+		return ErrConflictingSignature
+	}
+
+	if err := g.privVal.SignVote(chainID, vote); err != nil {
+		return err
+	}
+
+	// Persist the high-water mark before releasing the signature
+	if err := g.saveSigned(height, round, step, vote.SignBytes(chainID), vote.Signature); err != nil {
+		vote.Signature = nil
+
+		return err
+	}
+
+	return nil
+}
+
+// SignProposal signs the proposal, if it doesn't conflict with the high-water mark
+func (g *GuardedPrivValidator) SignProposal(chainID string, proposal *types.Proposal) error {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+
+	if err := g.ensureLease(); err != nil {
+		return err
+	}
+
+	height, round, step := proposal.Height, proposal.Round, stepPropose
+
+	sameHRS, err := g.checkHRS(height, round, step)
+	if err != nil {
+		return err
+	}
+
+	// The proposal was already signed for this HRS (ex. the node crashed
+	// before persisting it). Hand out the same signature, if possible
+	if sameHRS {
+		signBytes := proposal.SignBytes(chainID)
+
+		if bytes.Equal(signBytes, g.state.SignBytes) {
+			proposal.Signature = g.state.Signature
+
+			return nil
+		}
+
+		if timestamp, ok := checkProposalsOnlyDifferByTimestamp(g.state.SignBytes, signBytes); ok {
+			proposal.Timestamp = timestamp
+			proposal.Signature = g.state.Signature
+
+			return nil
+		}
+
+		return ErrConflictingSignature
+	}
+
+	if err := g.privVal.SignProposal(chainID, proposal); err != nil {
+		return err
+	}
+
+	// Persist the high-water mark before releasing the signature
+	if err := g.saveSigned(height, round, step, proposal.SignBytes(chainID), proposal.Signature); err != nil {
+		proposal.Signature = nil
+
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseLease gives up the signing lease (if configured),
+// allowing a standby signer to take over without waiting for it to expire
+func (g *GuardedPrivValidator) ReleaseLease() error {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+
+	if g.lease == nil {
+		return nil
+	}
+
+	g.lease.expires = time.Time{}
+
+	if err := g.lease.store.Release(g.lease.holder); err != nil {
+		return fmt.Errorf("unable to release lease, %w", err)
+	}
+
+	return nil
+}
+
+// String returns a string representation of the guarded signer
+func (g *GuardedPrivValidator) String() string {
+	return fmt.Sprintf("GuardedPrivValidator{%v LH:%v, LR:%v, LS:%v}", g.privVal, g.state.Height, g.state.Round, g.state.Step)
+}
+
+// ensureLease makes sure the signer holds the signing lease, with enough
+// time left on it to safely sign. The lease is renewed once half of it is used up.
+// When the lease is (re)acquired after not being held, the high-water mark
+// is reloaded, since a different signer could have advanced it
+func (g *GuardedPrivValidator) ensureLease() error {
+	if g.lease == nil {
+		return nil
+	}
+
+	now := g.now()
+
+	if now.Add(g.lease.ttl / 2).Before(g.lease.expires) {
+		// The lease is held, and fresh
+		return nil
+	}
+
+	wasHeld := now.Add(g.lease.margin()).Before(g.lease.expires)
+
+	lease, err := g.lease.store.Acquire(g.lease.holder, now, g.lease.ttl)
+	if err != nil {
+		g.lease.expires = time.Time{}
+
+		return fmt.Errorf("%w, %w", ErrLeaseNotHeld, err)
+	}
+
+	if !wasHeld {
+		state, err := g.store.Load()
+		if err != nil {
+			return fmt.Errorf("unable to reload sign state, %w", err)
+		}
+
+		g.state = state
+	}
+
+	// The lease is only marked as held once the sign state is up to date
+	g.lease.expires = lease.Expires
+
+	return nil
+}
+
+// checkHRS checks the HRS against the high-water mark
+func (g *GuardedPrivValidator) checkHRS(height int64, round int, step int8) (bool, error) {
+	sameHRS, err := g.state.CheckHRS(height, round, step)
+	if err != nil {
+		return false, fmt.Errorf("%w, %w", ErrSignStateRegression, err)
+	}
+
+	return sameHRS, nil
+}
+
+// saveSigned durably persists the new high-water mark
+func (g *GuardedPrivValidator) saveSigned(
+	height int64,
+	round int,
+	step int8,
+	signBytes,
+	sig []byte,
+) error {
+	state := FilePVLastSignState{
+		Height:    height,
+		Round:     round,
+		Step:      step,
+		Signature: sig,
+		SignBytes: signBytes,
+	}
+
+	if err := g.store.Save(state); err != nil {
+		return fmt.Errorf("unable to save sign state, %w", err)
+	}
+
+	g.state = state
+
+	return nil
+}
+
+// lastVoteRandomness returns the randomness of the vote with the given sign bytes
+func lastVoteRandomness(signBytes []byte) []byte {
+	var vote types.CanonicalVote
+	if err := amino.UnmarshalSized(signBytes, &vote); err != nil {
+		return nil
+	}
+
+	return vote.Randomness
+}
@@ -0,0 +1,340 @@
+package privval
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+)
+
+const guardedChainID = "guarded-chain"
+
+// mockSignStateStore is a mock SignStateStore
+type mockSignStateStore struct {
+	loadFn func() (FilePVLastSignState, error)
+	saveFn func(FilePVLastSignState) error
+}
+
+func (m *mockSignStateStore) Load() (FilePVLastSignState, error) {
+	if m.loadFn != nil {
+		return m.loadFn()
+	}
+
+	return FilePVLastSignState{}, nil
+}
+
+func (m *mockSignStateStore) Save(state FilePVLastSignState) error {
+	if m.saveFn != nil {
+		return m.saveFn(state)
+	}
+
+	return nil
+}
+
+// newGuardedTestSigner creates a guarded signer around a stateless mock PV
+func newGuardedTestSigner(
+	t *testing.T,
+	privVal types.PrivValidator,
+	store SignStateStore,
+	opts ...GuardedOption,
+) *GuardedPrivValidator {
+	t.Helper()
+
+	g, err := NewGuardedPrivValidator(privVal, store, opts...)
+	require.NoError(t, err)
+
+	return g
+}
+
+func TestGuardedPrivValidator_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sign state load failure", func(t *testing.T) {
+		t.Parallel()
+
+		loadErr := errors.New("load error")
+
+		store := &mockSignStateStore{
+			loadFn: func() (FilePVLastSignState, error) {
+				return FilePVLastSignState{}, loadErr
+			},
+		}
+
+		g, err := NewGuardedPrivValidator(types.NewMockPV(), store)
+
+		assert.Nil(t, g)
+		assert.ErrorIs(t, err, loadErr)
+	})
+
+	t.Run("sign state loaded", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewFileSignStateStore(filepath.Join(t.TempDir(), "state.json"))
+		require.NoError(t, store.Save(FilePVLastSignState{Height: 10, Step: stepPrevote}))
+
+		g := newGuardedTestSigner(t, types.NewMockPV(), store)
+
+		// Signing below the high-water mark is refused
+		vote := newVote(types.Address{}, 0, 9, 0, byte(types.PrevoteType), types.BlockID{})
+		assert.ErrorIs(t, g.SignVote(guardedChainID, vote), ErrSignStateRegression)
+		assert.Nil(t, vote.Signature)
+	})
+}
+
+func TestGuardedPrivValidator_SignVote(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blockID1 = types.BlockID{Hash: []byte{1, 2, 3}}
+		blockID2 = types.BlockID{Hash: []byte{3, 2, 1}}
+	)
+
+	t.Run("vote signed and persisted", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "state.json")
+		pv := types.NewMockPV()
+		g := newGuardedTestSigner(t, pv, NewFileSignStateStore(path))
+
+		vote := newVote(pv.GetPubKey().Address(), 0, 10, 1, byte(types.PrevoteType), blockID1)
+		require.NoError(t, g.SignVote(guardedChainID, vote))
+
+		assert.True(t, pv.GetPubKey().VerifyBytes(vote.SignBytes(guardedChainID), vote.Signature))
+
+		// Make sure the high-water mark is on disk
+		state, err := NewFileSignStateStore(path).Load()
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(10), state.Height)
+		assert.Equal(t, 1, state.Round)
+		assert.Equal(t, stepPrevote, state.Step)
+		assert.Equal(t, vote.Signature, state.Signature)
+		assert.Equal(t, vote.SignBytes(guardedChainID), state.SignBytes)
+	})
+
+	t.Run("conflicting vote refused", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGuardedTestSigner(t, types.NewMockPV(), &mockSignStateStore{})
+
+		vote := newVote(types.Address{}, 0, 10, 1, byte(types.PrevoteType), blockID1)
+		require.NoError(t, g.SignVote(guardedChainID, vote))
+
+		conflicting := newVote(types.Address{}, 0, 10, 1, byte(types.PrevoteType), blockID2)
+		assert.ErrorIs(t, g.SignVote(guardedChainID, conflicting), ErrConflictingSignature)
+		assert.Nil(t, conflicting.Signature)
+	})
+
+	t.Run("same vote re-signed", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGuardedTestSigner(t, types.NewMockPV(), &mockSignStateStore{})
+
+		vote := newVote(types.Address{}, 0, 10, 1, byte(types.PrevoteType), blockID1)
+		require.NoError(t, g.SignVote(guardedChainID, vote))
+
+		// Only the timestamp differs, the previous vote is reused
+		again := newVote(types.Address{}, 0, 10, 1, byte(types.PrevoteType), blockID1)
+		again.Timestamp = vote.Timestamp.Add(time.Second)
+
+		require.NoError(t, g.SignVote(guardedChainID, again))
+
+		assert.Equal(t, vote.Signature, again.Signature)
+		assert.True(t, vote.Timestamp.Equal(again.Timestamp))
+	})
+
+	t.Run("same precommit re-signed", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGuardedTestSigner(t, types.NewMockPV(), &mockSignStateStore{})
+
+		vote := newVote(types.Address{}, 0, 10, 1, byte(types.PrecommitType), blockID1)
+		require.NoError(t, g.SignVote(guardedChainID, vote))
+		require.NotEmpty(t, vote.Randomness)
+
+		// The randomness is restored from the previous vote
+		again := newVote(types.Address{}, 0, 10, 1, byte(types.PrecommitType), blockID1)
+		again.Timestamp = vote.Timestamp.Add(time.Second)
+
+		require.NoError(t, g.SignVote(guardedChainID, again))
+
+		assert.Equal(t, vote.Signature, again.Signature)
+		assert.Equal(t, vote.Randomness, again.Randomness)
+	})
+
+	t.Run("state save failure", func(t *testing.T) {
+		t.Parallel()
+
+		saveErr := errors.New("save error")
+
+		store := &mockSignStateStore{
+			saveFn: func(FilePVLastSignState) error {
+				return saveErr
+			},
+		}
+
+		g := newGuardedTestSigner(t, types.NewMockPV(), store)
+
+		vote := newVote(types.Address{}, 0, 10, 1, byte(types.PrevoteType), blockID1)
+
+		assert.ErrorIs(t, g.SignVote(guardedChainID, vote), saveErr)
+		assert.Nil(t, vote.Signature)
+	})
+}
+
+func TestGuardedPrivValidator_SignProposal(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blockID1 = types.BlockID{Hash: []byte{1, 2, 3}}
+		blockID2 = types.BlockID{Hash: []byte{3, 2, 1}}
+	)
+
+	t.Run("conflicting proposal refused", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGuardedTestSigner(t, types.NewMockPV(), &mockSignStateStore{})
+
+		proposal := newProposal(10, 1, blockID1)
+		require.NoError(t, g.SignProposal(guardedChainID, proposal))
+		require.NotNil(t, proposal.Signature)
+
+		conflicting := newProposal(10, 1, blockID2)
+		assert.ErrorIs(t, g.SignProposal(guardedChainID, conflicting), ErrConflictingSignature)
+		assert.Nil(t, conflicting.Signature)
+	})
+
+	t.Run("proposal after vote refused", func(t *testing.T) {
+		t.Parallel()
+
+		g := newGuardedTestSigner(t, types.NewMockPV(), &mockSignStateStore{})
+
+		vote := newVote(types.Address{}, 0, 10, 1, byte(types.PrecommitType), blockID1)
+		require.NoError(t, g.SignVote(guardedChainID, vote))
+
+		// The proposal step precedes the precommit step
+		proposal := newProposal(10, 1, blockID1)
+		assert.ErrorIs(t, g.SignProposal(guardedChainID, proposal), ErrSignStateRegression)
+	})
+}
+
+func TestGuardedPrivValidator_Lease(t *testing.T) {
+	t.Parallel()
+
+	const ttl = 9 * time.Second
+
+	var (
+		blockID = types.BlockID{Hash: []byte{1, 2, 3}}
+		start   = time.Unix(1_000_000, 0).UTC()
+	)
+
+	// newSignerPair creates an active / standby pair sharing
+	// the same key, sign state store and lease store
+	newSignerPair := func(t *testing.T) (*GuardedPrivValidator, *GuardedPrivValidator) {
+		t.Helper()
+
+		var (
+			dir        = t.TempDir()
+			key        = ed25519.GenPrivKey()
+			stateStore = NewFileSignStateStore(filepath.Join(dir, "state.json"))
+			leaseStore = NewFileLeaseStore(filepath.Join(dir, "lease.json"))
+		)
+
+		active := newGuardedTestSigner(
+			t,
+			types.NewMockPVWithParams(key, false, false),
+			stateStore,
+			WithSigningLease(leaseStore, "active", ttl),
+		)
+		standby := newGuardedTestSigner(
+			t,
+			types.NewMockPVWithParams(key, false, false),
+			stateStore,
+			WithSigningLease(leaseStore, "standby", ttl),
+		)
+
+		active.now = func() time.Time { return start }
+		standby.now = func() time.Time { return start }
+
+		return active, standby
+	}
+
+	t.Run("standby refused while lease held", func(t *testing.T) {
+		t.Parallel()
+
+		active, standby := newSignerPair(t)
+
+		require.NoError(t, active.SignVote(guardedChainID, newVote(types.Address{}, 0, 10, 0, byte(types.PrevoteType), blockID)))
+
+		vote := newVote(types.Address{}, 0, 11, 0, byte(types.PrevoteType), blockID)
+		assert.ErrorIs(t, standby.SignVote(guardedChainID, vote), ErrLeaseNotHeld)
+		assert.Nil(t, vote.Signature)
+	})
+
+	t.Run("standby takes over expired lease", func(t *testing.T) {
+		t.Parallel()
+
+		active, standby := newSignerPair(t)
+
+		vote := newVote(types.Address{}, 0, 10, 0, byte(types.PrevoteType), blockID)
+		require.NoError(t, active.SignVote(guardedChainID, vote))
+
+		// Let the lease lapse
+		standby.now = func() time.Time { return start.Add(ttl) }
+
+		// The standby picks up the high-water mark of the active signer
+		conflicting := newVote(types.Address{}, 0, 10, 0, byte(types.PrevoteType), types.BlockID{})
+		assert.ErrorIs(t, standby.SignVote(guardedChainID, conflicting), ErrConflictingSignature)
+
+		next := newVote(types.Address{}, 0, 11, 0, byte(types.PrevoteType), blockID)
+		require.NoError(t, standby.SignVote(guardedChainID, next))
+
+		// The former active signer can't sign anymore
+		active.now = standby.now
+
+		assert.ErrorIs(
+			t,
+			active.SignVote(guardedChainID, newVote(types.Address{}, 0, 12, 0, byte(types.PrevoteType), blockID)),
+			ErrLeaseNotHeld,
+		)
+	})
+
+	t.Run("lease renewed by active signer", func(t *testing.T) {
+		t.Parallel()
+
+		active, standby := newSignerPair(t)
+
+		require.NoError(t, active.SignVote(guardedChainID, newVote(types.Address{}, 0, 10, 0, byte(types.PrevoteType), blockID)))
+
+		// The lease is renewed when more than half of it is used up
+		active.now = func() time.Time { return start.Add(ttl/2 + time.Second) }
+
+		require.NoError(t, active.SignVote(guardedChainID, newVote(types.Address{}, 0, 11, 0, byte(types.PrevoteType), blockID)))
+
+		// The standby can't take over the renewed lease
+		standby.now = func() time.Time { return start.Add(ttl) }
+
+		assert.ErrorIs(
+			t,
+			standby.SignVote(guardedChainID, newVote(types.Address{}, 0, 12, 0, byte(types.PrevoteType), blockID)),
+			ErrLeaseNotHeld,
+		)
+	})
+
+	t.Run("released lease taken over", func(t *testing.T) {
+		t.Parallel()
+
+		active, standby := newSignerPair(t)
+
+		require.NoError(t, active.SignVote(guardedChainID, newVote(types.Address{}, 0, 10, 0, byte(types.PrevoteType), blockID)))
+		require.NoError(t, active.ReleaseLease())
+
+		require.NoError(t, standby.SignVote(guardedChainID, newVote(types.Address{}, 0, 11, 0, byte(types.PrevoteType), blockID)))
+	})
+}
@@ -0,0 +1,175 @@
+package privval
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+const (
+	leaseLockRetryInterval = 10 * time.Millisecond
+	leaseLockTimeout       = 2 * time.Second
+)
+
+var (
+	errLeaseLockTimeout = errors.New("timed out waiting for the lease lock")
+	errLeaseLockBusy    = errors.New("lease lock is held")
+)
+
+// Lease is a time-bound grant to sign, held by a single signer instance
+type Lease struct {
+	Holder  string    `json:"holder"`  // the ID of the signer holding the lease
+	Expires time.Time `json:"expires"` // the moment the lease lapses, unless renewed
+}
+
+// LeaseStore coordinates the signing lease between
+// the instances of an active / standby signer pair
+type LeaseStore interface {
+	// Acquire acquires the lease for the given holder, or renews it
+	// if the holder already has it. The lease is granted for the given TTL,
+	// starting at now. Acquire fails with ErrLeaseHeld if the lease
+	// is held by a different holder, and has not yet expired
+	Acquire(holder string, now time.Time, ttl time.Duration) (Lease, error)
+
+	// Release gives up the lease, if it is held by the given holder
+	Release(holder string) error
+}
+
+// FileLeaseStore is a LeaseStore backed by a single file,
+// meant to be placed on storage shared by all signer instances.
+// Updates are serialized using an advisory lock (flock) on a lock file
+type FileLeaseStore struct {
+	mux      sync.Mutex
+	path     string
+	lockPath string
+}
+
+// NewFileLeaseStore creates a new file-backed lease store.
+// The directory containing the path must already exist
+func NewFileLeaseStore(path string) *FileLeaseStore {
+	return &FileLeaseStore{
+		path:     path,
+		lockPath: path + ".lock",
+	}
+}
+
+// Acquire acquires or renews the lease in the lease file
+func (s *FileLeaseStore) Acquire(holder string, now time.Time, ttl time.Duration) (Lease, error) {
+	var lease Lease
+
+	err := s.withLock(func() error {
+		current, err := s.load()
+		if err != nil {
+			return err
+		}
+
+		if current.Holder != "" && current.Holder != holder && now.Before(current.Expires) {
+			return fmt.Errorf(
+				"%w by %q until %s",
+				ErrLeaseHeld,
+				current.Holder,
+				current.Expires.Format(time.RFC3339Nano),
+			)
+		}
+
+		lease = Lease{
+			Holder:  holder,
+			Expires: now.Add(ttl),
+		}
+
+		return s.save(lease)
+	})
+
+	return lease, err
+}
+
+// Release clears the lease in the lease file, if it belongs to the holder
+func (s *FileLeaseStore) Release(holder string) error {
+	return s.withLock(func() error {
+		current, err := s.load()
+		if err != nil {
+			return err
+		}
+
+		if current.Holder != holder {
+			return nil
+		}
+
+		return s.save(Lease{})
+	})
+}
+
+// load reads the lease file. A missing file is an empty lease
+func (s *FileLeaseStore) load() (Lease, error) {
+	leaseBytes, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Lease{}, nil
+	}
+
+	if err != nil {
+		return Lease{}, fmt.Errorf("unable to read lease, %w", err)
+	}
+
+	var lease Lease
+	if err := amino.UnmarshalJSON(leaseBytes, &lease); err != nil {
+		return Lease{}, fmt.Errorf("unable to unmarshal lease, %w", err)
+	}
+
+	return lease, nil
+}
+
+// save atomically overwrites the lease file
+func (s *FileLeaseStore) save(lease Lease) error {
+	leaseBytes, err := amino.MarshalJSONIndent(lease, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal lease, %w", err)
+	}
+
+	if err := osm.WriteFileAtomic(s.path, leaseBytes, 0o600); err != nil {
+		return fmt.Errorf("unable to write lease, %w", err)
+	}
+
+	return nil
+}
+
+// withLock runs the callback while holding the lease lock.
+// The lock file itself is never removed: the lock is held by the kernel,
+// and released when the file is closed, or when the holder crashes
+func (s *FileLeaseStore) withLock(cb func() error) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	f, err := os.OpenFile(s.lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open lease lock, %w", err)
+	}
+	defer f.Close()
+
+	deadline := time.Now().Add(leaseLockTimeout)
+
+	for {
+		err := tryLockFile(f)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, errLeaseLockBusy) {
+			return fmt.Errorf("unable to acquire lease lock, %w", err)
+		}
+
+		if time.Now().After(deadline) {
+			return errLeaseLockTimeout
+		}
+
+		time.Sleep(leaseLockRetryInterval)
+	}
+
+	defer unlockFile(f) //nolint:errcheck // released on close anyway
+
+	return cb()
+}
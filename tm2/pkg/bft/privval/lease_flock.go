@@ -0,0 +1,26 @@
+//go:build !js && !wasm
+// +build !js,!wasm
+
+package privval
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLockFile takes an exclusive advisory lock on the file,
+// failing with errLeaseLockBusy if it's already held
+func tryLockFile(f *os.File) error {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return errLeaseLockBusy
+	}
+
+	return err
+}
+
+// unlockFile releases the advisory lock on the file
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
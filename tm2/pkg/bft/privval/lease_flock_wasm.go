@@ -0,0 +1,19 @@
+//go:build js && wasm
+// +build js,wasm
+
+package privval
+
+import (
+	"errors"
+	"os"
+)
+
+var errFileLockUnsupported = errors.New("file locks are not supported")
+
+func tryLockFile(_ *os.File) error {
+	return errFileLockUnsupported
+}
+
+func unlockFile(_ *os.File) error {
+	return errFileLockUnsupported
+}
@@ -0,0 +1,162 @@
+package privval
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLeaseStore_Acquire(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now = time.Unix(1_000_000, 0).UTC()
+		ttl = 10 * time.Second
+	)
+
+	t.Run("free lease acquired", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewFileLeaseStore(filepath.Join(t.TempDir(), "lease.json"))
+
+		lease, err := store.Acquire("signer-a", now, ttl)
+		require.NoError(t, err)
+
+		assert.Equal(t, "signer-a", lease.Holder)
+		assert.True(t, lease.Expires.Equal(now.Add(ttl)))
+	})
+
+	t.Run("lease renewed by holder", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewFileLeaseStore(filepath.Join(t.TempDir(), "lease.json"))
+
+		_, err := store.Acquire("signer-a", now, ttl)
+		require.NoError(t, err)
+
+		lease, err := store.Acquire("signer-a", now.Add(ttl/2), ttl)
+		require.NoError(t, err)
+
+		assert.True(t, lease.Expires.Equal(now.Add(ttl/2).Add(ttl)))
+	})
+
+	t.Run("held lease refused", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "lease.json")
+
+		_, err := NewFileLeaseStore(path).Acquire("signer-a", now, ttl)
+		require.NoError(t, err)
+
+		// Attempt to grab the lease from a different store instance
+		_, err = NewFileLeaseStore(path).Acquire("signer-b", now.Add(ttl/2), ttl)
+		assert.ErrorIs(t, err, ErrLeaseHeld)
+	})
+
+	t.Run("expired lease taken over", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "lease.json")
+
+		_, err := NewFileLeaseStore(path).Acquire("signer-a", now, ttl)
+		require.NoError(t, err)
+
+		lease, err := NewFileLeaseStore(path).Acquire("signer-b", now.Add(ttl), ttl)
+		require.NoError(t, err)
+
+		assert.Equal(t, "signer-b", lease.Holder)
+	})
+
+	t.Run("lock file left behind", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewFileLeaseStore(filepath.Join(t.TempDir(), "lease.json"))
+
+		// Simulate a lock file left behind by a crashed process
+		require.NoError(t, os.WriteFile(store.lockPath, nil, 0o600))
+
+		_, err := store.Acquire("signer-a", now, ttl)
+		require.NoError(t, err)
+	})
+
+	t.Run("concurrent acquisitions", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "lease.json")
+
+		const signers = 10
+
+		var (
+			wg      sync.WaitGroup
+			granted atomic.Int32
+		)
+
+		for i := 0; i < signers; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				// Each signer has its own store, as if on a different machine
+				_, err := NewFileLeaseStore(path).Acquire(fmt.Sprintf("signer-%d", i), now, ttl)
+				if err == nil {
+					granted.Add(1)
+
+					return
+				}
+
+				assert.ErrorIs(t, err, ErrLeaseHeld)
+			}(i)
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(1), granted.Load())
+	})
+}
+
+func TestFileLeaseStore_Release(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now = time.Unix(1_000_000, 0).UTC()
+		ttl = 10 * time.Second
+	)
+
+	t.Run("release by non-holder ignored", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewFileLeaseStore(filepath.Join(t.TempDir(), "lease.json"))
+
+		_, err := store.Acquire("signer-a", now, ttl)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Release("signer-b"))
+
+		_, err = store.Acquire("signer-b", now, ttl)
+		assert.ErrorIs(t, err, ErrLeaseHeld)
+	})
+
+	t.Run("released lease acquired", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewFileLeaseStore(filepath.Join(t.TempDir(), "lease.json"))
+
+		_, err := store.Acquire("signer-a", now, ttl)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Release("signer-a"))
+
+		lease, err := store.Acquire("signer-b", now, ttl)
+		require.NoError(t, err)
+
+		assert.Equal(t, "signer-b", lease.Holder)
+	})
+}
@@ -0,0 +1,78 @@
+package privval
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+// SignStateStore persists the height/round/step high-water mark
+// of a signer, along with the last produced signature
+type SignStateStore interface {
+	// Load returns the last saved sign state.
+	// If no state was ever saved, an empty state is returned
+	Load() (FilePVLastSignState, error)
+
+	// Save durably persists the given sign state.
+	// The state must be on stable storage by the time Save returns
+	Save(state FilePVLastSignState) error
+}
+
+// FileSignStateStore is a SignStateStore backed by a single file.
+// Writes are atomic, and synced to disk before being renamed in place,
+// so a crash never leaves a partially written state behind
+type FileSignStateStore struct {
+	mux  sync.Mutex
+	path string
+}
+
+// NewFileSignStateStore creates a new file-backed sign state store.
+// The directory containing the path must already exist
+func NewFileSignStateStore(path string) *FileSignStateStore {
+	return &FileSignStateStore{
+		path: path,
+	}
+}
+
+// Load returns the sign state saved in the file, if any
+func (s *FileSignStateStore) Load() (FilePVLastSignState, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	stateBytes, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return FilePVLastSignState{Step: stepNone}, nil
+	}
+
+	if err != nil {
+		return FilePVLastSignState{}, fmt.Errorf("unable to read sign state, %w", err)
+	}
+
+	var state FilePVLastSignState
+	if err := amino.UnmarshalJSON(stateBytes, &state); err != nil {
+		return FilePVLastSignState{}, fmt.Errorf("unable to unmarshal sign state, %w", err)
+	}
+
+	return state, nil
+}
+
+// Save atomically overwrites the sign state file
+func (s *FileSignStateStore) Save(state FilePVLastSignState) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	stateBytes, err := amino.MarshalJSONIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal sign state, %w", err)
+	}
+
+	if err := osm.WriteFileAtomic(s.path, stateBytes, 0o600); err != nil {
+		return fmt.Errorf("unable to write sign state, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package privval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSignStateStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing state file", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewFileSignStateStore(filepath.Join(t.TempDir(), "state.json"))
+
+		state, err := store.Load()
+		require.NoError(t, err)
+
+		assert.Equal(t, FilePVLastSignState{Step: stepNone}, state)
+	})
+
+	t.Run("corrupted state file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "state.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		_, err := NewFileSignStateStore(path).Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("state saved and loaded", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "state.json")
+
+		state := FilePVLastSignState{
+			Height:    10,
+			Round:     2,
+			Step:      stepPrecommit,
+			Signature: []byte("signature"),
+			SignBytes: []byte("sign bytes"),
+		}
+
+		require.NoError(t, NewFileSignStateStore(path).Save(state))
+
+		// Load the state using a fresh store
+		loaded, err := NewFileSignStateStore(path).Load()
+		require.NoError(t, err)
+
+		assert.Equal(t, state, loaded)
+	})
+}
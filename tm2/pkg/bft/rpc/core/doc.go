@@ -88,6 +88,7 @@ Available endpoints:
 /status
 /health
 /unconfirmed_txs
+/unsafe_banned_peers
 /unsafe_flush_mempool
 /unsafe_stop_cpu_profiler
 /validators
@@ -103,7 +104,9 @@ Endpoints that require arguments:
 /dial_seeds?seeds=_
 /dial_persistent_peers?persistent_peers=_
 /tx?hash=_&prove=_
+/unsafe_ban_peer?peer=_&duration=_
 /unsafe_start_cpu_profiler?filename=_
+/unsafe_unban_peer?peer=_
 /unsafe_write_heap_profile?filename=_
 ```
 
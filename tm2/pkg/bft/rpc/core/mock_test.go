@@ -1,6 +1,13 @@
 package core
 
-import "github.com/gnolang/gno/tm2/pkg/bft/types"
+import (
+	"net"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/p2p/manager"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
 
 type (
 	heightDelegate          func() int64
@@ -76,3 +83,59 @@ func (m *mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet
 		m.saveBlockFn(block, blockParts, seenCommit)
 	}
 }
+
+type (
+	banPeerIDDelegate   func(p2pTypes.ID, time.Duration) error
+	banPeerIPDelegate   func(net.IP, time.Duration) error
+	unbanPeerIDDelegate func(p2pTypes.ID) error
+	unbanPeerIPDelegate func(net.IP) error
+	bannedPeersDelegate func() []manager.Ban
+)
+
+type mockBans struct {
+	banPeerIDFn   banPeerIDDelegate
+	banPeerIPFn   banPeerIPDelegate
+	unbanPeerIDFn unbanPeerIDDelegate
+	unbanPeerIPFn unbanPeerIPDelegate
+	bannedPeersFn bannedPeersDelegate
+}
+
+func (m *mockBans) BanPeerID(id p2pTypes.ID, duration time.Duration) error {
+	if m.banPeerIDFn != nil {
+		return m.banPeerIDFn(id, duration)
+	}
+
+	return nil
+}
+
+func (m *mockBans) BanPeerIP(ip net.IP, duration time.Duration) error {
+	if m.banPeerIPFn != nil {
+		return m.banPeerIPFn(ip, duration)
+	}
+
+	return nil
+}
+
+func (m *mockBans) UnbanPeerID(id p2pTypes.ID) error {
+	if m.unbanPeerIDFn != nil {
+		return m.unbanPeerIDFn(id)
+	}
+
+	return nil
+}
+
+func (m *mockBans) UnbanPeerIP(ip net.IP) error {
+	if m.unbanPeerIPFn != nil {
+		return m.unbanPeerIPFn(ip)
+	}
+
+	return nil
+}
+
+func (m *mockBans) BannedPeers() []manager.Ban {
+	if m.bannedPeersFn != nil {
+		return m.bannedPeersFn()
+	}
+
+	return nil
+}
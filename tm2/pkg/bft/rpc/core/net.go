@@ -1,8 +1,13 @@
 package core
 
 import (
+	"fmt"
+	"net"
+	"time"
+
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 )
 
 // Get network info.
@@ -227,3 +232,89 @@ func NetInfo(_ *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 func Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
 	return &ctypes.ResultGenesis{Genesis: genDoc}, nil
 }
+
+// Ban a peer, by ID or by IP, for the given duration (the node's configured
+// ban duration, if empty). Connected peers matching the ban are disconnected.
+//
+// ```shell
+// curl 'localhost:26657/unsafe_ban_peer?peer="g1m0rgan0rla00ygmdmp55f5m0unvsvknluyg2a4"&duration="1h"'
+// curl 'localhost:26657/unsafe_ban_peer?peer="198.51.100.7"'
+// ```
+func UnsafeBanPeer(_ *rpctypes.Context, peer, duration string) (*ctypes.ResultUnsafeBanPeer, error) {
+	var banDuration time.Duration
+
+	if duration != "" {
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ban duration %q, %w", duration, err)
+		}
+
+		banDuration = d
+	}
+
+	id, ip, err := parseBanTarget(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip != nil {
+		err = p2pBans.BanPeerIP(ip, banDuration)
+	} else {
+		err = p2pBans.BanPeerID(id, banDuration)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to ban peer, %w", err)
+	}
+
+	return &ctypes.ResultUnsafeBanPeer{}, nil
+}
+
+// Lift the ban on a peer, by ID or by IP.
+//
+// ```shell
+// curl 'localhost:26657/unsafe_unban_peer?peer="198.51.100.7"'
+// ```
+func UnsafeUnbanPeer(_ *rpctypes.Context, peer string) (*ctypes.ResultUnsafeBanPeer, error) {
+	id, ip, err := parseBanTarget(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip != nil {
+		err = p2pBans.UnbanPeerIP(ip)
+	} else {
+		err = p2pBans.UnbanPeerID(id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to unban peer, %w", err)
+	}
+
+	return &ctypes.ResultUnsafeBanPeer{}, nil
+}
+
+// Get the active peer bans.
+//
+// ```shell
+// curl 'localhost:26657/unsafe_banned_peers'
+// ```
+func UnsafeBannedPeers(_ *rpctypes.Context) (*ctypes.ResultBannedPeers, error) {
+	return &ctypes.ResultBannedPeers{
+		Bans: p2pBans.BannedPeers(),
+	}, nil
+}
+
+// parseBanTarget parses the given ban target, which is either a peer ID or an IP
+func parseBanTarget(peer string) (p2pTypes.ID, net.IP, error) {
+	if ip := net.ParseIP(peer); ip != nil {
+		return "", ip, nil
+	}
+
+	id := p2pTypes.ID(peer)
+	if err := id.Validate(); err != nil {
+		return "", nil, fmt.Errorf("invalid peer ID or IP %q, %w", peer, err)
+	}
+
+	return id, nil, nil
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsafeBanPeer(t *testing.T) {
+	// Tests are not run in parallel because the JSON-RPC
+	// handlers utilize global package-level variables
+	t.Run("invalid duration", func(t *testing.T) {
+		SetP2PBans(&mockBans{})
+
+		_, err := UnsafeBanPeer(nil, "198.51.100.7", "forever")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid peer", func(t *testing.T) {
+		SetP2PBans(&mockBans{})
+
+		_, err := UnsafeBanPeer(nil, "not a peer", "")
+		assert.Error(t, err)
+	})
+
+	t.Run("peer ID banned", func(t *testing.T) {
+		var (
+			id = p2pTypes.GenerateNodeKey().ID()
+
+			bannedID       p2pTypes.ID
+			bannedDuration time.Duration
+		)
+
+		SetP2PBans(&mockBans{
+			banPeerIDFn: func(id p2pTypes.ID, duration time.Duration) error {
+				bannedID = id
+				bannedDuration = duration
+
+				return nil
+			},
+		})
+
+		_, err := UnsafeBanPeer(nil, id.String(), "1h")
+		require.NoError(t, err)
+
+		assert.Equal(t, id, bannedID)
+		assert.Equal(t, time.Hour, bannedDuration)
+	})
+
+	t.Run("peer IP banned", func(t *testing.T) {
+		var (
+			ip = net.ParseIP("198.51.100.7")
+
+			bannedIP       net.IP
+			bannedDuration time.Duration
+		)
+
+		SetP2PBans(&mockBans{
+			banPeerIPFn: func(ip net.IP, duration time.Duration) error {
+				bannedIP = ip
+				bannedDuration = duration
+
+				return nil
+			},
+		})
+
+		_, err := UnsafeBanPeer(nil, ip.String(), "")
+		require.NoError(t, err)
+
+		assert.True(t, ip.Equal(bannedIP))
+		assert.Zero(t, bannedDuration)
+	})
+}
+
+func TestUnsafeUnbanPeer(t *testing.T) {
+	t.Run("peer ID unbanned", func(t *testing.T) {
+		var (
+			id = p2pTypes.GenerateNodeKey().ID()
+
+			unbannedID p2pTypes.ID
+		)
+
+		SetP2PBans(&mockBans{
+			unbanPeerIDFn: func(id p2pTypes.ID) error {
+				unbannedID = id
+
+				return nil
+			},
+		})
+
+		_, err := UnsafeUnbanPeer(nil, id.String())
+		require.NoError(t, err)
+
+		assert.Equal(t, id, unbannedID)
+	})
+
+	t.Run("peer IP unbanned", func(t *testing.T) {
+		var (
+			ip = net.ParseIP("198.51.100.7")
+
+			unbannedIP net.IP
+		)
+
+		SetP2PBans(&mockBans{
+			unbanPeerIPFn: func(ip net.IP) error {
+				unbannedIP = ip
+
+				return nil
+			},
+		})
+
+		_, err := UnsafeUnbanPeer(nil, ip.String())
+		require.NoError(t, err)
+
+		assert.True(t, ip.Equal(unbannedIP))
+	})
+}
@@ -3,6 +3,8 @@ package core
 import (
 	"fmt"
 	"log/slog"
+	"net"
+	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	cnscfg "github.com/gnolang/gno/tm2/pkg/bft/consensus/config"
@@ -15,6 +17,7 @@ import (
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/p2p"
+	"github.com/gnolang/gno/tm2/pkg/p2p/manager"
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 )
 
@@ -46,6 +49,14 @@ type peers interface {
 	Peers() p2p.PeerSet
 }
 
+type bans interface {
+	BanPeerID(p2pTypes.ID, time.Duration) error
+	BanPeerIP(net.IP, time.Duration) error
+	UnbanPeerID(p2pTypes.ID) error
+	UnbanPeerIP(net.IP) error
+	BannedPeers() []manager.Ban
+}
+
 // ----------------------------------------------
 // These package level globals come with setters
 // that are expected to be called only once, on startup
@@ -60,6 +71,7 @@ var (
 	consensusState Consensus
 	p2pPeers       peers
 	p2pTransport   transport
+	p2pBans        bans
 
 	// objects
 	pubKey        crypto.PubKey
@@ -98,6 +110,10 @@ func SetP2PTransport(t transport) {
 	p2pTransport = t
 }
 
+func SetP2PBans(b bans) {
+	p2pBans = b
+}
+
 func SetPubKey(pk crypto.PubKey) {
 	pubKey = pk
 }
@@ -37,6 +37,9 @@ var Routes = map[string]*rpc.RPCFunc{
 func AddUnsafeRoutes() {
 	// control API
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["unsafe_ban_peer"] = rpc.NewRPCFunc(UnsafeBanPeer, "peer,duration")
+	Routes["unsafe_unban_peer"] = rpc.NewRPCFunc(UnsafeUnbanPeer, "peer")
+	Routes["unsafe_banned_peers"] = rpc.NewRPCFunc(UnsafeBannedPeers, "")
 
 	// profiler API
 	Routes["unsafe_start_cpu_profiler"] = rpc.NewRPCFunc(UnsafeStartCPUProfiler, "filename")
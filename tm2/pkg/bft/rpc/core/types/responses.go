@@ -11,6 +11,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/p2p"
+	"github.com/gnolang/gno/tm2/pkg/p2p/manager"
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 )
 
@@ -96,6 +97,11 @@ type ResultNetInfo struct {
 	Peers     []Peer   `json:"peers"`
 }
 
+// Active peer bans
+type ResultBannedPeers struct {
+	Bans []manager.Ban `json:"bans"`
+}
+
 // Log from dialing seeds
 type ResultDialSeeds struct {
 	Log string `json:"log"`
@@ -199,6 +205,7 @@ type ResultABCIQuery struct {
 type (
 	ResultUnsafeFlushMempool struct{}
 	ResultUnsafeProfile      struct{}
+	ResultUnsafeBanPeer      struct{}
 	ResultHealth             struct{}
 )
 
@@ -13,6 +13,7 @@ import (
 	typesver "github.com/gnolang/gno/tm2/pkg/bft/types/version"
 	tmver "github.com/gnolang/gno/tm2/pkg/bft/version"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/vrf"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/events"
 )
@@ -241,12 +242,19 @@ func execBlockOnProxyApp(
 
 	commitInfo := getBeginBlockLastCommitInfo(block, stateDB)
 
+	// The app may use the txs to execute them ahead of DeliverTx.
+	txs := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		txs[i] = tx
+	}
+
 	// Begin block
 	var err error
 	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(abci.RequestBeginBlock{
 		Hash:           block.Hash(),
 		Header:         block.Header.Copy(),
 		LastCommitInfo: &commitInfo,
+		Txs:            txs,
 	})
 	if err != nil {
 		logger.Error("Error in proxyAppConn.BeginBlock", "err", err)
@@ -307,6 +315,14 @@ func getBeginBlockLastCommitInfo(block *types.Block, stateDB dbm.DB) abci.LastCo
 			Power:           val.VotingPower,
 			SignedLastBlock: vote != nil,
 		}
+		// The randomness was verified along with the last commit.
+		if vote != nil && len(vote.Randomness) > 0 && vote.BlockID.Equals(block.LastBlockID) {
+			output, err := vrf.ProofToHash(vote.Randomness)
+			if err != nil {
+				panic(err) // shouldn't happen
+			}
+			voteInfo.Randomness = output
+		}
 		voteInfos[i] = voteInfo
 	}
 
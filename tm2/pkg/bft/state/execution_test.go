@@ -108,6 +108,53 @@ func TestBeginBlockValidators(t *testing.T) {
 	}
 }
 
+func TestBeginBlockRandomness(t *testing.T) {
+	t.Parallel()
+
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := appconn.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop()
+
+	state, stateDB, privVals := makeState(2, 2)
+
+	prevBlockID := types.BlockID{Hash: []byte("last block")}
+	otherBlockID := types.BlockID{Hash: []byte("other block")}
+	state.LastBlockID = prevBlockID
+
+	// the first validator precommits the last block, the second another one.
+	precommits := make([]*types.CommitSig, 2)
+	outputs := make([][]byte, 2)
+	for i, blockID := range []types.BlockID{prevBlockID, otherBlockID} {
+		val := state.Validators.Validators[i]
+		vote := &types.Vote{
+			ValidatorAddress: val.Address,
+			ValidatorIndex:   i,
+			Height:           1,
+			Timestamp:        tmtime.Now(),
+			Type:             types.PrecommitType,
+			BlockID:          blockID,
+		}
+		require.NoError(t, privVals[val.Address.String()].SignVote(state.ChainID, vote))
+		outputs[i], err = vote.VerifyRandomness(state.ChainID, val.PubKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, outputs[i])
+		precommits[i] = vote.CommitSig()
+	}
+	lastCommit := types.NewCommit(prevBlockID, precommits)
+
+	block, _ := state.MakeBlock(2, makeTxs(2), lastCommit, state.Validators.GetProposer().Address)
+	_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.NewTestingLogger(t), stateDB)
+	require.NoError(t, err)
+
+	// -> only the randomness of the precommits for the last block is passed to the app
+	require.Len(t, app.CommitVotes, 2)
+	assert.Equal(t, outputs[0], app.CommitVotes[0].Randomness)
+	assert.Nil(t, app.CommitVotes[1].Randomness)
+}
+
 func TestValidateValidatorUpdates(t *testing.T) {
 	t.Parallel()
 
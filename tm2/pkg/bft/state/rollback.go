@@ -0,0 +1,101 @@
+package state
+
+import (
+	"fmt"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+// Rollback reconstructs the State as it was after committing the block at
+// the given height, using the headers stored in the block store and the
+// historical validator sets and consensus params kept in the state DB, and
+// saves it as the latest State.
+//
+// The block at height+1 must still be present in the block store, since its
+// header carries the app hash and results hash for the rolled back state.
+// Rollback does not modify the block store nor the application state.
+func Rollback(bs BlockStoreRPC, stateDB dbm.DB, height int64) (State, error) {
+	current := LoadState(stateDB)
+	if current.IsEmpty() {
+		return State{}, fmt.Errorf("no state found in the state DB")
+	}
+
+	if height < 1 || height >= current.LastBlockHeight {
+		return State{}, fmt.Errorf(
+			"invalid rollback height %d, state height is %d",
+			height,
+			current.LastBlockHeight,
+		)
+	}
+
+	// The header of the next block holds the results of the target height
+	rollbackMeta := bs.LoadBlockMeta(height)
+	if rollbackMeta == nil {
+		return State{}, UnknownBlockError{height}
+	}
+
+	nextMeta := bs.LoadBlockMeta(height + 1)
+	if nextMeta == nil {
+		return State{}, UnknownBlockError{height + 1}
+	}
+
+	lastValidators, err := LoadValidators(stateDB, height)
+	if err != nil {
+		return State{}, err
+	}
+
+	validators, err := LoadValidators(stateDB, height+1)
+	if err != nil {
+		return State{}, err
+	}
+
+	nextValidators, err := LoadValidators(stateDB, height+2)
+	if err != nil {
+		return State{}, err
+	}
+
+	consensusParams, err := LoadConsensusParams(stateDB, height+1)
+	if err != nil {
+		return State{}, err
+	}
+
+	// The last changed heights are stored alongside
+	// the next validator set and the next consensus params
+	valInfo := loadValidatorsInfo(stateDB, height+2)
+	if valInfo == nil {
+		return State{}, NoValSetForHeightError{height + 2}
+	}
+
+	paramsInfo := loadConsensusParamsInfo(stateDB, height+1)
+	if paramsInfo == nil {
+		return State{}, NoConsensusParamsForHeightError{height + 1}
+	}
+
+	rolledBack := State{
+		SoftwareVersion: current.SoftwareVersion,
+		BlockVersion:    nextMeta.Header.Version,
+		AppVersion:      nextMeta.Header.AppVersion,
+
+		ChainID: current.ChainID,
+
+		LastBlockHeight:  height,
+		LastBlockTotalTx: rollbackMeta.Header.TotalTxs,
+		LastBlockID:      rollbackMeta.BlockID,
+		LastBlockTime:    rollbackMeta.Header.Time,
+
+		NextValidators:              nextValidators,
+		Validators:                  validators,
+		LastValidators:              lastValidators,
+		LastHeightValidatorsChanged: valInfo.LastHeightChanged,
+
+		ConsensusParams:                  consensusParams,
+		LastHeightConsensusParamsChanged: paramsInfo.LastHeightChanged,
+
+		LastResultsHash: nextMeta.Header.LastResultsHash,
+		AppHash:         nextMeta.Header.AppHash,
+	}
+
+	SaveState(stateDB, rolledBack)
+
+	return rolledBack, nil
+}
@@ -0,0 +1,97 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/mempool/mock"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+// metaBlockStore is a minimal block store
+// that only keeps track of block metas
+type metaBlockStore struct {
+	sm.BlockStoreRPC
+
+	metas map[int64]*types.BlockMeta
+}
+
+func (bs *metaBlockStore) Height() int64 {
+	return int64(len(bs.metas))
+}
+
+func (bs *metaBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	return bs.metas[height]
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+
+	state, stateDB, privVals := makeState(3, 1)
+	blockExec := sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mock.Mempool{})
+	lastCommit := types.NewCommit(types.BlockID{}, nil)
+
+	var (
+		bs = &metaBlockStore{
+			metas: make(map[int64]*types.BlockMeta),
+		}
+		states = make(map[int64]sm.State)
+	)
+
+	// Build up a small chain, saving the state at each height
+	for height := int64(1); height <= 5; height++ {
+		proposerAddr := state.Validators.GetProposer().Address
+
+		block, _ := state.MakeBlock(height, makeTxs(height), lastCommit, proposerAddr)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: types.PartSetHeader{}}
+
+		var err error
+		state, err = blockExec.ApplyBlock(state, blockID, block)
+		require.NoError(t, err)
+
+		lastCommit, err = makeValidCommit(height, blockID, state.Validators, privVals)
+		require.NoError(t, err)
+
+		bs.metas[height] = &types.BlockMeta{
+			BlockID: blockID,
+			Header:  block.Header,
+		}
+		states[height] = state.Copy()
+	}
+
+	t.Run("invalid heights", func(t *testing.T) {
+		_, err := sm.Rollback(bs, stateDB, 0)
+		assert.Error(t, err)
+
+		_, err = sm.Rollback(bs, stateDB, 5)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing next block", func(t *testing.T) {
+		partial := &metaBlockStore{
+			metas: map[int64]*types.BlockMeta{
+				1: bs.metas[1],
+				2: bs.metas[2],
+			},
+		}
+
+		_, err := sm.Rollback(partial, stateDB, 2)
+		assert.ErrorIs(t, err, sm.UnknownBlockError{Height: 3})
+	})
+
+	t.Run("valid rollback", func(t *testing.T) {
+		rolledBack, err := sm.Rollback(bs, stateDB, 3)
+		require.NoError(t, err)
+
+		assert.True(t, states[3].Equals(rolledBack))
+		assert.True(t, states[3].Equals(sm.LoadState(stateDB)))
+	})
+}
@@ -187,6 +187,48 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.db.SetSync(nil, nil)
 }
 
+// Rollback deletes all blocks above the given height, so that height becomes
+// the last height stored in the BlockStore. It is meant to be used by offline
+// tooling only, never while the node is running.
+func (bs *BlockStore) Rollback(height int64) error {
+	current := bs.Height()
+	if height < 0 || height > current {
+		return fmt.Errorf("invalid rollback height %d, block store height is %d", height, current)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	for h := current; h > height; h-- {
+		blockMeta := bs.LoadBlockMeta(h)
+		if blockMeta == nil {
+			return fmt.Errorf("missing block meta for height %d", h)
+		}
+
+		for i := 0; i < blockMeta.BlockID.PartsHeader.Total; i++ {
+			batch.Delete(calcBlockPartKey(h, i))
+		}
+
+		batch.Delete(calcBlockMetaKey(h))
+		batch.Delete(calcBlockCommitKey(h - 1))
+		batch.Delete(calcSeenCommitKey(h))
+	}
+
+	bsjBytes, err := amino.MarshalJSON(BlockStoreStateJSON{Height: height})
+	if err != nil {
+		return fmt.Errorf("unable to marshal block store state, %w", err)
+	}
+
+	batch.Set(blockStoreKey, bsjBytes)
+	batch.WriteSync()
+
+	bs.mtx.Lock()
+	bs.height = height
+	bs.mtx.Unlock()
+
+	return nil
+}
+
 func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
 	if height != bs.Height()+1 {
 		panic(fmt.Sprintf("BlockStore can only save contiguous blocks. Wanted %v, got %v", bs.Height()+1, height))
@@ -421,6 +421,65 @@ func TestBlockFetchAtHeight(t *testing.T) {
 	require.Nil(t, blockAtHeightPlus2, "expecting an unsuccessful load of Height()+2")
 }
 
+func TestBlockStoreRollback(t *testing.T) {
+	t.Parallel()
+
+	state, bs, cleanup := makeStateAndBlockStore(log.NewNoopLogger())
+	defer cleanup()
+
+	// Save a few blocks
+	saveBlock := func(height int64) {
+		header := types.Header{
+			Height:          height,
+			ChainID:         "block_test",
+			Time:            tmtime.Now(),
+			ProposerAddress: state.Validators.GetProposer().Address,
+		}
+		block := newBlock(header, makeTestCommit(height-1, tmtime.Now()))
+
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(height, tmtime.Now()))
+	}
+
+	for height := int64(1); height <= 5; height++ {
+		saveBlock(height)
+	}
+
+	require.Equal(t, int64(5), bs.Height())
+
+	t.Run("invalid height", func(t *testing.T) {
+		assert.Error(t, bs.Rollback(-1))
+		assert.Error(t, bs.Rollback(6))
+	})
+
+	t.Run("valid rollback", func(t *testing.T) {
+		require.NoError(t, bs.Rollback(3))
+		assert.Equal(t, int64(3), bs.Height())
+
+		// Make sure the rolled back blocks are gone
+		for height := int64(4); height <= 5; height++ {
+			assert.Nil(t, bs.LoadBlock(height))
+			assert.Nil(t, bs.LoadBlockMeta(height))
+			assert.Nil(t, bs.LoadBlockPart(height, 0))
+			assert.Nil(t, bs.LoadSeenCommit(height))
+		}
+
+		assert.Nil(t, bs.LoadBlockCommit(3))
+
+		// Make sure the remaining blocks are intact
+		for height := int64(1); height <= 3; height++ {
+			assert.NotNil(t, bs.LoadBlock(height))
+			assert.NotNil(t, bs.LoadSeenCommit(height))
+		}
+
+		// Make sure the new height is persisted
+		assert.Equal(t, int64(3), LoadBlockStoreStateJSON(bs.db).Height)
+
+		// Make sure new blocks can be saved on top
+		saveBlock(4)
+		assert.Equal(t, int64(4), bs.Height())
+	})
+}
+
 func doFn(fn func() (interface{}, error)) (res interface{}, err error, panicErr error) {
 	defer func() {
 		if r := recover(); r != nil {
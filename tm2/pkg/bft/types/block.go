@@ -467,6 +467,7 @@ func (commit *Commit) GetVote(valIdx int) *Vote {
 		ValidatorAddress: commitSig.ValidatorAddress,
 		ValidatorIndex:   valIdx,
 		Signature:        commitSig.Signature,
+		Randomness:       commitSig.Randomness,
 	}
 }
 
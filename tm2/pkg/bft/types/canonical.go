@@ -32,11 +32,19 @@ type CanonicalProposal struct {
 }
 
 type CanonicalVote struct {
-	Type      SignedMsgType // type alias for byte
-	Height    int64         `binary:"fixed64"`
-	Round     int64         `binary:"fixed64"`
-	BlockID   CanonicalBlockID
-	Timestamp time.Time
+	Type       SignedMsgType // type alias for byte
+	Height     int64         `binary:"fixed64"`
+	Round      int64         `binary:"fixed64"`
+	BlockID    CanonicalBlockID
+	Timestamp  time.Time
+	ChainID    string
+	Randomness []byte
+}
+
+// CanonicalRandomness is the input of the VRF of the precommits.
+type CanonicalRandomness struct {
+	Height    int64 `binary:"fixed64"`
+	BlockHash []byte
 	ChainID   string
 }
 
@@ -71,11 +79,20 @@ func CanonicalizeProposal(chainID string, proposal *Proposal) CanonicalProposal
 
 func CanonicalizeVote(chainID string, vote *Vote) CanonicalVote {
 	return CanonicalVote{
-		Type:      vote.Type,
-		Height:    vote.Height,
-		Round:     int64(vote.Round), // cast int->int64 to make amino encode it fixed64 (does not work for int)
-		BlockID:   CanonicalizeBlockID(vote.BlockID),
-		Timestamp: vote.Timestamp,
+		Type:       vote.Type,
+		Height:     vote.Height,
+		Round:      int64(vote.Round), // cast int->int64 to make amino encode it fixed64 (does not work for int)
+		BlockID:    CanonicalizeBlockID(vote.BlockID),
+		Timestamp:  vote.Timestamp,
+		ChainID:    chainID,
+		Randomness: vote.Randomness,
+	}
+}
+
+func CanonicalizeRandomness(chainID string, height int64, blockHash []byte) CanonicalRandomness {
+	return CanonicalRandomness{
+		Height:    height,
+		BlockHash: blockHash,
 		ChainID:   chainID,
 	}
 }
@@ -12,7 +12,7 @@ import (
 
 const (
 	// MaxEvidenceBytes is a maximum size of any evidence (including amino overhead).
-	MaxEvidenceBytes int64 = 484
+	MaxEvidenceBytes int64 = 648
 )
 
 // EvidenceInvalidError wraps a piece of evidence and the error denoting how or why it is invalid.
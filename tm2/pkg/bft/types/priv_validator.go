@@ -71,6 +71,9 @@ func (pv *MockPV) SignVote(chainID string, vote *Vote) error {
 	if pv.breakVoteSigning {
 		useChainID = "incorrect-chain-id"
 	}
+	if err := vote.SignRandomness(useChainID, pv.privKey); err != nil {
+		return err
+	}
 	signBytes := vote.SignBytes(useChainID)
 	sig, err := pv.privKey.Sign(signBytes)
 	if err != nil {
@@ -656,6 +656,10 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height i
 		if !val.PubKey.VerifyBytes(precommitSignBytes, precommit.Signature) {
 			return fmt.Errorf("invalid commit -- invalid signature: %v", precommit)
 		}
+		// Validate randomness.
+		if _, err := commit.GetVote(idx).VerifyRandomness(chainID, val.PubKey); err != nil {
+			return fmt.Errorf("invalid commit -- invalid randomness: %v", precommit)
+		}
 		// Good precommit!
 		if blockID.Equals(precommit.BlockID) {
 			talliedVotingPower += val.VotingPower
@@ -14,6 +14,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/mock"
 	"github.com/gnolang/gno/tm2/pkg/random"
 )
@@ -659,6 +660,21 @@ func TestValidatorSetVerifyCommit(t *testing.T) {
 	// test a good one
 	err = vset.VerifyCommit(chainID, blockID, height, commit)
 	assert.Nil(t, err)
+
+	// the precommits of ed25519 keys must have randomness.
+	edKey := ed25519.GenPrivKey()
+	edVal := NewValidator(edKey.PubKey(), 1000)
+	edVset := NewValidatorSet([]*Validator{edVal})
+	vote.ValidatorAddress = edVal.Address
+	vote.Signature, err = edKey.Sign(vote.SignBytes(chainID))
+	assert.NoError(t, err)
+	err = edVset.VerifyCommit(chainID, blockID, height, NewCommit(blockID, []*CommitSig{vote.CommitSig()}))
+	assert.ErrorContains(t, err, "invalid randomness")
+	assert.NoError(t, vote.SignRandomness(chainID, edKey))
+	vote.Signature, err = edKey.Sign(vote.SignBytes(chainID))
+	assert.NoError(t, err)
+	err = edVset.VerifyCommit(chainID, blockID, height, NewCommit(blockID, []*CommitSig{vote.CommitSig()}))
+	assert.Nil(t, err)
 }
 
 func TestEmptySet(t *testing.T) {
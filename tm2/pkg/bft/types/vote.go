@@ -7,11 +7,13 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/vrf"
 )
 
 const (
 	// MaxVoteBytes is a maximum vote size (including amino overhead).
-	MaxVoteBytes int    = 247
+	MaxVoteBytes int    = 329
 	nilVoteStr   string = "nil-Vote"
 )
 
@@ -20,6 +22,8 @@ var (
 	ErrVoteInvalidValidatorIndex     = errors.New("invalid validator index")
 	ErrVoteInvalidValidatorAddress   = errors.New("invalid validator address")
 	ErrVoteInvalidSignature          = errors.New("invalid signature")
+	ErrVoteInvalidRandomness         = errors.New("invalid randomness")
+	ErrVoteMissingRandomness         = errors.New("missing randomness")
 	ErrVoteNonDeterministicSignature = errors.New("non-deterministic signature")
 	ErrVoteNil                       = errors.New("nil vote")
 )
@@ -56,6 +60,7 @@ type Vote struct {
 	ValidatorAddress Address       `json:"validator_address"`
 	ValidatorIndex   int           `json:"validator_index"`
 	Signature        []byte        `json:"signature"`
+	Randomness       []byte        `json:"randomness"` // VRF proof, only in non-nil precommits.
 }
 
 // CommitSig converts the Vote to a CommitSig.
@@ -116,9 +121,66 @@ func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
 	if !pubKey.VerifyBytes(vote.SignBytes(chainID), vote.Signature) {
 		return ErrVoteInvalidSignature
 	}
+	if _, err := vote.VerifyRandomness(chainID, pubKey); err != nil {
+		return err
+	}
 	return nil
 }
 
+// RandomnessBytes returns the input of the VRF whose proofs are included in
+// the non-nil precommits for the block with the given height and hash.
+// As the block commits to the previous one, the outputs of a validator can't
+// be computed before the previous block is committed and this one proposed.
+func RandomnessBytes(chainID string, height int64, blockHash []byte) []byte {
+	bz, err := amino.MarshalSized(CanonicalizeRandomness(chainID, height, blockHash))
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// SignRandomness sets the randomness of a non-nil precommit, computed with
+// the given private key. It must be called before signing the vote.
+// Only ed25519 keys contribute randomness; votes signed with other keys
+// are left unchanged.
+func (vote *Vote) SignRandomness(chainID string, privKey crypto.PrivKey) error {
+	if vote.Type != PrecommitType || vote.BlockID.IsZero() {
+		return nil
+	}
+	edKey, ok := privKey.(ed25519.PrivKeyEd25519)
+	if !ok {
+		return nil
+	}
+	proof, err := vrf.Prove(edKey, RandomnessBytes(chainID, vote.Height, vote.BlockID.Hash))
+	if err != nil {
+		return err
+	}
+	vote.Randomness = proof
+	return nil
+}
+
+// VerifyRandomness verifies the randomness of the vote, and returns its
+// output. The non-nil precommits of ed25519 keys must have randomness, so
+// that their validators can't withhold it; the other votes have none, and
+// nil is returned for them.
+func (vote *Vote) VerifyRandomness(chainID string, pubKey crypto.PubKey) ([]byte, error) {
+	edKey, ok := pubKey.(ed25519.PubKeyEd25519)
+	if !ok || vote.Type != PrecommitType || vote.BlockID.IsZero() {
+		if len(vote.Randomness) != 0 {
+			return nil, ErrVoteInvalidRandomness
+		}
+		return nil, nil
+	}
+	if len(vote.Randomness) == 0 {
+		return nil, ErrVoteMissingRandomness
+	}
+	output, err := vrf.Verify(edKey, RandomnessBytes(chainID, vote.Height, vote.BlockID.Hash), vote.Randomness)
+	if err != nil {
+		return nil, ErrVoteInvalidRandomness
+	}
+	return output, nil
+}
+
 // ValidateBasic performs basic validation.
 func (vote *Vote) ValidateBasic() error {
 	if !IsVoteTypeValid(vote.Type) {
@@ -153,5 +215,13 @@ func (vote *Vote) ValidateBasic() error {
 	if len(vote.Signature) > MaxSignatureSize {
 		return fmt.Errorf("signature is too big (max: %d)", MaxSignatureSize)
 	}
+	if len(vote.Randomness) != 0 {
+		if vote.Type != PrecommitType || vote.BlockID.IsZero() {
+			return errors.New("randomness is only allowed in non-nil precommits")
+		}
+		if len(vote.Randomness) != vrf.ProofSize {
+			return fmt.Errorf("randomness has a wrong size (expected: %d)", vrf.ProofSize)
+		}
+	}
 	return nil
 }
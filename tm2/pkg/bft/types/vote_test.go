@@ -12,6 +12,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
 	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
+	"github.com/gnolang/gno/tm2/pkg/crypto/vrf"
 )
 
 func examplePrevote() *Vote {
@@ -171,11 +172,13 @@ func TestVoteVerifySignature(t *testing.T) {
 	pubkey := privVal.GetPubKey()
 
 	vote := examplePrecommit()
-	signBytes := vote.SignBytes("test_chain_id")
 
 	// sign it
 	err := privVal.SignVote("test_chain_id", vote)
 	require.NoError(t, err)
+	// the randomness of the precommit is signed.
+	require.NotEmpty(t, vote.Randomness)
+	signBytes := vote.SignBytes("test_chain_id")
 
 	// verify the same vote
 	valid := pubkey.VerifyBytes(vote.SignBytes("test_chain_id"), vote.Signature)
@@ -195,6 +198,79 @@ func TestVoteVerifySignature(t *testing.T) {
 	require.True(t, valid)
 }
 
+func TestVoteRandomness(t *testing.T) {
+	t.Parallel()
+
+	const chainID = "test_chain_id"
+	privVal := NewMockPV()
+	pubkey := privVal.GetPubKey()
+
+	// only non-nil precommits have randomness.
+	prevote := examplePrevote()
+	prevote.ValidatorAddress = pubkey.Address()
+	require.NoError(t, privVal.SignVote(chainID, prevote))
+	assert.Empty(t, prevote.Randomness)
+	nilPrecommit := examplePrecommit()
+	nilPrecommit.ValidatorAddress = pubkey.Address()
+	nilPrecommit.BlockID = BlockID{}
+	require.NoError(t, privVal.SignVote(chainID, nilPrecommit))
+	assert.Empty(t, nilPrecommit.Randomness)
+
+	precommit := examplePrecommit()
+	precommit.ValidatorAddress = pubkey.Address()
+	require.NoError(t, privVal.SignVote(chainID, precommit))
+	require.Len(t, precommit.Randomness, vrf.ProofSize)
+	require.NoError(t, precommit.ValidateBasic())
+	require.NoError(t, precommit.Verify(chainID, pubkey))
+
+	// the output only depends on the height and the block.
+	output, err := precommit.VerifyRandomness(chainID, pubkey)
+	require.NoError(t, err)
+	require.Len(t, output, vrf.OutputSize)
+	other := examplePrecommit()
+	other.Round++
+	require.NoError(t, privVal.SignVote(chainID, other))
+	otherOutput, err := other.VerifyRandomness(chainID, pubkey)
+	require.NoError(t, err)
+	assert.Equal(t, output, otherOutput)
+	other.BlockID.Hash = tmhash.Sum([]byte("other_blockID_hash"))
+	other.Randomness = nil
+	require.NoError(t, privVal.SignVote(chainID, other))
+	otherOutput, err = other.VerifyRandomness(chainID, pubkey)
+	require.NoError(t, err)
+	assert.NotEqual(t, output, otherOutput)
+	other = examplePrecommit()
+	other.Height++
+	require.NoError(t, privVal.SignVote(chainID, other))
+	otherOutput, err = other.VerifyRandomness(chainID, pubkey)
+	require.NoError(t, err)
+	assert.NotEqual(t, output, otherOutput)
+
+	// the randomness of another validator is invalid.
+	otherPubkey := NewMockPV().GetPubKey()
+	_, err = precommit.VerifyRandomness(chainID, otherPubkey)
+	assert.Equal(t, ErrVoteInvalidRandomness, err)
+
+	// the randomness is signed.
+	stripped := precommit.Copy()
+	stripped.Randomness = nil
+	assert.Equal(t, ErrVoteInvalidSignature, stripped.Verify(chainID, pubkey))
+
+	// a non-nil precommit can't withhold its randomness, and only non-nil
+	// precommits can have some.
+	stripped.Signature, err = privVal.privKey.Sign(stripped.SignBytes(chainID))
+	require.NoError(t, err)
+	assert.Equal(t, ErrVoteMissingRandomness, stripped.Verify(chainID, pubkey))
+	prevote.Randomness = precommit.Randomness
+	_, err = prevote.VerifyRandomness(chainID, pubkey)
+	assert.Equal(t, ErrVoteInvalidRandomness, err)
+
+	// randomness is rejected in prevotes, and must be a proof.
+	assert.Error(t, prevote.ValidateBasic())
+	precommit.Randomness = precommit.Randomness[1:]
+	assert.Error(t, precommit.ValidateBasic())
+}
+
 func TestIsVoteTypeValid(t *testing.T) {
 	t.Parallel()
 
@@ -253,7 +329,7 @@ func TestMaxVoteBytes(t *testing.T) {
 		Height:           math.MaxInt64,
 		Round:            math.MaxInt64,
 		Timestamp:        timestamp,
-		Type:             PrevoteType,
+		Type:             PrecommitType, // non-nil precommits include randomness.
 		BlockID: BlockID{
 			Hash: tmhash.Sum([]byte("blockID_hash")),
 			PartsHeader: PartSetHeader{
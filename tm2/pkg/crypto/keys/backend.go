@@ -0,0 +1,190 @@
+package keys
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// Keyring backends, selecting where a keybase stores its keys.
+const (
+	// BackendDB stores the keys in a goleveldb directory,
+	// under the home directory. It is the default backend.
+	BackendDB = "db"
+	// BackendFile stores the keys in a single file under the home
+	// directory, encrypted with the keyring password.
+	BackendFile = "file"
+	// BackendOS stores the keys in the secret service (Linux),
+	// keychain (macOS) or credential manager (Windows) of the OS.
+	BackendOS = "os"
+	// BackendMemory stores the keys in memory, and is meant for tests.
+	BackendMemory = "memory"
+	// BackendExternal delegates the keys, and signing with them,
+	// to an external signer.
+	BackendExternal = "external"
+)
+
+// Backends are the supported keyring backends.
+var Backends = []string{
+	BackendDB,
+	BackendFile,
+	BackendOS,
+	BackendMemory,
+	BackendExternal,
+}
+
+// ErrUnknownBackend is returned when opening a keybase
+// with a keyring backend which is not supported.
+var ErrUnknownBackend = errors.New("unknown keyring backend")
+
+const (
+	defaultKeyringFile = "keyring.armor"
+	defaultOSService   = "gnokey"
+)
+
+// BackendOptions are the options to open a keybase with a keyring backend.
+type BackendOptions struct {
+	// Backend is the keyring backend, one of Backends.
+	// Defaults to BackendDB.
+	Backend string
+
+	// Dir is the home directory of the db and file backends.
+	Dir string
+
+	// Password is the keyring password of the file backend, with which the
+	// whole keyring is encrypted. Keys are still encrypted with their own
+	// password, like in the other backends.
+	Password string
+
+	// Service is the name of the service the keys are stored under, in the
+	// os backend. Defaults to "gnokey".
+	Service string
+
+	// SignerURL is the URL of the external signer of the external backend,
+	// either http://<host>:<port> or unix://<socket path>.
+	SignerURL string
+}
+
+// NewKeybase opens the keybase of the given keyring backend.
+func NewKeybase(opts BackendOptions) (Keybase, error) {
+	switch opts.Backend {
+	case "", BackendDB:
+		return NewKeyBaseFromDir(opts.Dir)
+	case BackendFile:
+		stor, err := newFileStorage(filepath.Join(opts.Dir, defaultKeyDBDir, defaultKeyringFile), opts.Password)
+		if err != nil {
+			return nil, err
+		}
+		return newStorageKeybase(stor)
+	case BackendOS:
+		service := opts.Service
+		if service == "" {
+			service = defaultOSService
+		}
+		return newStorageKeybase(newOSStorage(service))
+	case BackendMemory:
+		return NewInMemory(), nil
+	case BackendExternal:
+		return NewExternalKeybase(opts.SignerURL)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, opts.Backend)
+	}
+}
+
+// ----------------------------------------
+// storageDB
+
+// storage persists the entries of a keybase, for the backends which don't
+// store them in a database.
+type storage interface {
+	// load returns the stored entries.
+	load() (map[string][]byte, error)
+	// save replaces the stored entries.
+	save(entries map[string][]byte) error
+}
+
+// storageDB is an in-memory database loaded from a storage, which saves all
+// its entries back to the storage after each write.
+type storageDB struct {
+	*memdb.MemDB
+
+	mtx  sync.Mutex // serializes the saves
+	stor storage
+}
+
+var _ dbm.DB = (*storageDB)(nil)
+
+func newStorageKeybase(stor storage) (Keybase, error) {
+	entries, err := stor.load()
+	if err != nil {
+		return nil, err
+	}
+
+	db := &storageDB{
+		MemDB: memdb.NewMemDB(),
+		stor:  stor,
+	}
+	for key, value := range entries {
+		db.MemDB.Set([]byte(key), value)
+	}
+
+	return NewDBKeybase(db), nil
+}
+
+func (db *storageDB) Set(key []byte, value []byte) {
+	db.MemDB.Set(key, value)
+	db.persist()
+}
+
+func (db *storageDB) SetSync(key []byte, value []byte) {
+	db.Set(key, value)
+}
+
+func (db *storageDB) Delete(key []byte) {
+	db.MemDB.Delete(key)
+	db.persist()
+}
+
+func (db *storageDB) DeleteSync(key []byte) {
+	db.Delete(key)
+}
+
+func (db *storageDB) NewBatch() dbm.Batch {
+	return &storageBatch{Batch: db.MemDB.NewBatch(), db: db}
+}
+
+// persist saves all the entries to the storage.
+// Like the other databases, it panics if the write fails.
+func (db *storageDB) persist() {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	entries := make(map[string][]byte)
+	iter := db.MemDB.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		entries[string(iter.Key())] = iter.Value()
+	}
+	iter.Close()
+
+	if err := db.stor.save(entries); err != nil {
+		panic(fmt.Sprintf("unable to save keybase: %v", err))
+	}
+}
+
+type storageBatch struct {
+	dbm.Batch
+	db *storageDB
+}
+
+func (b *storageBatch) Write() {
+	b.Batch.Write()
+	b.db.persist()
+}
+
+func (b *storageBatch) WriteSync() {
+	b.Write()
+}
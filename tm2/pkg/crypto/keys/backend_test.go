@@ -0,0 +1,268 @@
+package keys
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+)
+
+const (
+	testMnemonic = "equip will roof matter pink blind book anxiety banner elbow sun young"
+	testPassword = "encrypt"
+)
+
+// testBackendRoundTrip creates a key with the keybase returned by open, and
+// makes sure it is kept when the keybase is opened again
+func testBackendRoundTrip(t *testing.T, open func() (Keybase, error)) {
+	t.Helper()
+
+	kb, err := open()
+	require.NoError(t, err)
+
+	info, err := kb.CreateAccount("key", testMnemonic, "", testPassword, 0, 0)
+	require.NoError(t, err)
+	_, err = kb.CreateAccount("other", testMnemonic, "", testPassword, 0, 1)
+	require.NoError(t, err)
+	kb.CloseDB()
+
+	kb, err = open()
+	require.NoError(t, err)
+
+	got, err := kb.GetByAddress(info.GetAddress())
+	require.NoError(t, err)
+	assert.Equal(t, "key", got.GetName())
+
+	sig, pub, err := kb.Sign("key", testPassword, []byte("msg"))
+	require.NoError(t, err)
+	assert.True(t, pub.VerifyBytes([]byte("msg"), sig))
+
+	require.NoError(t, kb.Delete("other", testPassword, false))
+	kb.CloseDB()
+
+	kb, err = open()
+	require.NoError(t, err)
+
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "key", infos[0].GetName())
+}
+
+func TestNewKeybase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("db", func(t *testing.T) {
+		t.Parallel()
+
+		opts := BackendOptions{Dir: t.TempDir()}
+		testBackendRoundTrip(t, func() (Keybase, error) {
+			return NewKeybase(opts)
+		})
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		t.Parallel()
+
+		kb, err := NewKeybase(BackendOptions{Backend: BackendMemory})
+		require.NoError(t, err)
+
+		_, err = kb.CreateAccount("key", testMnemonic, "", testPassword, 0, 0)
+		require.NoError(t, err)
+
+		has, err := kb.HasByName("key")
+		require.NoError(t, err)
+		assert.True(t, has)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewKeybase(BackendOptions{Backend: "cloud"})
+		assert.ErrorIs(t, err, ErrUnknownBackend)
+	})
+}
+
+func TestFileBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		opts := BackendOptions{
+			Backend:  BackendFile,
+			Dir:      t.TempDir(),
+			Password: "keyring",
+		}
+		testBackendRoundTrip(t, func() (Keybase, error) {
+			return NewKeybase(opts)
+		})
+
+		// The keyring is a single encrypted file
+		path := filepath.Join(opts.Dir, defaultKeyDBDir, defaultKeyringFile)
+		bz, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(bz), blockTypeKeyring)
+		assert.NotContains(t, string(bz), "key.info")
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		kb, err := NewKeybase(BackendOptions{Backend: BackendFile, Dir: dir, Password: "keyring"})
+		require.NoError(t, err)
+
+		_, err = kb.CreateAccount("key", testMnemonic, "", testPassword, 0, 0)
+		require.NoError(t, err)
+
+		_, err = NewKeybase(BackendOptions{Backend: BackendFile, Dir: dir, Password: "wrong"})
+		assert.True(t, keyerror.IsErrWrongPassword(err))
+	})
+}
+
+func TestOSBackend(t *testing.T) {
+	keyring.MockInit()
+
+	opts := BackendOptions{Backend: BackendOS, Service: "gnokey-test"}
+	testBackendRoundTrip(t, func() (Keybase, error) {
+		return NewKeybase(opts)
+	})
+
+	// Deleted keys are removed from the OS keyring
+	_, err := keyring.Get(opts.Service, string(infoKey("other")))
+	assert.ErrorIs(t, err, keyring.ErrNotFound)
+
+	_, err = keyring.Get(opts.Service, string(infoKey("key")))
+	assert.NoError(t, err)
+}
+
+func TestExternalBackend(t *testing.T) {
+	t.Parallel()
+
+	// Serve the keys of an in-memory keybase
+	signerKb := NewInMemory()
+	info, err := signerKb.CreateAccount("key", testMnemonic, "", testPassword, 0, 0)
+	require.NoError(t, err)
+	offlineKb := NewInMemory()
+	offline, err := offlineKb.CreateAccount("offline", testMnemonic, "", testPassword, 0, 1)
+	require.NoError(t, err)
+	_, err = signerKb.CreateOffline("offline", offline.GetPubKey())
+	require.NoError(t, err)
+
+	testExternal := func(t *testing.T, kb Keybase) {
+		t.Helper()
+
+		infos, err := kb.List()
+		require.NoError(t, err)
+		assert.Len(t, infos, 1)
+
+		got, err := kb.GetByNameOrAddress(info.GetAddress().String())
+		require.NoError(t, err)
+		assert.Equal(t, "key", got.GetName())
+		assert.True(t, got.GetPubKey().Equals(info.GetPubKey()))
+
+		has, err := kb.HasByName("missing")
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		sig, pub, err := kb.Sign("key", testPassword, []byte("msg"))
+		require.NoError(t, err)
+		assert.True(t, pub.Equals(info.GetPubKey()))
+		require.NoError(t, kb.Verify("key", []byte("msg"), sig))
+
+		_, _, err = kb.Sign("key", "wrong", []byte("msg"))
+		assert.ErrorContains(t, err, "invalid account password")
+
+		_, err = kb.CreateAccount("new", testMnemonic, "", testPassword, 0, 1)
+		assert.ErrorIs(t, err, errExternalUnsupported)
+	}
+
+	t.Run("http", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(NewSignerHandler(signerKb))
+		defer srv.Close()
+
+		kb, err := NewKeybase(BackendOptions{Backend: BackendExternal, SignerURL: srv.URL})
+		require.NoError(t, err)
+
+		testExternal(t, kb)
+	})
+
+	t.Run("unix socket", func(t *testing.T) {
+		t.Parallel()
+
+		// Unix socket paths are limited in length
+		dir, err := os.MkdirTemp("", "signer")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "signer.sock")
+		ln, err := net.Listen("unix", path)
+		require.NoError(t, err)
+
+		srv := &http.Server{Handler: NewSignerHandler(signerKb)}
+		go srv.Serve(ln)
+		defer srv.Close()
+
+		kb, err := NewKeybase(BackendOptions{Backend: BackendExternal, SignerURL: "unix://" + path})
+		require.NoError(t, err)
+
+		testExternal(t, kb)
+	})
+
+	t.Run("missing URL", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewKeybase(BackendOptions{Backend: BackendExternal})
+		assert.Error(t, err)
+	})
+
+	t.Run("untrusted signer", func(t *testing.T) {
+		t.Parallel()
+
+		// A signer returning signatures of another key
+		otherKb := NewInMemory()
+		_, err := otherKb.CreateAccount("key", testMnemonic, "", testPassword, 0, 1)
+		require.NoError(t, err)
+
+		handler := NewSignerHandler(otherKb)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, signerSignPath) {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, r)
+
+				// Tamper with the signature
+				var res signerSignResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				res.Signature[0] ^= 0xff
+				writeSignerResponse(w, res)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		defer srv.Close()
+
+		kb, err := NewKeybase(BackendOptions{Backend: BackendExternal, SignerURL: srv.URL})
+		require.NoError(t, err)
+
+		_, _, err = kb.Sign("key", testPassword, []byte("msg"))
+		assert.ErrorContains(t, err, "invalid signature from external signer")
+	})
+}
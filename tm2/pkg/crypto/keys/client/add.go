@@ -121,7 +121,7 @@ func execAdd(cfg *AddCfg, args []string, io commands.IO) error {
 	name := args[0]
 
 	// Read the keybase from the home directory
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.Keybase(io)
 	if err != nil {
 		return fmt.Errorf("unable to read keybase, %w", err)
 	}
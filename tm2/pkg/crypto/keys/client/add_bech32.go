@@ -7,7 +7,6 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
-	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 )
 
 type AddBech32Cfg struct {
@@ -53,7 +52,7 @@ func execAddBech32(cfg *AddBech32Cfg, args []string, io commands.IO) error {
 	name := args[0]
 
 	// Read the keybase from the home directory
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.RootCfg.Keybase(io)
 	if err != nil {
 		return fmt.Errorf("unable to read keybase, %w", err)
 	}
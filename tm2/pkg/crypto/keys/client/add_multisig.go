@@ -84,7 +84,7 @@ func execAddMultisig(cfg *AddMultisigCfg, args []string, io commands.IO) error {
 	name := args[0]
 
 	// Read the keybase from the home directory
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.RootCfg.Keybase(io)
 	if err != nil {
 		return fmt.Errorf("unable to read keybase, %w", err)
 	}
@@ -1,11 +1,15 @@
 package client
 
+import "github.com/gnolang/gno/tm2/pkg/crypto/keys"
+
 type BaseOptions struct {
 	Home                  string
 	Remote                string
 	Quiet                 bool
 	InsecurePasswordStdin bool
 	Config                string
+	KeyringBackend        string
+	KeyringSigner         string
 }
 
 var DefaultBaseOptions = BaseOptions{
@@ -14,4 +18,6 @@ var DefaultBaseOptions = BaseOptions{
 	Quiet:                 false,
 	InsecurePasswordStdin: false,
 	Config:                "",
+	KeyringBackend:        keys.BackendDB,
+	KeyringSigner:         "",
 }
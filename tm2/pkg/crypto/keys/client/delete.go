@@ -57,7 +57,7 @@ func execDelete(cfg *DeleteCfg, args []string, io commands.IO) error {
 
 	nameOrBech32 := args[0]
 
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.Keybase(io)
 	if err != nil {
 		return err
 	}
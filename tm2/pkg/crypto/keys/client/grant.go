@@ -0,0 +1,290 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/feegrant"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeGrantCfg struct {
+	RootCfg *MakeTxCfg
+
+	Grantee         string
+	SpendLimit      string
+	Expiration      string
+	Period          time.Duration
+	PeriodLimit     string
+	AllowedMsgs     string
+	AllowedPkgPaths string
+}
+
+func NewMakeGrantCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeGrantCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "grant",
+			ShortUsage: "grant [flags] <key-name or address>",
+			ShortHelp:  "grants an allowance to pay the fees of another account",
+			LongHelp:   "Grants an allowance to the grantee, replacing any existing one. The fees of the txs of the grantee setting the granter as fee granter are then paid by the granter, within the limits of the allowance.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeGrant(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeGrantCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Grantee,
+		"grantee",
+		"",
+		"address of the grantee",
+	)
+
+	fs.StringVar(
+		&c.SpendLimit,
+		"spend-limit",
+		"",
+		"total fees the grantee can spend (defaults to no limit)",
+	)
+
+	fs.StringVar(
+		&c.Expiration,
+		"expiration",
+		"",
+		"RFC 3339 time at which the allowance expires (defaults to never)",
+	)
+
+	fs.DurationVar(
+		&c.Period,
+		"period",
+		0,
+		"duration of the periods of a periodic allowance",
+	)
+
+	fs.StringVar(
+		&c.PeriodLimit,
+		"period-limit",
+		"",
+		"fees the grantee can spend per period (only useful with --period)",
+	)
+
+	fs.StringVar(
+		&c.AllowedMsgs,
+		"allowed-msgs",
+		"",
+		"comma-separated msgs the allowance is restricted to, as <route>/<type> (e.g. vm/exec)",
+	)
+
+	fs.StringVar(
+		&c.AllowedPkgPaths,
+		"allowed-pkgpaths",
+		"",
+		"comma-separated package paths the allowance is restricted to",
+	)
+}
+
+func execMakeGrant(cfg *MakeGrantCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.Grantee == "" {
+		return errors.New("grantee must be specified")
+	}
+
+	granter, err := getAddress(cfg.RootCfg, args[0], io)
+	if err != nil {
+		return err
+	}
+
+	grantee, err := crypto.AddressFromBech32(cfg.Grantee)
+	if err != nil {
+		return errors.Wrap(err, "parsing grantee address")
+	}
+
+	allowance, err := cfg.allowance()
+	if err != nil {
+		return err
+	}
+
+	msg := feegrant.NewMsgGrantAllowance(granter, grantee, allowance)
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	return execMakeMsgTx(cfg.RootCfg, args, msg, io)
+}
+
+// allowance builds the allowance described by the flags
+func (c *MakeGrantCfg) allowance() (feegrant.FeeAllowance, error) {
+	var (
+		basic feegrant.BasicAllowance
+		err   error
+	)
+
+	if c.SpendLimit != "" {
+		basic.SpendLimit, err = std.ParseCoins(c.SpendLimit)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing spend limit")
+		}
+	}
+
+	if c.Expiration != "" {
+		basic.Expiration, err = time.Parse(time.RFC3339, c.Expiration)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing expiration")
+		}
+	}
+
+	var allowance feegrant.FeeAllowance = &basic
+
+	if c.Period != 0 || c.PeriodLimit != "" {
+		periodLimit, err := std.ParseCoins(c.PeriodLimit)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing period limit")
+		}
+
+		allowance = &feegrant.PeriodicAllowance{
+			Basic:            basic,
+			Period:           c.Period,
+			PeriodSpendLimit: periodLimit,
+		}
+	}
+
+	if c.AllowedMsgs != "" || c.AllowedPkgPaths != "" {
+		allowance = &feegrant.AllowedMsgAllowance{
+			Allowance:       allowance,
+			AllowedMsgs:     splitList(c.AllowedMsgs),
+			AllowedPkgPaths: splitList(c.AllowedPkgPaths),
+		}
+	}
+
+	return allowance, nil
+}
+
+type MakeRevokeCfg struct {
+	RootCfg *MakeTxCfg
+
+	Grantee string
+}
+
+func NewMakeRevokeCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeRevokeCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "revoke",
+			ShortUsage: "revoke [flags] <key-name or address>",
+			ShortHelp:  "revokes the fee allowance granted to another account",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeRevoke(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeRevokeCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Grantee,
+		"grantee",
+		"",
+		"address of the grantee",
+	)
+}
+
+func execMakeRevoke(cfg *MakeRevokeCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.Grantee == "" {
+		return errors.New("grantee must be specified")
+	}
+
+	granter, err := getAddress(cfg.RootCfg, args[0], io)
+	if err != nil {
+		return err
+	}
+
+	grantee, err := crypto.AddressFromBech32(cfg.Grantee)
+	if err != nil {
+		return errors.Wrap(err, "parsing grantee address")
+	}
+
+	msg := feegrant.NewMsgRevokeAllowance(granter, grantee)
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	return execMakeMsgTx(cfg.RootCfg, args, msg, io)
+}
+
+// getAddress returns the address of the given key
+func getAddress(cfg *MakeTxCfg, nameOrBech32 string, io commands.IO) (crypto.Address, error) {
+	kb, err := cfg.RootCfg.Keybase(io)
+	if err != nil {
+		return crypto.Address{}, err
+	}
+
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return crypto.Address{}, err
+	}
+
+	return info.GetAddress(), nil
+}
+
+// execMakeMsgTx wraps msg in a tx, handled by ExecMakeTx
+func execMakeMsgTx(cfg *MakeTxCfg, args []string, msg std.Msg, io commands.IO) error {
+	gasfee, err := std.ParseCoin(cfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        std.NewFee(cfg.GasWanted, gasfee),
+		Signatures: nil,
+		Memo:       cfg.Memo,
+	}
+
+	return ExecMakeTx(cfg, args, tx, io)
+}
+
+// splitList splits a comma-separated list, ignoring empty items
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
-	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/armor"
 )
 
@@ -69,7 +68,7 @@ func execImport(cfg *ImportCfg, io commands.IO) error {
 	}
 
 	// Create a new instance of the key-base
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.Keybase(io)
 	if err != nil {
 		return fmt.Errorf(
 			"unable to create a key base from directory %s, %w",
@@ -27,7 +27,7 @@ func execList(cfg *BaseCfg, args []string, io commands.IO) error {
 		return flag.ErrHelp
 	}
 
-	kb, err := keys.NewKeyBaseFromDir(cfg.Home)
+	kb, err := cfg.Keybase(io)
 	if err != nil {
 		return err
 	}
@@ -1,12 +1,17 @@
 package client
 
 import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
 	"github.com/gnolang/gno/tm2/pkg/testutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_execList(t *testing.T) {
@@ -49,3 +54,81 @@ func Test_execList(t *testing.T) {
 		})
 	}
 }
+
+func Test_execList_KeyringBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("file", func(t *testing.T) {
+		t.Parallel()
+
+		kbHome := t.TempDir()
+
+		// Initialize the keyring file
+		kb, err := keys.NewKeybase(keys.BackendOptions{
+			Backend:  keys.BackendFile,
+			Dir:      kbHome,
+			Password: "keyring",
+		})
+		require.NoError(t, err)
+		_, err = kb.CreateAccount("something", testMnemonic, "", "", 0, 0)
+		require.NoError(t, err)
+
+		cfg := &BaseCfg{
+			BaseOptions: BaseOptions{
+				Home:                  kbHome,
+				InsecurePasswordStdin: true,
+				KeyringBackend:        keys.BackendFile,
+			},
+		}
+
+		mockOut := bytes.NewBufferString("")
+		io := commands.NewTestIO()
+		io.SetIn(strings.NewReader("keyring\n"))
+		io.SetOut(commands.WriteNopCloser(mockOut))
+
+		require.NoError(t, execList(cfg, []string{}, io))
+		assert.Contains(t, mockOut.String(), "something")
+
+		// Wrong keyring password
+		cfg.kb = nil
+		io.SetIn(strings.NewReader("wrong\n"))
+		assert.True(t, keyerror.IsErrWrongPassword(execList(cfg, []string{}, io)))
+	})
+
+	t.Run("external", func(t *testing.T) {
+		t.Parallel()
+
+		kb := keys.NewInMemory()
+		_, err := kb.CreateAccount("something", testMnemonic, "", "", 0, 0)
+		require.NoError(t, err)
+
+		srv := httptest.NewServer(keys.NewSignerHandler(kb))
+		defer srv.Close()
+
+		cfg := &BaseCfg{
+			BaseOptions: BaseOptions{
+				KeyringBackend: keys.BackendExternal,
+				KeyringSigner:  srv.URL,
+			},
+		}
+
+		mockOut := bytes.NewBufferString("")
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(mockOut))
+
+		require.NoError(t, execList(cfg, []string{}, io))
+		assert.Contains(t, mockOut.String(), "something")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := &BaseCfg{
+			BaseOptions: BaseOptions{
+				KeyringBackend: "cloud",
+			},
+		}
+
+		assert.ErrorIs(t, execList(cfg, []string{}, commands.NewTestIO()), keys.ErrUnknownBackend)
+	})
+}
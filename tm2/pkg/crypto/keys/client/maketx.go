@@ -4,10 +4,13 @@ import (
 	"encoding/base64"
 	"flag"
 	"fmt"
+	"math"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -20,10 +23,26 @@ type MakeTxCfg struct {
 	GasFee    string
 	Memo      string
 
+	// FeeGranter is the address paying the fees of the tx, through a fee
+	// allowance granted to the signer
+	FeeGranter string
+
 	Broadcast bool
 	// Valid options are SimulateTest, SimulateSkip or SimulateOnly.
 	Simulate string
 	ChainID  string
+
+	// Offline signs the tx with the given account number and sequence,
+	// without contacting a node
+	Offline       bool
+	AccountNumber uint64
+	Sequence      uint64
+
+	// GasAdjustment, if positive, enables estimating the gas wanted of a tx
+	// broadcast without it, as the gas used by its simulation multiplied by
+	// GasAdjustment. It's only set by the subcommands supporting estimation;
+	// the others require the gas wanted.
+	GasAdjustment float64
 }
 
 // These are the valid options for MakeTxConfig.Simulate.
@@ -39,6 +58,9 @@ func (c *MakeTxCfg) Validate() error {
 	default:
 		return fmt.Errorf("invalid simulate option: %q", c.Simulate)
 	}
+	if c.Offline && c.Broadcast {
+		return errors.New("offline and broadcast options are mutually exclusive")
+	}
 	return nil
 }
 
@@ -59,6 +81,10 @@ func NewMakeTxCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		NewMakeSendCmd(cfg, io),
+		NewMakeGrantCmd(cfg, io),
+		NewMakeRevokeCmd(cfg, io),
+		NewMakeCreateSessionCmd(cfg, io),
+		NewMakeRevokeSessionCmd(cfg, io),
 	)
 
 	return cmd
@@ -86,6 +112,13 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 		"any descriptive text",
 	)
 
+	fs.StringVar(
+		&c.FeeGranter,
+		"fee-granter",
+		"",
+		"address paying the gas fee, through a fee allowance granted to the signer",
+	)
+
 	fs.BoolVar(
 		&c.Broadcast,
 		"broadcast",
@@ -107,7 +140,28 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 		&c.ChainID,
 		"chainid",
 		"dev",
-		"chainid to sign for (only useful with --broadcast)",
+		"chainid to sign for (only useful with --broadcast or --offline)",
+	)
+
+	fs.BoolVar(
+		&c.Offline,
+		"offline",
+		false,
+		"sign the tx with --account-number and --account-sequence, without contacting a node",
+	)
+
+	fs.Uint64Var(
+		&c.AccountNumber,
+		"account-number",
+		0,
+		"account number to sign with (only useful with --offline)",
+	)
+
+	fs.Uint64Var(
+		&c.Sequence,
+		"account-sequence",
+		0,
+		"account sequence to sign with (only useful with --offline)",
 	)
 }
 
@@ -120,7 +174,7 @@ func SignAndBroadcastHandler(
 	baseopts := cfg.RootCfg
 	txopts := cfg
 
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.Keybase(nil)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +214,15 @@ func SignAndBroadcastHandler(
 		decryptPass: pass,
 	}
 
+	// estimate gas wanted, if not set and enabled
+	if tx.Fee.GasWanted == 0 && cfg.GasAdjustment > 0 {
+		gasWanted, err := estimateGas(cfg, tx, kb, sOpts, kOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "estimate gas")
+		}
+		tx.Fee.GasWanted = gasWanted
+	}
+
 	if err := signTx(&tx, kb, sOpts, kOpts); err != nil {
 		return nil, fmt.Errorf("unable to sign transaction, %w", err)
 	}
@@ -176,7 +239,87 @@ func SignAndBroadcastHandler(
 	return BroadcastHandler(bopts)
 }
 
-func ExecSignAndBroadcast(
+// maxTxGasWanted is the max gas wanted of a tx, see [std.Tx.ValidateBasic].
+const maxTxGasWanted = int64(1<<60) - 1
+
+// estimateGas simulates tx, signed with the max gas of a block as gas wanted,
+// and returns the gas it used multiplied by cfg.GasAdjustment.
+func estimateGas(
+	cfg *MakeTxCfg,
+	tx std.Tx,
+	kb keys.Keybase,
+	sOpts signOpts,
+	kOpts keyOpts,
+) (int64, error) {
+	cli, err := client.NewHTTPClient(cfg.RootCfg.Remote)
+	if err != nil {
+		return 0, errors.Wrap(err, "new http client")
+	}
+
+	cres, err := cli.ConsensusParams(nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "query consensus params")
+	}
+	maxGas := cres.ConsensusParams.Block.MaxGas
+	if maxGas < 0 { // unlimited
+		maxGas = maxTxGasWanted
+	}
+
+	// the simulation doesn't verify signatures, but needs the pubkey of the
+	// signer, so the tx is signed all the same.
+	tx.Fee.GasWanted = maxGas
+	tx.Signatures = nil
+	if err := signTx(&tx, kb, sOpts, kOpts); err != nil {
+		return 0, fmt.Errorf("unable to sign transaction, %w", err)
+	}
+
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		return 0, errors.Wrap(err, "remarshaling tx binary bytes")
+	}
+	res, err := SimulateTx(cli, bz)
+	if err != nil {
+		return 0, errors.Wrap(err, "simulate tx")
+	}
+	if res.DeliverTx.IsErr() {
+		return 0, errors.Wrapf(res.DeliverTx.Error, "simulate transaction failed: log:%s", res.DeliverTx.Log)
+	}
+
+	return int64(math.Ceil(float64(res.DeliverTx.GasUsed) * cfg.GasAdjustment)), nil
+}
+
+// ExecMakeTx handles the tx composed by a maketx subcommand: it's signed and
+// broadcast with --broadcast, signed without contacting a node with --offline,
+// and printed unsigned otherwise.
+func ExecMakeTx(
+	cfg *MakeTxCfg,
+	args []string,
+	tx std.Tx,
+	io commands.IO,
+) error {
+	if cfg.FeeGranter != "" {
+		granter, err := crypto.AddressFromBech32(cfg.FeeGranter)
+		if err != nil {
+			return fmt.Errorf("invalid fee granter address, %w", err)
+		}
+
+		tx.Fee = tx.Fee.WithGranter(granter)
+	}
+
+	switch {
+	case cfg.Broadcast:
+		return ExecSignAndBroadcast(cfg, args, tx, io)
+	case cfg.Offline:
+		return ExecSignOffline(cfg, args, tx, io)
+	default:
+		io.Println(string(amino.MustMarshalJSON(tx)))
+		return nil
+	}
+}
+
+// ExecSignOffline signs tx with the account number and sequence of cfg, and
+// prints the signed tx. Nodes are never contacted.
+func ExecSignOffline(
 	cfg *MakeTxCfg,
 	args []string,
 	tx std.Tx,
@@ -186,19 +329,51 @@ func ExecSignAndBroadcast(
 		return err
 	}
 
-	baseopts := cfg.RootCfg
-
-	// query account
 	nameOrBech32 := args[0]
 
-	var err error
-	var pass string
-	if baseopts.Quiet {
-		pass, err = io.GetPassword("", baseopts.InsecurePasswordStdin)
-	} else {
-		pass, err = io.GetPassword("Enter password.", baseopts.InsecurePasswordStdin)
+	kb, err := cfg.RootCfg.Keybase(io)
+	if err != nil {
+		return err
 	}
 
+	pass, err := getKeyPassword(cfg.RootCfg, io)
+	if err != nil {
+		return err
+	}
+
+	sOpts := signOpts{
+		chainID:         cfg.ChainID,
+		accountSequence: cfg.Sequence,
+		accountNumber:   cfg.AccountNumber,
+	}
+
+	kOpts := keyOpts{
+		keyName:     nameOrBech32,
+		decryptPass: pass,
+	}
+
+	if err := signTx(&tx, kb, sOpts, kOpts); err != nil {
+		return fmt.Errorf("unable to sign transaction, %w", err)
+	}
+
+	io.Println(string(amino.MustMarshalJSON(tx)))
+	return nil
+}
+
+func ExecSignAndBroadcast(
+	cfg *MakeTxCfg,
+	args []string,
+	tx std.Tx,
+	io commands.IO,
+) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	// query account
+	nameOrBech32 := args[0]
+
+	pass, err := getKeyPassword(cfg.RootCfg, io)
 	if err != nil {
 		return err
 	}
@@ -225,3 +400,11 @@ func ExecSignAndBroadcast(
 
 	return nil
 }
+
+// getKeyPassword reads the password decrypting the signing key
+func getKeyPassword(baseopts *BaseCfg, io commands.IO) (string, error) {
+	if baseopts.Quiet {
+		return io.GetPassword("", baseopts.InsecurePasswordStdin)
+	}
+	return io.GetPassword("Enter password.", baseopts.InsecurePasswordStdin)
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeTx_Offline(t *testing.T) {
+	t.Parallel()
+
+	var (
+		keyName         = "generated-key"
+		encryptPassword = "encrypt"
+		toAddress       = crypto.AddressFromPreimage([]byte("to"))
+	)
+
+	runMakeSend := func(t *testing.T, kbHome string, extraArgs ...string) (string, error) {
+		t.Helper()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		out := bytes.NewBufferString("")
+
+		io := commands.NewTestIO()
+		io.SetIn(strings.NewReader(encryptPassword + "\n"))
+		io.SetOut(commands.WriteNopCloser(out))
+
+		// Any query to the remote would fail
+		cmd := NewRootCmdWithBaseConfig(io, BaseOptions{
+			Home:   kbHome,
+			Remote: "127.0.0.1:1",
+		})
+
+		args := []string{
+			"maketx",
+			"--insecure-password-stdin",
+			"--home", kbHome,
+			"--remote", "127.0.0.1:1",
+			"--gas-wanted", "100000",
+			"--gas-fee", "1000ugnot",
+			"--chainid", "test-chain",
+		}
+		args = append(args, extraArgs...)
+		args = append(args, "send", "--send", "10ugnot", "--to", toAddress.String(), keyName)
+
+		err := cmd.ParseAndRun(ctx, args)
+
+		return out.String(), err
+	}
+
+	newKeybase := func(t *testing.T) (string, keys.Info) {
+		t.Helper()
+
+		kbHome := t.TempDir()
+
+		kb, err := keys.NewKeyBaseFromDir(kbHome)
+		require.NoError(t, err)
+
+		info, err := kb.CreateAccount(keyName, generateTestMnemonic(t), "", encryptPassword, 0, 0)
+		require.NoError(t, err)
+
+		return kbHome, info
+	}
+
+	t.Run("signed offline", func(t *testing.T) {
+		t.Parallel()
+
+		kbHome, info := newKeybase(t)
+
+		out, err := runMakeSend(
+			t,
+			kbHome,
+			"--offline",
+			"--account-number", "4",
+			"--account-sequence", "2",
+		)
+		require.NoError(t, err)
+
+		var tx std.Tx
+		require.NoError(t, amino.UnmarshalJSON([]byte(out), &tx))
+
+		require.Len(t, tx.Msgs, 1)
+		assert.Equal(t, info.GetAddress(), tx.Msgs[0].(bank.MsgSend).FromAddress)
+
+		// Make sure the tx is signed with the given account number and sequence
+		require.Len(t, tx.Signatures, 1)
+
+		sig := tx.Signatures[0]
+		assert.True(t, sig.PubKey.Equals(info.GetPubKey()))
+
+		signBytes, err := tx.GetSignBytes("test-chain", 4, 2)
+		require.NoError(t, err)
+		assert.True(t, sig.PubKey.VerifyBytes(signBytes, sig.Signature))
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		t.Parallel()
+
+		kbHome, _ := newKeybase(t)
+
+		out, err := runMakeSend(t, kbHome)
+		require.NoError(t, err)
+
+		var tx std.Tx
+		require.NoError(t, amino.UnmarshalJSON([]byte(out), &tx))
+
+		assert.Empty(t, tx.Signatures)
+	})
+
+	t.Run("fee granter", func(t *testing.T) {
+		t.Parallel()
+
+		kbHome, _ := newKeybase(t)
+		granter := crypto.AddressFromPreimage([]byte("granter"))
+
+		out, err := runMakeSend(t, kbHome, "--fee-granter", granter.String())
+		require.NoError(t, err)
+
+		var tx std.Tx
+		require.NoError(t, amino.UnmarshalJSON([]byte(out), &tx))
+
+		feeGranter, ok := tx.Fee.GetGranter()
+		require.True(t, ok)
+		assert.Equal(t, granter, feeGranter)
+
+		_, err = runMakeSend(t, kbHome, "--fee-granter", "invalid")
+		assert.ErrorContains(t, err, "invalid fee granter address")
+	})
+
+	t.Run("offline broadcast", func(t *testing.T) {
+		t.Parallel()
+
+		kbHome, _ := newKeybase(t)
+
+		_, err := runMakeSend(t, kbHome, "--offline", "--broadcast")
+		assert.ErrorContains(t, err, "offline and broadcast options are mutually exclusive")
+	})
+}
@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var errNoPartialSignatures = errors.New("no partial signatures provided")
+
+type MultisignCfg struct {
+	RootCfg *BaseCfg
+
+	TxPath        string
+	ChainID       string
+	AccountNumber uint64
+	Sequence      uint64
+	Signatures    commands.StringArr
+}
+
+// NewMultisignCmd creates a gnokey multisign command
+func NewMultisignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &MultisignCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "multisign",
+			ShortUsage: "multisign [flags] <multisig key-name or address>",
+			ShortHelp:  "combines partial signatures into the multisig signature of the given tx document",
+			LongHelp: "Combines the partial signatures made with `sign --multisig` into the signature of " +
+				"the multisig account, and saves it to the tx document. At least as many partial " +
+				"signatures as the multisig threshold are required.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMultisign(cfg, args, io)
+		},
+	)
+}
+
+func (c *MultisignCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.TxPath,
+		"tx-path",
+		"",
+		"path to the Amino JSON-encoded tx (file) to sign",
+	)
+
+	fs.StringVar(
+		&c.ChainID,
+		"chainid",
+		"dev",
+		"the ID of the chain",
+	)
+
+	fs.Uint64Var(
+		&c.AccountNumber,
+		"account-number",
+		0,
+		"account number of the multisig account",
+	)
+
+	fs.Uint64Var(
+		&c.Sequence,
+		"account-sequence",
+		0,
+		"account sequence of the multisig account",
+	)
+
+	fs.Var(
+		&c.Signatures,
+		"signature",
+		"path to an Amino JSON-encoded partial signature (file); can be repeated",
+	)
+}
+
+func execMultisign(cfg *MultisignCfg, args []string, io commands.IO) error {
+	// Make sure the multisig key name is provided
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if len(cfg.Signatures) == 0 {
+		return errNoPartialSignatures
+	}
+
+	// Load the keybase
+	kb, err := cfg.RootCfg.Keybase(io)
+	if err != nil {
+		return fmt.Errorf("unable to load keybase, %w", err)
+	}
+
+	// Fetch the multisig key info from the keybase
+	info, err := kb.GetByNameOrAddress(args[0])
+	if err != nil {
+		return fmt.Errorf("unable to get key from keybase, %w", err)
+	}
+
+	// Load the transaction
+	tx, err := loadTx(cfg.TxPath)
+	if err != nil {
+		return err
+	}
+
+	// Load the partial signatures
+	sigs := make([]std.Signature, 0, len(cfg.Signatures))
+	for _, path := range cfg.Signatures {
+		sig, err := loadPartialSignature(path)
+		if err != nil {
+			return err
+		}
+
+		sigs = append(sigs, sig)
+	}
+
+	// Prepare the signature ops
+	sOpts := signOpts{
+		chainID:         cfg.ChainID,
+		accountSequence: cfg.Sequence,
+		accountNumber:   cfg.AccountNumber,
+	}
+
+	// Combine the partial signatures
+	if err := multisignTx(&tx, info, sigs, sOpts); err != nil {
+		return fmt.Errorf("unable to sign transaction, %w", err)
+	}
+
+	if err := saveTx(&tx, cfg.TxPath); err != nil {
+		return err
+	}
+
+	io.Printf("\nTx successfully signed by %s and saved to %s\n", args[0], cfg.TxPath)
+
+	return nil
+}
+
+// loadPartialSignature loads the partial signature at the given path
+// (Amino-encoded JSON)
+func loadPartialSignature(path string) (std.Signature, error) {
+	var sig std.Signature
+
+	sigRaw, err := os.ReadFile(path)
+	if err != nil {
+		return sig, fmt.Errorf("unable to read signature file %s, %w", path, err)
+	}
+
+	if err := amino.UnmarshalJSON(sigRaw, &sig); err != nil {
+		return sig, fmt.Errorf("unable to unmarshal signature %s, %w", path, err)
+	}
+
+	return sig, nil
+}
+
+// multisignTx combines the partial signatures into the signature of the
+// multisig account, and saves it to the given transaction
+func multisignTx(
+	tx *std.Tx,
+	info keys.Info,
+	sigs []std.Signature,
+	signOpts signOpts,
+) error {
+	signBytes, err := tx.GetSignBytes(
+		signOpts.chainID,
+		signOpts.accountNumber,
+		signOpts.accountSequence,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to get signature bytes, %w", err)
+	}
+
+	sig, err := std.CombineMultisig(info.GetPubKey(), signBytes, sigs)
+	if err != nil {
+		return err
+	}
+
+	// Save the signature
+	if addSignature(tx, sig) {
+		return nil
+	}
+
+	// Validate the tx after signing
+	if err := tx.ValidateBasic(); err != nil {
+		return fmt.Errorf("unable to validate transaction, %w", err)
+	}
+
+	return nil
+}
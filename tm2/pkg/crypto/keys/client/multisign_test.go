@@ -0,0 +1,272 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	multisigName     = "treasury"
+	multisigPassword = "encrypt"
+)
+
+// multisigTestEnv is a keybase with a 3-of-5 multisig account,
+// and a tx document sending from it
+type multisigTestEnv struct {
+	kbHome   string
+	keyNames []string
+	info     keys.Info
+	txPath   string
+}
+
+func newMultisigTestEnv(t *testing.T) multisigTestEnv {
+	t.Helper()
+
+	kbHome := t.TempDir()
+
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+
+	// Generate the keys of the multisig
+	keyNames := make([]string, 5)
+	pubs := make([]crypto.PubKey, 5)
+	for i := range keyNames {
+		keyNames[i] = fmt.Sprintf("key-%d", i)
+
+		info, err := kb.CreateAccount(keyNames[i], generateTestMnemonic(t), "", multisigPassword, 0, 0)
+		require.NoError(t, err)
+
+		pubs[i] = info.GetPubKey()
+	}
+
+	info, err := kb.CreateMulti(multisigName, multisig.NewPubKeyMultisigThreshold(3, pubs))
+	require.NoError(t, err)
+
+	// Create the tx file
+	tx := std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				FromAddress: info.GetAddress(),
+			},
+		},
+		Fee: std.Fee{
+			GasWanted: 10,
+			GasFee: std.Coin{
+				Amount: std.NewInt(10),
+				Denom:  "ugnot",
+			},
+		},
+	}
+
+	encodedTx, err := amino.MarshalJSON(tx)
+	require.NoError(t, err)
+
+	txPath := filepath.Join(t.TempDir(), "tx.json")
+	require.NoError(t, os.WriteFile(txPath, encodedTx, 0o644))
+
+	return multisigTestEnv{
+		kbHome:   kbHome,
+		keyNames: keyNames,
+		info:     info,
+		txPath:   txPath,
+	}
+}
+
+// signPartial makes the partial signature of the tx by the given key,
+// and returns the path of the signature file
+func (env multisigTestEnv) signPartial(t *testing.T, keyName string) (string, error) {
+	t.Helper()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+
+	io := commands.NewTestIO()
+	io.SetIn(strings.NewReader(multisigPassword + "\n"))
+
+	cmd := NewRootCmdWithBaseConfig(io, BaseOptions{
+		InsecurePasswordStdin: true,
+		Home:                  env.kbHome,
+		Quiet:                 true,
+	})
+
+	sigPath := filepath.Join(t.TempDir(), keyName+".sig")
+	args := []string{
+		"sign",
+		"--insecure-password-stdin",
+		"--home",
+		env.kbHome,
+		"--tx-path",
+		env.txPath,
+		"--chainid",
+		"dev",
+		"--account-number",
+		"7",
+		"--account-sequence",
+		"2",
+		"--multisig",
+		multisigName,
+		"--output-path",
+		sigPath,
+		keyName,
+	}
+
+	return sigPath, cmd.ParseAndRun(ctx, args)
+}
+
+// multisign combines the given partial signatures into the tx
+func (env multisigTestEnv) multisign(t *testing.T, sigPaths ...string) error {
+	t.Helper()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+
+	cmd := NewRootCmdWithBaseConfig(commands.NewTestIO(), BaseOptions{
+		Home: env.kbHome,
+	})
+
+	args := []string{
+		"multisign",
+		"--home",
+		env.kbHome,
+		"--tx-path",
+		env.txPath,
+		"--chainid",
+		"dev",
+		"--account-number",
+		"7",
+		"--account-sequence",
+		"2",
+	}
+	for _, sigPath := range sigPaths {
+		args = append(args, "--signature", sigPath)
+	}
+	args = append(args, multisigName)
+
+	return cmd.ParseAndRun(ctx, args)
+}
+
+func TestMultisign_MultisignTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no multisig key provided", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		cmd := NewRootCmdWithBaseConfig(commands.NewTestIO(), BaseOptions{
+			Home: env.kbHome,
+		})
+
+		args := []string{
+			"multisign",
+			"--home",
+			env.kbHome,
+			"--tx-path",
+			env.txPath,
+		}
+
+		assert.ErrorIs(t, cmd.ParseAndRun(ctx, args), flag.ErrHelp)
+	})
+
+	t.Run("no partial signatures", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		assert.ErrorIs(t, env.multisign(t), errNoPartialSignatures)
+	})
+
+	t.Run("key not part of the multisig", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		kb, err := keys.NewKeyBaseFromDir(env.kbHome)
+		require.NoError(t, err)
+
+		_, err = kb.CreateAccount("outsider", generateTestMnemonic(t), "", multisigPassword, 0, 0)
+		require.NoError(t, err)
+
+		_, err = env.signPartial(t, "outsider")
+		assert.ErrorContains(t, err, "is not part of multisig")
+	})
+
+	t.Run("not enough partial signatures", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		sigPaths := make([]string, 0, 2)
+		for _, keyName := range env.keyNames[:2] {
+			sigPath, err := env.signPartial(t, keyName)
+			require.NoError(t, err)
+
+			sigPaths = append(sigPaths, sigPath)
+		}
+
+		assert.ErrorContains(
+			t,
+			env.multisign(t, sigPaths...),
+			"not enough partial signatures: 2 of 3 required",
+		)
+	})
+
+	t.Run("valid multisig signature", func(t *testing.T) {
+		t.Parallel()
+
+		env := newMultisigTestEnv(t)
+
+		// Make sure the partial signatures don't modify the tx
+		originalTx, err := os.ReadFile(env.txPath)
+		require.NoError(t, err)
+
+		sigPaths := make([]string, 0, 3)
+		for _, keyName := range []string{env.keyNames[4], env.keyNames[1], env.keyNames[2]} {
+			sigPath, err := env.signPartial(t, keyName)
+			require.NoError(t, err)
+
+			sigPaths = append(sigPaths, sigPath)
+		}
+
+		savedTxRaw, err := os.ReadFile(env.txPath)
+		require.NoError(t, err)
+		assert.Equal(t, originalTx, savedTxRaw)
+
+		require.NoError(t, env.multisign(t, sigPaths...))
+
+		// Make sure the tx file was updated with the multisig signature
+		savedTxRaw, err = os.ReadFile(env.txPath)
+		require.NoError(t, err)
+
+		var savedTx std.Tx
+		require.NoError(t, amino.UnmarshalJSON(savedTxRaw, &savedTx))
+
+		require.Len(t, savedTx.Signatures, 1)
+
+		sig := savedTx.Signatures[0]
+		assert.True(t, sig.PubKey.Equals(env.info.GetPubKey()))
+
+		signBytes, err := savedTx.GetSignBytes("dev", 7, 2)
+		require.NoError(t, err)
+		assert.True(t, sig.PubKey.VerifyBytes(signBytes, sig.Signature))
+	})
+}
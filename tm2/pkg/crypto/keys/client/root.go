@@ -2,9 +2,13 @@
 package client
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"strings"
 
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 
 	"github.com/peterbourgon/ff/v3"
 	"github.com/peterbourgon/ff/v3/fftoml"
@@ -16,6 +20,8 @@ const (
 
 type BaseCfg struct {
 	BaseOptions
+
+	kb keys.Keybase // opened keybase, see Keybase
 }
 
 func NewRootCmdWithBaseConfig(io commands.IO, base BaseOptions) *commands.Command {
@@ -45,9 +51,11 @@ func NewRootCmdWithBaseConfig(io commands.IO, base BaseOptions) *commands.Comman
 		NewListCmd(cfg, io),
 		NewRotateCmd(cfg, io),
 		NewSignCmd(cfg, io),
+		NewMultisignCmd(cfg, io),
 		NewVerifyCmd(cfg, io),
 		NewQueryCmd(cfg, io),
 		NewBroadcastCmd(cfg, io),
+		NewTxCmd(cfg, io),
 		NewMakeTxCmd(cfg, io),
 	)
 
@@ -90,4 +98,57 @@ func (c *BaseCfg) RegisterFlags(fs *flag.FlagSet) {
 		c.Config,
 		"config file (optional)",
 	)
+
+	fs.StringVar(
+		&c.KeyringBackend,
+		"keyring-backend",
+		c.KeyringBackend,
+		fmt.Sprintf("keyring backend to store the keys in (%s)", strings.Join(keys.Backends, ", ")),
+	)
+
+	fs.StringVar(
+		&c.KeyringSigner,
+		"keyring-signer",
+		c.KeyringSigner,
+		"URL of the external signer of the external keyring backend (http://<host>:<port> or unix://<socket path>)",
+	)
+}
+
+// Keybase opens the keybase of the selected keyring backend, once per
+// command. The keyring password of the file backend is read from io.
+func (c *BaseCfg) Keybase(io commands.IO) (keys.Keybase, error) {
+	if c.kb != nil {
+		return c.kb, nil
+	}
+
+	opts := keys.BackendOptions{
+		Backend:   c.KeyringBackend,
+		Dir:       c.Home,
+		SignerURL: c.KeyringSigner,
+	}
+
+	if opts.Backend == keys.BackendFile {
+		if io == nil {
+			return nil, errors.New("unable to read keyring password")
+		}
+
+		prompt := "Enter keyring password"
+		if c.Quiet {
+			prompt = "" // No prompt
+		}
+
+		password, err := io.GetPassword(prompt, c.InsecurePasswordStdin)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get keyring password, %w", err)
+		}
+		opts.Password = password
+	}
+
+	kb, err := keys.NewKeybase(opts)
+	if err != nil {
+		return nil, err
+	}
+	c.kb = kb
+
+	return kb, nil
 }
@@ -6,7 +6,6 @@ import (
 	"fmt"
 
 	"github.com/gnolang/gno/tm2/pkg/commands"
-	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 )
 
 type RotateCfg struct {
@@ -43,7 +42,7 @@ func execRotate(cfg *RotateCfg, args []string, io commands.IO) error {
 
 	nameOrBech32 := args[0]
 
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.Keybase(io)
 	if err != nil {
 		return err
 	}
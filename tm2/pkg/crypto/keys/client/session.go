@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/session"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeCreateSessionCfg struct {
+	RootCfg *MakeTxCfg
+
+	SessionKey string
+	Scopes     commands.StringArr
+	SpendLimit string
+	Expiration string
+}
+
+func NewMakeCreateSessionCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeCreateSessionCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "create-session",
+			ShortUsage: "create-session [flags] <key-name or address>",
+			ShortHelp:  "authorizes a session key to sign realm calls on behalf of an account",
+			LongHelp:   "Authorizes the session key to sign txs on behalf of the account, replacing any existing session of the key. The txs signed by the session key may only call the functions of the scopes, and spend up to the spend limit in sent coins and fees.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeCreateSession(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeCreateSessionCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.SessionKey,
+		"session-key",
+		"",
+		"name or address of the session key in the keybase, or its bech32 public key",
+	)
+
+	fs.Var(
+		&c.Scopes,
+		"scope",
+		"realm the session key can call, as <pkgpath> or <pkgpath>:<func>,<func>... (can be repeated)",
+	)
+
+	fs.StringVar(
+		&c.SpendLimit,
+		"spend-limit",
+		"",
+		"total coins the session key can send and spend in fees (defaults to none)",
+	)
+
+	fs.StringVar(
+		&c.Expiration,
+		"expiration",
+		"",
+		"RFC 3339 time at which the session expires",
+	)
+}
+
+func execMakeCreateSession(cfg *MakeCreateSessionCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.SessionKey == "" {
+		return errors.New("session-key must be specified")
+	}
+	if len(cfg.Scopes) == 0 {
+		return errors.New("at least one scope must be specified")
+	}
+	if cfg.Expiration == "" {
+		return errors.New("expiration must be specified")
+	}
+
+	account, err := getAddress(cfg.RootCfg, args[0], io)
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := getSessionPubKey(cfg.RootCfg, cfg.SessionKey, io)
+	if err != nil {
+		return err
+	}
+
+	s := session.Session{
+		Account: account,
+		PubKey:  pubKey,
+		Scopes:  parseScopes(cfg.Scopes),
+	}
+
+	if cfg.SpendLimit != "" {
+		s.SpendLimit, err = std.ParseCoins(cfg.SpendLimit)
+		if err != nil {
+			return errors.Wrap(err, "parsing spend limit")
+		}
+	}
+
+	s.Expiration, err = time.Parse(time.RFC3339, cfg.Expiration)
+	if err != nil {
+		return errors.Wrap(err, "parsing expiration")
+	}
+
+	msg := session.NewMsgCreateSession(s)
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	return execMakeMsgTx(cfg.RootCfg, args, msg, io)
+}
+
+type MakeRevokeSessionCfg struct {
+	RootCfg *MakeTxCfg
+
+	SessionKey string
+}
+
+func NewMakeRevokeSessionCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeRevokeSessionCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "revoke-session",
+			ShortUsage: "revoke-session [flags] <key-name or address>",
+			ShortHelp:  "revokes the session of a session key of an account",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeRevokeSession(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeRevokeSessionCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.SessionKey,
+		"session-key",
+		"",
+		"address of the session key, or its name in the keybase",
+	)
+}
+
+func execMakeRevokeSession(cfg *MakeRevokeSessionCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.SessionKey == "" {
+		return errors.New("session-key must be specified")
+	}
+
+	account, err := getAddress(cfg.RootCfg, args[0], io)
+	if err != nil {
+		return err
+	}
+
+	address, err := crypto.AddressFromBech32(cfg.SessionKey)
+	if err != nil {
+		// Not an address, look up the key
+		address, err = getAddress(cfg.RootCfg, cfg.SessionKey, io)
+		if err != nil {
+			return err
+		}
+	}
+
+	msg := session.NewMsgRevokeSession(account, address)
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	return execMakeMsgTx(cfg.RootCfg, args, msg, io)
+}
+
+// getSessionPubKey returns the public key of the session key, given as a
+// bech32 public key, or as the name or address of a key of the keybase
+func getSessionPubKey(cfg *MakeTxCfg, key string, io commands.IO) (crypto.PubKey, error) {
+	if pubKey, err := crypto.PubKeyFromBech32(key); err == nil {
+		return pubKey, nil
+	}
+
+	kb, err := cfg.RootCfg.Keybase(io)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := kb.GetByNameOrAddress(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return info.GetPubKey(), nil
+}
+
+// parseScopes parses the scopes given as <pkgpath>[:<func>,<func>...]
+func parseScopes(scopes []string) []session.Scope {
+	res := make([]session.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		pkgPath, funcs, _ := strings.Cut(scope, ":")
+		res = append(res, session.Scope{
+			PkgPath: strings.TrimSpace(pkgPath),
+			Funcs:   splitList(funcs),
+		})
+	}
+	return res
+}
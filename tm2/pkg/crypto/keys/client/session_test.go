@@ -0,0 +1,114 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/sdk/session"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeTx_Session(t *testing.T) {
+	t.Parallel()
+
+	kbHome := t.TempDir()
+
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+
+	account, err := kb.CreateAccount("account", generateTestMnemonic(t), "", "encrypt", 0, 0)
+	require.NoError(t, err)
+	sessionKey, err := kb.CreateAccount("session", generateTestMnemonic(t), "", "encrypt", 0, 0)
+	require.NoError(t, err)
+
+	runMakeTx := func(t *testing.T, args ...string) (std.Tx, error) {
+		t.Helper()
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		out := bytes.NewBufferString("")
+
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(out))
+
+		cmd := NewRootCmdWithBaseConfig(io, BaseOptions{
+			Home:   kbHome,
+			Remote: "127.0.0.1:1",
+		})
+
+		args = append([]string{
+			"maketx",
+			"--home", kbHome,
+			"--gas-wanted", "100000",
+			"--gas-fee", "1000ugnot",
+		}, args...)
+
+		var tx std.Tx
+		if err := cmd.ParseAndRun(ctx, args); err != nil {
+			return tx, err
+		}
+
+		require.NoError(t, amino.UnmarshalJSON(out.Bytes(), &tx))
+		return tx, nil
+	}
+
+	t.Run("create session", func(t *testing.T) {
+		t.Parallel()
+
+		tx, err := runMakeTx(
+			t,
+			"create-session",
+			"--session-key", crypto.PubKeyToBech32(sessionKey.GetPubKey()),
+			"--scope", "gno.land/r/demo/game:Move, Attack",
+			"--scope", "gno.land/r/demo/chat",
+			"--spend-limit", "100ugnot",
+			"--expiration", "2030-01-01T00:00:00Z",
+			"account",
+		)
+		require.NoError(t, err)
+
+		require.Len(t, tx.Msgs, 1)
+		assert.Equal(t, session.Session{
+			Account: account.GetAddress(),
+			PubKey:  sessionKey.GetPubKey(),
+			Scopes: []session.Scope{
+				{PkgPath: "gno.land/r/demo/game", Funcs: []string{"Move", "Attack"}},
+				{PkgPath: "gno.land/r/demo/chat"},
+			},
+			SpendLimit: std.NewCoins(std.NewCoin("ugnot", 100)),
+			Expiration: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, tx.Msgs[0].(session.MsgCreateSession).Session())
+	})
+
+	t.Run("create session, missing scope", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := runMakeTx(
+			t,
+			"create-session",
+			"--session-key", "session",
+			"--expiration", "2030-01-01T00:00:00Z",
+			"account",
+		)
+		assert.ErrorContains(t, err, "at least one scope must be specified")
+	})
+
+	t.Run("revoke session", func(t *testing.T) {
+		t.Parallel()
+
+		tx, err := runMakeTx(t, "revoke-session", "--session-key", "session", "account")
+		require.NoError(t, err)
+
+		require.Len(t, tx.Msgs, 1)
+		assert.Equal(t, session.NewMsgRevokeSession(account.GetAddress(), sessionKey.GetAddress()), tx.Msgs[0])
+	})
+}
@@ -5,9 +5,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -34,6 +36,8 @@ type SignCfg struct {
 	AccountNumber uint64
 	Sequence      uint64
 	NameOrBech32  string
+	Multisig      string
+	OutputPath    string
 }
 
 func NewSignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
@@ -82,6 +86,20 @@ func (c *SignCfg) RegisterFlags(fs *flag.FlagSet) {
 		0,
 		"account sequence to sign with",
 	)
+
+	fs.StringVar(
+		&c.Multisig,
+		"multisig",
+		"",
+		"name or address of the multisig account to make a partial signature for, instead of signing the tx",
+	)
+
+	fs.StringVar(
+		&c.OutputPath,
+		"output-path",
+		"",
+		"path to save the partial signature to, when using --multisig (defaults to stdout)",
+	)
 }
 
 func execSign(cfg *SignCfg, args []string, io commands.IO) error {
@@ -90,26 +108,8 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 		return flag.ErrHelp
 	}
 
-	// saveTx saves the given transaction to the given path (Amino-encoded JSON)
-	saveTx := func(tx *std.Tx, path string) error {
-		// Encode the transaction
-		encodedTx, err := amino.MarshalJSON(tx)
-		if err != nil {
-			return fmt.Errorf("unable ot marshal tx to JSON, %w", err)
-		}
-
-		// Save the transaction
-		if err := os.WriteFile(path, encodedTx, 0o644); err != nil {
-			return fmt.Errorf("unable to write tx to %s, %w", path, err)
-		}
-
-		io.Printf("\nTx successfully signed and saved to %s\n", path)
-
-		return nil
-	}
-
 	// Load the keybase
-	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	kb, err := cfg.RootCfg.Keybase(io)
 	if err != nil {
 		return fmt.Errorf("unable to load keybase, %w", err)
 	}
@@ -120,21 +120,23 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 		return fmt.Errorf("unable to get key from keybase, %w", err)
 	}
 
-	// Get the transaction bytes
-	txRaw, err := os.ReadFile(cfg.TxPath)
+	// Load the transaction
+	tx, err := loadTx(cfg.TxPath)
 	if err != nil {
-		return fmt.Errorf("unable to read transaction file")
+		return err
 	}
 
-	// Make sure there is something to actually sign
-	if len(txRaw) == 0 {
-		return errInvalidTxFile
-	}
+	// Fetch the multisig account info, if making a partial signature
+	var multisigInfo keys.Info
+	if cfg.Multisig != "" {
+		multisigInfo, err = kb.GetByNameOrAddress(cfg.Multisig)
+		if err != nil {
+			return fmt.Errorf("unable to get multisig key from keybase, %w", err)
+		}
 
-	// Make sure the tx is valid Amino JSON
-	var tx std.Tx
-	if err := amino.UnmarshalJSON(txRaw, &tx); err != nil {
-		return fmt.Errorf("unable to unmarshal transaction, %w", err)
+		if !std.IsMultisigKey(multisigInfo.GetPubKey(), info.GetPubKey()) {
+			return fmt.Errorf("key %s is not part of multisig %s", args[0], cfg.Multisig)
+		}
 	}
 
 	var password string
@@ -169,12 +171,92 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 		decryptPass: password,
 	}
 
+	if multisigInfo != nil {
+		// Make the partial signature
+		sig, err := signMultisigTx(&tx, multisigInfo.GetAddress(), kb, sOpts, kOpts)
+		if err != nil {
+			return fmt.Errorf("unable to sign transaction, %w", err)
+		}
+
+		return savePartialSignature(sig, cfg.OutputPath, io)
+	}
+
 	// Sign the transaction
 	if err := signTx(&tx, kb, sOpts, kOpts); err != nil {
 		return fmt.Errorf("unable to sign transaction, %w", err)
 	}
 
-	return saveTx(&tx, cfg.TxPath)
+	if err := saveTx(&tx, cfg.TxPath); err != nil {
+		return err
+	}
+
+	io.Printf("\nTx successfully signed and saved to %s\n", cfg.TxPath)
+
+	return nil
+}
+
+// loadTx loads the transaction at the given path (Amino-encoded JSON)
+func loadTx(path string) (std.Tx, error) {
+	var tx std.Tx
+
+	// Get the transaction bytes
+	txRaw, err := os.ReadFile(path)
+	if err != nil {
+		return tx, fmt.Errorf("unable to read transaction file")
+	}
+
+	// Make sure there is something to actually sign
+	if len(txRaw) == 0 {
+		return tx, errInvalidTxFile
+	}
+
+	// Make sure the tx is valid Amino JSON
+	if err := amino.UnmarshalJSON(txRaw, &tx); err != nil {
+		return tx, fmt.Errorf("unable to unmarshal transaction, %w", err)
+	}
+
+	return tx, nil
+}
+
+// saveTx saves the given transaction to the given path (Amino-encoded JSON)
+func saveTx(tx *std.Tx, path string) error {
+	// Encode the transaction
+	encodedTx, err := amino.MarshalJSON(tx)
+	if err != nil {
+		return fmt.Errorf("unable ot marshal tx to JSON, %w", err)
+	}
+
+	// Save the transaction
+	if err := os.WriteFile(path, encodedTx, 0o644); err != nil {
+		return fmt.Errorf("unable to write tx to %s, %w", path, err)
+	}
+
+	return nil
+}
+
+// savePartialSignature saves the given partial signature to the given path
+// (Amino-encoded JSON), or prints it if the path is empty
+func savePartialSignature(sig std.Signature, path string, io commands.IO) error {
+	// Encode the signature
+	encodedSig, err := amino.MarshalJSON(sig)
+	if err != nil {
+		return fmt.Errorf("unable to marshal signature to JSON, %w", err)
+	}
+
+	if path == "" {
+		io.Println(string(encodedSig))
+
+		return nil
+	}
+
+	// Save the signature
+	if err := os.WriteFile(path, encodedSig, 0o644); err != nil {
+		return fmt.Errorf("unable to write signature to %s, %w", path, err)
+	}
+
+	io.Printf("\nPartial signature successfully saved to %s\n", path)
+
+	return nil
 }
 
 // signTx generates the transaction signature,
@@ -205,38 +287,83 @@ func signTx(
 	}
 
 	// Save the signature
+	if addSignature(tx, std.Signature{
+		PubKey:    pub,
+		Signature: sig,
+	}) {
+		return nil
+	}
+
+	// Validate the tx after signing
+	if err := tx.ValidateBasic(); err != nil {
+		return fmt.Errorf("unable to validate transaction, %w", err)
+	}
+
+	return nil
+}
+
+// addSignature saves the signature to the given transaction, overwriting the
+// existing signature of the same key if any. It returns true if the signature
+// was overwritten.
+func addSignature(tx *std.Tx, sig std.Signature) bool {
 	if tx.Signatures == nil {
 		tx.Signatures = make([]std.Signature, 0, 1)
 	}
 
 	// Check if the signature needs to be overwritten
 	for index, signature := range tx.Signatures {
-		if !signature.PubKey.Equals(pub) {
+		if signature.PubKey == nil || !signature.PubKey.Equals(sig.PubKey) {
 			continue
 		}
 
 		// Save the signature
-		tx.Signatures[index] = std.Signature{
-			PubKey:    pub,
-			Signature: sig,
-		}
+		tx.Signatures[index] = sig
 
-		return nil
+		return true
 	}
 
 	// Append the signature, since it wasn't
 	// present before
-	tx.Signatures = append(
-		tx.Signatures, std.Signature{
-			PubKey:    pub,
-			Signature: sig,
-		},
+	tx.Signatures = append(tx.Signatures, sig)
+
+	return false
+}
+
+// signMultisigTx generates the partial signature of the transaction by
+// a key of the multisig account with the given address
+func signMultisigTx(
+	tx *std.Tx,
+	multisigAddr crypto.Address,
+	kb keys.Keybase,
+	signOpts signOpts,
+	keyOpts keyOpts,
+) (std.Signature, error) {
+	// Make sure the multisig account needs to sign the transaction
+	if !slices.Contains(tx.GetSigners(), multisigAddr) {
+		return std.Signature{}, fmt.Errorf("multisig %s is not a signer of the transaction", multisigAddr)
+	}
+
+	signBytes, err := tx.GetSignBytes(
+		signOpts.chainID,
+		signOpts.accountNumber,
+		signOpts.accountSequence,
 	)
+	if err != nil {
+		return std.Signature{}, fmt.Errorf("unable to get signature bytes, %w", err)
+	}
 
-	// Validate the tx after signing
-	if err := tx.ValidateBasic(); err != nil {
-		return fmt.Errorf("unable to validate transaction, %w", err)
+	// Sign the transaction data
+	sig, pub, err := kb.Sign(
+		keyOpts.keyName,
+		keyOpts.decryptPass,
+		signBytes,
+	)
+	if err != nil {
+		return std.Signature{}, fmt.Errorf("unable to sign transaction bytes, %w", err)
 	}
 
-	return nil
+	return std.Signature{
+		PubKey:    pub,
+		Signature: sig,
+	}, nil
 }
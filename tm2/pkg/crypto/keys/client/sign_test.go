@@ -248,7 +248,7 @@ func TestSign_SignTx(t *testing.T) {
 			tx = std.Tx{
 				Fee: std.Fee{
 					GasFee: std.Coin{ // invalid gas fee
-						Amount: 0,
+						Amount: std.NewInt(0),
 						Denom:  "ugnot",
 					},
 				},
@@ -327,7 +327,7 @@ func TestSign_SignTx(t *testing.T) {
 				Fee: std.Fee{
 					GasWanted: 10,
 					GasFee: std.Coin{
-						Amount: 10,
+						Amount: std.NewInt(10),
 						Denom:  "ugnot",
 					},
 				},
@@ -424,7 +424,7 @@ func TestSign_SignTx(t *testing.T) {
 				Fee: std.Fee{
 					GasWanted: 10,
 					GasFee: std.Coin{
-						Amount: 10,
+						Amount: std.NewInt(10),
 						Denom:  "ugnot",
 					},
 				},
@@ -542,7 +542,7 @@ func TestSign_SignTx(t *testing.T) {
 				Fee: std.Fee{
 					GasWanted: 10,
 					GasFee: std.Coin{
-						Amount: 10,
+						Amount: std.NewInt(10),
 						Denom:  "ugnot",
 					},
 				},
@@ -0,0 +1,391 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Encodings of the tx documents read and written by the tx commands
+const (
+	txEncodingJSON   = "json"   // Amino JSON
+	txEncodingBinary = "binary" // Amino binary
+	txEncodingBase64 = "base64" // base64 of the Amino binary
+)
+
+var errInvalidTxEncoding = errors.New("invalid tx encoding")
+
+// NewTxCmd creates a gnokey tx command, grouping the commands
+// inspecting tx documents without contacting a node
+func NewTxCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			Name:       "tx",
+			ShortUsage: "tx <subcommand> [flags] <file-name>",
+			ShortHelp:  "decodes, encodes and hashes tx documents offline",
+		},
+		commands.NewEmptyConfig(),
+		commands.HelpExec,
+	)
+
+	cmd.AddSubCommands(
+		NewTxDecodeCmd(rootCfg, io),
+		NewTxEncodeCmd(rootCfg, io),
+		NewTxHashCmd(rootCfg, io),
+	)
+
+	return cmd
+}
+
+type TxDecodeCfg struct {
+	RootCfg *BaseCfg
+
+	Encoding      string
+	ChainID       string
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+func NewTxDecodeCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &TxDecodeCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "decode",
+			ShortUsage: "tx decode [flags] <file-name>",
+			ShortHelp:  "prints a human-readable view of the given tx document",
+			LongHelp:   "Prints the messages, fee, memo, signatures and sign bytes of the given tx document. Use - as the file name to read it from stdin.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execTxDecode(cfg, args, io)
+		},
+	)
+}
+
+func (c *TxDecodeCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Encoding,
+		"encoding",
+		txEncodingJSON,
+		fmt.Sprintf("encoding of the tx document (%s, %s, %s)", txEncodingJSON, txEncodingBinary, txEncodingBase64),
+	)
+
+	fs.StringVar(
+		&c.ChainID,
+		"chainid",
+		"dev",
+		"the ID of the chain, for the sign bytes",
+	)
+
+	fs.Uint64Var(
+		&c.AccountNumber,
+		"account-number",
+		0,
+		"account number of the signer, for the sign bytes",
+	)
+
+	fs.Uint64Var(
+		&c.Sequence,
+		"account-sequence",
+		0,
+		"account sequence of the signer, for the sign bytes",
+	)
+}
+
+func execTxDecode(cfg *TxDecodeCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	tx, err := readTx(args[0], cfg.Encoding, io.In())
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashTx(tx)
+	if err != nil {
+		return err
+	}
+
+	signBytes, err := tx.GetSignBytes(cfg.ChainID, cfg.AccountNumber, cfg.Sequence)
+	if err != nil {
+		return fmt.Errorf("unable to get signature bytes, %w", err)
+	}
+
+	io.Printfln("TX HASH:    %s", base64.StdEncoding.EncodeToString(hash))
+
+	io.Printfln("MESSAGES:   %d", len(tx.Msgs))
+	for i, msg := range tx.Msgs {
+		msgJSON, err := amino.MarshalJSONIndent(msg, "    ", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to marshal message %d, %w", i, err)
+		}
+
+		io.Printfln("  #%d %s/%s (%s)", i, msg.Route(), msg.Type(), amino.GetTypeURL(msg))
+		io.Printfln("    %s", msgJSON)
+	}
+
+	io.Printfln("GAS WANTED: %d", tx.Fee.GasWanted)
+	io.Printfln("GAS FEE:    %s", tx.Fee.GasFee)
+	if tx.Fee.Granter != "" {
+		io.Printfln("FEE PAYER:  %s (granter)", tx.Fee.Granter)
+	}
+	io.Printfln("MEMO:       %q", tx.Memo)
+
+	signers := make([]string, 0, len(tx.GetSigners()))
+	for _, signer := range tx.GetSigners() {
+		signers = append(signers, signer.String())
+	}
+	io.Printfln("SIGNERS:    %s", strings.Join(signers, ", "))
+
+	io.Printfln("SIGNATURES: %d", len(tx.Signatures))
+	for i, sig := range tx.Signatures {
+		if sig.PubKey == nil {
+			io.Printfln("  #%d empty", i)
+
+			continue
+		}
+
+		io.Printfln(
+			"  #%d %s (%s)",
+			i,
+			sig.PubKey.Address(),
+			crypto.PubKeyToBech32(sig.PubKey),
+		)
+	}
+
+	io.Printfln(
+		"SIGN BYTES (chain ID %q, account number %d, sequence %d):",
+		cfg.ChainID,
+		cfg.AccountNumber,
+		cfg.Sequence,
+	)
+	io.Println(string(signBytes))
+
+	return nil
+}
+
+type TxEncodeCfg struct {
+	RootCfg *BaseCfg
+
+	Encoding   string
+	OutputPath string
+}
+
+func NewTxEncodeCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &TxEncodeCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "encode",
+			ShortUsage: "tx encode [flags] <file-name>",
+			ShortHelp:  "encodes the given Amino JSON tx document to Amino binary",
+			LongHelp:   "Encodes the given Amino JSON tx document to Amino binary, as broadcast to the nodes. Use - as the file name to read it from stdin.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execTxEncode(cfg, args, io)
+		},
+	)
+}
+
+func (c *TxEncodeCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Encoding,
+		"encoding",
+		txEncodingBase64,
+		fmt.Sprintf("encoding of the output (%s, %s)", txEncodingBinary, txEncodingBase64),
+	)
+
+	fs.StringVar(
+		&c.OutputPath,
+		"output-path",
+		"",
+		"path to save the encoded tx to (defaults to stdout)",
+	)
+}
+
+func execTxEncode(cfg *TxEncodeCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	tx, err := readTx(args[0], txEncodingJSON, io.In())
+	if err != nil {
+		return err
+	}
+
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("unable to marshal tx to binary, %w", err)
+	}
+
+	switch cfg.Encoding {
+	case txEncodingBinary:
+	case txEncodingBase64:
+		bz = []byte(base64.StdEncoding.EncodeToString(bz))
+	default:
+		return fmt.Errorf("%w: %q", errInvalidTxEncoding, cfg.Encoding)
+	}
+
+	if cfg.OutputPath == "" {
+		if cfg.Encoding == txEncodingBase64 {
+			io.Println(string(bz))
+
+			return nil
+		}
+
+		if _, err := io.Out().Write(bz); err != nil {
+			return fmt.Errorf("unable to write encoded tx, %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(cfg.OutputPath, bz, 0o644); err != nil {
+		return fmt.Errorf("unable to write tx to %s, %w", cfg.OutputPath, err)
+	}
+
+	return nil
+}
+
+type TxHashCfg struct {
+	RootCfg *BaseCfg
+
+	Encoding string
+	Hex      bool
+}
+
+func NewTxHashCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &TxHashCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "hash",
+			ShortUsage: "tx hash [flags] <file-name>",
+			ShortHelp:  "prints the hash of the given tx document",
+			LongHelp:   "Prints the hash of the given tx document, as returned when broadcasting it. Use - as the file name to read it from stdin.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execTxHash(cfg, args, io)
+		},
+	)
+}
+
+func (c *TxHashCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Encoding,
+		"encoding",
+		txEncodingJSON,
+		fmt.Sprintf("encoding of the tx document (%s, %s, %s)", txEncodingJSON, txEncodingBinary, txEncodingBase64),
+	)
+
+	fs.BoolVar(
+		&c.Hex,
+		"hex",
+		false,
+		"print the hash in hex instead of base64",
+	)
+}
+
+func execTxHash(cfg *TxHashCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	tx, err := readTx(args[0], cfg.Encoding, io.In())
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashTx(tx)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Hex {
+		io.Println(strings.ToUpper(hex.EncodeToString(hash)))
+	} else {
+		io.Println(base64.StdEncoding.EncodeToString(hash))
+	}
+
+	return nil
+}
+
+// readTx reads the transaction at the given path, or from in if the
+// path is "-", in the given encoding
+func readTx(path, encoding string, in io.Reader) (std.Tx, error) {
+	var (
+		tx    std.Tx
+		txRaw []byte
+		err   error
+	)
+
+	// Get the transaction bytes
+	if path == "-" {
+		txRaw, err = io.ReadAll(in)
+	} else {
+		txRaw, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return tx, fmt.Errorf("unable to read transaction, %w", err)
+	}
+
+	if len(txRaw) == 0 {
+		return tx, errInvalidTxFile
+	}
+
+	switch encoding {
+	case txEncodingJSON:
+		err = amino.UnmarshalJSON(txRaw, &tx)
+	case txEncodingBinary:
+		err = amino.Unmarshal(txRaw, &tx)
+	case txEncodingBase64:
+		var bz []byte
+
+		bz, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(txRaw)))
+		if err != nil {
+			return tx, fmt.Errorf("unable to decode base64 transaction, %w", err)
+		}
+
+		err = amino.Unmarshal(bz, &tx)
+	default:
+		return tx, fmt.Errorf("%w: %q", errInvalidTxEncoding, encoding)
+	}
+	if err != nil {
+		return tx, fmt.Errorf("unable to unmarshal transaction, %w", err)
+	}
+
+	return tx, nil
+}
+
+// hashTx returns the hash of the transaction, which is the hash of its
+// Amino binary encoding
+func hashTx(tx std.Tx) ([]byte, error) {
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal tx to binary, %w", err)
+	}
+
+	return tmhash.Sum(bz), nil
+}
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/tmhash"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTx creates a send tx between two test addresses
+func newTestTx(t *testing.T) std.Tx {
+	t.Helper()
+
+	from := crypto.AddressFromPreimage([]byte("from"))
+	to := crypto.AddressFromPreimage([]byte("to"))
+
+	return std.Tx{
+		Msgs: []std.Msg{
+			bank.MsgSend{
+				FromAddress: from,
+				ToAddress:   to,
+				Amount:      std.NewCoins(std.NewCoin("ugnot", 10)),
+			},
+		},
+		Fee:  std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+		Memo: "reviewed",
+	}
+}
+
+// runTxCmd runs a gnokey tx subcommand, returning its output
+func runTxCmd(t *testing.T, in []byte, args ...string) (string, error) {
+	t.Helper()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+
+	out := bytes.NewBufferString("")
+
+	io := commands.NewTestIO()
+	io.SetIn(bytes.NewReader(in))
+	io.SetOut(commands.WriteNopCloser(out))
+
+	cmd := NewRootCmdWithBaseConfig(io, BaseOptions{Home: t.TempDir()})
+	err := cmd.ParseAndRun(ctx, append([]string{"tx"}, args...))
+
+	return out.String(), err
+}
+
+func TestTx_Decode(t *testing.T) {
+	t.Parallel()
+
+	tx := newTestTx(t)
+
+	jsonTx, err := amino.MarshalJSON(tx)
+	require.NoError(t, err)
+
+	binaryTx, err := amino.Marshal(tx)
+	require.NoError(t, err)
+
+	signBytes, err := tx.GetSignBytes("test-chain", 4, 2)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		encoding string
+		input    []byte
+	}{
+		{txEncodingJSON, jsonTx},
+		{txEncodingBinary, binaryTx},
+		{txEncodingBase64, []byte(base64.StdEncoding.EncodeToString(binaryTx) + "\n")},
+	} {
+		t.Run(tc.encoding, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := runTxCmd(
+				t,
+				tc.input,
+				"decode",
+				"--encoding", tc.encoding,
+				"--chainid", "test-chain",
+				"--account-number", "4",
+				"--account-sequence", "2",
+				"-",
+			)
+			require.NoError(t, err)
+
+			msg := tx.Msgs[0].(bank.MsgSend)
+
+			assert.Contains(t, out, "TX HASH:    "+base64.StdEncoding.EncodeToString(tmhash.Sum(binaryTx)))
+			assert.Contains(t, out, "MESSAGES:   1")
+			assert.Contains(t, out, "#0 bank/send (/bank.MsgSend)")
+			assert.Contains(t, out, `"to_address": "`+msg.ToAddress.String()+`"`)
+			assert.Contains(t, out, "GAS WANTED: 100000")
+			assert.Contains(t, out, "GAS FEE:    1000ugnot")
+			assert.Contains(t, out, `MEMO:       "reviewed"`)
+			assert.Contains(t, out, "SIGNERS:    "+msg.FromAddress.String())
+			assert.Contains(t, out, "SIGNATURES: 0")
+			assert.Contains(t, out, `SIGN BYTES (chain ID "test-chain", account number 4, sequence 2):`)
+			assert.Contains(t, out, string(signBytes))
+		})
+	}
+
+	t.Run("no tx provided", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := runTxCmd(t, nil, "decode")
+		assert.ErrorIs(t, err, flag.ErrHelp)
+	})
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := runTxCmd(t, jsonTx, "decode", "--encoding", "yaml", "-")
+		assert.ErrorIs(t, err, errInvalidTxEncoding)
+	})
+
+	t.Run("mismatched encoding", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := runTxCmd(t, jsonTx, "decode", "--encoding", txEncodingBinary, "-")
+		assert.ErrorContains(t, err, "unable to unmarshal transaction")
+	})
+
+	t.Run("empty tx", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := runTxCmd(t, nil, "decode", "-")
+		assert.ErrorIs(t, err, errInvalidTxFile)
+	})
+}
+
+func TestTx_Encode(t *testing.T) {
+	t.Parallel()
+
+	tx := newTestTx(t)
+
+	jsonTx, err := amino.MarshalJSON(tx)
+	require.NoError(t, err)
+
+	binaryTx, err := amino.Marshal(tx)
+	require.NoError(t, err)
+
+	t.Run("base64", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runTxCmd(t, jsonTx, "encode", "-")
+		require.NoError(t, err)
+
+		assert.Equal(t, base64.StdEncoding.EncodeToString(binaryTx)+"\n", out)
+	})
+
+	t.Run("binary file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		txPath := filepath.Join(dir, "tx.json")
+		outputPath := filepath.Join(dir, "tx.bin")
+
+		require.NoError(t, os.WriteFile(txPath, jsonTx, 0o644))
+
+		_, err := runTxCmd(
+			t,
+			nil,
+			"encode",
+			"--encoding", txEncodingBinary,
+			"--output-path", outputPath,
+			txPath,
+		)
+		require.NoError(t, err)
+
+		encodedTx, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		assert.Equal(t, binaryTx, encodedTx)
+
+		// Make sure the encoded tx decodes to the original one
+		var decodedTx std.Tx
+		require.NoError(t, amino.Unmarshal(encodedTx, &decodedTx))
+		assert.Equal(t, jsonTx, amino.MustMarshalJSON(decodedTx))
+	})
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := runTxCmd(t, jsonTx, "encode", "--encoding", txEncodingJSON, "-")
+		assert.ErrorIs(t, err, errInvalidTxEncoding)
+	})
+}
+
+func TestTx_Hash(t *testing.T) {
+	t.Parallel()
+
+	tx := newTestTx(t)
+
+	jsonTx, err := amino.MarshalJSON(tx)
+	require.NoError(t, err)
+
+	binaryTx, err := amino.Marshal(tx)
+	require.NoError(t, err)
+
+	hash := tmhash.Sum(binaryTx)
+
+	t.Run("base64", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runTxCmd(t, jsonTx, "hash", "-")
+		require.NoError(t, err)
+
+		assert.Equal(t, base64.StdEncoding.EncodeToString(hash)+"\n", out)
+	})
+
+	t.Run("hex", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runTxCmd(t, binaryTx, "hash", "--encoding", txEncodingBinary, "--hex", "-")
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.ToUpper(hex.EncodeToString(hash))+"\n", out)
+	})
+}
@@ -59,7 +59,7 @@ func execVerify(cfg *VerifyCfg, args []string, io commands.IO) error {
 		return err
 	}
 	docpath := cfg.DocPath
-	kb, err = keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	kb, err = cfg.RootCfg.Keybase(io)
 	if err != nil {
 		return err
 	}
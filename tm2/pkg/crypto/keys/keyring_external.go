@@ -0,0 +1,346 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/hd"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// The external signer protocol is JSON over HTTP, on a TCP or unix socket:
+//
+//	GET  /keys  -> [{"name": <name>, "pub_key": <bech32 pubkey>}, ...]
+//	POST /sign  {"name": <name or bech32 address>, "passphrase": <passphrase>, "msg": <base64>}
+//	            -> {"signature": <base64>, "pub_key": <bech32 pubkey>}
+//
+// Errors are returned with a non-200 status, as {"error": <message>}.
+// NewSignerHandler serves the protocol for any keybase.
+const (
+	signerKeysPath = "/keys"
+	signerSignPath = "/sign"
+
+	signerTimeout = 30 * time.Second
+)
+
+var errExternalUnsupported = errors.New("not supported by the external keyring backend")
+
+type signerKey struct {
+	Name   string `json:"name"`
+	PubKey string `json:"pub_key"`
+}
+
+type signerSignRequest struct {
+	Name       string `json:"name"`
+	Passphrase string `json:"passphrase"`
+	Msg        []byte `json:"msg"`
+}
+
+type signerSignResponse struct {
+	Signature []byte `json:"signature"`
+	PubKey    string `json:"pub_key"`
+}
+
+type signerError struct {
+	Error string `json:"error"`
+}
+
+// ----------------------------------------
+// externalKeybase
+
+// externalKeybase is a keybase whose keys are managed by an external signer.
+// Its keys are read-only, and are listed as offline keys.
+type externalKeybase struct {
+	url    string
+	client *http.Client
+}
+
+var _ Keybase = externalKeybase{}
+
+// NewExternalKeybase creates a keybase delegating to the external signer
+// at signerURL, either http://<host>:<port> or unix://<socket path>.
+func NewExternalKeybase(signerURL string) (Keybase, error) {
+	if signerURL == "" {
+		return nil, errors.New("missing external signer URL")
+	}
+
+	kb := externalKeybase{
+		url:    strings.TrimSuffix(signerURL, "/"),
+		client: &http.Client{Timeout: signerTimeout},
+	}
+
+	if path, ok := strings.CutPrefix(signerURL, "unix://"); ok {
+		var dialer net.Dialer
+		kb.url = "http://signer"
+		kb.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "unix", path)
+			},
+		}
+	}
+
+	return kb, nil
+}
+
+func (kb externalKeybase) do(method, path string, req, res any) error {
+	var body io.Reader
+	if req != nil {
+		bz, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(bz)
+	}
+
+	httpReq, err := http.NewRequest(method, kb.url+path, body)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := kb.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("unable to reach external signer, %w", err)
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode != http.StatusOK {
+		var serr signerError
+		if err := json.NewDecoder(httpRes.Body).Decode(&serr); err != nil || serr.Error == "" {
+			return fmt.Errorf("external signer: %s", httpRes.Status)
+		}
+		return fmt.Errorf("external signer: %s", serr.Error)
+	}
+
+	return json.NewDecoder(httpRes.Body).Decode(res)
+}
+
+func (kb externalKeybase) List() ([]Info, error) {
+	var keys []signerKey
+	if err := kb.do(http.MethodGet, signerKeysPath, nil, &keys); err != nil {
+		return nil, err
+	}
+
+	res := make([]Info, 0, len(keys))
+	for _, key := range keys {
+		pub, err := crypto.PubKeyFromBech32(key.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of %s, %w", key.Name, err)
+		}
+		res = append(res, newOfflineInfo(key.Name, pub))
+	}
+	return res, nil
+}
+
+func (kb externalKeybase) HasByNameOrAddress(nameOrBech32 string) (bool, error) {
+	address, err := crypto.AddressFromBech32(nameOrBech32)
+	if err != nil {
+		return kb.HasByName(nameOrBech32)
+	}
+	return kb.HasByAddress(address)
+}
+
+func (kb externalKeybase) HasByName(name string) (bool, error) {
+	_, err := kb.GetByName(name)
+	if keyerror.IsErrKeyNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (kb externalKeybase) HasByAddress(address crypto.Address) (bool, error) {
+	_, err := kb.GetByAddress(address)
+	if keyerror.IsErrKeyNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (kb externalKeybase) GetByNameOrAddress(nameOrBech32 string) (Info, error) {
+	addr, err := crypto.AddressFromBech32(nameOrBech32)
+	if err != nil {
+		return kb.GetByName(nameOrBech32)
+	}
+	return kb.GetByAddress(addr)
+}
+
+func (kb externalKeybase) GetByName(name string) (Info, error) {
+	infos, err := kb.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.GetName() == name {
+			return info, nil
+		}
+	}
+	return nil, keyerror.NewErrKeyNotFound(name)
+}
+
+func (kb externalKeybase) GetByAddress(address crypto.Address) (Info, error) {
+	infos, err := kb.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.GetAddress() == address {
+			return info, nil
+		}
+	}
+	return nil, keyerror.NewErrKeyNotFound(fmt.Sprintf("key with address %s not found", address))
+}
+
+// Sign asks the external signer to sign msg with the named key. The
+// passphrase is forwarded to the signer, which may use it to unlock the key.
+func (kb externalKeybase) Sign(nameOrBech32, passphrase string, msg []byte) ([]byte, crypto.PubKey, error) {
+	req := signerSignRequest{
+		Name:       nameOrBech32,
+		Passphrase: passphrase,
+		Msg:        msg,
+	}
+	var res signerSignResponse
+	if err := kb.do(http.MethodPost, signerSignPath, req, &res); err != nil {
+		return nil, nil, err
+	}
+
+	pub, err := crypto.PubKeyFromBech32(res.PubKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid public key from external signer, %w", err)
+	}
+
+	// Don't trust the signer blindly
+	if !pub.VerifyBytes(msg, res.Signature) {
+		return nil, nil, errors.New("invalid signature from external signer")
+	}
+
+	return res.Signature, pub, nil
+}
+
+func (kb externalKeybase) Verify(nameOrBech32 string, msg []byte, sig []byte) error {
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+	if !info.GetPubKey().VerifyBytes(msg, sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (kb externalKeybase) Delete(string, string, bool) error {
+	return errExternalUnsupported
+}
+
+func (kb externalKeybase) CreateAccount(string, string, string, string, uint32, uint32) (Info, error) {
+	return nil, errExternalUnsupported
+}
+
+func (kb externalKeybase) CreateAccountBip44(string, string, string, string, hd.BIP44Params) (Info, error) {
+	return nil, errExternalUnsupported
+}
+
+func (kb externalKeybase) CreateLedger(string, SigningAlgo, string, uint32, uint32) (Info, error) {
+	return nil, errExternalUnsupported
+}
+
+func (kb externalKeybase) CreateOffline(string, crypto.PubKey) (Info, error) {
+	return nil, errExternalUnsupported
+}
+
+func (kb externalKeybase) CreateMulti(string, crypto.PubKey) (Info, error) {
+	return nil, errExternalUnsupported
+}
+
+func (kb externalKeybase) Rotate(string, string, func() (string, error)) error {
+	return errExternalUnsupported
+}
+
+func (kb externalKeybase) ImportPrivKey(string, crypto.PrivKey, string) error {
+	return errExternalUnsupported
+}
+
+func (kb externalKeybase) ExportPrivKey(string, string) (crypto.PrivKey, error) {
+	return nil, errExternalUnsupported
+}
+
+func (kb externalKeybase) CloseDB() {}
+
+// ----------------------------------------
+// Signer handler
+
+// NewSignerHandler returns an HTTP handler serving the external signer
+// protocol for the local and ledger keys of kb, so it can be used by the
+// external keyring backend.
+func NewSignerHandler(kb Keybase) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+signerKeysPath, func(w http.ResponseWriter, _ *http.Request) {
+		infos, err := kb.List()
+		if err != nil {
+			writeSignerError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		keys := make([]signerKey, 0, len(infos))
+		for _, info := range infos {
+			// Only list the keys which can sign
+			if t := info.GetType(); t == TypeOffline || t == TypeMulti {
+				continue
+			}
+
+			keys = append(keys, signerKey{
+				Name:   info.GetName(),
+				PubKey: crypto.PubKeyToBech32(info.GetPubKey()),
+			})
+		}
+		writeSignerResponse(w, keys)
+	})
+
+	mux.HandleFunc("POST "+signerSignPath, func(w http.ResponseWriter, r *http.Request) {
+		var req signerSignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeSignerError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		sig, pub, err := kb.Sign(req.Name, req.Passphrase, req.Msg)
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case keyerror.IsErrKeyNotFound(err):
+				status = http.StatusNotFound
+			case keyerror.IsErrWrongPassword(err):
+				status = http.StatusForbidden
+			}
+			writeSignerError(w, status, err)
+			return
+		}
+
+		writeSignerResponse(w, signerSignResponse{
+			Signature: sig,
+			PubKey:    crypto.PubKeyToBech32(pub),
+		})
+	})
+
+	return mux
+}
+
+func writeSignerResponse(w http.ResponseWriter, res any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func writeSignerError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(signerError{Error: err.Error()})
+}
@@ -0,0 +1,124 @@
+package keys
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/armor"
+	"github.com/gnolang/gno/tm2/pkg/crypto/bcrypt"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+	"github.com/gnolang/gno/tm2/pkg/crypto/xsalsa20symmetric"
+)
+
+const (
+	blockTypeKeyring        = "TENDERMINT KEYRING"
+	bcryptSecurityParameter = 12
+)
+
+// fileStorage stores the entries of a keybase in a single armored file,
+// encrypted with the keyring password. The encryption key is derived from
+// the password and a random salt with bcrypt, and the entries are encrypted
+// with xsalsa20, like the private keys.
+type fileStorage struct {
+	path string
+	salt []byte
+	key  []byte // derived from the keyring password and salt
+}
+
+var _ storage = (*fileStorage)(nil)
+
+func newFileStorage(path, password string) (*fileStorage, error) {
+	stor := &fileStorage{path: path}
+
+	// Reuse the salt of the existing keyring, so the key is only derived
+	// once, when opening it
+	armorBytes, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		stor.salt = crypto.CRandBytes(16)
+	case err != nil:
+		return nil, fmt.Errorf("unable to read keyring file, %w", err)
+	default:
+		_, header, _, err := armor.DecodeArmor(string(armorBytes))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode keyring file, %w", err)
+		}
+		if header["kdf"] != "bcrypt" {
+			return nil, fmt.Errorf("unrecognized KDF type: %v", header["kdf"])
+		}
+		stor.salt, err = hex.DecodeString(header["salt"])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding salt: %w", err)
+		}
+	}
+
+	key, err := bcrypt.GenerateFromPassword(stor.salt, []byte(password), bcryptSecurityParameter)
+	if err != nil {
+		return nil, fmt.Errorf("error generating bcrypt key from password: %w", err)
+	}
+	stor.key = crypto.Sha256(key) // get 32 bytes
+
+	return stor, nil
+}
+
+func (stor *fileStorage) load() (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+
+	armorBytes, err := os.ReadFile(stor.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read keyring file, %w", err)
+	}
+
+	blockType, _, encBytes, err := armor.DecodeArmor(string(armorBytes))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode keyring file, %w", err)
+	}
+	if blockType != blockTypeKeyring {
+		return nil, fmt.Errorf("unrecognized armor type: %v", blockType)
+	}
+
+	bz, err := xsalsa20symmetric.DecryptSymmetric(encBytes, stor.key)
+	if err != nil && err.Error() == "ciphertext decryption failed" {
+		return nil, keyerror.NewErrWrongPassword()
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bz, &entries); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal keyring, %w", err)
+	}
+
+	return entries, nil
+}
+
+func (stor *fileStorage) save(entries map[string][]byte) error {
+	bz, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	header := map[string]string{
+		"kdf":  "bcrypt",
+		"salt": fmt.Sprintf("%X", stor.salt),
+	}
+	armorStr := armor.EncodeArmor(blockTypeKeyring, header, xsalsa20symmetric.EncryptSymmetric(bz, stor.key))
+
+	if err := os.MkdirAll(filepath.Dir(stor.path), 0o700); err != nil {
+		return err
+	}
+
+	// Write the keyring atomically, so it can't be left half-written
+	tmpPath := stor.path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(armorStr), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, stor.path)
+}
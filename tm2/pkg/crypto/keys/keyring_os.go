@@ -0,0 +1,107 @@
+package keys
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/zalando/go-keyring"
+)
+
+// osIndexUser is the user of the secret listing the entries of the keybase,
+// since secrets can't be listed.
+const osIndexUser = "keybase.index"
+
+// osStorage stores the entries of a keybase as secrets of the OS keyring,
+// one secret per entry, as secrets are limited in size on some systems.
+type osStorage struct {
+	service string
+	saved   map[string]string // last saved entries, base64-encoded
+}
+
+var _ storage = (*osStorage)(nil)
+
+func newOSStorage(service string) *osStorage {
+	return &osStorage{
+		service: service,
+		saved:   make(map[string]string),
+	}
+}
+
+func (stor *osStorage) load() (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+
+	index, err := keyring.Get(stor.service, osIndexUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read OS keyring, %w", err)
+	}
+
+	var keys []string
+	if err := json.Unmarshal([]byte(index), &keys); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal OS keyring index, %w", err)
+	}
+
+	for _, key := range keys {
+		secret, err := keyring.Get(stor.service, key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s from OS keyring, %w", key, err)
+		}
+
+		value, err := base64.StdEncoding.DecodeString(secret)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s from OS keyring, %w", key, err)
+		}
+
+		entries[key] = value
+		stor.saved[key] = secret
+	}
+
+	return entries, nil
+}
+
+func (stor *osStorage) save(entries map[string][]byte) error {
+	keys := make([]string, 0, len(entries))
+
+	// Only write the entries which changed
+	for key, value := range entries {
+		keys = append(keys, key)
+
+		secret := base64.StdEncoding.EncodeToString(value)
+		if stor.saved[key] == secret {
+			continue
+		}
+
+		if err := keyring.Set(stor.service, key, secret); err != nil {
+			return fmt.Errorf("unable to write %s to OS keyring, %w", key, err)
+		}
+		stor.saved[key] = secret
+	}
+
+	slices.Sort(keys)
+	index, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	if err := keyring.Set(stor.service, osIndexUser, string(index)); err != nil {
+		return fmt.Errorf("unable to write OS keyring index, %w", err)
+	}
+
+	// Delete the removed entries, once they're no longer indexed
+	for key := range stor.saved {
+		if _, ok := entries[key]; ok {
+			continue
+		}
+
+		if err := keyring.Delete(stor.service, key); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			return fmt.Errorf("unable to delete %s from OS keyring, %w", key, err)
+		}
+		delete(stor.saved, key)
+	}
+
+	return nil
+}
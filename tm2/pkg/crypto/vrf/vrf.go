@@ -0,0 +1,190 @@
+// Package vrf implements the ECVRF-EDWARDS25519-SHA512-TAI verifiable random
+// function of RFC 9381, with ed25519 keys.
+//
+// The holder of a private key can compute, for any input, a proof from which
+// anyone knowing the public key can derive a pseudorandom output. The output
+// is unique for a given key and input, and can't be predicted without the
+// private key.
+//
+// The curve arithmetic is that of filippo.io/edwards25519: the operations on
+// the secret scalar of Prove are constant time, while Verify, which only
+// handles public values, uses the faster variable time ones.
+package vrf
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+
+	"filippo.io/edwards25519"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+)
+
+const (
+	// ProofSize is the size of a proof: an encoded point, a 16 bytes
+	// challenge and a scalar.
+	ProofSize = 80
+	// OutputSize is the size of an output, a SHA-512 hash.
+	OutputSize = sha512.Size
+
+	suiteString   = 0x03
+	challengeSize = 16
+)
+
+var (
+	ErrInvalidProof     = errors.New("invalid VRF proof")
+	ErrInvalidPublicKey = errors.New("invalid VRF public key")
+)
+
+// Prove returns the proof for the input alpha.
+func Prove(privKey ed25519.PrivKeyEd25519, alpha []byte) ([]byte, error) {
+	hashedSK := sha512.Sum512(privKey[:32])
+	x, err := edwards25519.NewScalar().SetBytesWithClamping(hashedSK[:32])
+	if err != nil {
+		return nil, err
+	}
+	pk := privKey[32:]
+
+	y, ok := decodePoint(pk)
+	if !ok {
+		return nil, ErrInvalidPublicKey
+	}
+	h, ok := encodeToCurve(pk, alpha)
+	if !ok {
+		return nil, errors.New("unable to hash VRF input to the curve")
+	}
+	gamma := new(edwards25519.Point).ScalarMult(x, h)
+
+	// nonce generation, as in RFC 8032.
+	kHash := sha512.New()
+	kHash.Write(hashedSK[32:])
+	kHash.Write(h.Bytes())
+	k, err := edwards25519.NewScalar().SetUniformBytes(kHash.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	c := challenge(y, h, gamma,
+		new(edwards25519.Point).ScalarBaseMult(k),
+		new(edwards25519.Point).ScalarMult(k, h))
+	s := edwards25519.NewScalar().MultiplyAdd(c, x, k)
+
+	proof := make([]byte, 0, ProofSize)
+	proof = append(proof, gamma.Bytes()...)
+	proof = append(proof, c.Bytes()[:challengeSize]...)
+	proof = append(proof, s.Bytes()...)
+	return proof, nil
+}
+
+// Verify checks the proof for the input alpha, and returns its output.
+func Verify(pubKey ed25519.PubKeyEd25519, alpha, proof []byte) ([]byte, error) {
+	y, ok := decodePoint(pubKey[:])
+	if !ok || isSmallOrder(y) {
+		return nil, ErrInvalidPublicKey
+	}
+	gamma, c, s, ok := decodeProof(proof)
+	if !ok {
+		return nil, ErrInvalidProof
+	}
+	h, ok := encodeToCurve(pubKey[:], alpha)
+	if !ok {
+		return nil, ErrInvalidProof
+	}
+
+	// U = s*B - c*Y, V = s*H - c*Gamma
+	negC := edwards25519.NewScalar().Negate(c)
+	u := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(negC, y, s)
+	v := new(edwards25519.Point).VarTimeMultiScalarMult(
+		[]*edwards25519.Scalar{s, negC},
+		[]*edwards25519.Point{h, gamma})
+	if challenge(y, h, gamma, u, v).Equal(c) != 1 {
+		return nil, ErrInvalidProof
+	}
+	return gammaToHash(gamma), nil
+}
+
+// ProofToHash returns the output of a proof, without verifying it.
+func ProofToHash(proof []byte) ([]byte, error) {
+	gamma, _, _, ok := decodeProof(proof)
+	if !ok {
+		return nil, ErrInvalidProof
+	}
+	return gammaToHash(gamma), nil
+}
+
+// decodePoint decodes a point as in RFC 8032, which unlike
+// edwards25519.Point.SetBytes rejects the non-canonical encodings.
+func decodePoint(bz []byte) (*edwards25519.Point, bool) {
+	p, err := new(edwards25519.Point).SetBytes(bz)
+	if err != nil || !bytes.Equal(p.Bytes(), bz) {
+		return nil, false
+	}
+	return p, true
+}
+
+// isSmallOrder returns whether p is in the subgroup of order 8.
+func isSmallOrder(p *edwards25519.Point) bool {
+	return new(edwards25519.Point).MultByCofactor(p).Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+func decodeProof(proof []byte) (gamma *edwards25519.Point, c, s *edwards25519.Scalar, ok bool) {
+	if len(proof) != ProofSize {
+		return nil, nil, nil, false
+	}
+	gamma, ok = decodePoint(proof[:32])
+	if !ok {
+		return nil, nil, nil, false
+	}
+	c = challengeScalar(proof[32 : 32+challengeSize])
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(proof[32+challengeSize:])
+	if err != nil {
+		return nil, nil, nil, false
+	}
+	return gamma, c, s, true
+}
+
+func gammaToHash(gamma *edwards25519.Point) []byte {
+	h := sha512.New()
+	h.Write([]byte{suiteString, 0x03})
+	h.Write(new(edwards25519.Point).MultByCofactor(gamma).Bytes())
+	h.Write([]byte{0x00})
+	return h.Sum(nil)
+}
+
+// encodeToCurve hashes alpha to a point, with the try-and-increment method.
+func encodeToCurve(pk, alpha []byte) (*edwards25519.Point, bool) {
+	for ctr := range 256 {
+		h := sha512.New()
+		h.Write([]byte{suiteString, 0x01})
+		h.Write(pk)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr), 0x00})
+		if p, ok := decodePoint(h.Sum(nil)[:32]); ok {
+			return p.MultByCofactor(p), true
+		}
+	}
+	return nil, false
+}
+
+func challenge(points ...*edwards25519.Point) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write([]byte{suiteString, 0x02})
+	for _, p := range points {
+		h.Write(p.Bytes())
+	}
+	h.Write([]byte{0x00})
+	return challengeScalar(h.Sum(nil)[:challengeSize])
+}
+
+// challengeScalar returns the scalar of a challenge, a little-endian integer
+// which is always smaller than the order of the group.
+func challengeScalar(bz []byte) *edwards25519.Scalar {
+	var buf [32]byte
+	copy(buf[:], bz)
+	c, err := edwards25519.NewScalar().SetCanonicalBytes(buf[:])
+	if err != nil {
+		panic(err) // unreachable: 2^128 is smaller than the order.
+	}
+	return c
+}
@@ -0,0 +1,140 @@
+package vrf
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	bz, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return bz
+}
+
+// Examples 16 to 18 of RFC 9381.
+func TestProveVerify_RFC9381(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		sk, pk, alpha string
+		h, pi, beta   string
+	}{
+		{
+			sk:    "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			pk:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			alpha: "",
+			h:     "91bbed02a99461df1ad4c6564a5f5d829d0b90cfc7903e7a5797bd658abf3318",
+			pi:    "8657106690b5526245a92b003bb079ccd1a92130477671f6fc01ad16f26f723f26f8a57ccaed74ee1b190bed1f479d9727d2d0f9b005a6e456a35d4fb0daab1268a1b0db10836d9826a528ca76567805",
+			beta:  "90cf1df3b703cce59e2a35b925d411164068269d7b2d29f3301c03dd757876ff66b71dda49d2de59d03450451af026798e8f81cd2e333de5cdf4f3e140fdd8ae",
+		},
+		{
+			sk:    "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			pk:    "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			alpha: "72",
+			h:     "5b659fc3d4e9263fd9a4ed1d022d75eaacc20df5e09f9ea937502396598dc551",
+			pi:    "f3141cd382dc42909d19ec5110469e4feae18300e94f304590abdced48aed5933bf0864a62558b3ed7f2fea45c92a465301b3bbf5e3e54ddf2d935be3b67926da3ef39226bbc355bdc9850112c8f4b02",
+			beta:  "eb4440665d3891d668e7e0fcaf587f1b4bd7fbfe99d0eb2211ccec90496310eb5e33821bc613efb94db5e5b54c70a848a0bef4553a41befc57663b56373a5031",
+		},
+		{
+			sk:    "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+			pk:    "fc51cd8e6218a1a38da47ed00230f0580816ed13ba3303ac5deb911548908025",
+			alpha: "af82",
+			h:     "bf4339376f5542811de615e3313d2b36f6f53c0acfebb482159711201192576a",
+			pi:    "9bc0f79119cc5604bf02d23b4caede71393cedfbb191434dd016d30177ccbf8096bb474e53895c362d8628ee9f9ea3c0e52c7a5c691b6c18c9979866568add7a2d41b00b05081ed0f58ee5e31b3a970e",
+			beta:  "645427e5d00c62a23fb703732fa5d892940935942101e456ecca7bb217c61c452118fec1219202a0edcf038bb6373241578be7217ba85a2687f7a0310b2df19f",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pk, func(t *testing.T) {
+			t.Parallel()
+
+			var privKey ed25519.PrivKeyEd25519
+			copy(privKey[:32], mustHex(t, tc.sk))
+			copy(privKey[32:], mustHex(t, tc.pk))
+			pubKey := privKey.PubKey().(ed25519.PubKeyEd25519)
+			alpha := mustHex(t, tc.alpha)
+
+			h, ok := encodeToCurve(pubKey[:], alpha)
+			require.True(t, ok)
+			assert.Equal(t, tc.h, hex.EncodeToString(h.Bytes()))
+
+			proof, err := Prove(privKey, alpha)
+			require.NoError(t, err)
+			assert.Equal(t, tc.pi, hex.EncodeToString(proof))
+
+			output, err := Verify(pubKey, alpha, proof)
+			require.NoError(t, err)
+			assert.Equal(t, tc.beta, hex.EncodeToString(output))
+
+			output2, err := ProofToHash(proof)
+			require.NoError(t, err)
+			assert.Equal(t, output, output2)
+		})
+	}
+}
+
+func TestVerify_invalid(t *testing.T) {
+	t.Parallel()
+
+	privKey := ed25519.GenPrivKey()
+	pubKey := privKey.PubKey().(ed25519.PubKeyEd25519)
+	alpha := []byte("alpha")
+	proof, err := Prove(privKey, alpha)
+	require.NoError(t, err)
+	require.Len(t, proof, ProofSize)
+
+	output, err := Verify(pubKey, alpha, proof)
+	require.NoError(t, err)
+	require.Len(t, output, OutputSize)
+
+	// deterministic
+	proof2, err := Prove(privKey, alpha)
+	require.NoError(t, err)
+	assert.Equal(t, proof, proof2)
+
+	_, err = Verify(pubKey, []byte("beta"), proof)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+
+	otherPubKey := ed25519.GenPrivKey().PubKey().(ed25519.PubKeyEd25519)
+	_, err = Verify(otherPubKey, alpha, proof)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+
+	for i := range proof {
+		tampered := append([]byte(nil), proof...)
+		tampered[i] ^= 0x01
+		_, err = Verify(pubKey, alpha, tampered)
+		assert.Error(t, err, "byte %d", i)
+	}
+
+	_, err = Verify(pubKey, alpha, proof[:ProofSize-1])
+	assert.ErrorIs(t, err, ErrInvalidProof)
+
+	// the identity is a low order point.
+	var identity ed25519.PubKeyEd25519
+	identity[0] = 1
+	_, err = Verify(identity, alpha, proof)
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+func TestSecretScalar(t *testing.T) {
+	t.Parallel()
+
+	// the public keys of ed25519 are the encoding of x*B.
+	for range 10 {
+		privKey := ed25519.GenPrivKey()
+		hashedSK := sha512.Sum512(privKey[:32])
+		x, err := edwards25519.NewScalar().SetBytesWithClamping(hashedSK[:32])
+		require.NoError(t, err)
+		assert.Equal(t, privKey[32:], new(edwards25519.Point).ScalarBaseMult(x).Bytes())
+	}
+}
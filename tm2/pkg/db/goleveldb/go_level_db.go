@@ -13,6 +13,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 func init() {
@@ -22,7 +23,10 @@ func init() {
 	db.InternalRegisterDBCreator(db.GoLevelDBBackend, dbCreator, false)
 }
 
-var _ db.DB = (*GoLevelDB)(nil)
+var (
+	_ db.DB        = (*GoLevelDB)(nil)
+	_ db.Compacter = (*GoLevelDB)(nil)
+)
 
 type GoLevelDB struct {
 	db *leveldb.DB
@@ -104,6 +108,11 @@ func (db *GoLevelDB) DB() *leveldb.DB {
 	return db.db
 }
 
+// Implements Compacter.
+func (db *GoLevelDB) Compact(start, end []byte) error {
+	return db.db.CompactRange(util.Range{Start: start, Limit: end})
+}
+
 // Implements DB.
 func (db *GoLevelDB) Close() {
 	db.db.Close()
@@ -44,6 +44,29 @@ func TestGoLevelDBBackend(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestGoLevelDBCompact(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("test_%x", internal.RandStr(12))
+	db, err := NewGoLevelDB(name, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := 0; i < 100; i++ {
+		db.Set([]byte(fmt.Sprintf("key-%d", i)), []byte("value"))
+	}
+
+	for i := 0; i < 50; i++ {
+		db.Delete([]byte(fmt.Sprintf("key-%d", i)))
+	}
+
+	require.NoError(t, db.Compact(nil, nil))
+
+	// Make sure the data is intact
+	assert.Nil(t, db.Get([]byte("key-0")))
+	assert.Equal(t, []byte("value"), db.Get([]byte("key-99")))
+}
+
 func BenchmarkGoLevelDBRandomReadsWrites(b *testing.B) {
 	name := fmt.Sprintf("test_%x", internal.RandStr(12))
 	db, err := NewGoLevelDB(name, b.TempDir())
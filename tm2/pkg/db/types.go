@@ -53,6 +53,15 @@ type DB interface {
 	Stats() map[string]string
 }
 
+// Compacter is implemented by databases whose backend
+// supports manually compacting the underlying storage.
+type Compacter interface {
+	// Compact compacts the underlying storage for the given key range.
+	// A nil start is interpreted as the beginning of the key space,
+	// and a nil end as the end of the key space.
+	Compact(start, end []byte) error
+}
+
 //----------------------------------------
 // Batch
 
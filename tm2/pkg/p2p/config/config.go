@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"path/filepath"
 	"time"
 )
 
@@ -10,12 +11,30 @@ var (
 	ErrInvalidMaxPayloadSize       = errors.New("invalid message payload size")
 	ErrInvalidSendRate             = errors.New("invalid packet send rate")
 	ErrInvalidReceiveRate          = errors.New("invalid packet receive rate")
+	ErrInvalidBanDuration          = errors.New("invalid peer ban duration")
+	ErrInvalidTransport            = errors.New("invalid p2p transport")
 )
 
+const (
+	// TransportTCP is the TCP transport, multiplexing channels
+	// over a single secret connection (MConnection)
+	TransportTCP = "tcp"
+
+	// TransportQUIC is the QUIC transport, carrying
+	// each channel over a separate QUIC stream
+	TransportQUIC = "quic"
+)
+
+// defaultAddrBookPath is the default address book path, relative to the root dir
+var defaultAddrBookPath = filepath.Join("config", "addrbook.json")
+
 // P2PConfig defines the configuration options for the Tendermint peer-to-peer networking layer
 type P2PConfig struct {
 	RootDir string `json:"rpc" toml:"home"`
 
+	// Transport protocol used for peer connections
+	Transport string `json:"transport" toml:"transport" comment:"Transport protocol used for peer connections (tcp or quic)\n The quic transport listens on the laddr UDP port"`
+
 	// Address to listen for incoming connections
 	ListenAddress string `json:"laddr" toml:"laddr" comment:"Address to listen for incoming connections"`
 
@@ -51,11 +70,21 @@ type P2PConfig struct {
 
 	// Comma separated list of peer IDs to keep private (will not be gossiped to other peers)
 	PrivatePeerIDs string `json:"private_peer_ids" toml:"private_peer_ids" comment:"Comma separated list of peer IDs to keep private (will not be gossiped to other peers)"`
+
+	// Path to the address book of known good peers and active peer bans
+	AddrBook string `json:"addr_book_file" toml:"addr_book_file" comment:"Path to the address book of known good peers and active peer bans"`
+
+	// Number of reported misbehaviors after which a peer is banned
+	BanThreshold uint64 `json:"ban_threshold" toml:"ban_threshold" comment:"Number of reported misbehaviors after which a peer is banned (0 disables banning)"`
+
+	// Time for which a misbehaving peer is banned
+	BanDuration time.Duration `json:"ban_duration" toml:"ban_duration" comment:"Time for which a misbehaving peer is banned"`
 }
 
 // DefaultP2PConfig returns a default configuration for the peer-to-peer layer
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
+		Transport:               TransportTCP,
 		ListenAddress:           "tcp://0.0.0.0:26656",
 		ExternalAddress:         "", // nothing is advertised differently
 		MaxNumInboundPeers:      40,
@@ -65,12 +94,26 @@ func DefaultP2PConfig() *P2PConfig {
 		SendRate:                5120000, // 5 mB/s
 		RecvRate:                5120000, // 5 mB/s
 		PeerExchange:            true,
+		AddrBook:                defaultAddrBookPath,
+		BanThreshold:            5,
+		BanDuration:             24 * time.Hour,
 	}
 }
 
+// AddrBookFile returns the full path to the address book file
+func (cfg *P2PConfig) AddrBookFile() string {
+	return filepath.Join(cfg.RootDir, cfg.AddrBook)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case "", TransportTCP, TransportQUIC: // empty defaults to TCP
+	default:
+		return ErrInvalidTransport
+	}
+
 	if cfg.FlushThrottleTimeout < 0 {
 		return ErrInvalidFlushThrottleTimeout
 	}
@@ -87,5 +130,9 @@ func (cfg *P2PConfig) ValidateBasic() error {
 		return ErrInvalidReceiveRate
 	}
 
+	if cfg.BanDuration < 0 {
+		return ErrInvalidBanDuration
+	}
+
 	return nil
 }
@@ -9,6 +9,16 @@ import (
 func TestP2PConfig_ValidateBasic(t *testing.T) {
 	t.Parallel()
 
+	t.Run("invalid transport", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultP2PConfig()
+
+		cfg.Transport = "sctp"
+
+		assert.ErrorIs(t, cfg.ValidateBasic(), ErrInvalidTransport)
+	})
+
 	t.Run("invalid flush throttle timeout", func(t *testing.T) {
 		t.Parallel()
 
@@ -49,6 +59,16 @@ func TestP2PConfig_ValidateBasic(t *testing.T) {
 		assert.ErrorIs(t, cfg.ValidateBasic(), ErrInvalidReceiveRate)
 	})
 
+	t.Run("invalid ban duration", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultP2PConfig()
+
+		cfg.BanDuration = -1
+
+		assert.ErrorIs(t, cfg.ValidateBasic(), ErrInvalidBanDuration)
+	})
+
 	t.Run("valid configuration", func(t *testing.T) {
 		t.Parallel()
 
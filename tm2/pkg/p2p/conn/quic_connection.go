@@ -0,0 +1,476 @@
+package conn
+
+import (
+	"bufio"
+	"encoding/binary"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/flow"
+	"github.com/gnolang/gno/tm2/pkg/service"
+	"github.com/quic-go/quic-go"
+)
+
+// QUICErrorCodeNone is the application error code
+// used when gracefully closing a QUIC connection
+const QUICErrorCodeNone quic.ApplicationErrorCode = 0
+
+var (
+	errUnknownQUICChannel  = errors.New("unknown channel on QUIC stream")
+	errQUICMessageTooLarge = errors.New("received message exceeds channel capacity")
+)
+
+/*
+QUICConnection is the QUIC counterpart of the `MConnection`.
+
+Instead of interleaving packets of all channels on a single byte stream,
+every channel gets its own unidirectional QUIC stream, per direction.
+The stream header is the channel ID, followed by the number of streams the
+sender opened. Every following message is prefixed with its uvarint encoded length.
+
+Since QUIC streams are independent from one another, a lost packet
+only stalls the channel it belongs to, and not the entire connection
+(there is no head-of-line blocking between channels).
+
+Connection liveness (ping / pong) is handled by QUIC keep-alives.
+A graceful shutdown (FlushStop) closes every outbound stream after the
+pending messages are written, and the peer closes the connection
+once it has read all the streams to the end.
+*/
+type QUICConnection struct {
+	service.BaseService
+
+	conn quic.Connection
+
+	channels    []*quicChannel
+	channelsIdx map[byte]*quicChannel
+
+	onReceive receiveCbFunc
+	onError   errorCbFunc
+	errored   uint32
+	config    MConnConfig
+
+	finishedStreams atomic.Int32 // number of inbound streams closed by the peer
+
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+
+	stopMtx  sync.Mutex
+	quitCh   chan struct{}  // closed when the connection is stopping
+	sendWg   sync.WaitGroup // tracks the channel send routines
+	flushing atomic.Bool    // flag indicating if pending messages should be sent before exiting
+
+	created time.Time // time of creation
+}
+
+// NewQUICConnection wraps the QUIC connection and creates a multiplex connection with a config
+func NewQUICConnection(
+	conn quic.Connection,
+	chDescs []*ChannelDescriptor,
+	onReceive receiveCbFunc,
+	onError errorCbFunc,
+	config MConnConfig,
+) *QUICConnection {
+	qconn := &QUICConnection{
+		conn:        conn,
+		channelsIdx: make(map[byte]*quicChannel, len(chDescs)),
+		onReceive:   onReceive,
+		onError:     onError,
+		config:      config,
+		sendMonitor: flow.New(0, 0),
+		recvMonitor: flow.New(0, 0),
+		created:     time.Now(),
+	}
+
+	for _, desc := range chDescs {
+		ch := newQUICChannel(*desc)
+
+		qconn.channelsIdx[ch.desc.ID] = ch
+		qconn.channels = append(qconn.channels, ch)
+	}
+
+	qconn.BaseService = *service.NewBaseService(nil, "QUICConnection", qconn)
+
+	return qconn
+}
+
+// OnStart implements BaseService
+func (c *QUICConnection) OnStart() error {
+	if err := c.BaseService.OnStart(); err != nil {
+		return err
+	}
+
+	c.quitCh = make(chan struct{})
+
+	// Open an outbound stream for each channel
+	for _, ch := range c.channels {
+		stream, err := c.conn.OpenUniStream()
+		if err != nil {
+			return fmt.Errorf("unable to open stream for channel %X, %w", ch.desc.ID, err)
+		}
+
+		// Announce the channel the stream belongs to
+		if _, err = stream.Write([]byte{ch.desc.ID, byte(len(c.channels))}); err != nil {
+			return fmt.Errorf("unable to initialize stream for channel %X, %w", ch.desc.ID, err)
+		}
+
+		c.sendWg.Add(1)
+
+		go c.sendRoutine(ch, stream)
+	}
+
+	go c.acceptRoutine()
+
+	return nil
+}
+
+// stopServices stops the BaseService and signals the routines to quit.
+// If the connection was already stopping, it returns true, otherwise it returns false
+func (c *QUICConnection) stopServices() (alreadyStopped bool) {
+	c.stopMtx.Lock()
+	defer c.stopMtx.Unlock()
+
+	select {
+	case <-c.quitCh:
+		// already quit
+		return true
+	default:
+	}
+
+	c.BaseService.OnStop()
+	close(c.quitCh)
+
+	return false
+}
+
+// FlushStop replicates the logic of OnStop.
+// It additionally ensures that all successful
+// .Send() calls will get flushed before closing
+// the connection
+func (c *QUICConnection) FlushStop() {
+	c.flushing.Store(true)
+
+	if c.stopServices() {
+		return
+	}
+
+	// Wait for the send routines to drain their queues
+	c.sendWg.Wait()
+
+	// Wait for the peer to read the streams, and close the connection
+	select {
+	case <-c.conn.Context().Done():
+	case <-time.After(defaultSendTimeout):
+	}
+
+	c.conn.CloseWithError(QUICErrorCodeNone, "") //nolint: errcheck
+}
+
+// OnStop implements BaseService
+func (c *QUICConnection) OnStop() {
+	if c.stopServices() {
+		return
+	}
+
+	c.conn.CloseWithError(QUICErrorCodeNone, "") //nolint: errcheck
+}
+
+func (c *QUICConnection) String() string {
+	return fmt.Sprintf("QUICConn{%v}", c.conn.RemoteAddr())
+}
+
+func (c *QUICConnection) SetLogger(l *slog.Logger) {
+	c.BaseService.SetLogger(l)
+}
+
+// Catch panics, usually caused by remote disconnects.
+func (c *QUICConnection) _recover() {
+	if r := recover(); r != nil {
+		c.Logger.Error("QUICConnection panicked", "err", r, "stack", string(debug.Stack()))
+		c.stopForError(errors.New("recovered from panic: %v", r))
+	}
+}
+
+func (c *QUICConnection) stopForError(r error) {
+	c.Stop()
+
+	if atomic.CompareAndSwapUint32(&c.errored, 0, 1) {
+		if c.onError != nil {
+			c.onError(r)
+		}
+	}
+}
+
+// Send queues a message to be sent to channel.
+// Times out (and returns false) after defaultSendTimeout
+func (c *QUICConnection) Send(chID byte, msgBytes []byte) bool {
+	if !c.IsRunning() {
+		return false
+	}
+
+	ch, ok := c.channelsIdx[chID]
+	if !ok {
+		c.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+
+		return false
+	}
+
+	select {
+	case ch.sendQueue <- msgBytes:
+		atomic.AddInt32(&ch.sendQueueSize, 1)
+
+		return true
+	case <-time.After(defaultSendTimeout):
+		c.Logger.Debug("Send failed", "channel", chID, "conn", c)
+
+		return false
+	}
+}
+
+// TrySend queues a message to be sent to channel.
+// Nonblocking, returns true if successful
+func (c *QUICConnection) TrySend(chID byte, msgBytes []byte) bool {
+	if !c.IsRunning() {
+		return false
+	}
+
+	ch, ok := c.channelsIdx[chID]
+	if !ok {
+		c.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+
+		return false
+	}
+
+	select {
+	case ch.sendQueue <- msgBytes:
+		atomic.AddInt32(&ch.sendQueueSize, 1)
+
+		return true
+	default:
+		return false
+	}
+}
+
+// Status returns the connection status
+func (c *QUICConnection) Status() ConnectionStatus {
+	status := ConnectionStatus{
+		Duration:    time.Since(c.created),
+		SendMonitor: c.sendMonitor.Status(),
+		RecvMonitor: c.recvMonitor.Status(),
+		Channels:    make([]ChannelStatus, len(c.channels)),
+	}
+
+	for i, ch := range c.channels {
+		status.Channels[i] = ChannelStatus{
+			ID:                ch.desc.ID,
+			SendQueueCapacity: cap(ch.sendQueue),
+			SendQueueSize:     int(atomic.LoadInt32(&ch.sendQueueSize)),
+			Priority:          ch.desc.Priority,
+			RecentlySent:      atomic.LoadInt64(&ch.recentlySent),
+		}
+	}
+
+	return status
+}
+
+// sendRoutine writes the queued channel messages to the channel stream
+func (c *QUICConnection) sendRoutine(ch *quicChannel, stream quic.SendStream) {
+	defer c.sendWg.Done()
+	defer c._recover()
+
+	w := bufio.NewWriterSize(stream, minWriteBufferSize)
+
+	write := func(msg []byte) error {
+		atomic.AddInt32(&ch.sendQueueSize, -1)
+
+		// Block until .sendMonitor says we can write
+		c.sendMonitor.Limit(len(msg), atomic.LoadInt64(&c.config.SendRate), true)
+
+		n, err := writeQUICMessage(w, msg)
+		if err != nil {
+			return err
+		}
+
+		c.sendMonitor.Update(n)
+		atomic.AddInt64(&ch.recentlySent, int64(n))
+
+		// Flush only when there is nothing else queued up,
+		// so bursts of messages are coalesced
+		if len(ch.sendQueue) == 0 {
+			return w.Flush()
+		}
+
+		return nil
+	}
+
+	for {
+		select {
+		case msg := <-ch.sendQueue:
+			if err := write(msg); err != nil {
+				if c.IsRunning() {
+					c.Logger.Error("Connection failed @ sendRoutine", "conn", c, "err", err)
+					c.stopForError(err)
+				}
+
+				return
+			}
+		case <-c.quitCh:
+			if !c.flushing.Load() {
+				return
+			}
+
+			// Send out all pending messages
+			for len(ch.sendQueue) > 0 {
+				if err := write(<-ch.sendQueue); err != nil {
+					return
+				}
+			}
+
+			_ = w.Flush()
+			_ = stream.Close()
+
+			return
+		}
+	}
+}
+
+// acceptRoutine accepts the inbound channel streams opened by the peer
+func (c *QUICConnection) acceptRoutine() {
+	defer c._recover()
+
+	for {
+		stream, err := c.conn.AcceptUniStream(c.conn.Context())
+		if err != nil {
+			if c.IsRunning() {
+				c.stopForError(err)
+			}
+
+			return
+		}
+
+		go c.recvRoutine(stream)
+	}
+}
+
+// recvRoutine reads the messages from the given channel stream, and
+// pushes them to onReceive()
+func (c *QUICConnection) recvRoutine(stream quic.ReceiveStream) {
+	defer c._recover()
+
+	r := bufio.NewReaderSize(stream, minReadBufferSize)
+
+	// Read the stream header (channel ID, and number of peer streams)
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		c.handleRecvErr(err)
+
+		return
+	}
+
+	chID, numStreams := header[0], int32(header[1])
+
+	ch, ok := c.channelsIdx[chID]
+	if !ok {
+		c.handleRecvErr(fmt.Errorf("%w: %X", errUnknownQUICChannel, chID))
+
+		return
+	}
+
+	for {
+		// Block until .recvMonitor says we can read
+		c.recvMonitor.Limit(minReadBufferSize, atomic.LoadInt64(&c.config.RecvRate), true)
+
+		msgBytes, n, err := readQUICMessage(r, ch.desc.RecvMessageCapacity)
+		if goerrors.Is(err, io.EOF) {
+			// The peer closed the stream gracefully.
+			// Once all streams are closed, the connection is done
+			if c.finishedStreams.Add(1) == numStreams {
+				c.handleRecvErr(err)
+			}
+
+			return
+		}
+
+		if err != nil {
+			c.handleRecvErr(err)
+
+			return
+		}
+
+		c.recvMonitor.Update(n)
+
+		c.onReceive(chID, msgBytes)
+	}
+}
+
+// handleRecvErr handles a stream read error
+func (c *QUICConnection) handleRecvErr(err error) {
+	if !c.IsRunning() {
+		// Errors while stopping are expected
+		return
+	}
+
+	c.Logger.Debug("Connection failed @ recvRoutine", "conn", c, "err", err)
+	c.stopForError(err)
+}
+
+// writeQUICMessage writes the length-prefixed message to the writer
+func writeQUICMessage(w io.Writer, msg []byte) (int, error) {
+	prefix := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64), uint64(len(msg)))
+
+	n, err := w.Write(prefix)
+	if err != nil {
+		return n, err
+	}
+
+	m, err := w.Write(msg)
+
+	return n + m, err
+}
+
+// readQUICMessage reads a length-prefixed message from the reader,
+// limited by the given capacity
+func readQUICMessage(r *bufio.Reader, capacity int) ([]byte, int, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if size > uint64(capacity) {
+		return nil, 0, fmt.Errorf("%w: %d > %d", errQUICMessageTooLarge, size, capacity)
+	}
+
+	msg := make([]byte, size)
+	if _, err = io.ReadFull(r, msg); err != nil {
+		return nil, 0, err
+	}
+
+	return msg, len(binary.AppendUvarint(nil, size)) + len(msg), nil
+}
+
+// quicChannel is a single QUIC multiplexed channel
+type quicChannel struct {
+	desc          ChannelDescriptor
+	sendQueue     chan []byte
+	sendQueueSize int32 // atomic
+	recentlySent  int64 // atomic
+}
+
+func newQUICChannel(desc ChannelDescriptor) *quicChannel {
+	desc = desc.FillDefaults()
+	if desc.Priority <= 0 {
+		panic("Channel default priority must be a positive integer")
+	}
+
+	return &quicChannel{
+		desc:      desc,
+		sendQueue: make(chan []byte, desc.SendQueueCapacity),
+	}
+}
@@ -0,0 +1,250 @@
+package conn
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+const testQUICALPN = "tm2-p2p-test"
+
+// newTestQUICPair creates a pair of connected QUIC connections
+func newTestQUICPair(t *testing.T) (quic.Connection, quic.Connection) {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	tlsConfig := &tls.Config{
+		Certificates:       []tls.Certificate{{Certificate: [][]byte{certDER}, PrivateKey: key}},
+		NextProtos:         []string{testQUICALPN},
+		InsecureSkipVerify: true, //nolint:gosec // testing
+	}
+
+	ln, err := quic.ListenAddr("127.0.0.1:0", tlsConfig, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFn()
+
+	client, err := quic.DialAddr(ctx, ln.Addr().String(), tlsConfig, nil)
+	require.NoError(t, err)
+
+	server, err := ln.Accept(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = client.CloseWithError(QUICErrorCodeNone, "")
+		_ = server.CloseWithError(QUICErrorCodeNone, "")
+	})
+
+	return client, server
+}
+
+func createQUICConnectionWithCallbacks(
+	t *testing.T,
+	conn quic.Connection,
+	onReceive func(chID byte, msgBytes []byte),
+	onError func(r error),
+) *QUICConnection {
+	t.Helper()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 1},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 1, RecvMessageCapacity: 10},
+	}
+
+	c := NewQUICConnection(conn, chDescs, onReceive, onError, DefaultMConnConfig())
+	c.SetLogger(log.NewTestingLogger(t))
+
+	return c
+}
+
+type receivedMsg struct {
+	chID byte
+	msg  []byte
+}
+
+func TestQUICConnection_SendReceive(t *testing.T) {
+	t.Parallel()
+
+	client, server := newTestQUICPair(t)
+
+	receivedCh := make(chan receivedMsg, 10)
+
+	clientConn := createQUICConnectionWithCallbacks(t, client, func(byte, []byte) {}, func(error) {})
+	serverConn := createQUICConnectionWithCallbacks(
+		t,
+		server,
+		func(chID byte, msg []byte) {
+			receivedCh <- receivedMsg{chID: chID, msg: msg}
+		},
+		func(err error) {
+			t.Errorf("unexpected error: %v", err)
+		},
+	)
+
+	require.NoError(t, serverConn.Start())
+	defer serverConn.Stop()
+
+	require.NoError(t, clientConn.Start())
+	defer clientConn.Stop()
+
+	// Send messages on separate channels
+	assert.True(t, clientConn.Send(0x01, []byte("Ant-Man")))
+	assert.True(t, clientConn.Send(0x02, []byte("Wasp")))
+
+	received := make(map[byte][]byte)
+
+	for len(received) < 2 {
+		select {
+		case msg := <-receivedCh:
+			received[msg.chID] = msg.msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for messages")
+		}
+	}
+
+	assert.Equal(t, []byte("Ant-Man"), received[0x01])
+	assert.Equal(t, []byte("Wasp"), received[0x02])
+
+	// Unknown channels are rejected
+	assert.False(t, clientConn.Send(0x05, []byte("Absorbing Man")))
+	assert.False(t, clientConn.TrySend(0x05, []byte("Absorbing Man")))
+
+	status := clientConn.Status()
+	require.Len(t, status.Channels, 2)
+	assert.Equal(t, byte(0x01), status.Channels[0].ID)
+	assert.Equal(t, 1, status.Channels[0].SendQueueCapacity)
+}
+
+func TestQUICConnection_FlushStop(t *testing.T) {
+	t.Parallel()
+
+	client, server := newTestQUICPair(t)
+
+	receivedCh := make(chan []byte, 10)
+
+	clientConn := createQUICConnectionWithCallbacks(t, client, func(byte, []byte) {}, func(error) {})
+	serverConn := createQUICConnectionWithCallbacks(
+		t,
+		server,
+		func(_ byte, msg []byte) {
+			receivedCh <- msg
+		},
+		func(error) {},
+	)
+
+	require.NoError(t, serverConn.Start())
+	defer serverConn.Stop()
+
+	require.NoError(t, clientConn.Start())
+
+	msg := []byte("abc")
+	assert.True(t, clientConn.Send(0x01, msg))
+
+	// Stop the connection, it should flush pending messages
+	clientConn.FlushStop()
+
+	select {
+	case received := <-receivedCh:
+		assert.Equal(t, msg, received)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for msgs to be read")
+	}
+}
+
+func TestQUICConnection_MessageTooLarge(t *testing.T) {
+	t.Parallel()
+
+	client, server := newTestQUICPair(t)
+
+	errCh := make(chan error, 1)
+
+	clientConn := createQUICConnectionWithCallbacks(t, client, func(byte, []byte) {}, func(error) {})
+	serverConn := createQUICConnectionWithCallbacks(
+		t,
+		server,
+		func(byte, []byte) {
+			t.Error("unexpected message received")
+		},
+		func(err error) {
+			errCh <- err
+		},
+	)
+
+	require.NoError(t, serverConn.Start())
+	defer serverConn.Stop()
+
+	require.NoError(t, clientConn.Start())
+	defer clientConn.Stop()
+
+	// Channel 0x02 accepts messages up to 10B
+	assert.True(t, clientConn.Send(0x02, []byte("Absorbing Man")))
+
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, errQUICMessageTooLarge)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for error")
+	}
+
+	assert.False(t, serverConn.IsRunning())
+}
+
+func TestQUICConnection_RemoteClose(t *testing.T) {
+	t.Parallel()
+
+	client, server := newTestQUICPair(t)
+
+	errCh := make(chan error, 1)
+
+	clientConn := createQUICConnectionWithCallbacks(t, client, func(byte, []byte) {}, func(error) {})
+	serverConn := createQUICConnectionWithCallbacks(
+		t,
+		server,
+		func(byte, []byte) {},
+		func(err error) {
+			errCh <- err
+		},
+	)
+
+	require.NoError(t, serverConn.Start())
+	defer serverConn.Stop()
+
+	require.NoError(t, clientConn.Start())
+
+	// Close the remote connection
+	require.NoError(t, clientConn.Stop())
+
+	select {
+	case err := <-errCh:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for error")
+	}
+}
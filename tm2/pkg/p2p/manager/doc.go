@@ -0,0 +1,14 @@
+// Package manager contains an implementation of a thread-safe peer manager, used by the p2p switch to keep track
+// of peer behavior.
+// The behavior of the peer manager is the following:
+//
+// - Misbehavior reported for a peer increases its score. Once the score reaches the ban threshold, the peer ID and IP
+// (if public) are banned for the configured ban duration, and the peer is removed from the address book. Scores are
+// reset after a period of good behavior.
+//
+// - Peers can also be banned (and unbanned) manually, by ID or by IP. Bans expire on their own.
+//
+// - Peers that were successfully dialed are kept in the address book, as known good addresses.
+//
+// - The address book and the active bans can be persisted to a file, so they survive node restarts.
+package manager
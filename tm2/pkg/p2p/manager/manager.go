@@ -0,0 +1,363 @@
+package manager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+const (
+	// DefaultBanThreshold is the default number of misbehaviors after which a peer is banned
+	DefaultBanThreshold = 5
+
+	// DefaultBanDuration is the default peer ban duration
+	DefaultBanDuration = 24 * time.Hour
+
+	// scoreResetInterval is the period of good behavior after which a peer's score is reset
+	scoreResetInterval = time.Hour
+
+	// bookFilePerm is the address book file permission
+	bookFilePerm = 0o600
+)
+
+var errInvalidBan = errors.New("invalid ban, no peer ID or IP specified")
+
+// Ban is a single active peer ban, either by peer ID or by IP
+type Ban struct {
+	ID    types.ID  `json:"id,omitempty"` // the banned peer ID, if any
+	IP    string    `json:"ip,omitempty"` // the banned IP, if any
+	Until time.Time `json:"until"`        // the ban expiry time
+}
+
+// score keeps track of a peer's misbehavior
+type score struct {
+	misbehaviors uint64    // number of reported misbehaviors
+	lastReport   time.Time // time of the latest misbehavior report
+}
+
+// bookFile is the persisted format of the address book
+type bookFile struct {
+	Addresses []*types.NetAddress `json:"addresses"`
+	Bans      []Ban               `json:"bans"`
+}
+
+// Manager keeps track of peer behavior. It scores peers on reported misbehavior,
+// bans abusive peer IDs and IPs for a period of time, and keeps an address book
+// of known good peers, which can be persisted across restarts
+type Manager struct {
+	mux sync.RWMutex
+
+	scores    map[types.ID]*score
+	bannedIDs map[types.ID]time.Time // ID -> ban expiry
+	bannedIPs map[string]time.Time   // IP -> ban expiry
+	book      map[types.ID]*types.NetAddress
+
+	banThreshold uint64        // 0 disables misbehavior bans
+	banDuration  time.Duration // the duration of misbehavior bans
+	bookPath     string        // the address book file path, if any
+
+	now func() time.Time
+}
+
+// New creates a new peer manager
+func New(opts ...Option) *Manager {
+	m := &Manager{
+		scores:       make(map[types.ID]*score),
+		bannedIDs:    make(map[types.ID]time.Time),
+		bannedIPs:    make(map[string]time.Time),
+		book:         make(map[types.ID]*types.NetAddress),
+		banThreshold: DefaultBanThreshold,
+		banDuration:  DefaultBanDuration,
+		now:          time.Now,
+	}
+
+	// Apply the options
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Load loads the address book and the active bans from the address book file, if any.
+// A missing address book file is not considered an error
+func (m *Manager) Load() error {
+	if m.bookPath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(m.bookPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to read address book, %w", err)
+	}
+
+	var file bookFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("unable to parse address book, %w", err)
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, addr := range file.Addresses {
+		if err := addr.Validate(); err != nil {
+			continue
+		}
+
+		m.book[addr.ID] = addr
+	}
+
+	now := m.now()
+
+	for _, ban := range file.Bans {
+		if !ban.Until.After(now) {
+			// Ban expired in the meantime
+			continue
+		}
+
+		if ban.ID != "" {
+			m.bannedIDs[ban.ID] = ban.Until
+		}
+
+		if ban.IP != "" {
+			m.bannedIPs[ban.IP] = ban.Until
+		}
+	}
+
+	return nil
+}
+
+// ReportMisbehavior increases the score of the given peer, and bans
+// its ID and (public) IP once the ban threshold is reached.
+// Returns a flag indicating if the peer was banned as a result
+func (m *Manager) ReportMisbehavior(id types.ID, ip net.IP) (bool, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	now := m.now()
+
+	s, ok := m.scores[id]
+	if !ok || now.Sub(s.lastReport) > scoreResetInterval {
+		// Start over, since the peer behaved
+		// for long enough (or this is the first report)
+		s = &score{}
+		m.scores[id] = s
+	}
+
+	s.misbehaviors++
+	s.lastReport = now
+
+	if m.banThreshold == 0 || s.misbehaviors < m.banThreshold {
+		return false, nil
+	}
+
+	// The peer has misbehaved enough, ban it
+	delete(m.scores, id)
+
+	until := now.Add(m.banDuration)
+
+	m.bannedIDs[id] = until
+
+	// Local and private IPs are commonly shared by many peers
+	// (local deployments, sentry setups), so they are never banned
+	// automatically, as that would take down honest peers as well
+	if ip != nil && ip.IsGlobalUnicast() && !ip.IsPrivate() {
+		m.bannedIPs[ip.String()] = until
+	}
+
+	delete(m.book, id)
+
+	return true, m.save()
+}
+
+// BanID bans the given peer ID for the given duration
+func (m *Manager) BanID(id types.ID, duration time.Duration) error {
+	return m.ban(Ban{ID: id}, duration)
+}
+
+// BanIP bans the given IP for the given duration
+func (m *Manager) BanIP(ip net.IP, duration time.Duration) error {
+	return m.ban(Ban{IP: ip.String()}, duration)
+}
+
+// ban applies the given ban, for the given duration.
+// If the duration is not set, the default ban duration is used
+func (m *Manager) ban(ban Ban, duration time.Duration) error {
+	if ban.ID == "" && ban.IP == "" {
+		return errInvalidBan
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if duration <= 0 {
+		duration = m.banDuration
+	}
+
+	until := m.now().Add(duration)
+
+	if ban.ID != "" {
+		m.bannedIDs[ban.ID] = until
+
+		delete(m.scores, ban.ID)
+		delete(m.book, ban.ID)
+	}
+
+	if ban.IP != "" {
+		m.bannedIPs[ban.IP] = until
+	}
+
+	return m.save()
+}
+
+// UnbanID lifts the ban on the given peer ID, if any
+func (m *Manager) UnbanID(id types.ID) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	delete(m.bannedIDs, id)
+
+	return m.save()
+}
+
+// UnbanIP lifts the ban on the given IP, if any
+func (m *Manager) UnbanIP(ip net.IP) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	delete(m.bannedIPs, ip.String())
+
+	return m.save()
+}
+
+// IsBanned returns a flag indicating if either the
+// given peer ID, or the given IP (if set) are banned
+func (m *Manager) IsBanned(id types.ID, ip net.IP) bool {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	now := m.now()
+
+	if until, ok := m.bannedIDs[id]; ok && until.After(now) {
+		return true
+	}
+
+	if ip == nil {
+		return false
+	}
+
+	until, ok := m.bannedIPs[ip.String()]
+
+	return ok && until.After(now)
+}
+
+// Bans returns the active peer bans
+func (m *Manager) Bans() []Ban {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	return m.activeBans()
+}
+
+// activeBans returns the active peer bans.
+// Must be called with the lock held
+func (m *Manager) activeBans() []Ban {
+	var (
+		now  = m.now()
+		bans = make([]Ban, 0, len(m.bannedIDs)+len(m.bannedIPs))
+	)
+
+	for id, until := range m.bannedIDs {
+		if until.After(now) {
+			bans = append(bans, Ban{ID: id, Until: until})
+		}
+	}
+
+	for ip, until := range m.bannedIPs {
+		if until.After(now) {
+			bans = append(bans, Ban{IP: ip, Until: until})
+		}
+	}
+
+	return bans
+}
+
+// MarkGood adds the given peer address to the address book,
+// as a known good address
+func (m *Manager) MarkGood(addr *types.NetAddress) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if existing, ok := m.book[addr.ID]; ok && existing.Equals(*addr) {
+		// Address already known
+		return nil
+	}
+
+	m.book[addr.ID] = addr
+
+	return m.save()
+}
+
+// Addresses returns the known good peer addresses
+// from the address book, excluding banned ones
+func (m *Manager) Addresses() []*types.NetAddress {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	now := m.now()
+
+	addrs := make([]*types.NetAddress, 0, len(m.book))
+	for id, addr := range m.book {
+		if until, ok := m.bannedIDs[id]; ok && until.After(now) {
+			continue
+		}
+
+		if until, ok := m.bannedIPs[addr.IP.String()]; ok && until.After(now) {
+			continue
+		}
+
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
+
+// save persists the address book and the active bans
+// to the address book file, if any.
+// Must be called with the lock held
+func (m *Manager) save() error {
+	if m.bookPath == "" {
+		return nil
+	}
+
+	file := bookFile{
+		Addresses: make([]*types.NetAddress, 0, len(m.book)),
+		Bans:      m.activeBans(),
+	}
+
+	for _, addr := range m.book {
+		file.Addresses = append(file.Addresses, addr)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal address book, %w", err)
+	}
+
+	if err := osm.WriteFileAtomic(m.bookPath, data, bookFilePerm); err != nil {
+		return fmt.Errorf("unable to write address book, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package manager
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateNetAddr generates random peer net addresses
+func generateNetAddr(t *testing.T, count int) []*types.NetAddress {
+	t.Helper()
+
+	addrs := make([]*types.NetAddress, 0, count)
+
+	for i := range count {
+		key := types.GenerateNodeKey()
+
+		addr, err := types.NewNetAddress(
+			key.ID(),
+			&net.TCPAddr{
+				IP:   net.IPv4(198, 51, 100, byte(i+1)),
+				Port: 26656,
+			},
+		)
+		require.NoError(t, err)
+
+		addrs = append(addrs, addr)
+	}
+
+	return addrs
+}
+
+// mockClock is a manually advanced clock
+type mockClock struct {
+	now time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	return c.now
+}
+
+func (c *mockClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestManager(clock *mockClock, opts ...Option) *Manager {
+	m := New(opts...)
+	m.now = clock.Now
+
+	return m
+}
+
+func TestManager_ReportMisbehavior(t *testing.T) {
+	t.Parallel()
+
+	t.Run("peer banned at threshold", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			clock = &mockClock{now: time.Now()}
+			addr  = generateNetAddr(t, 1)[0]
+
+			m = newTestManager(
+				clock,
+				WithBanThreshold(3),
+				WithBanDuration(time.Hour),
+			)
+		)
+
+		require.NoError(t, m.MarkGood(addr))
+
+		for range 2 {
+			banned, err := m.ReportMisbehavior(addr.ID, addr.IP)
+			require.NoError(t, err)
+
+			assert.False(t, banned)
+			assert.False(t, m.IsBanned(addr.ID, addr.IP))
+		}
+
+		banned, err := m.ReportMisbehavior(addr.ID, addr.IP)
+		require.NoError(t, err)
+
+		// Make sure the ID and the IP are banned
+		assert.True(t, banned)
+		assert.True(t, m.IsBanned(addr.ID, nil))
+		assert.True(t, m.IsBanned(types.GenerateNodeKey().ID(), addr.IP))
+
+		// Make sure the peer is dropped from the address book
+		assert.Empty(t, m.Addresses())
+
+		// Make sure the ban expires
+		clock.Advance(time.Hour)
+
+		assert.False(t, m.IsBanned(addr.ID, addr.IP))
+	})
+
+	t.Run("banning disabled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			addr = generateNetAddr(t, 1)[0]
+			m    = New(WithBanThreshold(0))
+		)
+
+		for range 10 {
+			banned, err := m.ReportMisbehavior(addr.ID, addr.IP)
+			require.NoError(t, err)
+
+			assert.False(t, banned)
+		}
+
+		assert.False(t, m.IsBanned(addr.ID, addr.IP))
+	})
+
+	t.Run("score reset after good behavior", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			clock = &mockClock{now: time.Now()}
+			addr  = generateNetAddr(t, 1)[0]
+
+			m = newTestManager(clock, WithBanThreshold(2))
+		)
+
+		banned, err := m.ReportMisbehavior(addr.ID, addr.IP)
+		require.NoError(t, err)
+		require.False(t, banned)
+
+		clock.Advance(scoreResetInterval + time.Second)
+
+		banned, err = m.ReportMisbehavior(addr.ID, addr.IP)
+		require.NoError(t, err)
+
+		assert.False(t, banned)
+	})
+
+	t.Run("private IPs not banned", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			id = types.GenerateNodeKey().ID()
+			ip = net.ParseIP("127.0.0.1")
+
+			m = New(WithBanThreshold(1))
+		)
+
+		banned, err := m.ReportMisbehavior(id, ip)
+		require.NoError(t, err)
+
+		assert.True(t, banned)
+		assert.True(t, m.IsBanned(id, ip))
+
+		// Make sure only the ID is banned
+		assert.False(t, m.IsBanned(types.GenerateNodeKey().ID(), ip))
+	})
+}
+
+func TestManager_Bans(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid ban", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(t, New().ban(Ban{}, time.Hour), errInvalidBan)
+	})
+
+	t.Run("ban and unban ID", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			addr = generateNetAddr(t, 1)[0]
+			m    = New()
+		)
+
+		require.NoError(t, m.MarkGood(addr))
+		require.NoError(t, m.BanID(addr.ID, 0))
+
+		assert.True(t, m.IsBanned(addr.ID, nil))
+		assert.Empty(t, m.Addresses())
+
+		bans := m.Bans()
+		require.Len(t, bans, 1)
+
+		assert.Equal(t, addr.ID, bans[0].ID)
+
+		require.NoError(t, m.UnbanID(addr.ID))
+
+		assert.False(t, m.IsBanned(addr.ID, nil))
+		assert.Empty(t, m.Bans())
+	})
+
+	t.Run("ban and unban IP", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			clock = &mockClock{now: time.Now()}
+			addrs = generateNetAddr(t, 2)
+
+			m = newTestManager(clock)
+		)
+
+		for _, addr := range addrs {
+			require.NoError(t, m.MarkGood(addr))
+		}
+
+		require.NoError(t, m.BanIP(addrs[0].IP, time.Minute))
+
+		assert.True(t, m.IsBanned(addrs[0].ID, addrs[0].IP))
+		assert.False(t, m.IsBanned(addrs[1].ID, addrs[1].IP))
+
+		// Make sure the banned address is not returned
+		assert.Equal(t, []*types.NetAddress{addrs[1]}, m.Addresses())
+
+		require.NoError(t, m.UnbanIP(addrs[0].IP))
+
+		assert.False(t, m.IsBanned(addrs[0].ID, addrs[0].IP))
+		assert.Len(t, m.Addresses(), 2)
+	})
+}
+
+func TestManager_AddressBook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing address book", func(t *testing.T) {
+		t.Parallel()
+
+		m := New(WithAddressBook(filepath.Join(t.TempDir(), "addrbook.json")))
+
+		require.NoError(t, m.Load())
+
+		assert.Empty(t, m.Addresses())
+	})
+
+	t.Run("address book persisted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			path  = filepath.Join(t.TempDir(), "addrbook.json")
+			addrs = generateNetAddr(t, 3)
+
+			bannedID = types.GenerateNodeKey().ID()
+			bannedIP = net.ParseIP("203.0.113.10")
+
+			m = New(WithAddressBook(path))
+		)
+
+		for _, addr := range addrs {
+			require.NoError(t, m.MarkGood(addr))
+		}
+
+		require.NoError(t, m.BanID(bannedID, time.Hour))
+		require.NoError(t, m.BanIP(bannedIP, time.Hour))
+
+		// Load the address book into a new manager
+		loaded := New(WithAddressBook(path))
+		require.NoError(t, loaded.Load())
+
+		assert.ElementsMatch(t, m.Addresses(), loaded.Addresses())
+		assert.True(t, loaded.IsBanned(bannedID, nil))
+		assert.True(t, loaded.IsBanned(types.GenerateNodeKey().ID(), bannedIP))
+	})
+
+	t.Run("expired bans not loaded", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			path  = filepath.Join(t.TempDir(), "addrbook.json")
+			clock = &mockClock{now: time.Now()}
+			id    = types.GenerateNodeKey().ID()
+
+			m = newTestManager(clock, WithAddressBook(path))
+		)
+
+		require.NoError(t, m.BanID(id, time.Minute))
+
+		loaded := newTestManager(clock, WithAddressBook(path))
+
+		clock.Advance(time.Minute)
+
+		require.NoError(t, loaded.Load())
+
+		assert.False(t, loaded.IsBanned(id, nil))
+		assert.Empty(t, loaded.Bans())
+	})
+}
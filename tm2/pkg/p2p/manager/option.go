@@ -0,0 +1,29 @@
+package manager
+
+import "time"
+
+type Option func(*Manager)
+
+// WithBanThreshold sets the number of misbehaviors
+// after which a peer is banned. A threshold of 0 disables
+// misbehavior bans
+func WithBanThreshold(threshold uint64) Option {
+	return func(m *Manager) {
+		m.banThreshold = threshold
+	}
+}
+
+// WithBanDuration sets the misbehavior ban duration
+func WithBanDuration(duration time.Duration) Option {
+	return func(m *Manager) {
+		m.banDuration = duration
+	}
+}
+
+// WithAddressBook sets the address book file path,
+// used for persisting known good addresses and active bans
+func WithAddressBook(path string) Option {
+	return func(m *Manager) {
+		m.bookPath = path
+	}
+}
@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
@@ -9,6 +10,10 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
 	"github.com/gnolang/gno/tm2/pkg/p2p/types"
 	"github.com/gnolang/gno/tm2/pkg/service"
+	"github.com/gnolang/gno/tm2/pkg/telemetry"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type ConnConfig struct {
@@ -39,6 +44,10 @@ type multiplexConn interface {
 	String() string
 }
 
+// multiplexConnFn creates the multiplexed connection for a peer,
+// using the given message and error callbacks
+type multiplexConnFn func(onReceive func(byte, []byte), onError func(error)) multiplexConn
+
 // peer is a wrapper for a remote peer
 // Before using a peer, you will need to perform a handshake on connection.
 type peer struct {
@@ -51,11 +60,33 @@ type peer struct {
 	data *cmap.CMap // Arbitrary data store associated with the peer
 }
 
-// newPeer creates an uninitialized peer instance
+// newPeer creates an uninitialized peer instance,
+// that multiplexes the connection using an MConnection
 func newPeer(
 	connInfo *ConnInfo,
 	nodeInfo types.NodeInfo,
 	mConfig *ConnConfig,
+) PeerConn {
+	mConnFn := func(onReceive func(byte, []byte), onError func(error)) multiplexConn {
+		return conn.NewMConnectionWithConfig(
+			connInfo.Conn,
+			mConfig.ChDescs,
+			onReceive,
+			onError,
+			mConfig.MConfig,
+		)
+	}
+
+	return newPeerWithConn(connInfo, nodeInfo, mConfig, mConnFn)
+}
+
+// newPeerWithConn creates an uninitialized peer instance,
+// that multiplexes the connection using the given multiplexed connection
+func newPeerWithConn(
+	connInfo *ConnInfo,
+	nodeInfo types.NodeInfo,
+	mConfig *ConnConfig,
+	mConnFn multiplexConnFn,
 ) PeerConn {
 	p := &peer{
 		connInfo: connInfo,
@@ -63,10 +94,7 @@ func newPeer(
 		data:     cmap.NewCMap(),
 	}
 
-	p.mConn = p.createMConnection(
-		connInfo.Conn,
-		mConfig,
-	)
+	p.mConn = p.createMConnection(mConfig, mConnFn)
 
 	p.BaseService = *service.NewBaseService(nil, "Peer", p)
 
@@ -182,7 +210,13 @@ func (p *peer) Send(chID byte, msgBytes []byte) bool {
 		return false
 	}
 
-	return p.mConn.Send(chID, msgBytes)
+	if !p.mConn.Send(chID, msgBytes) {
+		return false
+	}
+
+	logSendTelemetry(chID, msgBytes)
+
+	return true
 }
 
 // TrySend msg bytes to the channel identified by chID byte. Immediately returns
@@ -192,7 +226,13 @@ func (p *peer) TrySend(chID byte, msgBytes []byte) bool {
 		return false
 	}
 
-	return p.mConn.TrySend(chID, msgBytes)
+	if !p.mConn.TrySend(chID, msgBytes) {
+		return false
+	}
+
+	logSendTelemetry(chID, msgBytes)
+
+	return true
 }
 
 // Get the data for a given key.
@@ -218,9 +258,9 @@ func (p *peer) hasChannel(chID byte) bool {
 }
 
 func (p *peer) createMConnection(
-	c net.Conn,
 	config *ConnConfig,
-) *conn.MConnection {
+	mConnFn multiplexConnFn,
+) multiplexConn {
 	onReceive := func(chID byte, msgBytes []byte) {
 		reactor := config.ReactorsByCh[chID]
 		if reactor == nil {
@@ -229,6 +269,8 @@ func (p *peer) createMConnection(
 			panic(fmt.Sprintf("Unknown channel %X", chID))
 		}
 
+		logReceiveTelemetry(chID, msgBytes)
+
 		reactor.Receive(chID, p, msgBytes)
 	}
 
@@ -236,11 +278,36 @@ func (p *peer) createMConnection(
 		config.OnPeerError(p, r)
 	}
 
-	return conn.NewMConnectionWithConfig(
-		c,
-		config.ChDescs,
-		onReceive,
-		onError,
-		config.MConfig,
+	return mConnFn(onReceive, onError)
+}
+
+// logSendTelemetry logs the telemetry for a message sent to the peer
+func logSendTelemetry(chID byte, msgBytes []byte) {
+	if !telemetry.MetricsEnabled() {
+		return
+	}
+
+	metrics.PeerSendBytes.Add(
+		context.Background(),
+		int64(len(msgBytes)),
+		metric.WithAttributes(channelAttribute(chID)),
 	)
 }
+
+// logReceiveTelemetry logs the telemetry for a message received from the peer
+func logReceiveTelemetry(chID byte, msgBytes []byte) {
+	if !telemetry.MetricsEnabled() {
+		return
+	}
+
+	metrics.PeerReceiveBytes.Add(
+		context.Background(),
+		int64(len(msgBytes)),
+		metric.WithAttributes(channelAttribute(chID)),
+	)
+}
+
+// channelAttribute returns the telemetry attribute for the given channel
+func channelAttribute(chID byte) attribute.KeyValue {
+	return attribute.String("channel", fmt.Sprintf("%#x", chID))
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
 	"sync"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
 	"github.com/gnolang/gno/tm2/pkg/p2p/dial"
 	"github.com/gnolang/gno/tm2/pkg/p2p/events"
+	"github.com/gnolang/gno/tm2/pkg/p2p/manager"
 	"github.com/gnolang/gno/tm2/pkg/p2p/types"
 	"github.com/gnolang/gno/tm2/pkg/service"
 	"github.com/gnolang/gno/tm2/pkg/telemetry"
@@ -24,6 +26,8 @@ import (
 // defaultDialTimeout is the default wait time for a dial to succeed
 var defaultDialTimeout = 3 * time.Second
 
+var errPeerBanned = errors.New("peer is banned")
+
 type reactorPeerBehavior struct {
 	chDescs      []*conn.ChannelDescriptor
 	reactorsByCh map[byte]Reactor
@@ -73,6 +77,7 @@ type MultiplexSwitch struct {
 	persistentPeers sync.Map // ID -> *NetAddress; peers whose connections are constant
 	privatePeers    sync.Map // ID -> nothing; lookup table of peers who are not shared
 	transport       Transport
+	peerManager     *manager.Manager // peer scoring, bans and the address book
 
 	dialQueue  *dial.Queue
 	dialNotify chan struct{}
@@ -90,6 +95,7 @@ func NewMultiplexSwitch(
 		reactors:         make(map[string]Reactor),
 		peers:            newSet(),
 		transport:        transport,
+		peerManager:      manager.New(),
 		dialQueue:        dial.NewQueue(),
 		dialNotify:       make(chan struct{}, 1),
 		events:           events.New(),
@@ -157,6 +163,9 @@ func (sw *MultiplexSwitch) OnStart() error {
 	// to them
 	go sw.runRedialLoop(sw.ctx)
 
+	// Dial the known good peers from the address book
+	sw.DialPeers(sw.peerManager.Addresses()...)
+
 	return nil
 }
 
@@ -201,6 +210,25 @@ func (sw *MultiplexSwitch) Peers() PeerSet {
 	return sw.peers
 }
 
+// ReportMisbehavior disconnects from a peer that violated the protocol.
+// The violation increases the peer score, and non-persistent peers
+// that misbehave repeatedly are banned.
+// If the peer is persistent, it will attempt to reconnect
+func (sw *MultiplexSwitch) ReportMisbehavior(peer PeerConn, reason error) {
+	if !peer.IsPersistent() {
+		banned, banErr := sw.peerManager.ReportMisbehavior(peer.ID(), peer.RemoteIP())
+		if banErr != nil {
+			sw.Logger.Error("unable to persist peer ban", "peer", peer, "err", banErr)
+		}
+
+		if banned {
+			sw.Logger.Warn("Banning misbehaving peer", "peer", peer, "reason", reason)
+		}
+	}
+
+	sw.StopPeerForError(peer, reason)
+}
+
 // StopPeerForError disconnects from a peer due to external error.
 // If the peer is persistent, it will attempt to reconnect
 func (sw *MultiplexSwitch) StopPeerForError(peer PeerConn, err error) {
@@ -307,6 +335,16 @@ func (sw *MultiplexSwitch) runDialLoop(ctx context.Context) {
 				continue
 			}
 
+			// Check if the peer is banned
+			if sw.peerManager.IsBanned(peerAddr.ID, peerAddr.IP) {
+				sw.Logger.Warn(
+					"ignoring dial request for banned peer",
+					"id", peerAddr.ID,
+				)
+
+				continue
+			}
+
 			// Create a dial context
 			dialCtx, cancelFn := context.WithTimeout(ctx, defaultDialTimeout)
 			defer cancelFn()
@@ -660,6 +698,20 @@ func (sw *MultiplexSwitch) runAcceptLoop(ctx context.Context) {
 			continue
 		}
 
+		// Ignore connection if the peer is banned
+		if sw.peerManager.IsBanned(p.ID(), p.RemoteIP()) {
+			sw.Logger.Info(
+				"Ignoring inbound connection: peer is banned",
+				"address", p.SocketAddr(),
+				"id", p.ID(),
+			)
+
+			sw.transport.Remove(p)
+			_ = p.CloseConn()
+
+			continue
+		}
+
 		// Ignore connection if we already have enough peers.
 		if in := sw.Peers().NumInbound(); in >= sw.maxInboundPeers {
 			sw.Logger.Info(
@@ -720,6 +772,14 @@ func (sw *MultiplexSwitch) addPeer(p PeerConn) error {
 
 	sw.Logger.Info("Added peer", "peer", p)
 
+	// Remember the dial address of outbound peers,
+	// since they are known to be good
+	if p.IsOutbound() {
+		if err := sw.peerManager.MarkGood(p.SocketAddr()); err != nil {
+			sw.Logger.Error("unable to persist peer address", "peer", p, "err", err)
+		}
+	}
+
 	sw.events.Notify(events.PeerConnectedEvent{
 		Address: p.RemoteAddr(),
 		PeerID:  p.ID(),
@@ -728,6 +788,55 @@ func (sw *MultiplexSwitch) addPeer(p PeerConn) error {
 	return nil
 }
 
+// ---------------------------------------------------------------------
+// Bans
+
+// BanPeerID bans the given peer ID for the given duration
+// (the configured ban duration if not set), and disconnects from it
+func (sw *MultiplexSwitch) BanPeerID(id types.ID, duration time.Duration) error {
+	if err := sw.peerManager.BanID(id, duration); err != nil {
+		return err
+	}
+
+	if p := sw.peers.Get(id); p != nil {
+		sw.stopAndRemovePeer(p, errPeerBanned)
+	}
+
+	return nil
+}
+
+// BanPeerIP bans the given IP for the given duration
+// (the configured ban duration if not set), and disconnects
+// from any peer connected from it
+func (sw *MultiplexSwitch) BanPeerIP(ip net.IP, duration time.Duration) error {
+	if err := sw.peerManager.BanIP(ip, duration); err != nil {
+		return err
+	}
+
+	for _, p := range sw.peers.List() {
+		if p.RemoteIP().Equal(ip) {
+			sw.stopAndRemovePeer(p, errPeerBanned)
+		}
+	}
+
+	return nil
+}
+
+// UnbanPeerID lifts the ban on the given peer ID, if any
+func (sw *MultiplexSwitch) UnbanPeerID(id types.ID) error {
+	return sw.peerManager.UnbanID(id)
+}
+
+// UnbanPeerIP lifts the ban on the given IP, if any
+func (sw *MultiplexSwitch) UnbanPeerIP(ip net.IP) error {
+	return sw.peerManager.UnbanIP(ip)
+}
+
+// BannedPeers returns the active peer bans
+func (sw *MultiplexSwitch) BannedPeers() []manager.Ban {
+	return sw.peerManager.Bans()
+}
+
 func (sw *MultiplexSwitch) notifyAddPeerToDial() {
 	select {
 	case sw.dialNotify <- struct{}{}:
@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"github.com/gnolang/gno/tm2/pkg/p2p/manager"
 	"github.com/gnolang/gno/tm2/pkg/p2p/types"
 )
 
@@ -59,3 +60,11 @@ func WithMaxOutboundPeers(maxOutbound uint64) SwitchOption {
 		sw.maxOutboundPeers = maxOutbound
 	}
 }
+
+// WithPeerManager sets the p2p switch's peer manager,
+// used for peer scoring, bans and the address book
+func WithPeerManager(m *manager.Manager) SwitchOption {
+	return func(sw *MultiplexSwitch) {
+		sw.peerManager = m
+	}
+}
@@ -2,15 +2,21 @@ package p2p
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
 	"github.com/gnolang/gno/tm2/pkg/p2p/dial"
+	"github.com/gnolang/gno/tm2/pkg/p2p/events"
+	"github.com/gnolang/gno/tm2/pkg/p2p/manager"
 	"github.com/gnolang/gno/tm2/pkg/p2p/mock"
 	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/gnolang/gno/tm2/pkg/versionset"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -235,6 +241,177 @@ func TestMultiplexSwitch_StopPeer(t *testing.T) {
 		// Make sure the peer is in the dial queue
 		sw.dialQueue.Has(p.SocketAddr())
 	})
+
+	t.Run("misbehaving peer banned", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			p  = mock.GeneratePeers(t, 1)[0]
+			sw = NewMultiplexSwitch(
+				&mockTransport{},
+				WithPeerManager(manager.New(manager.WithBanThreshold(2))),
+			)
+		)
+
+		// Create a new peer set
+		sw.peers = newSet()
+
+		// Report the peer misbehavior, without reaching the threshold
+		sw.peers.Add(p)
+		sw.ReportMisbehavior(p, errors.New("invalid message"))
+
+		assert.False(t, sw.peers.Has(p.ID()))
+		assert.False(t, sw.peerManager.IsBanned(p.ID(), nil))
+
+		// Report the peer misbehavior again, reaching the threshold
+		sw.peers.Add(p)
+		sw.ReportMisbehavior(p, errors.New("invalid message"))
+
+		assert.False(t, sw.peers.Has(p.ID()))
+		assert.True(t, sw.peerManager.IsBanned(p.ID(), nil))
+	})
+
+	t.Run("persistent peer not banned", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			p  = mock.GeneratePeers(t, 1)[0]
+			sw = NewMultiplexSwitch(
+				&mockTransport{},
+				WithPeerManager(manager.New(manager.WithBanThreshold(1))),
+			)
+		)
+
+		// Make sure the peer is persistent
+		p.IsPersistentFn = func() bool {
+			return true
+		}
+
+		// Create a new peer set
+		sw.peers = newSet()
+		sw.peers.Add(p)
+
+		sw.ReportMisbehavior(p, errors.New("invalid message"))
+
+		assert.False(t, sw.peerManager.IsBanned(p.ID(), nil))
+	})
+
+	t.Run("connection error not penalized", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			p  = mock.GeneratePeers(t, 1)[0]
+			sw = NewMultiplexSwitch(
+				&mockTransport{},
+				WithPeerManager(manager.New(manager.WithBanThreshold(1))),
+			)
+		)
+
+		// Create a new peer set
+		sw.peers = newSet()
+		sw.peers.Add(p)
+
+		sw.peerBehavior.HandlePeerError(p, errors.New("connection reset"))
+
+		assert.False(t, sw.peers.Has(p.ID()))
+		assert.False(t, sw.peerManager.IsBanned(p.ID(), nil))
+	})
+
+	t.Run("stopped peer not penalized", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			p  = mock.GeneratePeers(t, 1)[0]
+			sw = NewMultiplexSwitch(
+				&mockTransport{},
+				WithPeerManager(manager.New(manager.WithBanThreshold(1))),
+			)
+		)
+
+		// Create a new peer set
+		sw.peers = newSet()
+		sw.peers.Add(p)
+
+		// Errors that are not protocol violations, like timeouts,
+		// only disconnect the peer
+		sw.StopPeerForError(p, errors.New("timed out"))
+
+		assert.False(t, sw.peers.Has(p.ID()))
+		assert.False(t, sw.peerManager.IsBanned(p.ID(), nil))
+	})
+}
+
+func TestMultiplexSwitch_Bans(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ban peer ID", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			peers = mock.GeneratePeers(t, 2)
+			sw    = NewMultiplexSwitch(&mockTransport{})
+		)
+
+		// Create a new peer set
+		sw.peers = newSet()
+
+		for _, p := range peers {
+			sw.peers.Add(p)
+		}
+
+		require.NoError(t, sw.BanPeerID(peers[0].ID(), time.Hour))
+
+		// Make sure only the banned peer is disconnected
+		assert.False(t, sw.peers.Has(peers[0].ID()))
+		assert.True(t, sw.peers.Has(peers[1].ID()))
+
+		bans := sw.BannedPeers()
+		require.Len(t, bans, 1)
+
+		assert.Equal(t, peers[0].ID(), bans[0].ID)
+
+		// Lift the ban
+		require.NoError(t, sw.UnbanPeerID(peers[0].ID()))
+
+		assert.Empty(t, sw.BannedPeers())
+	})
+
+	t.Run("ban peer IP", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			bannedIP = net.ParseIP("198.51.100.7")
+
+			peers = mock.GeneratePeers(t, 2)
+			sw    = NewMultiplexSwitch(&mockTransport{})
+		)
+
+		peers[0].RemoteIPFn = func() net.IP {
+			return bannedIP
+		}
+
+		peers[1].RemoteIPFn = func() net.IP {
+			return net.ParseIP("198.51.100.8")
+		}
+
+		// Create a new peer set
+		sw.peers = newSet()
+
+		for _, p := range peers {
+			sw.peers.Add(p)
+		}
+
+		require.NoError(t, sw.BanPeerIP(bannedIP, time.Hour))
+
+		// Make sure only the banned peer is disconnected
+		assert.False(t, sw.peers.Has(peers[0].ID()))
+		assert.True(t, sw.peers.Has(peers[1].ID()))
+
+		// Lift the ban
+		require.NoError(t, sw.UnbanPeerIP(bannedIP))
+
+		assert.Empty(t, sw.BannedPeers())
+	})
 }
 
 func TestMultiplexSwitch_DialLoop(t *testing.T) {
@@ -481,6 +658,61 @@ func TestMultiplexSwitch_AcceptLoop(t *testing.T) {
 		assert.True(t, peerRemoved)
 	})
 
+	t.Run("peer banned", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancelFn := context.WithTimeout(
+			context.Background(),
+			5*time.Second,
+		)
+		defer cancelFn()
+
+		var (
+			ch = make(chan struct{}, 1)
+
+			peerRemoved bool
+
+			p = mock.GeneratePeers(t, 1)[0]
+
+			mockTransport = &mockTransport{
+				acceptFn: func(_ context.Context, _ PeerBehavior) (PeerConn, error) {
+					return p, nil
+				},
+				removeFn: func(removedPeer PeerConn) {
+					require.Equal(t, p.ID(), removedPeer.ID())
+
+					peerRemoved = true
+
+					ch <- struct{}{}
+				},
+			}
+
+			ps = &mockSet{
+				addFn: func(_ PeerConn) {
+					t.Fatal("banned peer added")
+				},
+			}
+
+			sw = NewMultiplexSwitch(mockTransport)
+		)
+
+		// Ban the peer
+		require.NoError(t, sw.peerManager.BanID(p.ID(), time.Hour))
+
+		// Set the peer set
+		sw.peers = ps
+
+		// Run the accept loop
+		go sw.runAcceptLoop(ctx)
+
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+		}
+
+		assert.True(t, peerRemoved)
+	})
+
 	t.Run("peer accepted", func(t *testing.T) {
 		t.Parallel()
 
@@ -921,3 +1153,143 @@ func TestSwitchAcceptLoopTransportClosed(t *testing.T) {
 		assert.True(t, transportClosed)
 	}
 }
+
+// channelReactor is a reactor that forwards
+// received channel messages to a Go channel
+type channelReactor struct {
+	BaseReactor
+
+	chID       byte
+	receivedCh chan []byte
+}
+
+func newChannelReactor(chID byte) *channelReactor {
+	r := &channelReactor{
+		chID:       chID,
+		receivedCh: make(chan []byte, 1),
+	}
+
+	r.BaseReactor = *NewBaseReactor("channelReactor", r)
+
+	return r
+}
+
+func (r *channelReactor) GetChannels() []*conn.ChannelDescriptor {
+	return []*conn.ChannelDescriptor{
+		{
+			ID:       r.chID,
+			Priority: 1,
+		},
+	}
+}
+
+func (r *channelReactor) Receive(_ byte, _ PeerConn, msgBytes []byte) {
+	r.receivedCh <- msgBytes
+}
+
+func TestMultiplexSwitch_Transports(t *testing.T) {
+	t.Parallel()
+
+	const chID = byte(42)
+
+	testTable := []struct {
+		name        string
+		transportFn func(types.NodeInfo, types.NodeKey) (NetworkTransport, error)
+	}{
+		{
+			"tcp transport",
+			func(ni types.NodeInfo, nk types.NodeKey) (NetworkTransport, error) {
+				return NewMultiplexTransport(ni, nk, conn.DefaultMConnConfig(), log.NewNoopLogger()), nil
+			},
+		},
+		{
+			"quic transport",
+			func(ni types.NodeInfo, nk types.NodeKey) (NetworkTransport, error) {
+				return NewQUICTransport(ni, nk, conn.DefaultMConnConfig(), log.NewNoopLogger())
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				switches = make([]*MultiplexSwitch, 0, 2)
+				reactors = make([]*channelReactor, 0, 2)
+			)
+
+			for index := 0; index < 2; index++ {
+				key := types.GenerateNodeKey()
+
+				addr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
+				require.NoError(t, err)
+
+				na, err := types.NewNetAddress(key.ID(), addr)
+				require.NoError(t, err)
+
+				ni := types.NodeInfo{
+					Network:    "dev",
+					NetAddress: na,
+					Version:    "v1.0.0-rc.0",
+					Moniker:    fmt.Sprintf("node-%d", index),
+					VersionSet: make(versionset.VersionSet, 0),
+					Channels:   []byte{chID},
+				}
+
+				transport, err := testCase.transportFn(ni, *key)
+				require.NoError(t, err)
+
+				require.NoError(t, transport.Listen(*na))
+				t.Cleanup(func() {
+					assert.NoError(t, transport.Close())
+				})
+
+				reactor := newChannelReactor(chID)
+
+				sw := NewMultiplexSwitch(transport, WithReactor("channel", reactor))
+
+				require.NoError(t, sw.Start())
+				t.Cleanup(func() {
+					assert.NoError(t, sw.Stop())
+				})
+
+				switches = append(switches, sw)
+				reactors = append(reactors, reactor)
+			}
+
+			// Wait for the peers to connect
+			ch, unsubFn := switches[1].Subscribe(func(event events.Event) bool {
+				return event.Type() == events.PeerConnected
+			})
+			defer unsubFn()
+
+			addr := switches[1].transport.NetAddress()
+			switches[0].DialPeers(&addr)
+
+			select {
+			case <-ch:
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for peers to connect")
+			}
+
+			require.Eventually(t, func() bool {
+				return switches[0].Peers().NumOutbound() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			// Exchange messages on the reactor channel, both ways
+			for index, sw := range switches {
+				data := []byte(fmt.Sprintf("message from node-%d", index))
+
+				sw.Broadcast(chID, data)
+
+				select {
+				case received := <-reactors[1-index].receivedCh:
+					assert.Equal(t, data, received)
+				case <-time.After(5 * time.Second):
+					t.Fatal("timed out waiting for message")
+				}
+			}
+		})
+	}
+}
@@ -302,27 +302,10 @@ func (mt *MultiplexTransport) upgradeAndVerifyConn(c net.Conn) (secretConn, type
 		return nil, types.NodeInfo{}, fmt.Errorf("unable to upgrade p2p connection, %w", err)
 	}
 
-	// Exchange node information
-	nodeInfo, err := exchangeNodeInfo(sc, defaultHandshakeTimeout, mt.nodeInfo)
+	// Exchange and verify the node information
+	nodeInfo, err := verifyNodeInfo(sc, mt.nodeInfo)
 	if err != nil {
-		return nil, types.NodeInfo{}, fmt.Errorf("unable to exchange node information, %w", err)
-	}
-
-	// Ensure the connection ID matches the node's reported ID
-	connID := sc.RemotePubKey().Address().ID()
-
-	if connID != nodeInfo.ID() {
-		return nil, types.NodeInfo{}, fmt.Errorf(
-			"%w (expected %q got %q)",
-			errPeerIDNodeInfoMismatch,
-			connID.String(),
-			nodeInfo.ID().String(),
-		)
-	}
-
-	// Check compatibility with the node
-	if err = mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
-		return nil, types.NodeInfo{}, fmt.Errorf("%w, %w", errIncompatibleNodeInfo, err)
+		return nil, types.NodeInfo{}, err
 	}
 
 	return sc, nodeInfo, nil
@@ -335,6 +318,30 @@ func (mt *MultiplexTransport) newMultiplexPeer(
 	behavior PeerBehavior,
 	isOutbound bool,
 ) (PeerConn, error) {
+	// Wrap the info related to the connection
+	peerConn, err := newConnInfo(info, behavior, isOutbound)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the info related to the multiplex connection
+	mConfig := &ConnConfig{
+		MConfig:      mt.mConfig,
+		ReactorsByCh: behavior.Reactors(),
+		ChDescs:      behavior.ReactorChDescriptors(),
+		OnPeerError:  behavior.HandlePeerError,
+	}
+
+	return newPeer(peerConn, info.nodeInfo, mConfig), nil
+}
+
+// newConnInfo wraps the verified peer connection info,
+// using the provided Peer behavior
+func newConnInfo(
+	info peerInfo,
+	behavior PeerBehavior,
+	isOutbound bool,
+) (*ConnInfo, error) {
 	// Extract the host
 	host, _, err := net.SplitHostPort(info.conn.RemoteAddr().String())
 	if err != nil {
@@ -347,25 +354,43 @@ func (mt *MultiplexTransport) newMultiplexPeer(
 		return nil, fmt.Errorf("unable to lookup peer IPs, %w", err)
 	}
 
-	// Wrap the info related to the connection
-	peerConn := &ConnInfo{
+	return &ConnInfo{
 		Outbound:   isOutbound,
 		Persistent: behavior.IsPersistentPeer(info.addr.ID),
 		Private:    behavior.IsPrivatePeer(info.nodeInfo.ID()),
 		Conn:       info.conn,
 		RemoteIP:   ips[0], // IPv4
 		SocketAddr: info.addr,
+	}, nil
+}
+
+// verifyNodeInfo exchanges node information with the peer
+// over the authenticated connection, and verifies that the peer is valid
+func verifyNodeInfo(sc secretConn, ourNodeInfo types.NodeInfo) (types.NodeInfo, error) {
+	// Exchange node information
+	nodeInfo, err := exchangeNodeInfo(sc, defaultHandshakeTimeout, ourNodeInfo)
+	if err != nil {
+		return types.NodeInfo{}, fmt.Errorf("unable to exchange node information, %w", err)
 	}
 
-	// Create the info related to the multiplex connection
-	mConfig := &ConnConfig{
-		MConfig:      mt.mConfig,
-		ReactorsByCh: behavior.Reactors(),
-		ChDescs:      behavior.ReactorChDescriptors(),
-		OnPeerError:  behavior.HandlePeerError,
+	// Ensure the connection ID matches the node's reported ID
+	connID := sc.RemotePubKey().Address().ID()
+
+	if connID != nodeInfo.ID() {
+		return types.NodeInfo{}, fmt.Errorf(
+			"%w (expected %q got %q)",
+			errPeerIDNodeInfoMismatch,
+			connID.String(),
+			nodeInfo.ID().String(),
+		)
 	}
 
-	return newPeer(peerConn, info.nodeInfo, mConfig), nil
+	// Check compatibility with the node
+	if err = ourNodeInfo.CompatibleWith(nodeInfo); err != nil {
+		return types.NodeInfo{}, fmt.Errorf("%w, %w", errIncompatibleNodeInfo, err)
+	}
+
+	return nodeInfo, nil
 }
 
 // exchangeNodeInfo performs a data swap, where node
@@ -0,0 +1,553 @@
+package p2p
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	goerrors "errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
+	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/quic-go/quic-go"
+)
+
+const (
+	// quicALPN is the application protocol negotiated for p2p QUIC connections
+	quicALPN = "tm2-p2p"
+
+	// quicKeepAlivePeriod is the period in which QUIC keep-alive packets are sent
+	quicKeepAlivePeriod = 15 * time.Second
+
+	// quicMaxIdleTimeout is the maximum time a QUIC connection can be inactive
+	quicMaxIdleTimeout = 45 * time.Second
+
+	// quicCertValidity is the validity period of the self-signed node certificate
+	quicCertValidity = 10 * 365 * 24 * time.Hour
+
+	// quicRejectLinger is the maximum time a rejected connection is kept open,
+	// so the peer can receive the already sent node info, and reach the same verdict
+	quicRejectLinger = 500 * time.Millisecond
+)
+
+var (
+	errUnsupportedNodeKey   = errors.New("node key is not an ed25519 key")
+	errInvalidPeerCert      = errors.New("invalid peer certificate")
+	errMissingPeerCert      = errors.New("missing peer certificate")
+	errUnsupportedPeerCert  = errors.New("peer certificate key is not an ed25519 key")
+	errHandshakeStreamStale = errors.New("handshake stream not opened in time")
+)
+
+// QUICTransport accepts and dials QUIC connections and upgrades them to
+// multiplexed peers, where every channel is carried by its own QUIC stream.
+//
+// Peers are authenticated during the TLS 1.3 handshake, using self-signed
+// certificates derived from the node keys, so peer IDs are the same as the
+// ones used by the MultiplexTransport
+type QUICTransport struct {
+	ctx      context.Context
+	cancelFn context.CancelFunc
+
+	logger *slog.Logger
+
+	netAddr  types.NetAddress // the node's P2P dial address, used for handshaking
+	nodeInfo types.NodeInfo   // the node's P2P info, used for handshaking
+	nodeKey  types.NodeKey    // the node's private P2P key, used for handshaking
+
+	tlsConfig  *tls.Config  // the TLS configuration, based on the node key
+	quicConfig *quic.Config // the QUIC connection configuration
+
+	transport   *quic.Transport // the shared UDP socket, for listening and dialing
+	listener    *quic.Listener  // listener for inbound peer connections
+	peerCh      chan peerInfo   // pipe for inbound peer connections
+	activeConns sync.Map        // active peer connections (remote address -> nothing)
+
+	mConfig conn.MConnConfig
+}
+
+// NewQUICTransport returns a QUIC connected multiplexed peer transport.
+// The node key needs to be an ed25519 key
+func NewQUICTransport(
+	nodeInfo types.NodeInfo,
+	nodeKey types.NodeKey,
+	mConfig conn.MConnConfig,
+	logger *slog.Logger,
+) (*QUICTransport, error) {
+	tlsConfig, err := newQUICTLSConfig(nodeKey.PrivKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create TLS config, %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &QUICTransport{
+		ctx:       ctx,
+		cancelFn:  cancel,
+		peerCh:    make(chan peerInfo, 1),
+		mConfig:   mConfig,
+		nodeInfo:  nodeInfo,
+		nodeKey:   nodeKey,
+		logger:    logger,
+		tlsConfig: tlsConfig,
+		quicConfig: &quic.Config{
+			HandshakeIdleTimeout: defaultHandshakeTimeout,
+			MaxIdleTimeout:       quicMaxIdleTimeout,
+			KeepAlivePeriod:      quicKeepAlivePeriod,
+		},
+	}, nil
+}
+
+// NetAddress returns the transport's listen address (for p2p connections)
+func (qt *QUICTransport) NetAddress() types.NetAddress {
+	return qt.netAddr
+}
+
+// Accept waits for a verified inbound Peer to connect, and returns it [BLOCKING]
+func (qt *QUICTransport) Accept(ctx context.Context, behavior PeerBehavior) (PeerConn, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case info, ok := <-qt.peerCh:
+		if !ok {
+			return nil, errTransportClosed
+		}
+
+		return qt.newQUICPeer(info, behavior, false)
+	}
+}
+
+// Dial creates an outbound Peer connection, and
+// verifies it (performs handshaking) [BLOCKING]
+func (qt *QUICTransport) Dial(
+	ctx context.Context,
+	addr types.NetAddress,
+	behavior PeerBehavior,
+) (PeerConn, error) {
+	qc, err := qt.dial(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial address, %w", err)
+	}
+
+	// The dialer opens the handshake stream
+	stream, err := qc.OpenStreamSync(ctx)
+	if err != nil {
+		_ = qc.CloseWithError(conn.QUICErrorCodeNone, "")
+
+		return nil, fmt.Errorf("unable to open handshake stream, %w", err)
+	}
+
+	// Process the connection with expected ID
+	info, err := qt.processConn(qc, stream, addr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to process connection, %w", err)
+	}
+
+	return qt.newQUICPeer(info, behavior, true)
+}
+
+// dial establishes a QUIC connection with the given address.
+// If the transport is listening, the listen socket is reused for dialing
+func (qt *QUICTransport) dial(ctx context.Context, addr types.NetAddress) (quic.Connection, error) {
+	if qt.transport == nil {
+		return quic.DialAddr(ctx, addr.DialString(), qt.tlsConfig, qt.quicConfig)
+	}
+
+	udpAddr, err := net.ResolveUDPAddr("udp", addr.DialString())
+	if err != nil {
+		return nil, err
+	}
+
+	return qt.transport.Dial(ctx, udpAddr, qt.tlsConfig, qt.quicConfig)
+}
+
+// Close stops the QUIC transport
+func (qt *QUICTransport) Close() error {
+	if qt.transport == nil {
+		return nil
+	}
+
+	qt.cancelFn()
+
+	return qt.transport.Close()
+}
+
+// Listen starts an active process of listening for incoming connections [NON-BLOCKING]
+func (qt *QUICTransport) Listen(addr types.NetAddress) error {
+	// Reserve a port, and start listening
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: addr.IP, Port: int(addr.Port)})
+	if err != nil {
+		return fmt.Errorf("unable to listen on address, %w", err)
+	}
+
+	if addr.Port == 0 {
+		// net.ListenUDP on port 0 means the kernel will auto-allocate a port
+		// - find out which one has been given to us.
+		udpAddr, ok := udpConn.LocalAddr().(*net.UDPAddr)
+		if !ok {
+			udpConn.Close()
+
+			return errors.New("error finding port (after listening on port 0)")
+		}
+
+		addr.Port = uint16(udpAddr.Port)
+	}
+
+	transport := &quic.Transport{Conn: udpConn}
+
+	ln, err := transport.Listen(qt.tlsConfig, qt.quicConfig)
+	if err != nil {
+		_ = transport.Close()
+
+		return fmt.Errorf("unable to listen for QUIC connections, %w", err)
+	}
+
+	qt.netAddr = addr
+	qt.transport = transport
+	qt.listener = ln
+
+	// Run the routine for accepting
+	// incoming peer connections
+	go qt.runAcceptLoop(qt.ctx)
+
+	return nil
+}
+
+// runAcceptLoop runs the loop where incoming peers are:
+//
+// 1. accepted by the transport
+// 2. filtered
+// 3. upgraded (handshaked + verified)
+func (qt *QUICTransport) runAcceptLoop(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait() // Wait for all process routines
+		close(qt.peerCh)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // cancel sub-connection process
+
+	for {
+		// Accept an incoming peer connection
+		qc, err := qt.listener.Accept(ctx)
+
+		switch {
+		case err == nil: // ok
+		case goerrors.Is(err, quic.ErrServerClosed), ctx.Err() != nil:
+			// Listener has been closed, this is not recoverable.
+			qt.logger.Debug("listener has been closed")
+			return // exit
+		default:
+			// An error occurred during accept, report and continue
+			qt.logger.Warn("accept p2p connection error", "err", err)
+			continue
+		}
+
+		// Process the new connection asynchronously
+		wg.Add(1)
+
+		go func(qc quic.Connection) {
+			defer wg.Done()
+
+			info, err := qt.acceptConn(ctx, qc)
+			if err != nil {
+				qt.logger.Error(
+					"unable to process p2p connection",
+					"err", err,
+				)
+
+				return
+			}
+
+			select {
+			case qt.peerCh <- info:
+			case <-ctx.Done():
+				// Give up if the transport was closed.
+				_ = qc.CloseWithError(conn.QUICErrorCodeNone, "")
+			}
+		}(qc)
+	}
+}
+
+// acceptConn waits for the dialer to open the handshake stream,
+// and processes the inbound connection
+func (qt *QUICTransport) acceptConn(ctx context.Context, qc quic.Connection) (peerInfo, error) {
+	streamCtx, cancelFn := context.WithTimeout(ctx, defaultHandshakeTimeout)
+	defer cancelFn()
+
+	stream, err := qc.AcceptStream(streamCtx)
+	if err != nil {
+		_ = qc.CloseWithError(conn.QUICErrorCodeNone, "")
+
+		return peerInfo{}, fmt.Errorf("%w, %w", errHandshakeStreamStale, err)
+	}
+
+	return qt.processConn(qc, stream, "")
+}
+
+// processConn verifies the authenticated QUIC connection
+// and exchanges node information over the handshake stream.
+// Connections that fail verification are closed
+func (qt *QUICTransport) processConn(
+	qc quic.Connection,
+	stream quic.Stream,
+	expectedID types.ID,
+) (peerInfo, error) {
+	info, err := qt.verifyConn(qc, stream, expectedID)
+	if err != nil {
+		// Give the peer a chance to read the node info before closing,
+		// so it can reject the connection with the proper reason
+		_ = stream.Close()
+
+		select {
+		case <-qc.Context().Done():
+		case <-time.After(quicRejectLinger):
+		}
+
+		_ = qc.CloseWithError(conn.QUICErrorCodeNone, "")
+
+		return peerInfo{}, err
+	}
+
+	return info, nil
+}
+
+// verifyConn verifies the QUIC connection, and the peer node info
+func (qt *QUICTransport) verifyConn(
+	qc quic.Connection,
+	stream quic.Stream,
+	expectedID types.ID,
+) (peerInfo, error) {
+	dialAddr := qc.RemoteAddr().String()
+
+	// Check if the connection is a duplicate one
+	if _, exists := qt.activeConns.LoadOrStore(dialAddr, struct{}{}); exists {
+		return peerInfo{}, errDuplicateConnection
+	}
+
+	// Grab the peer's key, verified in the TLS handshake
+	pubKey, err := quicRemotePubKey(qc.ConnectionState().TLS)
+	if err != nil {
+		qt.activeConns.Delete(dialAddr)
+
+		return peerInfo{}, fmt.Errorf("unable to verify connection, %w", err)
+	}
+
+	c := &quicConn{
+		Stream: stream,
+		conn:   qc,
+		pubKey: pubKey,
+	}
+
+	nodeInfo, err := verifyNodeInfo(c, qt.nodeInfo)
+	if err != nil {
+		qt.activeConns.Delete(dialAddr)
+
+		return peerInfo{}, fmt.Errorf("unable to verify connection, %w", err)
+	}
+
+	// Verify the dial ID, for outbound peers
+	id := pubKey.Address().ID()
+
+	if !expectedID.IsZero() && id.String() != expectedID.String() {
+		qt.activeConns.Delete(dialAddr)
+
+		return peerInfo{}, fmt.Errorf(
+			"%w (expected %q got %q)",
+			errPeerIDDialMismatch,
+			expectedID,
+			id,
+		)
+	}
+
+	netAddr, _ := types.NewNetAddress(id, c.tcpAddr())
+
+	return peerInfo{
+		addr:     netAddr,
+		conn:     c,
+		nodeInfo: nodeInfo,
+	}, nil
+}
+
+// Remove removes the peer resources from the transport
+func (qt *QUICTransport) Remove(p PeerConn) {
+	qt.activeConns.Delete(p.RemoteAddr().String())
+}
+
+// newQUICPeer creates a new QUIC multiplexed Peer, using
+// the provided Peer behavior and info
+func (qt *QUICTransport) newQUICPeer(
+	info peerInfo,
+	behavior PeerBehavior,
+	isOutbound bool,
+) (PeerConn, error) {
+	// Wrap the info related to the connection
+	peerConn, err := newConnInfo(info, behavior, isOutbound)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the info related to the multiplex connection
+	mConfig := &ConnConfig{
+		MConfig:      qt.mConfig,
+		ReactorsByCh: behavior.Reactors(),
+		ChDescs:      behavior.ReactorChDescriptors(),
+		OnPeerError:  behavior.HandlePeerError,
+	}
+
+	qc := info.conn.(*quicConn).conn
+
+	mConnFn := func(onReceive func(byte, []byte), onError func(error)) multiplexConn {
+		return conn.NewQUICConnection(
+			qc,
+			mConfig.ChDescs,
+			onReceive,
+			onError,
+			mConfig.MConfig,
+		)
+	}
+
+	return newPeerWithConn(peerConn, info.nodeInfo, mConfig, mConnFn), nil
+}
+
+// quicConn wraps an authenticated QUIC connection,
+// and its handshake stream
+type quicConn struct {
+	quic.Stream
+
+	conn   quic.Connection
+	pubKey crypto.PubKey
+}
+
+// LocalAddr returns the local address of the QUIC connection
+func (c *quicConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// RemoteAddr returns the remote address of the QUIC connection
+func (c *quicConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// RemotePubKey returns the peer's public key, verified in the TLS handshake
+func (c *quicConn) RemotePubKey() crypto.PubKey {
+	return c.pubKey
+}
+
+// Close closes the entire QUIC connection
+func (c *quicConn) Close() error {
+	return c.conn.CloseWithError(conn.QUICErrorCodeNone, "")
+}
+
+// tcpAddr returns the remote address as a TCP address,
+// for compatibility with the p2p net addresses
+func (c *quicConn) tcpAddr() *net.TCPAddr {
+	udpAddr, ok := c.conn.RemoteAddr().(*net.UDPAddr)
+	if !ok {
+		return &net.TCPAddr{}
+	}
+
+	return &net.TCPAddr{
+		IP:   udpAddr.IP,
+		Port: udpAddr.Port,
+		Zone: udpAddr.Zone,
+	}
+}
+
+// newQUICTLSConfig creates the TLS configuration for QUIC connections,
+// based on a self-signed certificate derived from the node key
+func newQUICTLSConfig(privKey crypto.PrivKey) (*tls.Config, error) {
+	key, ok := privKey.(ed25519.PrivKeyEd25519)
+	if !ok {
+		return nil, errUnsupportedNodeKey
+	}
+
+	var (
+		signer = stded25519.PrivateKey(key[:])
+		now    = time.Now()
+	)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano()),
+		NotBefore:    now.Add(-time.Hour), // account for clock drift
+		NotAfter:     now.Add(quicCertValidity),
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create certificate, %w", err)
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		NextProtos: []string{quicALPN},
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{certDER},
+				PrivateKey:  signer,
+			},
+		},
+		// Peers are not authenticated through a CA, but by their node keys.
+		// The certificate chain is verified in verifyQUICCertificate
+		ClientAuth:            tls.RequireAnyClientCert,
+		InsecureSkipVerify:    true, //nolint:gosec // see above
+		VerifyPeerCertificate: verifyQUICCertificate,
+	}, nil
+}
+
+// verifyQUICCertificate verifies the peer presented a single,
+// valid self-signed certificate for an ed25519 node key.
+// Ownership of the key is proven by the TLS 1.3 handshake itself
+func verifyQUICCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("%w: expected 1 certificate, got %d", errInvalidPeerCert, len(rawCerts))
+	}
+
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidPeerCert, err)
+	}
+
+	if _, ok := cert.PublicKey.(stded25519.PublicKey); !ok {
+		return errUnsupportedPeerCert
+	}
+
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return fmt.Errorf("%w: certificate expired or not yet valid", errInvalidPeerCert)
+	}
+
+	if err = cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidPeerCert, err)
+	}
+
+	return nil
+}
+
+// quicRemotePubKey extracts the peer's node key from the TLS connection state
+func quicRemotePubKey(state tls.ConnectionState) (crypto.PubKey, error) {
+	if len(state.PeerCertificates) == 0 {
+		return nil, errMissingPeerCert
+	}
+
+	key, ok := state.PeerCertificates[0].PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return nil, errUnsupportedPeerCert
+	}
+
+	var pubKey ed25519.PubKeyEd25519
+
+	copy(pubKey[:], key)
+
+	return pubKey, nil
+}
@@ -0,0 +1,289 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
+	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/gnolang/gno/tm2/pkg/versionset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQUICTestTransports creates and starts QUIC transports on random local ports.
+// The node info of each transport can be modified using the given callback
+func newQUICTestTransports(
+	t *testing.T,
+	count int,
+	modifyFn func(index int, ni *types.NodeInfo),
+) []*QUICTransport {
+	t.Helper()
+
+	transports := make([]*QUICTransport, 0, count)
+
+	for index := 0; index < count; index++ {
+		key := types.GenerateNodeKey()
+
+		addr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		na, err := types.NewNetAddress(key.ID(), addr)
+		require.NoError(t, err)
+
+		ni := types.NodeInfo{
+			Network:    "dev", // common network
+			NetAddress: na,
+			Version:    "v1.0.0-rc.0",
+			Moniker:    fmt.Sprintf("node-%d", index),
+			VersionSet: make(versionset.VersionSet, 0), // compatible version set
+			Channels:   []byte{42},                     // common channel
+		}
+
+		if modifyFn != nil {
+			modifyFn(index, &ni)
+		}
+
+		// Create a fresh transport
+		tr, err := NewQUICTransport(ni, *key, conn.DefaultMConnConfig(), log.NewNoopLogger())
+		require.NoError(t, err)
+
+		// Start the transport
+		require.NoError(t, tr.Listen(*na))
+
+		t.Cleanup(func() {
+			assert.NoError(t, tr.Close())
+		})
+
+		transports = append(transports, tr)
+	}
+
+	return transports
+}
+
+// newQUICTestBehavior creates a peer behavior with no reactors
+func newQUICTestBehavior() *reactorPeerBehavior {
+	return &reactorPeerBehavior{
+		chDescs:            make([]*conn.ChannelDescriptor, 0),
+		reactorsByCh:       make(map[byte]Reactor),
+		handlePeerErrFn:    func(_ PeerConn, _ error) {},
+		isPersistentPeerFn: func(_ types.ID) bool { return false },
+		isPrivatePeerFn:    func(_ types.ID) bool { return false },
+	}
+}
+
+func TestQUICTransport_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unsupported node key", func(t *testing.T) {
+		t.Parallel()
+
+		nk := types.NodeKey{
+			PrivKey: secp256k1.GenPrivKey(),
+		}
+
+		tr, err := NewQUICTransport(types.NodeInfo{}, nk, conn.DefaultMConnConfig(), log.NewNoopLogger())
+
+		assert.Nil(t, tr)
+		assert.ErrorIs(t, err, errUnsupportedNodeKey)
+	})
+
+	t.Run("valid node key", func(t *testing.T) {
+		t.Parallel()
+
+		tr, err := NewQUICTransport(
+			types.NodeInfo{},
+			*types.GenerateNodeKey(),
+			conn.DefaultMConnConfig(),
+			log.NewNoopLogger(),
+		)
+		require.NoError(t, err)
+
+		// Inactive transport
+		addr := tr.NetAddress()
+		assert.Error(t, addr.Validate())
+		assert.NoError(t, tr.Close())
+	})
+}
+
+func TestQUICTransport_NetAddress(t *testing.T) {
+	t.Parallel()
+
+	tr := newQUICTestTransports(t, 1, nil)[0]
+
+	netAddr := tr.NetAddress()
+	assert.NotZero(t, netAddr.Port)
+	assert.NoError(t, netAddr.Validate())
+}
+
+func TestQUICTransport_Accept(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transport closed", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newQUICTestTransports(t, 1, nil)[0]
+
+		// Stop the transport
+		require.NoError(t, tr.Close())
+
+		p, err := tr.Accept(context.Background(), nil)
+
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, errTransportClosed)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		t.Parallel()
+
+		tr := newQUICTestTransports(t, 1, nil)[0]
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		cancelFn()
+
+		p, err := tr.Accept(ctx, nil)
+
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("peer ID mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		peers := newQUICTestTransports(t, 2, func(_ int, ni *types.NodeInfo) {
+			// Hijack the key value
+			ni.NetAddress.ID = types.GenerateNodeKey().ID()
+		})
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		p, err := peers[0].Dial(
+			ctx,
+			types.NetAddress{
+				ID:   "", // the dialed ID is not verified
+				IP:   peers[1].netAddr.IP,
+				Port: peers[1].netAddr.Port,
+			},
+			newQUICTestBehavior(),
+		)
+		assert.ErrorIs(t, err, errPeerIDNodeInfoMismatch)
+		assert.Nil(t, p)
+	})
+
+	t.Run("incompatible peers", func(t *testing.T) {
+		t.Parallel()
+
+		peers := newQUICTestTransports(t, 2, func(index int, ni *types.NodeInfo) {
+			if index%2 == 0 {
+				ni.Network = "totally-random-network"
+			}
+		})
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		p, err := peers[0].Dial(ctx, peers[1].netAddr, newQUICTestBehavior())
+		assert.ErrorIs(t, err, errIncompatibleNodeInfo)
+		assert.Nil(t, p)
+	})
+
+	t.Run("dialed peer ID mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		peers := newQUICTestTransports(t, 2, nil)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		p, err := peers[0].Dial(
+			ctx,
+			types.NetAddress{
+				ID:   types.GenerateNodeKey().ID(), // mismatched ID
+				IP:   peers[1].netAddr.IP,
+				Port: peers[1].netAddr.Port,
+			},
+			newQUICTestBehavior(),
+		)
+		assert.ErrorIs(t, err, errPeerIDDialMismatch)
+		assert.Nil(t, p)
+	})
+
+	t.Run("valid peer accepted", func(t *testing.T) {
+		t.Parallel()
+
+		peers := newQUICTestTransports(t, 2, nil)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFn()
+
+		p, err := peers[0].Dial(ctx, peers[1].netAddr, newQUICTestBehavior())
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		// Make sure the new peer info is valid
+		assert.Equal(t, peers[1].netAddr.ID, p.ID())
+		assert.True(t, p.IsOutbound())
+
+		assert.Equal(t, peers[1].nodeInfo.Channels, p.NodeInfo().Channels)
+		assert.Equal(t, peers[1].nodeInfo.Moniker, p.NodeInfo().Moniker)
+		assert.Equal(t, peers[1].nodeInfo.Network, p.NodeInfo().Network)
+
+		// Make sure the dialed peer accepted the connection
+		accepted, err := peers[1].Accept(ctx, newQUICTestBehavior())
+		require.NoError(t, err)
+
+		assert.Equal(t, peers[0].netAddr.ID, accepted.ID())
+		assert.False(t, accepted.IsOutbound())
+
+		// The listen socket is reused for dialing
+		assert.Equal(t, peers[0].netAddr.Port, accepted.SocketAddr().Port)
+
+		// Attempt to dial again, expect the dial to fail
+		// because the connection is already active
+		dialedPeer, err := peers[0].Dial(ctx, peers[1].netAddr, newQUICTestBehavior())
+		require.ErrorIs(t, err, errDuplicateConnection)
+		assert.Nil(t, dialedPeer)
+
+		// Remove the peer
+		peers[0].Remove(p)
+
+		assert.NoError(t, p.CloseConn())
+	})
+}
+
+func TestQUICTransport_VerifyCertificate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no certificates", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(t, verifyQUICCertificate(nil, nil), errInvalidPeerCert)
+	})
+
+	t.Run("malformed certificate", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(
+			t,
+			verifyQUICCertificate([][]byte{[]byte("certificate")}, nil),
+			errInvalidPeerCert,
+		)
+	})
+
+	t.Run("valid node certificate", func(t *testing.T) {
+		t.Parallel()
+
+		key := types.GenerateNodeKey()
+
+		cfg, err := newQUICTLSConfig(key.PrivKey)
+		require.NoError(t, err)
+
+		assert.NoError(t, verifyQUICCertificate(cfg.Certificates[0].Certificate, nil))
+	})
+}
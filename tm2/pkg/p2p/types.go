@@ -72,6 +72,18 @@ type Transport interface {
 	Remove(PeerConn)
 }
 
+// NetworkTransport is a Transport that listens
+// for inbound peer connections on a network address
+type NetworkTransport interface {
+	Transport
+
+	// Listen starts listening for inbound connections on the given address
+	Listen(types.NetAddress) error
+
+	// Close stops the transport
+	Close() error
+}
+
 // Switch is the abstraction in the p2p module that handles
 // and manages peer connections thorough a Transport
 type Switch interface {
@@ -87,6 +99,10 @@ type Switch interface {
 	// StopPeerForError stops the peer with the given reason
 	StopPeerForError(peer PeerConn, err error)
 
+	// ReportMisbehavior stops the peer for the given protocol violation,
+	// and bans it if it misbehaves repeatedly
+	ReportMisbehavior(peer PeerConn, reason error)
+
 	// DialPeers marks the given peers as ready for async dialing
 	DialPeers(peerAddrs ...*types.NetAddress)
 }
@@ -25,3 +25,8 @@ type BeginTxHook func(ctx Context) Context
 // EndTxHook is a BaseApp-specific hook, called after all the messages in a
 // transaction have terminated.
 type EndTxHook func(ctx Context, result Result)
+
+// ParallelTxFilter reports whether a transaction may be executed speculatively,
+// in parallel with the other transactions of its block. See
+// [BaseApp.SetParallelTxs].
+type ParallelTxFilter func(tx Tx) bool
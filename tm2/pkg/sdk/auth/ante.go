@@ -33,6 +33,16 @@ type AnteOptions struct {
 	// This is useful for development, and maybe production chains.
 	// Always check your settings and inspect genesis transactions.
 	VerifyGenesisSignatures bool
+
+	// If FeeGrantKeeper is set, the fees of a tx with a fee granter are
+	// paid by the granter, provided it granted an allowance covering them
+	// to the first signer. Otherwise, such txs are rejected.
+	FeeGrantKeeper FeeGrantKeeperI
+
+	// If SessionKeeper is set, signers may sign with a session key of their
+	// account instead of its own key, provided the session allows the tx.
+	// Otherwise, only the keys of the accounts are accepted.
+	SessionKeeper SessionKeeperI
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
@@ -122,7 +132,7 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 
 		// deduct the fees
 		if !tx.Fee.GasFee.IsZero() {
-			res = DeductFees(bank, newCtx, signerAccs[0], std.Coins{tx.Fee.GasFee})
+			res = deductTxFees(newCtx, ak, bank, opts.FeeGrantKeeper, tx, signerAccs[0])
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
@@ -154,7 +164,15 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 				if err != nil {
 					return newCtx, res, true
 				}
-				signerAccs[i], res = processSig(newCtx, sacc, stdSigs[i], signBytes, simulate, params, sigGasConsumer)
+				if opts.SessionKeeper != nil && isSessionSig(sacc, stdSigs[i]) {
+					var fee std.Coins
+					if i == 0 {
+						fee = signerFee(tx)
+					}
+					signerAccs[i], res = processSessionSig(newCtx, opts.SessionKeeper, sacc, stdSigs[i], signBytes, simulate, params, sigGasConsumer, fee, tx.GetMsgs())
+				} else {
+					signerAccs[i], res = processSig(newCtx, sacc, stdSigs[i], signBytes, simulate, params, sigGasConsumer)
+				}
 				if !res.IsOK() {
 					return newCtx, res, true
 				}
@@ -240,6 +258,38 @@ func processSig(
 	return acc, res
 }
 
+// isSessionSig returns whether the signature was made by a key other than
+// the one of the account, which is then expected to be a session key.
+func isSessionSig(acc std.Account, sig std.Signature) bool {
+	return sig.PubKey != nil && sig.PubKey.Address() != acc.GetAddress()
+}
+
+// verify the signature of a session key of the account, and increment the
+// sequence. The session must allow the msgs of the tx, and the fee paid by
+// the account. The public key of the account is left unchanged.
+func processSessionSig(
+	ctx sdk.Context, sk SessionKeeperI, acc std.Account, sig std.Signature, signBytes []byte, simulate bool, params Params,
+	sigGasConsumer SignatureVerificationGasConsumer, fee std.Coins, msgs []std.Msg,
+) (updatedAcc std.Account, res sdk.Result) {
+	if err := sk.UseSession(ctx, acc.GetAddress(), sig.PubKey, fee, msgs); err != nil {
+		return nil, abciResult(err)
+	}
+
+	if res := sigGasConsumer(ctx.GasMeter(), sig.Signature, sig.PubKey, params); !res.IsOK() {
+		return nil, res
+	}
+
+	if !simulate && !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+		return nil, abciResult(std.ErrUnauthorized("session signature verification failed; verify correct account, sequence, and chain-id"))
+	}
+
+	if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
+		panic(err)
+	}
+
+	return acc, res
+}
+
 // ProcessPubKey verifies that the given account address matches that of the
 // std.Signature. In addition, it will set the public key of the account if it
 // has not been set.
@@ -303,12 +353,55 @@ func consumeMultisignatureVerificationGas(meter store.GasMeter,
 	}
 }
 
+// signerFee returns the fee paid by the first signer of the tx, which is
+// nil if it is paid by a fee granter.
+func signerFee(tx std.Tx) std.Coins {
+	if tx.Fee.GasFee.IsZero() {
+		return nil
+	}
+
+	granter, ok := tx.Fee.GetGranter()
+	if ok && granter != tx.GetSigners()[0] {
+		return nil
+	}
+
+	return std.Coins{tx.Fee.GasFee}
+}
+
+// deductTxFees deducts the fees of the tx from its fee payer, which is the
+// fee granter if any, or the first signer.
+func deductTxFees(ctx sdk.Context, ak AccountKeeper, bank BankKeeperI, fgk FeeGrantKeeperI, tx std.Tx, firstSigner std.Account) sdk.Result {
+	fees := std.Coins{tx.Fee.GasFee}
+
+	granter, ok := tx.Fee.GetGranter()
+	if !ok || granter == firstSigner.GetAddress() {
+		return DeductFees(bank, ctx, firstSigner, fees)
+	}
+
+	if fgk == nil {
+		return abciResult(std.ErrUnauthorized("fee grants are not enabled"))
+	}
+
+	err := fgk.UseGrantedFees(ctx, granter, firstSigner.GetAddress(), fees, tx.GetMsgs())
+	if err != nil {
+		return abciResult(err)
+	}
+
+	granterAcc, res := GetSignerAcc(ctx, ak, granter)
+	if !res.IsOK() {
+		return res
+	}
+
+	return DeductFees(bank, ctx, granterAcc, fees)
+}
+
 // DeductFees deducts fees from the given account.
 //
 // NOTE: We could use the CoinKeeper (in addition to the AccountKeeper, because
 // the CoinKeeper doesn't give us accounts), but it seems easier to do this.
 func DeductFees(bank BankKeeperI, ctx sdk.Context, acc std.Account, fees std.Coins) sdk.Result {
-	coins := acc.GetCoins()
+	// the locked coins of vesting accounts can't pay for fees
+	coins := std.SpendableCoins(acc, ctx.BlockTime())
 
 	if !fees.IsValid() {
 		return abciResult(std.ErrInsufficientFee(fmt.Sprintf("invalid fee amount: %s", fees)))
@@ -369,12 +462,12 @@ func EnsureSufficientMempoolFees(ctx sdk.Context, fee std.Fee) sdk.Result {
 		return sdk.Result{}
 	} else {
 		fgw := big.NewInt(fee.GasWanted)
-		fga := big.NewInt(fee.GasFee.Amount)
+		fga := fee.GasFee.Amount.BigInt()
 		fgd := fee.GasFee.Denom
 
 		for _, gp := range minGasPrices {
 			gpg := big.NewInt(gp.Gas)
-			gpa := big.NewInt(gp.Price.Amount)
+			gpa := gp.Price.Amount.BigInt()
 			gpd := gp.Price.Denom
 
 			if fgd == gpd {
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -340,14 +341,187 @@ func TestAnteHandlerFees(t *testing.T) {
 
 	collector := env.bank.(DummyBankKeeper).acck.GetAccount(ctx, FeeCollectorAddress())
 	require.Nil(t, collector)
-	require.Equal(t, env.acck.GetAccount(ctx, addr1).GetCoins().AmountOf("atom"), int64(149))
+	require.Equal(t, env.acck.GetAccount(ctx, addr1).GetCoins().AmountOf("atom").Int64(), int64(149))
 
 	acc1.SetCoins(std.NewCoins(std.NewCoin("atom", 150)))
 	env.acck.SetAccount(ctx, acc1)
 	checkValidTx(t, anteHandler, ctx, tx, false)
 
-	require.Equal(t, env.bank.(DummyBankKeeper).acck.GetAccount(ctx, FeeCollectorAddress()).GetCoins().AmountOf("atom"), int64(150))
-	require.Equal(t, env.acck.GetAccount(ctx, addr1).GetCoins().AmountOf("atom"), int64(0))
+	require.Equal(t, env.bank.(DummyBankKeeper).acck.GetAccount(ctx, FeeCollectorAddress()).GetCoins().AmountOf("atom").Int64(), int64(150))
+	require.Equal(t, env.acck.GetAccount(ctx, addr1).GetCoins().AmountOf("atom").Int64(), int64(0))
+}
+
+// dummyFeeGrantKeeper grants an allowance of limit to every grantee of
+// granter.
+type dummyFeeGrantKeeper struct {
+	granter crypto.Address
+	limit   *std.Coins
+}
+
+func (fgk dummyFeeGrantKeeper) UseGrantedFees(ctx sdk.Context, granter, grantee crypto.Address, fee std.Coins, msgs []std.Msg) error {
+	if granter != fgk.granter || !fgk.limit.IsAllGTE(fee) {
+		return std.ErrUnauthorized("no allowance")
+	}
+	*fgk.limit = fgk.limit.Sub(fee)
+	return nil
+}
+
+// Test logic around fees paid by a granter.
+func TestAnteHandlerFeeGranter(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+	_, _, granter := tu.KeyTestPubAddr()
+	_, _, other := tu.KeyTestPubAddr()
+
+	// set the accounts, the signer can't pay the fees
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	env.acck.SetAccount(ctx, acc1)
+	granterAcc := env.acck.NewAccountWithAddress(ctx, granter)
+	granterAcc.SetCoins(std.NewCoins(std.NewCoin("atom", 300)))
+	env.acck.SetAccount(ctx, granterAcc)
+
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+	fee := tu.NewTestFee().WithGranter(granter)
+	tx := tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, fee)
+
+	// fee grants are not enabled
+	anteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	limit := std.NewCoins(std.NewCoin("atom", 200))
+	opts := defaultAnteOptions()
+	opts.FeeGrantKeeper = dummyFeeGrantKeeper{granter: granter, limit: &limit}
+	anteHandler = NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, opts)
+
+	// the granter pays the fees
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.Equal(t, int64(150), env.acck.GetAccount(ctx, granter).GetCoins().AmountOf("atom").Int64())
+	require.Equal(t, int64(50), limit.AmountOf("atom").Int64())
+	require.True(t, env.acck.GetAccount(ctx, addr1).GetCoins().IsZero())
+
+	// the allowance does not cover the fees
+	seqs = []uint64{1}
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// no allowance from this granter
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, seqs, tu.NewTestFee().WithGranter(other))
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+}
+
+// Test that the locked coins of vesting accounts can't pay for fees.
+func TestAnteHandlerFeesVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "mychainid", Height: 1, Time: time.Unix(100, 0)})
+	anteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+
+	// set the vesting account, with 100atom locked until 200
+	base := std.NewBaseAccountWithAddress(addr1)
+	base.Coins = std.NewCoins(std.NewCoin("atom", 249))
+	acc1 := std.NewDelayedVestingAccount(base, std.NewCoins(std.NewCoin("atom", 100)), 200)
+	env.acck.SetAccount(ctx, acc1)
+
+	// msg and signatures
+	msg := tu.NewTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+	tx := tu.NewTestTx(t, ctx.ChainID(), []std.Msg{msg}, privs, accnums, seqs, tu.NewTestFee())
+
+	// the spendable coins can't pay the fee
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.InsufficientFundsError{})
+
+	// the coins are vested
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "mychainid", Height: 1, Time: time.Unix(200, 0)})
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.Equal(t, int64(99), env.acck.GetAccount(ctx, addr1).GetCoins().AmountOf("atom").Int64())
+}
+
+// dummySessionKeeper allows the session key to sign any tx of account,
+// as long as allow is true.
+type dummySessionKeeper struct {
+	account crypto.Address
+	key     crypto.PubKey
+	allow   *bool
+	fees    *std.Coins
+}
+
+func (sk dummySessionKeeper) UseSession(ctx sdk.Context, account crypto.Address, pubKey crypto.PubKey, fee std.Coins, msgs []std.Msg) error {
+	if account != sk.account || !pubKey.Equals(sk.key) || !*sk.allow {
+		return std.ErrUnauthorized("no session")
+	}
+	*sk.fees = sk.fees.Add(fee)
+	return nil
+}
+
+// Test logic around txs signed by a session key.
+func TestAnteHandlerSessionKey(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// keys and addresses
+	priv1, pub1, addr1 := tu.KeyTestPubAddr()
+	sessionPriv, sessionPub, _ := tu.KeyTestPubAddr()
+	otherPriv, _, _ := tu.KeyTestPubAddr()
+
+	// set the account, with its public key
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(std.NewCoins(std.NewCoin("atom", 1000)))
+	require.NoError(t, acc1.SetPubKey(pub1))
+	env.acck.SetAccount(ctx, acc1)
+
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	accnums := []uint64{0}
+	tx := tu.NewTestTx(t, ctx.ChainID(), msgs, []crypto.PrivKey{sessionPriv}, accnums, []uint64{0}, tu.NewTestFee())
+
+	// session keys are not enabled
+	anteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	allow := true
+	var fees std.Coins
+	opts := defaultAnteOptions()
+	opts.SessionKeeper = dummySessionKeeper{account: addr1, key: sessionPub, allow: &allow, fees: &fees}
+	anteHandler = NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, opts)
+
+	// the session key signs for the account, which pays the fees
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	acc1 = env.acck.GetAccount(ctx, addr1)
+	require.Equal(t, uint64(1), acc1.GetSequence())
+	require.Equal(t, pub1, acc1.GetPubKey())
+	require.Equal(t, std.NewCoins(std.NewCoin("atom", 150)), fees)
+
+	// the account key still signs
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, []crypto.PrivKey{priv1}, accnums, []uint64{1}, tu.NewTestFee())
+	checkValidTx(t, anteHandler, ctx, tx, false)
+
+	// the session does not allow the tx
+	allow = false
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, []crypto.PrivKey{sessionPriv}, accnums, []uint64{2}, tu.NewTestFee())
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// no session for this key
+	allow = true
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, []crypto.PrivKey{otherPriv}, accnums, []uint64{2}, tu.NewTestFee())
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// invalid signature of the session key
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, []crypto.PrivKey{sessionPriv}, accnums, []uint64{1}, tu.NewTestFee())
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
 }
 
 // Test logic around memo gas consumption.
@@ -474,7 +648,7 @@ func TestAnteHandlerBadSignBytes(t *testing.T) {
 	fee2 := tu.NewTestFee()
 	fee2.GasWanted += 100
 	fee3 := tu.NewTestFee()
-	fee3.GasFee.Amount += 100
+	fee3.GasFee.Amount = fee3.GasFee.Amount.Add(std.NewInt(100))
 
 	// test good tx and signBytes
 	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
@@ -794,8 +968,8 @@ func TestEnsureSufficientMempoolFees(t *testing.T) {
 	env := setupTestEnv()
 	ctx := env.ctx.WithMinGasPrices(
 		[]std.GasPrice{
-			{Gas: 100000, Price: std.Coin{Denom: "photino", Amount: 5}},
-			{Gas: 100000, Price: std.Coin{Denom: "stake", Amount: 1}},
+			{Gas: 100000, Price: std.Coin{Denom: "photino", Amount: std.NewInt(5)}},
+			{Gas: 100000, Price: std.Coin{Denom: "stake", Amount: std.NewInt(1)}},
 		},
 	)
 
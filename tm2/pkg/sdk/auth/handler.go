@@ -31,13 +31,18 @@ func (ah authHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 //----------------------------------------
 // Query
 
-// query account path
-const QueryAccount = "accounts"
+// query paths
+const (
+	QueryAccount = "accounts"
+	QueryVesting = "vesting"
+)
 
 func (ah authHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	switch secondPart(req.Path) {
 	case QueryAccount:
 		return ah.queryAccount(ctx, req)
+	case QueryVesting:
+		return ah.queryVesting(ctx, req)
 	default:
 		res = sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest("unknown auth query endpoint"))
@@ -72,6 +77,47 @@ func (ah authHandler) queryAccount(ctx sdk.Context, req abci.RequestQuery) (res
 	return
 }
 
+// queryVesting fetches the vesting schedule of an account, and its locked
+// coins at the current block time.
+// Account address are passed as path component.
+func (ah authHandler) queryVesting(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// parse addr from path.
+	b32addr := thirdPart(req.Path)
+	addr, err := crypto.AddressFromBech32(b32addr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress(
+				"invalid query address " + b32addr))
+		return
+	}
+
+	acc := ah.acck.GetAccount(ctx, addr)
+	if acc == nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr)))
+		return
+	}
+
+	vacc, ok := acc.(std.VestingAccount)
+	if !ok {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest(fmt.Sprintf("account %s is not a vesting account", addr)))
+		return
+	}
+
+	bz, err := amino.MarshalJSONIndent(
+		NewVestingSchedule(vacc, ctx.BlockTime()),
+		"", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
 //----------------------------------------
 // misc
 
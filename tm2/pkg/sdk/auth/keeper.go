@@ -217,7 +217,7 @@ func (gk GasPriceKeeper) UpdateGasPrice(ctx sdk.Context) {
 // representable by one simple formula
 func (gk GasPriceKeeper) calcBlockGasPrice(lastGasPrice std.GasPrice, gasUsed int64, maxGas int64, params Params) std.GasPrice {
 	// If no block gas price is set, there is no need to change the last gas price.
-	if lastGasPrice.Price.Amount == 0 {
+	if lastGasPrice.Price.Amount.IsZero() {
 		return lastGasPrice
 	}
 
@@ -247,7 +247,7 @@ func (gk GasPriceKeeper) calcBlockGasPrice(lastGasPrice std.GasPrice, gasUsed in
 	}
 
 	c := params.GasPricesChangeCompressor
-	lastPriceInt := big.NewInt(lastGasPrice.Price.Amount)
+	lastPriceInt := lastGasPrice.Price.Amount.BigInt()
 
 	bigOne := big.NewInt(1)
 	if gasUsedInt.Cmp(targetGasInt) == 1 { // gas used is more than the target
@@ -262,7 +262,7 @@ func (gk GasPriceKeeper) calcBlockGasPrice(lastGasPrice std.GasPrice, gasUsed in
 		// XXX should we cap it with a max gas price?
 	} else { // gas used is less than the target
 		// decrease gas price down to initial gas price
-		initPriceInt := big.NewInt(params.InitialGasPrice.Price.Amount)
+		initPriceInt := params.InitialGasPrice.Price.Amount.BigInt()
 		if lastPriceInt.Cmp(initPriceInt) == -1 {
 			return params.InitialGasPrice
 		}
@@ -280,7 +280,7 @@ func (gk GasPriceKeeper) calcBlockGasPrice(lastGasPrice std.GasPrice, gasUsed in
 		panic("The min gas price is out of int64 range")
 	}
 
-	lastGasPrice.Price.Amount = num.Int64()
+	lastGasPrice.Price.Amount = std.NewInt(num.Int64())
 	return lastGasPrice
 }
 
@@ -91,12 +91,12 @@ func TestGasPrice(t *testing.T) {
 		Gas: 100,
 		Price: std.Coin{
 			Denom:  "token",
-			Amount: 10,
+			Amount: std.NewInt(10),
 		},
 	}
 	env.gk.SetGasPrice(env.ctx, gp)
 	gp2 := env.gk.LastGasPrice(env.ctx)
-	require.True(t, gp == gp2)
+	require.Equal(t, gp, gp2)
 }
 
 func TestMax(t *testing.T) {
@@ -138,7 +138,7 @@ func TestCalcBlockGasPrice(t *testing.T) {
 
 	lastGasPrice := std.GasPrice{
 		Price: std.Coin{
-			Amount: 100,
+			Amount: std.NewInt(100),
 			Denom:  "atom",
 		},
 	}
@@ -157,22 +157,22 @@ func TestCalcBlockGasPrice(t *testing.T) {
 	num.Div(num, big.NewInt(maxGas*params.TargetGasRatio/100))
 	num.Div(num, big.NewInt(params.GasPricesChangeCompressor))
 	expectedAmount.Add(expectedAmount, num)
-	require.Equal(t, expectedAmount.Int64(), newGasPrice.Price.Amount)
+	require.Equal(t, expectedAmount.Int64(), newGasPrice.Price.Amount.Int64())
 
 	// Test with lastGasPrice amount as 0
-	lastGasPrice.Price.Amount = 0
+	lastGasPrice.Price.Amount = std.NewInt(0)
 	newGasPrice = gk.calcBlockGasPrice(lastGasPrice, gasUsed, maxGas, params)
-	require.Equal(t, int64(0), newGasPrice.Price.Amount)
+	require.Equal(t, int64(0), newGasPrice.Price.Amount.Int64())
 
 	// Test with TargetGasRatio as 0 (should not change the last price)
 	params.TargetGasRatio = 0
 	newGasPrice = gk.calcBlockGasPrice(lastGasPrice, gasUsed, maxGas, params)
-	require.Equal(t, int64(0), newGasPrice.Price.Amount)
+	require.Equal(t, int64(0), newGasPrice.Price.Amount.Int64())
 
 	// Test with gasUsed as 0 (should not change the last price)
 	params.TargetGasRatio = 50
-	lastGasPrice.Price.Amount = 100
+	lastGasPrice.Price.Amount = std.NewInt(100)
 	gasUsed = 0
 	newGasPrice = gk.calcBlockGasPrice(lastGasPrice, gasUsed, maxGas, params)
-	require.Equal(t, int64(100), newGasPrice.Price.Amount)
+	require.Equal(t, int64(100), newGasPrice.Price.Amount.Int64())
 }
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -24,6 +26,18 @@ type BankKeeperI interface {
 	SendCoins(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error
 }
 
+// FeeGrantKeeperI checks and updates the fee allowances, for the fees of
+// txs paid by a granter.
+type FeeGrantKeeperI interface {
+	UseGrantedFees(ctx sdk.Context, granter, grantee crypto.Address, fee std.Coins, msgs []std.Msg) error
+}
+
+// SessionKeeperI checks and updates the sessions, for the txs signed by a
+// session key of a signer.
+type SessionKeeperI interface {
+	UseSession(ctx sdk.Context, account crypto.Address, pubKey crypto.PubKey, fee std.Coins, msgs []std.Msg) error
+}
+
 type GasPriceKeeperI interface {
 	LastGasPrice(ctx sdk.Context) std.GasPrice
 	SetGasPrice(ctx sdk.Context, gp std.GasPrice)
@@ -32,6 +46,35 @@ type GasPriceKeeperI interface {
 
 var _ GasPriceKeeperI = GasPriceKeeper{}
 
+// VestingSchedule is the vesting schedule of a vesting account, with its
+// vested and locked coins at the time of the query.
+type VestingSchedule struct {
+	OriginalVesting std.Coins           `json:"original_vesting"`
+	StartTime       int64               `json:"start_time"`
+	EndTime         int64               `json:"end_time"`
+	VestingPeriods  []std.VestingPeriod `json:"vesting_periods,omitempty"`
+	Vested          std.Coins           `json:"vested"`
+	Locked          std.Coins           `json:"locked"`
+	Spendable       std.Coins           `json:"spendable"`
+}
+
+// NewVestingSchedule returns the vesting schedule of the account, at the
+// given time.
+func NewVestingSchedule(acc std.VestingAccount, blockTime time.Time) VestingSchedule {
+	schedule := VestingSchedule{
+		OriginalVesting: acc.GetOriginalVesting(),
+		StartTime:       acc.GetStartTime(),
+		EndTime:         acc.GetEndTime(),
+		Vested:          acc.GetVestedCoins(blockTime),
+		Locked:          acc.GetVestingCoins(blockTime),
+		Spendable:       std.SpendableCoins(acc, blockTime),
+	}
+	if pacc, ok := acc.(*std.PeriodicVestingAccount); ok {
+		schedule.VestingPeriods = pacc.VestingPeriods
+	}
+	return schedule
+}
+
 // GenesisState - all auth state that must be provided at genesis
 type GenesisState struct {
 	Params Params `json:"params"`
@@ -2,4 +2,20 @@ package bank
 
 const (
 	ModuleName = "bank"
+
+	// SupplyStoreKeyPrefix prefix for total-supply-by-denom store
+	SupplyStoreKeyPrefix = "/supply/"
+
+	// DenomStoreKeyPrefix prefix for denom-metadata-by-denom store
+	DenomStoreKeyPrefix = "/denom/"
 )
+
+// SupplyStoreKey returns the key of the total supply of denom.
+func SupplyStoreKey(denom string) []byte {
+	return append([]byte(SupplyStoreKeyPrefix), denom...)
+}
+
+// DenomStoreKey returns the key of the metadata of denom.
+func DenomStoreKey(denom string) []byte {
+	return append([]byte(DenomStoreKeyPrefix), denom...)
+}
@@ -14,8 +14,9 @@ func (abciError) AssertABCIError() {}
 type NoInputsError struct{ abciError }
 
 type (
-	NoOutputsError           struct{ abciError }
-	InputOutputMismatchError struct{ abciError }
+	NoOutputsError            struct{ abciError }
+	InputOutputMismatchError  struct{ abciError }
+	InvalidDenomMetadataError struct{ abciError }
 )
 
 func (e NoInputsError) Error() string  { return "no inputs in send transaction" }
@@ -23,6 +24,7 @@ func (e NoOutputsError) Error() string { return "no outputs in send transaction"
 func (e InputOutputMismatchError) Error() string {
 	return "sum inputs != sum outputs in send transaction"
 }
+func (e InvalidDenomMetadataError) Error() string { return "invalid denom metadata" }
 
 func ErrNoInputs() error {
 	return errors.Wrap(NoInputsError{}, "")
@@ -35,3 +37,7 @@ func ErrNoOutputs() error {
 func ErrInputOutputMismatch() error {
 	return errors.Wrap(InputOutputMismatchError{}, "")
 }
+
+func ErrInvalidDenomMetadata(msg string) error {
+	return errors.Wrap(InvalidDenomMetadataError{}, msg)
+}
@@ -98,13 +98,21 @@ func (bh bankHandler) handleMsgMultiSend(ctx sdk.Context, msg MsgMultiSend) sdk.
 //----------------------------------------
 // Query
 
-// query balance path
-const QueryBalance = "balances"
+// query paths
+const (
+	QueryBalance = "balances"
+	QuerySupply  = "supply"
+	QueryDenoms  = "denoms"
+)
 
 func (bh bankHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	switch secondPart(req.Path) {
 	case QueryBalance:
 		return bh.queryBalance(ctx, req)
+	case QuerySupply:
+		return bh.querySupply(ctx, req)
+	case QueryDenoms:
+		return bh.queryDenoms(ctx, req)
 	default:
 		res = sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest("unknown bank query endpoint"))
@@ -135,6 +143,59 @@ func (bh bankHandler) queryBalance(ctx sdk.Context, req abci.RequestQuery) (res
 	return
 }
 
+// querySupply fetches the total supply of all the denoms, or of the denom
+// passed as the rest of the path (realm denoms contain slashes).
+func (bh bankHandler) querySupply(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	var result any
+	if denom := restPath(req.Path); denom != "" {
+		result = bh.bank.GetSupply(ctx, denom)
+	} else {
+		result = bh.bank.GetTotalSupply(ctx)
+	}
+
+	bz, err := amino.MarshalJSONIndent(result, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+// queryDenoms fetches the metadata of all the denoms, or of the denom passed
+// as the rest of the path.
+func (bh bankHandler) queryDenoms(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	var result any
+	if denom := restPath(req.Path); denom != "" {
+		md, ok := bh.bank.GetDenomMetadata(ctx, denom)
+		if !ok {
+			res = sdk.ABCIResponseQueryFromError(
+				std.ErrUnknownRequest("no metadata for denom " + denom))
+			return
+		}
+		result = md
+	} else {
+		denoms := []DenomMetadata{}
+		bh.bank.IterateDenomMetadata(ctx, func(md DenomMetadata) bool {
+			denoms = append(denoms, md)
+			return false
+		})
+		result = denoms
+	}
+
+	bz, err := amino.MarshalJSONIndent(result, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
 //----------------------------------------
 // misc
 
@@ -161,3 +222,12 @@ func thirdPart(path string) string {
 		return parts[2]
 	}
 }
+
+// returns the components of a path after the second one.
+func restPath(path string) string {
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
@@ -51,7 +51,7 @@ func TestBalances(t *testing.T) {
 	require.Nil(t, res.Error)
 	require.NotNil(t, res)
 	require.NoError(t, amino.UnmarshalJSON(res.Data, &coins))
-	require.True(t, coins.AmountOf("foo") == 10)
+	require.True(t, coins.AmountOf("foo").Int64() == 10)
 }
 
 func TestQuerierRouteNotFound(t *testing.T) {
@@ -66,3 +66,57 @@ func TestQuerierRouteNotFound(t *testing.T) {
 	res := h.Query(env.ctx, req)
 	require.Error(t, res.Error)
 }
+
+func TestSupply(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.bank)
+	_, _, addr := tu.KeyTestPubAddr()
+	denom := "/gno.land/r/demo/foo:foo"
+
+	require.NoError(t, env.bank.MintCoins(env.ctx, addr, std.NewCoins(std.NewCoin(denom, 10), std.NewCoin("ugnot", 100))))
+
+	res := h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QuerySupply})
+	require.Nil(t, res.Error)
+	var coins std.Coins
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &coins))
+	require.True(t, coins.IsEqual(std.NewCoins(std.NewCoin(denom, 10), std.NewCoin("ugnot", 100))))
+
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QuerySupply + "/" + denom})
+	require.Nil(t, res.Error)
+	var coin std.Coin
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &coin))
+	require.Equal(t, std.NewCoin(denom, 10), coin)
+}
+
+func TestDenoms(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.bank)
+	ugnot := DenomMetadata{Denom: "ugnot", Display: "gnot", Exponent: 6}
+	foo := DenomMetadata{Denom: "/gno.land/r/demo/foo:foo", Display: "foo", Realm: "gno.land/r/demo/foo"}
+
+	res := h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QueryDenoms})
+	require.Nil(t, res.Error)
+	require.Equal(t, "[]", string(res.Data))
+
+	require.NoError(t, env.bank.SetDenomMetadata(env.ctx, ugnot))
+	require.NoError(t, env.bank.SetDenomMetadata(env.ctx, foo))
+
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QueryDenoms})
+	require.Nil(t, res.Error)
+	var denoms []DenomMetadata
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &denoms))
+	require.Equal(t, []DenomMetadata{foo, ugnot}, denoms)
+
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QueryDenoms + "/" + foo.Denom})
+	require.Nil(t, res.Error)
+	var md DenomMetadata
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &md))
+	require.Equal(t, foo, md)
+
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QueryDenoms + "/unknown"})
+	require.Error(t, res.Error)
+}
@@ -5,12 +5,15 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 // RegisterInvariants registers the bank module invariants
-func RegisterInvariants(ir sdk.InvariantRegistry, acck auth.AccountKeeper) {
+func RegisterInvariants(ir sdk.InvariantRegistry, bank ViewKeeperI, acck auth.AccountKeeper) {
 	ir.RegisterRoute(ModuleName, "nonnegative-outstanding",
 		NonnegativeBalanceInvariant(acck))
+	ir.RegisterRoute(ModuleName, "total-supply",
+		TotalSupplyInvariant(bank, acck))
 }
 
 // NonnegativeBalanceInvariant checks that all accounts in the application have non-negative balances
@@ -35,3 +38,19 @@ func NonnegativeBalanceInvariant(acck auth.AccountKeeper) sdk.Invariant {
 			fmt.Sprintf("amount of negative accounts found %d\n%s", count, msg)), broken
 	}
 }
+
+// TotalSupplyInvariant checks that the total supply tracked by the bank equals
+// the sum of the balances of all the accounts in the application
+func TotalSupplyInvariant(bank ViewKeeperI, acck auth.AccountKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		balances := std.NewCoins()
+		for _, acc := range acck.GetAllAccounts(ctx) {
+			balances = balances.AddUnsafe(acc.GetCoins())
+		}
+		supply := bank.GetTotalSupply(ctx)
+		broken := !balances.SubUnsafe(supply).IsZero()
+
+		return sdk.FormatInvariant(ModuleName, "total-supply",
+			fmt.Sprintf("\tsum of balances: %s\n\ttotal supply: %s\n", balances, supply)), broken
+	}
+}
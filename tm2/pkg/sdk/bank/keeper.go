@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 // bank.Keeper defines a module interface that facilitates the transfer of
@@ -21,22 +23,32 @@ type BankKeeperI interface {
 	SubtractCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error)
 	AddCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error)
 	SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error
+
+	MintCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error
+	BurnCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error
+	SetDenomMetadata(ctx sdk.Context, md DenomMetadata) error
+
+	InitGenesis(ctx sdk.Context, data GenesisState)
 }
 
 var _ BankKeeperI = BankKeeper{}
 
-// BankKeeper only allows transfers between accounts without the possibility of
-// creating coins. It implements the BankKeeperI interface.
+// BankKeeper allows transfers between accounts, and tracks the total supply
+// of the coins created and destroyed with MintCoins and BurnCoins. It
+// implements the BankKeeperI interface.
 type BankKeeper struct {
 	ViewKeeper
 
+	// The (unexposed) key used to access the store from the Context.
+	key  store.StoreKey
 	acck auth.AccountKeeper
 }
 
 // NewBankKeeper returns a new BankKeeper.
-func NewBankKeeper(acck auth.AccountKeeper) BankKeeper {
+func NewBankKeeper(key store.StoreKey, acck auth.AccountKeeper) BankKeeper {
 	return BankKeeper{
-		ViewKeeper: NewViewKeeper(acck),
+		ViewKeeper: NewViewKeeper(key, acck),
+		key:        key,
 		acck:       acck,
 	}
 }
@@ -121,19 +133,22 @@ func (bank BankKeeper) SubtractCoins(ctx sdk.Context, addr crypto.Address, amt s
 		return nil, std.ErrInvalidCoins(amt.String())
 	}
 
-	oldCoins := std.NewCoins()
+	oldCoins, spendable := std.NewCoins(), std.NewCoins()
 	acc := bank.acck.GetAccount(ctx, addr)
 	if acc != nil {
 		oldCoins = acc.GetCoins()
+		spendable = std.SpendableCoins(acc, ctx.BlockTime())
 	}
 
-	newCoins := oldCoins.SubUnsafe(amt)
-	if !newCoins.IsValid() {
+	// the locked coins of vesting accounts can't be spent
+	if !spendable.SubUnsafe(amt).IsValid() {
 		err := std.ErrInsufficientCoins(
-			fmt.Sprintf("insufficient account funds; %s < %s", oldCoins, amt),
+			fmt.Sprintf("insufficient account funds; %s < %s", spendable, amt),
 		)
 		return nil, err
 	}
+
+	newCoins := oldCoins.SubUnsafe(amt)
 	err := bank.SetCoins(ctx, addr, newCoins)
 
 	return newCoins, err
@@ -178,6 +193,91 @@ func (bank BankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Co
 	return nil
 }
 
+// MintCoins creates amt and adds it to the coins at the addr, increasing the
+// total supply.
+func (bank BankKeeper) MintCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error {
+	if _, err := bank.AddCoins(ctx, addr, amt); err != nil {
+		return err
+	}
+
+	for _, coin := range amt {
+		supply := bank.GetSupply(ctx, coin.Denom)
+		bank.setSupply(ctx, supply.Add(coin))
+	}
+	return nil
+}
+
+// BurnCoins subtracts amt from the coins at the addr and destroys it,
+// decreasing the total supply.
+func (bank BankKeeper) BurnCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error {
+	if !amt.IsValid() {
+		return std.ErrInvalidCoins(amt.String())
+	}
+
+	supplies := make([]std.Coin, len(amt))
+	for i, coin := range amt {
+		// the supply is lower if the coins were not minted with MintCoins.
+		supply := bank.GetSupply(ctx, coin.Denom)
+		if supply.IsLT(coin) {
+			return std.ErrInsufficientCoins(
+				fmt.Sprintf("insufficient supply; %s < %s", supply, coin))
+		}
+		supplies[i] = supply.Sub(coin)
+	}
+
+	if _, err := bank.SubtractCoins(ctx, addr, amt); err != nil {
+		return err
+	}
+
+	for _, supply := range supplies {
+		bank.setSupply(ctx, supply)
+	}
+	return nil
+}
+
+func (bank BankKeeper) setSupply(ctx sdk.Context, supply std.Coin) {
+	stor := ctx.GasStore(bank.key)
+	if supply.IsZero() {
+		stor.Delete(SupplyStoreKey(supply.Denom))
+		return
+	}
+	stor.Set(SupplyStoreKey(supply.Denom), amino.MustMarshal(supply))
+}
+
+// SetDenomMetadata sets the metadata of a denom, replacing any existing one.
+func (bank BankKeeper) SetDenomMetadata(ctx sdk.Context, md DenomMetadata) error {
+	if err := md.ValidateBasic(); err != nil {
+		return err
+	}
+
+	stor := ctx.GasStore(bank.key)
+	stor.Set(DenomStoreKey(md.Denom), amino.MustMarshal(md))
+	return nil
+}
+
+// InitGenesis - Init store state from genesis data
+func (bank BankKeeper) InitGenesis(ctx sdk.Context, data GenesisState) {
+	if err := ValidateGenesis(data); err != nil {
+		panic(err)
+	}
+
+	for _, md := range data.Denoms {
+		if err := bank.SetDenomMetadata(ctx, md); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper
+func (bank BankKeeper) ExportGenesis(ctx sdk.Context) GenesisState {
+	var data GenesisState
+	bank.IterateDenomMetadata(ctx, func(md DenomMetadata) bool {
+		data.Denoms = append(data.Denoms, md)
+		return false
+	})
+	return data
+}
+
 // ----------------------------------------
 // ViewKeeper
 
@@ -186,18 +286,25 @@ func (bank BankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Co
 type ViewKeeperI interface {
 	GetCoins(ctx sdk.Context, addr crypto.Address) std.Coins
 	HasCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) bool
+	SpendableCoins(ctx sdk.Context, addr crypto.Address) std.Coins
+
+	GetSupply(ctx sdk.Context, denom string) std.Coin
+	GetTotalSupply(ctx sdk.Context) std.Coins
+	GetDenomMetadata(ctx sdk.Context, denom string) (DenomMetadata, bool)
+	IterateDenomMetadata(ctx sdk.Context, process func(DenomMetadata) (stop bool))
 }
 
 var _ ViewKeeperI = ViewKeeper{}
 
 // ViewKeeper implements a read only keeper implementation of ViewKeeperI.
 type ViewKeeper struct {
+	key  store.StoreKey
 	acck auth.AccountKeeper
 }
 
 // NewViewKeeper returns a new ViewKeeper.
-func NewViewKeeper(acck auth.AccountKeeper) ViewKeeper {
-	return ViewKeeper{acck: acck}
+func NewViewKeeper(key store.StoreKey, acck auth.AccountKeeper) ViewKeeper {
+	return ViewKeeper{key: key, acck: acck}
 }
 
 // Logger returns a module-specific logger.
@@ -214,7 +321,71 @@ func (view ViewKeeper) GetCoins(ctx sdk.Context, addr crypto.Address) std.Coins
 	return acc.GetCoins()
 }
 
+// SpendableCoins returns the coins at the addr which can be spent, without
+// the locked coins of vesting accounts.
+func (view ViewKeeper) SpendableCoins(ctx sdk.Context, addr crypto.Address) std.Coins {
+	acc := view.acck.GetAccount(ctx, addr)
+	if acc == nil {
+		return std.NewCoins()
+	}
+	return std.SpendableCoins(acc, ctx.BlockTime())
+}
+
 // HasCoins returns whether or not an account has at least amt coins.
 func (view ViewKeeper) HasCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) bool {
 	return view.GetCoins(ctx, addr).IsAllGTE(amt)
 }
+
+// GetSupply returns the total supply of denom.
+func (view ViewKeeper) GetSupply(ctx sdk.Context, denom string) std.Coin {
+	stor := ctx.GasStore(view.key)
+	bz := stor.Get(SupplyStoreKey(denom))
+	if bz == nil {
+		return std.Coin{Denom: denom}
+	}
+	var supply std.Coin
+	amino.MustUnmarshal(bz, &supply)
+	return supply
+}
+
+// GetTotalSupply returns the total supply of all the denoms.
+func (view ViewKeeper) GetTotalSupply(ctx sdk.Context) std.Coins {
+	stor := ctx.GasStore(view.key)
+	iter := store.PrefixIterator(stor, []byte(SupplyStoreKeyPrefix))
+	defer iter.Close()
+
+	supply := std.NewCoins()
+	for ; iter.Valid(); iter.Next() {
+		var coin std.Coin
+		amino.MustUnmarshal(iter.Value(), &coin)
+		supply = append(supply, coin)
+	}
+	return supply
+}
+
+// GetDenomMetadata returns the metadata of denom, if any.
+func (view ViewKeeper) GetDenomMetadata(ctx sdk.Context, denom string) (md DenomMetadata, ok bool) {
+	stor := ctx.GasStore(view.key)
+	bz := stor.Get(DenomStoreKey(denom))
+	if bz == nil {
+		return DenomMetadata{}, false
+	}
+	amino.MustUnmarshal(bz, &md)
+	return md, true
+}
+
+// IterateDenomMetadata iterates over the metadata of the denoms, sorted by
+// denom.
+func (view ViewKeeper) IterateDenomMetadata(ctx sdk.Context, process func(DenomMetadata) (stop bool)) {
+	stor := ctx.GasStore(view.key)
+	iter := store.PrefixIterator(stor, []byte(DenomStoreKeyPrefix))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var md DenomMetadata
+		amino.MustUnmarshal(iter.Value(), &md)
+		if process(md) {
+			return
+		}
+	}
+}
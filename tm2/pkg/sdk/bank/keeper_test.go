@@ -2,9 +2,11 @@ package bank
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -95,7 +97,7 @@ func TestBankKeeper(t *testing.T) {
 	env := setupTestEnv()
 	ctx := env.ctx
 
-	bank := NewBankKeeper(env.acck)
+	bank := NewBankKeeper(env.key, env.acck)
 
 	addr := crypto.AddressFromPreimage([]byte("addr1"))
 	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
@@ -133,7 +135,7 @@ func TestBankKeeper(t *testing.T) {
 	// validate coins with invalid denoms or negative values cannot be sent
 	// NOTE: We must use the Coin literal as the constructor does not allow
 	// negative values.
-	err = bank.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.Coin{Denom: "FOOCOIN", Amount: -5}})
+	err = bank.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.Coin{Denom: "FOOCOIN", Amount: std.NewInt(-5)}})
 	require.Error(t, err)
 }
 
@@ -142,7 +144,7 @@ func TestViewKeeper(t *testing.T) {
 
 	env := setupTestEnv()
 	ctx := env.ctx
-	view := NewViewKeeper(env.acck)
+	view := NewViewKeeper(env.key, env.acck)
 
 	addr := crypto.AddressFromPreimage([]byte("addr1"))
 	acc := env.acck.NewAccountWithAddress(ctx, addr)
@@ -160,3 +162,117 @@ func TestViewKeeper(t *testing.T) {
 	require.False(t, view.HasCoins(ctx, addr, std.NewCoins(std.NewCoin("foocoin", 15))))
 	require.False(t, view.HasCoins(ctx, addr, std.NewCoins(std.NewCoin("barcoin", 5))))
 }
+
+func TestKeeperVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Time: time.Unix(100, 0)})
+	view := NewViewKeeper(env.key, env.acck)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+
+	base := std.NewBaseAccountWithAddress(addr)
+	base.Coins = std.NewCoins(std.NewCoin("foocoin", 15))
+	env.acck.SetAccount(ctx, std.NewDelayedVestingAccount(base, std.NewCoins(std.NewCoin("foocoin", 10)), 200))
+
+	require.True(t, view.SpendableCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 5))))
+
+	// Locked coins can't be sent
+	err := env.bank.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 6)))
+	require.ErrorAs(t, err, &std.InsufficientCoinsError{})
+	require.True(t, env.bank.GetCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 15))))
+
+	require.NoError(t, env.bank.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 5))))
+	require.True(t, view.SpendableCoins(ctx, addr).IsZero())
+
+	// Received coins are spendable
+	require.NoError(t, env.bank.SendCoins(ctx, addr2, addr, std.NewCoins(std.NewCoin("foocoin", 1))))
+	require.NoError(t, env.bank.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 1))))
+
+	// All coins are spendable once vested
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Time: time.Unix(200, 0)})
+	require.NoError(t, env.bank.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 10))))
+	require.True(t, env.bank.GetCoins(ctx, addr).IsZero())
+	_, ok := env.acck.GetAccount(ctx, addr).(*std.DelayedVestingAccount)
+	require.True(t, ok)
+}
+
+func TestKeeperSupply(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+	invariant := TotalSupplyInvariant(env.bank, env.acck)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	denom := "/gno.land/r/demo/foo:foo"
+
+	require.True(t, env.bank.GetSupply(ctx, denom).IsZero())
+	require.True(t, env.bank.GetTotalSupply(ctx).IsZero())
+
+	// Mint
+	require.NoError(t, env.bank.MintCoins(ctx, addr, std.NewCoins(std.NewCoin(denom, 10), std.NewCoin("ugnot", 100))))
+	require.NoError(t, env.bank.MintCoins(ctx, addr2, std.NewCoins(std.NewCoin(denom, 5))))
+	require.True(t, env.bank.GetCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin(denom, 10), std.NewCoin("ugnot", 100))))
+	require.Equal(t, std.NewCoin(denom, 15), env.bank.GetSupply(ctx, denom))
+	require.True(t, env.bank.GetTotalSupply(ctx).IsEqual(std.NewCoins(std.NewCoin(denom, 15), std.NewCoin("ugnot", 100))))
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	// Transfers don't change the supply
+	require.NoError(t, env.bank.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin(denom, 3))))
+	require.Equal(t, std.NewCoin(denom, 15), env.bank.GetSupply(ctx, denom))
+
+	// Burn
+	require.NoError(t, env.bank.BurnCoins(ctx, addr2, std.NewCoins(std.NewCoin(denom, 8))))
+	require.True(t, env.bank.GetCoins(ctx, addr2).IsZero())
+	require.Equal(t, std.NewCoin(denom, 7), env.bank.GetSupply(ctx, denom))
+	err := env.bank.BurnCoins(ctx, addr2, std.NewCoins(std.NewCoin(denom, 1)))
+	require.ErrorAs(t, err, &std.InsufficientCoinsError{})
+	require.Equal(t, std.NewCoin(denom, 7), env.bank.GetSupply(ctx, denom))
+	require.NoError(t, env.bank.BurnCoins(ctx, addr, std.NewCoins(std.NewCoin(denom, 7))))
+	require.True(t, env.bank.GetTotalSupply(ctx).IsEqual(std.NewCoins(std.NewCoin("ugnot", 100))))
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// Coins set without minting break the invariant, and can't be burnt
+	require.NoError(t, env.bank.SetCoins(ctx, addr2, std.NewCoins(std.NewCoin("barcoin", 5))))
+	err = env.bank.BurnCoins(ctx, addr2, std.NewCoins(std.NewCoin("barcoin", 5)))
+	require.ErrorAs(t, err, &std.InsufficientCoinsError{})
+	require.True(t, env.bank.GetCoins(ctx, addr2).IsEqual(std.NewCoins(std.NewCoin("barcoin", 5))))
+	msg, broken := invariant(ctx)
+	require.True(t, broken)
+	require.Contains(t, msg, "sum of balances: 5barcoin,100ugnot")
+}
+
+func TestKeeperDenomMetadata(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	_, ok := env.bank.GetDenomMetadata(ctx, "ugnot")
+	require.False(t, ok)
+
+	ugnot := DenomMetadata{Denom: "ugnot", Display: "gnot", Exponent: 6}
+	foo := DenomMetadata{Denom: "/gno.land/r/demo/foo:foo", Display: "foo", Realm: "gno.land/r/demo/foo"}
+	require.NoError(t, env.bank.SetDenomMetadata(ctx, ugnot))
+	require.NoError(t, env.bank.SetDenomMetadata(ctx, foo))
+
+	md, ok := env.bank.GetDenomMetadata(ctx, "ugnot")
+	require.True(t, ok)
+	require.Equal(t, ugnot, md)
+
+	err := env.bank.SetDenomMetadata(ctx, DenomMetadata{Denom: "ugnot", Display: "gnot", Exponent: 19})
+	require.ErrorAs(t, err, &InvalidDenomMetadataError{})
+	err = env.bank.SetDenomMetadata(ctx, DenomMetadata{Denom: "ugnot"})
+	require.ErrorAs(t, err, &InvalidDenomMetadataError{})
+	err = env.bank.SetDenomMetadata(ctx, DenomMetadata{Denom: "A", Display: "a"})
+	require.ErrorAs(t, err, &InvalidDenomMetadataError{})
+
+	// Sorted by denom
+	require.Equal(t, GenesisState{Denoms: []DenomMetadata{foo, ugnot}}, env.bank.ExportGenesis(ctx))
+}
@@ -12,5 +12,8 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	NoInputsError{}, "NoInputsError",
 	NoOutputsError{}, "NoOutputsError",
 	InputOutputMismatchError{}, "InputOutputMismatchError",
+	InvalidDenomMetadataError{}, "InvalidDenomMetadataError",
 	MsgSend{}, "MsgSend",
+	DenomMetadata{}, "DenomMetadata",
+	GenesisState{}, "GenesisState",
 ))
@@ -0,0 +1,57 @@
+package bank
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// MaxDenomExponent is the maximum exponent of a display unit.
+const MaxDenomExponent = 18
+
+// DenomMetadata describes a denomination, so that clients can display its
+// amounts. Amounts of Display are amounts of Denom divided by 10^Exponent;
+// "ugnot" is displayed as "gnot" with an exponent of 6.
+type DenomMetadata struct {
+	Denom    string `json:"denom" yaml:"denom"`
+	Display  string `json:"display" yaml:"display"`
+	Exponent uint32 `json:"exponent" yaml:"exponent"`
+	Realm    string `json:"realm,omitempty" yaml:"realm,omitempty"` // path of the issuing realm, if any
+}
+
+// ValidateBasic performs basic validation of the metadata.
+func (md DenomMetadata) ValidateBasic() error {
+	if !(std.Coin{Denom: md.Denom}).IsValid() {
+		return ErrInvalidDenomMetadata(fmt.Sprintf("invalid denom %q", md.Denom))
+	}
+	if strings.TrimSpace(md.Display) == "" {
+		return ErrInvalidDenomMetadata("missing display name of " + md.Denom)
+	}
+	if md.Exponent > MaxDenomExponent {
+		return ErrInvalidDenomMetadata(
+			fmt.Sprintf("exponent of %s exceeds %d", md.Denom, MaxDenomExponent))
+	}
+	return nil
+}
+
+// GenesisState - all bank state that must be provided at genesis
+type GenesisState struct {
+	Denoms []DenomMetadata `json:"denoms,omitempty"`
+}
+
+// ValidateGenesis performs basic validation of bank genesis data returning an
+// error for any failed validation criteria.
+func ValidateGenesis(data GenesisState) error {
+	seen := make(map[string]struct{}, len(data.Denoms))
+	for _, md := range data.Denoms {
+		if err := md.ValidateBasic(); err != nil {
+			return err
+		}
+		if _, ok := seen[md.Denom]; ok {
+			return ErrInvalidDenomMetadata("duplicate metadata of " + md.Denom)
+		}
+		seen[md.Denom] = struct{}{}
+	}
+	return nil
+}
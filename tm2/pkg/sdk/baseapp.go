@@ -45,6 +45,12 @@ type BaseApp struct {
 	beginTxHook BeginTxHook // BaseApp-specific hook run before running transaction messages.
 	endTxHook   EndTxHook   // BaseApp-specific hook run after running transaction messages.
 
+	storeKeys []store.StoreKey // keys of the mounted stores
+
+	// parallel execution of txs, see parallel.go.
+	parallelTxs      int              // number of workers; disabled if 0
+	parallelTxFilter ParallelTxFilter // txs which may be executed in parallel
+
 	// --------------------
 	// Volatile state
 	// checkState is set on initialization and reset on Commit.
@@ -53,6 +59,7 @@ type BaseApp struct {
 	checkState   *state          // for CheckTx
 	deliverState *state          // for DeliverTx
 	voteInfos    []abci.VoteInfo // absent validators from begin block
+	parallel     *parallelBlock  // set in BeginBlock and cleared in EndBlock
 
 	// consensus params
 	// TODO: Move this in the future to baseapp param store on main store.
@@ -125,12 +132,14 @@ func (app *BaseApp) Logger() *slog.Logger {
 // multistore, using a specified DB.
 func (app *BaseApp) MountStoreWithDB(key store.StoreKey, cons store.CommitStoreConstructor, db dbm.DB) {
 	app.cms.MountStoreWithDB(key, cons, db)
+	app.storeKeys = append(app.storeKeys, key)
 }
 
 // MountStore mounts a store to the provided key in the BaseApp multistore,
 // using the default DB.
 func (app *BaseApp) MountStore(key store.StoreKey, cons store.CommitStoreConstructor) {
 	app.cms.MountStoreWithDB(key, cons, nil)
+	app.storeKeys = append(app.storeKeys, key)
 }
 
 // LoadLatestVersion loads the latest application version. It will panic if
@@ -563,6 +572,11 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	if req.LastCommitInfo != nil {
 		app.voteInfos = req.LastCommitInfo.Votes
 	}
+
+	app.parallel = nil
+	if app.parallelTxs > 0 && len(req.Txs) > 1 {
+		app.speculateTxs(req.Txs)
+	}
 	return
 }
 
@@ -580,7 +594,7 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 	} else {
 		ctx := app.getContextForTx(RunTxModeCheck, req.Tx)
 
-		result := app.runTx(ctx, tx)
+		result := app.runTx(ctx, tx, nil)
 		res.ResponseBase = result.ResponseBase
 		res.GasWanted = result.GasWanted
 		res.GasUsed = result.GasUsed
@@ -590,6 +604,8 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 
 // DeliverTx implements the ABCI interface.
 func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
+	spec := app.parallel.nextSpeculation(req.Tx)
+
 	var tx Tx
 	err := amino.Unmarshal(req.Tx, &tx)
 	if err != nil {
@@ -598,7 +614,7 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 	} else {
 		ctx := app.getContextForTx(RunTxModeDeliver, req.Tx)
 
-		result := app.runTx(ctx, tx)
+		result := app.runTx(ctx, tx, spec)
 		res.ResponseBase = result.ResponseBase
 		res.GasWanted = result.GasWanted
 		res.GasUsed = result.GasUsed
@@ -726,7 +742,9 @@ func (app *BaseApp) cacheTxContext(ctx Context) (Context, store.MultiStore) {
 // anteHandler. The provided txBytes may be nil in some cases, eg. in tests. For
 // further details on transaction execution, reference the BaseApp SDK
 // documentation.
-func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
+// If spec is not nil, it is the speculative execution of the transaction,
+// used in place of the execution of its messages if still valid.
+func (app *BaseApp) runTx(ctx Context, tx Tx, spec *speculation) (result Result) {
 	var (
 		// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 		// determined by the GasMeter. We need access to the context to get the gas
@@ -853,11 +871,24 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 	// multi-store in case message processing fails.
 	runMsgCtx, msCache := app.cacheTxContext(ctx)
 
-	if app.beginTxHook != nil {
-		runMsgCtx = app.beginTxHook(runMsgCtx)
-	}
+	parallel := mode == RunTxModeDeliver && app.parallel != nil
+	if parallel && spec != nil && app.parallel.valid(spec, ctx, gasWanted) {
+		// Use the speculative execution of the messages as is; the gas
+		// meter is the one it left, in the same state.
+		ctx = ctx.WithGasMeter(spec.ctx.GasMeter())
+		spec.ms.Apply(msCache)
+		runMsgCtx = spec.ctx.WithMultiStore(msCache)
+		result = spec.result
+	} else {
+		if parallel {
+			runMsgCtx = runMsgCtx.WithAccessSet(NewAccessSet())
+		}
+		if app.beginTxHook != nil {
+			runMsgCtx = app.beginTxHook(runMsgCtx)
+		}
 
-	result = app.runMsgs(runMsgCtx, msgs, mode)
+		result = app.runMsgs(runMsgCtx, msgs, mode)
+	}
 	result.GasWanted = gasWanted
 
 	// Safety check: don't write the cache state unless we're in DeliverTx.
@@ -872,6 +903,9 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 	// only update state if all messages pass
 	if result.IsOK() {
 		msCache.MultiWrite()
+		if parallel {
+			app.parallel.commit(runMsgCtx, spec)
+		}
 	}
 
 	return result
@@ -879,6 +913,8 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 
 // EndBlock implements the ABCI interface.
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
+	app.parallel = nil
+
 	if app.endBlocker != nil {
 		// we need to load consensusParams to the end blocker Context
 		// end blocker use consensusParams to calculat the gas price changes.
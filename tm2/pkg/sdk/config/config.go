@@ -12,6 +12,9 @@ import (
 type AppConfig struct {
 	// Lowest gas prices accepted by a validator in the form of "100tokenA/3gas;10tokenB/5gas" separated by semicolons
 	MinGasPrices string `json:"min_gas_prices" toml:"min_gas_prices" comment:"Lowest gas prices accepted by a validator"`
+
+	// Number of workers executing the transactions of a block speculatively in parallel, or 0 to execute them sequentially
+	ParallelTxs int `json:"parallel_txs" toml:"parallel_txs" comment:"Number of workers executing the transactions of a block in parallel (0 to disable)"`
 }
 
 // DefaultAppConfig returns a default configuration for the application
@@ -24,6 +27,9 @@ func DefaultAppConfig() *AppConfig {
 // ValidateBasic performs basic validation, checking format and param bounds, etc., and
 // returns an error if any check fails.
 func (cfg *AppConfig) ValidateBasic() error {
+	if cfg.ParallelTxs < 0 {
+		return errors.New("parallel_txs can't be negative")
+	}
 	if cfg.MinGasPrices == "" {
 		return nil
 	}
@@ -34,3 +34,11 @@ func TestValidateAppConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAppConfigParallelTxs(t *testing.T) {
+	cfg := DefaultAppConfig()
+	cfg.ParallelTxs = 4
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ParallelTxs = -1
+	assert.Error(t, cfg.ValidateBasic())
+}
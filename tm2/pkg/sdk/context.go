@@ -33,6 +33,8 @@ type Context struct {
 	minGasPrices  []GasPrice
 	consParams    *abci.ConsensusParams
 	eventLogger   *EventLogger
+	speculative   bool
+	accessSet     *AccessSet
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -53,6 +55,8 @@ func (c Context) BlockGasMeter() store.GasMeter { return c.blockGasMeter }
 func (c Context) IsCheckTx() bool               { return c.mode == RunTxModeCheck }
 func (c Context) MinGasPrices() []GasPrice      { return c.minGasPrices }
 func (c Context) EventLogger() *EventLogger     { return c.eventLogger }
+func (c Context) IsSpeculative() bool           { return c.speculative }
+func (c Context) AccessSet() *AccessSet         { return c.accessSet }
 
 // clone the header before returning
 func (c Context) BlockHeader() abci.Header {
@@ -147,6 +151,18 @@ func (c Context) WithEventLogger(em *EventLogger) Context {
 	return c
 }
 
+// WithSpeculative marks the context as that of a speculative execution of a
+// transaction, whose effects may be discarded. See [BaseApp.SetParallelTxs].
+func (c Context) WithSpeculative(speculative bool) Context {
+	c.speculative = speculative
+	return c
+}
+
+func (c Context) WithAccessSet(as *AccessSet) Context {
+	c.accessSet = as
+	return c
+}
+
 // WithValue is shorthand for:
 //
 //	c.WithContext(context.WithValue(c.Context(), key, value))
@@ -0,0 +1,245 @@
+package feegrant
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// FeeAllowance is a permission given by a granter to a grantee to have the
+// fees of the grantee's transactions paid by the granter.
+type FeeAllowance interface {
+	// Accept checks whether the given fee can be paid for a tx containing the
+	// given msgs, and updates the allowance accordingly. If remove is true,
+	// the allowance is used up and must be deleted.
+	Accept(ctx sdk.Context, fee std.Coins, msgs []std.Msg) (remove bool, err error)
+
+	// ValidateBasic performs stateless validation of the allowance.
+	ValidateBasic() error
+}
+
+// PkgPathMsg is implemented by msgs targeting a package or realm, to be
+// matched against the package paths of an AllowedMsgAllowance.
+type PkgPathMsg interface {
+	GetPkgPath() string
+}
+
+// Grant is an allowance given by a granter to a grantee.
+type Grant struct {
+	Granter   crypto.Address `json:"granter" yaml:"granter"`
+	Grantee   crypto.Address `json:"grantee" yaml:"grantee"`
+	Allowance FeeAllowance   `json:"allowance" yaml:"allowance"`
+}
+
+//----------------------------------------
+// BasicAllowance
+
+var _ FeeAllowance = &BasicAllowance{}
+
+// BasicAllowance allows the grantee to spend up to SpendLimit in fees,
+// until Expiration. An empty SpendLimit means no limit, and a zero
+// Expiration means the allowance never expires.
+type BasicAllowance struct {
+	SpendLimit std.Coins `json:"spend_limit" yaml:"spend_limit"`
+	Expiration time.Time `json:"expiration" yaml:"expiration"`
+}
+
+// Accept implements FeeAllowance.
+func (a *BasicAllowance) Accept(ctx sdk.Context, fee std.Coins, _ []std.Msg) (bool, error) {
+	if isExpired(ctx, a.Expiration) {
+		return true, ErrFeeLimitExpired("")
+	}
+
+	if a.SpendLimit.Empty() {
+		return false, nil
+	}
+
+	left := a.SpendLimit.SubUnsafe(fee)
+	if left.IsAnyNegative() {
+		return false, ErrFeeLimitExceeded(fmt.Sprintf("fee %s, spend limit %s", fee, a.SpendLimit))
+	}
+	a.SpendLimit = left
+
+	return left.IsZero(), nil
+}
+
+// ValidateBasic implements FeeAllowance.
+func (a *BasicAllowance) ValidateBasic() error {
+	if !a.SpendLimit.IsValid() {
+		return ErrInvalidAllowance("invalid spend limit " + a.SpendLimit.String())
+	}
+	return nil
+}
+
+//----------------------------------------
+// PeriodicAllowance
+
+var _ FeeAllowance = &PeriodicAllowance{}
+
+// PeriodicAllowance extends a BasicAllowance with a limit on the fees
+// spent per period. PeriodCanSpend is what is left to spend in the current
+// period, which ends at PeriodReset.
+type PeriodicAllowance struct {
+	Basic            BasicAllowance `json:"basic" yaml:"basic"`
+	Period           time.Duration  `json:"period" yaml:"period"`
+	PeriodSpendLimit std.Coins      `json:"period_spend_limit" yaml:"period_spend_limit"`
+	PeriodCanSpend   std.Coins      `json:"period_can_spend" yaml:"period_can_spend"`
+	PeriodReset      time.Time      `json:"period_reset" yaml:"period_reset"`
+}
+
+// Accept implements FeeAllowance.
+func (a *PeriodicAllowance) Accept(ctx sdk.Context, fee std.Coins, msgs []std.Msg) (bool, error) {
+	if isExpired(ctx, a.Basic.Expiration) {
+		return true, ErrFeeLimitExpired("")
+	}
+
+	a.tryResetPeriod(ctx.BlockTime())
+
+	left := a.PeriodCanSpend.SubUnsafe(fee)
+	if left.IsAnyNegative() {
+		return false, ErrFeeLimitExceeded(fmt.Sprintf("fee %s, period spend limit %s", fee, a.PeriodCanSpend))
+	}
+
+	remove, err := a.Basic.Accept(ctx, fee, msgs)
+	if err != nil {
+		return remove, err
+	}
+	a.PeriodCanSpend = left
+
+	return remove, nil
+}
+
+// tryResetPeriod starts a new period if the current one is over. The
+// spendable amount of the new period is capped by the basic spend limit.
+func (a *PeriodicAllowance) tryResetPeriod(blockTime time.Time) {
+	if blockTime.Before(a.PeriodReset) {
+		return
+	}
+
+	a.PeriodCanSpend = a.PeriodSpendLimit
+	if !a.Basic.SpendLimit.Empty() && !a.Basic.SpendLimit.IsAllGTE(a.PeriodCanSpend) {
+		a.PeriodCanSpend = minCoins(a.PeriodCanSpend, a.Basic.SpendLimit)
+	}
+
+	// Periods start from the end of the previous one, unless more than a
+	// full period was skipped.
+	a.PeriodReset = a.PeriodReset.Add(a.Period)
+	if blockTime.After(a.PeriodReset) {
+		a.PeriodReset = blockTime.Add(a.Period)
+	}
+}
+
+// ValidateBasic implements FeeAllowance.
+func (a *PeriodicAllowance) ValidateBasic() error {
+	if err := a.Basic.ValidateBasic(); err != nil {
+		return err
+	}
+	if a.Period <= 0 {
+		return ErrInvalidAllowance("period must be positive")
+	}
+	if !a.PeriodSpendLimit.IsValid() || a.PeriodSpendLimit.Empty() {
+		return ErrInvalidAllowance("invalid period spend limit " + a.PeriodSpendLimit.String())
+	}
+	if !a.PeriodCanSpend.IsValid() {
+		return ErrInvalidAllowance("invalid period can spend " + a.PeriodCanSpend.String())
+	}
+	if !a.Basic.SpendLimit.Empty() && !a.PeriodSpendLimit.DenomsSubsetOf(a.Basic.SpendLimit) {
+		return ErrInvalidAllowance("period spend limit has denoms not in the spend limit")
+	}
+	return nil
+}
+
+//----------------------------------------
+// AllowedMsgAllowance
+
+var _ FeeAllowance = &AllowedMsgAllowance{}
+
+// AllowedMsgAllowance restricts an allowance to txs containing only the
+// given msgs, identified by "<route>/<type>" (e.g. "bank/send" or
+// "vm/exec"), and to msgs targeting the given package paths. An empty list
+// does not restrict the tx.
+type AllowedMsgAllowance struct {
+	Allowance       FeeAllowance `json:"allowance" yaml:"allowance"`
+	AllowedMsgs     []string     `json:"allowed_msgs" yaml:"allowed_msgs"`
+	AllowedPkgPaths []string     `json:"allowed_pkg_paths" yaml:"allowed_pkg_paths"`
+}
+
+// Accept implements FeeAllowance.
+func (a *AllowedMsgAllowance) Accept(ctx sdk.Context, fee std.Coins, msgs []std.Msg) (bool, error) {
+	for _, msg := range msgs {
+		if err := a.allowMsg(msg); err != nil {
+			return false, err
+		}
+	}
+
+	return a.Allowance.Accept(ctx, fee, msgs)
+}
+
+func (a *AllowedMsgAllowance) allowMsg(msg std.Msg) error {
+	if len(a.AllowedMsgs) > 0 {
+		name := msg.Route() + "/" + msg.Type()
+		if !contains(a.AllowedMsgs, name) {
+			return ErrMsgNotAllowed(name)
+		}
+	}
+
+	if len(a.AllowedPkgPaths) > 0 {
+		pmsg, ok := msg.(PkgPathMsg)
+		if !ok {
+			return ErrMsgNotAllowed(fmt.Sprintf("%s/%s does not target a package", msg.Route(), msg.Type()))
+		}
+		if !contains(a.AllowedPkgPaths, pmsg.GetPkgPath()) {
+			return ErrMsgNotAllowed(pmsg.GetPkgPath())
+		}
+	}
+
+	return nil
+}
+
+// ValidateBasic implements FeeAllowance.
+func (a *AllowedMsgAllowance) ValidateBasic() error {
+	if a.Allowance == nil {
+		return ErrInvalidAllowance("missing allowance")
+	}
+	if _, ok := a.Allowance.(*AllowedMsgAllowance); ok {
+		return ErrInvalidAllowance("nested allowed msg allowance")
+	}
+	if len(a.AllowedMsgs) == 0 && len(a.AllowedPkgPaths) == 0 {
+		return ErrInvalidAllowance("no allowed msgs or package paths")
+	}
+	return a.Allowance.ValidateBasic()
+}
+
+//----------------------------------------
+// misc
+
+func isExpired(ctx sdk.Context, expiration time.Time) bool {
+	return !expiration.IsZero() && !ctx.BlockTime().Before(expiration)
+}
+
+// minCoins returns the smallest amount of each denom of a found in b.
+func minCoins(a, b std.Coins) std.Coins {
+	var res std.Coins
+	for _, coin := range a {
+		amt := b.AmountOf(coin.Denom)
+		if coin.Amount.LT(amt) {
+			amt = coin.Amount
+		}
+		if !amt.IsZero() {
+			res = append(res, std.Coin{Denom: coin.Denom, Amount: amt})
+		}
+	}
+	return res
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package feegrant
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// testPkgMsg is a msg targeting a package.
+type testPkgMsg struct {
+	std.Msg
+	pkgPath string
+}
+
+func (msg testPkgMsg) Route() string      { return "vm" }
+func (msg testPkgMsg) Type() string       { return "exec" }
+func (msg testPkgMsg) GetPkgPath() string { return msg.pkgPath }
+
+func TestBasicAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	t.Run("spend limit", func(t *testing.T) {
+		t.Parallel()
+
+		a := &BasicAllowance{SpendLimit: std.NewCoins(std.NewCoin("ugnot", 100))}
+
+		remove, err := a.Accept(ctx, std.NewCoins(std.NewCoin("ugnot", 60)), nil)
+		require.NoError(t, err)
+		assert.False(t, remove)
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 40)), a.SpendLimit)
+
+		_, err = a.Accept(ctx, std.NewCoins(std.NewCoin("ugnot", 60)), nil)
+		assert.True(t, errors.As(err, &FeeLimitExceededError{}))
+
+		_, err = a.Accept(ctx, std.NewCoins(std.NewCoin("foo", 1)), nil)
+		assert.True(t, errors.As(err, &FeeLimitExceededError{}))
+
+		remove, err = a.Accept(ctx, std.NewCoins(std.NewCoin("ugnot", 40)), nil)
+		require.NoError(t, err)
+		assert.True(t, remove)
+	})
+
+	t.Run("no spend limit", func(t *testing.T) {
+		t.Parallel()
+
+		a := &BasicAllowance{}
+
+		remove, err := a.Accept(ctx, std.NewCoins(std.NewCoin("ugnot", 1000000)), nil)
+		require.NoError(t, err)
+		assert.False(t, remove)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		t.Parallel()
+
+		a := &BasicAllowance{Expiration: testTime.Add(time.Hour)}
+
+		_, err := a.Accept(ctx, std.NewCoins(std.NewCoin("ugnot", 10)), nil)
+		require.NoError(t, err)
+
+		remove, err := a.Accept(withBlockTime(ctx, testTime.Add(time.Hour)), std.NewCoins(std.NewCoin("ugnot", 10)), nil)
+		assert.True(t, errors.As(err, &FeeLimitExpiredError{}))
+		assert.True(t, remove)
+	})
+}
+
+func TestPeriodicAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	a := &PeriodicAllowance{
+		Basic:            BasicAllowance{SpendLimit: std.NewCoins(std.NewCoin("ugnot", 250))},
+		Period:           time.Hour,
+		PeriodSpendLimit: std.NewCoins(std.NewCoin("ugnot", 100)),
+	}
+	require.NoError(t, a.ValidateBasic())
+
+	fee := std.NewCoins(std.NewCoin("ugnot", 60))
+
+	// The first period starts with the first use
+	_, err := a.Accept(ctx, fee, nil)
+	require.NoError(t, err)
+	assert.Equal(t, testTime.Add(time.Hour), a.PeriodReset)
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 40)), a.PeriodCanSpend)
+
+	_, err = a.Accept(withBlockTime(ctx, testTime.Add(time.Minute)), fee, nil)
+	assert.True(t, errors.As(err, &FeeLimitExceededError{}))
+
+	// A new period starts
+	_, err = a.Accept(withBlockTime(ctx, testTime.Add(time.Hour)), fee, nil)
+	require.NoError(t, err)
+	assert.Equal(t, testTime.Add(2*time.Hour), a.PeriodReset)
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 130)), a.Basic.SpendLimit)
+
+	// Skipped periods are not accumulated
+	later := testTime.Add(5*time.Hour + time.Minute)
+	_, err = a.Accept(withBlockTime(ctx, later), fee, nil)
+	require.NoError(t, err)
+	assert.Equal(t, later.Add(time.Hour), a.PeriodReset)
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 70)), a.Basic.SpendLimit)
+
+	// The period spend limit is capped by the spend limit
+	remove, err := a.Accept(withBlockTime(ctx, later.Add(time.Hour)), std.NewCoins(std.NewCoin("ugnot", 70)), nil)
+	require.NoError(t, err)
+	assert.True(t, remove)
+}
+
+func TestAllowedMsgAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+	fee := std.NewCoins(std.NewCoin("ugnot", 10))
+
+	send := bank.NewMsgSend(
+		crypto.AddressFromPreimage([]byte("from")),
+		crypto.AddressFromPreimage([]byte("to")),
+		fee,
+	)
+	call := testPkgMsg{pkgPath: "gno.land/r/demo/boards"}
+
+	t.Run("msg types", func(t *testing.T) {
+		t.Parallel()
+
+		a := &AllowedMsgAllowance{
+			Allowance:   &BasicAllowance{},
+			AllowedMsgs: []string{"vm/exec"},
+		}
+		require.NoError(t, a.ValidateBasic())
+
+		_, err := a.Accept(ctx, fee, []std.Msg{call})
+		require.NoError(t, err)
+
+		_, err = a.Accept(ctx, fee, []std.Msg{call, send})
+		assert.True(t, errors.As(err, &MsgNotAllowedError{}))
+	})
+
+	t.Run("pkg paths", func(t *testing.T) {
+		t.Parallel()
+
+		a := &AllowedMsgAllowance{
+			Allowance:       &BasicAllowance{},
+			AllowedPkgPaths: []string{"gno.land/r/demo/boards"},
+		}
+		require.NoError(t, a.ValidateBasic())
+
+		_, err := a.Accept(ctx, fee, []std.Msg{call})
+		require.NoError(t, err)
+
+		_, err = a.Accept(ctx, fee, []std.Msg{testPkgMsg{pkgPath: "gno.land/r/demo/users"}})
+		assert.True(t, errors.As(err, &MsgNotAllowedError{}))
+
+		_, err = a.Accept(ctx, fee, []std.Msg{send})
+		assert.True(t, errors.As(err, &MsgNotAllowedError{}))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		a := &AllowedMsgAllowance{Allowance: &BasicAllowance{}}
+		assert.True(t, errors.As(a.ValidateBasic(), &InvalidAllowanceError{}))
+	})
+}
@@ -0,0 +1,50 @@
+package feegrant
+
+// DONTCOVER
+
+import (
+	"time"
+
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+type testEnv struct {
+	ctx  sdk.Context
+	fgk  FeeGrantKeeper
+	acck auth.AccountKeeper
+}
+
+func setupTestEnv() testEnv {
+	db := memdb.NewMemDB()
+
+	authCapKey := store.NewStoreKey("authCapKey")
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(authCapKey, iavl.StoreConstructor, db)
+	ms.LoadLatestVersion()
+	paramk := params.NewParamsKeeper(authCapKey, "")
+	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, &bft.Header{ChainID: "test-chain-id", Time: testTime}, log.NewNoopLogger())
+	acck := auth.NewAccountKeeper(
+		authCapKey, paramk, std.ProtoBaseAccount,
+	)
+
+	fgk := NewFeeGrantKeeper(authCapKey, acck)
+
+	return testEnv{ctx: ctx, fgk: fgk, acck: acck}
+}
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// withBlockTime returns ctx at the given block time.
+func withBlockTime(ctx sdk.Context, t time.Time) sdk.Context {
+	return ctx.WithBlockHeader(&bft.Header{ChainID: ctx.ChainID(), Time: t})
+}
@@ -0,0 +1,27 @@
+package feegrant
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	// module name
+	ModuleName = "feegrant"
+
+	// RouterKey is the route of the feegrant messages
+	RouterKey = ModuleName
+
+	// AllowanceStoreKeyPrefix prefix for allowance-by-grantee-and-granter store
+	AllowanceStoreKeyPrefix = "/fg/"
+)
+
+// GranteeStoreKeyPrefix returns the prefix of the keys of the allowances
+// granted to the given grantee.
+func GranteeStoreKeyPrefix(grantee crypto.Address) []byte {
+	return append([]byte(AllowanceStoreKeyPrefix), grantee.Bytes()...)
+}
+
+// AllowanceStoreKey returns the key of the allowance granted by granter to grantee.
+func AllowanceStoreKey(granter, grantee crypto.Address) []byte {
+	return append(GranteeStoreKeyPrefix(grantee), granter.Bytes()...)
+}
@@ -0,0 +1,47 @@
+package feegrant
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// for convenience:
+type abciError struct{}
+
+func (abciError) AssertABCIError() {}
+
+// declare all feegrant errors.
+// NOTE: these are meant to be used in conjunction with pkgs/errors.
+type NoAllowanceError struct{ abciError }
+
+type (
+	InvalidAllowanceError struct{ abciError }
+	FeeLimitExceededError struct{ abciError }
+	FeeLimitExpiredError  struct{ abciError }
+	MsgNotAllowedError    struct{ abciError }
+)
+
+func (e NoAllowanceError) Error() string      { return "fee allowance not found" }
+func (e InvalidAllowanceError) Error() string { return "invalid fee allowance" }
+func (e FeeLimitExceededError) Error() string { return "fee limit exceeded" }
+func (e FeeLimitExpiredError) Error() string  { return "fee allowance expired" }
+func (e MsgNotAllowedError) Error() string    { return "message not allowed by the fee allowance" }
+
+func ErrNoAllowance(msg string) error {
+	return errors.Wrap(NoAllowanceError{}, msg)
+}
+
+func ErrInvalidAllowance(msg string) error {
+	return errors.Wrap(InvalidAllowanceError{}, msg)
+}
+
+func ErrFeeLimitExceeded(msg string) error {
+	return errors.Wrap(FeeLimitExceededError{}, msg)
+}
+
+func ErrFeeLimitExpired(msg string) error {
+	return errors.Wrap(FeeLimitExpiredError{}, msg)
+}
+
+func ErrMsgNotAllowed(msg string) error {
+	return errors.Wrap(MsgNotAllowedError{}, msg)
+}
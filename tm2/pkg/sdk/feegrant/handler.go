@@ -0,0 +1,175 @@
+package feegrant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type feeGrantHandler struct {
+	fgk FeeGrantKeeper
+}
+
+// NewHandler returns a handler for "feegrant" type messages.
+func NewHandler(fgk FeeGrantKeeper) feeGrantHandler {
+	return feeGrantHandler{
+		fgk: fgk,
+	}
+}
+
+func (fh feeGrantHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	switch msg := msg.(type) {
+	case MsgGrantAllowance:
+		return fh.handleMsgGrantAllowance(ctx, msg)
+
+	case MsgRevokeAllowance:
+		return fh.handleMsgRevokeAllowance(ctx, msg)
+
+	default:
+		errMsg := fmt.Sprintf("unrecognized feegrant message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgGrantAllowance.
+func (fh feeGrantHandler) handleMsgGrantAllowance(ctx sdk.Context, msg MsgGrantAllowance) sdk.Result {
+	err := fh.fgk.GrantAllowance(ctx, msg.Granter, msg.Grantee, msg.Allowance)
+	if err != nil {
+		return abciResult(err)
+	}
+
+	return sdk.Result{}
+}
+
+// Handle MsgRevokeAllowance.
+func (fh feeGrantHandler) handleMsgRevokeAllowance(ctx sdk.Context, msg MsgRevokeAllowance) sdk.Result {
+	err := fh.fgk.RevokeAllowance(ctx, msg.Granter, msg.Grantee)
+	if err != nil {
+		return abciResult(err)
+	}
+
+	return sdk.Result{}
+}
+
+//----------------------------------------
+// Query
+
+// query paths
+const (
+	QueryAllowance  = "allowance"  // allowance/<granter>/<grantee>
+	QueryAllowances = "allowances" // allowances/<grantee>
+)
+
+func (fh feeGrantHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	switch secondPart(req.Path) {
+	case QueryAllowance:
+		return fh.queryAllowance(ctx, req)
+	case QueryAllowances:
+		return fh.queryAllowances(ctx, req)
+	default:
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("unknown feegrant query endpoint"))
+		return
+	}
+}
+
+// queryAllowance fetches the allowance granted by a granter to a grantee.
+// Both addresses are passed as path components.
+func (fh feeGrantHandler) queryAllowance(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	parts := strings.Split(req.Path, "/")
+	if len(parts) != 4 {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("expected allowance/<granter>/<grantee>"))
+		return
+	}
+
+	granter, err := crypto.AddressFromBech32(parts[2])
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid granter address " + parts[2]))
+		return
+	}
+	grantee, err := crypto.AddressFromBech32(parts[3])
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid grantee address " + parts[3]))
+		return
+	}
+
+	allowance := fh.fgk.GetAllowance(ctx, granter, grantee)
+	if allowance == nil {
+		res = sdk.ABCIResponseQueryFromError(
+			ErrNoAllowance(fmt.Sprintf("granter %s, grantee %s", granter, grantee)))
+		return
+	}
+
+	bz, err := amino.MarshalJSONIndent(Grant{granter, grantee, allowance}, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+// queryAllowances fetches all the allowances granted to a grantee.
+// The grantee address is passed as path component.
+func (fh feeGrantHandler) queryAllowances(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	b32addr := thirdPart(req.Path)
+	grantee, err := crypto.AddressFromBech32(b32addr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query address " + b32addr))
+		return
+	}
+
+	grants := []Grant{}
+	fh.fgk.IterateAllowances(ctx, grantee, func(grant Grant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+
+	bz, err := amino.MarshalJSONIndent(grants, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+//----------------------------------------
+// misc
+
+func abciResult(err error) sdk.Result {
+	return sdk.ABCIResultFromError(err)
+}
+
+// returns the second component of a path.
+func secondPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return ""
+	} else {
+		return parts[1]
+	}
+}
+
+// returns the third component of a path.
+func thirdPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return ""
+	} else {
+		return parts[2]
+	}
+}
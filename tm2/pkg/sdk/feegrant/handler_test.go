@@ -0,0 +1,101 @@
+package feegrant
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(FeeGrantKeeper{})
+	res := h.Process(sdk.NewContext(sdk.RunTxModeDeliver, nil, &bft.Header{ChainID: "test-chain"}, nil), tu.NewTestMsg())
+	require.False(t, res.IsOK())
+	require.True(t, strings.Contains(res.Log, "unrecognized feegrant message type"))
+}
+
+func TestGrantRevoke(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.fgk)
+
+	granter := crypto.AddressFromPreimage([]byte("granter"))
+	grantee := crypto.AddressFromPreimage([]byte("grantee"))
+
+	allowance := &BasicAllowance{SpendLimit: std.NewCoins(std.NewCoin("ugnot", 100))}
+	msg := NewMsgGrantAllowance(granter, grantee, allowance)
+	require.NoError(t, msg.ValidateBasic())
+
+	res := h.Process(env.ctx, msg)
+	require.True(t, res.IsOK())
+
+	// Query the allowance
+	res2 := h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("feegrant/%s/%s/%s", QueryAllowance, granter, grantee),
+	})
+	require.Nil(t, res2.Error)
+
+	var grant Grant
+	require.NoError(t, amino.UnmarshalJSON(res2.Data, &grant))
+	require.Equal(t, Grant{granter, grantee, allowance}, grant)
+
+	// Query the allowances of the grantee
+	res2 = h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("feegrant/%s/%s", QueryAllowances, grantee),
+	})
+	require.Nil(t, res2.Error)
+
+	var grants []Grant
+	require.NoError(t, amino.UnmarshalJSON(res2.Data, &grants))
+	require.Equal(t, []Grant{grant}, grants)
+
+	// Revoke
+	res = h.Process(env.ctx, NewMsgRevokeAllowance(granter, grantee))
+	require.True(t, res.IsOK())
+
+	res = h.Process(env.ctx, NewMsgRevokeAllowance(granter, grantee))
+	require.False(t, res.IsOK())
+
+	res2 = h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("feegrant/%s/%s/%s", QueryAllowance, granter, grantee),
+	})
+	require.NotNil(t, res2.Error)
+}
+
+func TestMsgValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	granter := crypto.AddressFromPreimage([]byte("granter"))
+	grantee := crypto.AddressFromPreimage([]byte("grantee"))
+
+	require.Error(t, NewMsgGrantAllowance(granter, granter, &BasicAllowance{}).ValidateBasic())
+	require.Error(t, NewMsgGrantAllowance(crypto.Address{}, grantee, &BasicAllowance{}).ValidateBasic())
+	require.Error(t, NewMsgGrantAllowance(granter, grantee, nil).ValidateBasic())
+	require.Error(t, NewMsgRevokeAllowance(granter, crypto.Address{}).ValidateBasic())
+	require.NoError(t, NewMsgRevokeAllowance(granter, grantee).ValidateBasic())
+}
+
+func TestQuerierRouteNotFound(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.fgk)
+	req := abci.RequestQuery{
+		Path: "feegrant/notfound",
+		Data: []byte{},
+	}
+	res := h.Query(env.ctx, req)
+	require.Error(t, res.Error)
+}
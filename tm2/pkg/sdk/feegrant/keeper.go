@@ -0,0 +1,143 @@
+package feegrant
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// FeeGrantKeeperI manages the fee allowances granted between accounts.
+type FeeGrantKeeperI interface {
+	auth.FeeGrantKeeperI
+
+	GrantAllowance(ctx sdk.Context, granter, grantee crypto.Address, allowance FeeAllowance) error
+	RevokeAllowance(ctx sdk.Context, granter, grantee crypto.Address) error
+	GetAllowance(ctx sdk.Context, granter, grantee crypto.Address) FeeAllowance
+	IterateAllowances(ctx sdk.Context, grantee crypto.Address, process func(Grant) (stop bool))
+}
+
+var _ FeeGrantKeeperI = FeeGrantKeeper{}
+
+// FeeGrantKeeper stores the fee allowances. It implements FeeGrantKeeperI.
+type FeeGrantKeeper struct {
+	// The (unexposed) key used to access the store from the Context.
+	key  store.StoreKey
+	acck auth.AccountKeeperI
+}
+
+// NewFeeGrantKeeper returns a new FeeGrantKeeper.
+func NewFeeGrantKeeper(key store.StoreKey, acck auth.AccountKeeperI) FeeGrantKeeper {
+	return FeeGrantKeeper{
+		key:  key,
+		acck: acck,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (fgk FeeGrantKeeper) Logger(ctx sdk.Context) *slog.Logger {
+	return ctx.Logger().With("module", ModuleName)
+}
+
+// GrantAllowance grants the allowance to grantee, replacing any existing
+// one. The account of the grantee is created if it does not exist yet, so
+// that a new user can sign their first txs without holding any coins.
+func (fgk FeeGrantKeeper) GrantAllowance(ctx sdk.Context, granter, grantee crypto.Address, allowance FeeAllowance) error {
+	if err := allowance.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if fgk.acck.GetAccount(ctx, grantee) == nil {
+		fgk.acck.SetAccount(ctx, fgk.acck.NewAccountWithAddress(ctx, grantee))
+	}
+
+	fgk.setAllowance(ctx, granter, grantee, allowance)
+	return nil
+}
+
+// RevokeAllowance deletes the allowance granted to grantee.
+func (fgk FeeGrantKeeper) RevokeAllowance(ctx sdk.Context, granter, grantee crypto.Address) error {
+	if fgk.GetAllowance(ctx, granter, grantee) == nil {
+		return ErrNoAllowance(fmt.Sprintf("granter %s, grantee %s", granter, grantee))
+	}
+
+	stor := ctx.GasStore(fgk.key)
+	stor.Delete(AllowanceStoreKey(granter, grantee))
+	return nil
+}
+
+// GetAllowance returns the allowance granted to grantee, or nil.
+func (fgk FeeGrantKeeper) GetAllowance(ctx sdk.Context, granter, grantee crypto.Address) FeeAllowance {
+	stor := ctx.GasStore(fgk.key)
+	bz := stor.Get(AllowanceStoreKey(granter, grantee))
+	if bz == nil {
+		return nil
+	}
+	return decodeAllowance(bz)
+}
+
+// IterateAllowances iterates over the allowances granted to grantee.
+func (fgk FeeGrantKeeper) IterateAllowances(ctx sdk.Context, grantee crypto.Address, process func(Grant) (stop bool)) {
+	stor := ctx.GasStore(fgk.key)
+	prefix := GranteeStoreKeyPrefix(grantee)
+	iter := store.PrefixIterator(stor, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		granter := crypto.AddressFromBytes(iter.Key()[len(prefix):])
+		grant := Grant{
+			Granter:   granter,
+			Grantee:   grantee,
+			Allowance: decodeAllowance(iter.Value()),
+		}
+		if process(grant) {
+			return
+		}
+	}
+}
+
+// UseGrantedFees implements auth.FeeGrantKeeperI. It checks that the
+// allowance granted to grantee covers the fee of a tx containing msgs, and
+// updates or deletes it. The fee itself is deducted by the caller.
+func (fgk FeeGrantKeeper) UseGrantedFees(ctx sdk.Context, granter, grantee crypto.Address, fee std.Coins, msgs []std.Msg) error {
+	allowance := fgk.GetAllowance(ctx, granter, grantee)
+	if allowance == nil {
+		return ErrNoAllowance(fmt.Sprintf("granter %s, grantee %s", granter, grantee))
+	}
+
+	// NOTE: the state changes of a failing ante handler are discarded, so
+	// expired allowances are only deleted when revoked.
+	remove, err := allowance.Accept(ctx, fee, msgs)
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		stor := ctx.GasStore(fgk.key)
+		stor.Delete(AllowanceStoreKey(granter, grantee))
+	} else {
+		fgk.setAllowance(ctx, granter, grantee, allowance)
+	}
+	return nil
+}
+
+func (fgk FeeGrantKeeper) setAllowance(ctx sdk.Context, granter, grantee crypto.Address, allowance FeeAllowance) {
+	stor := ctx.GasStore(fgk.key)
+	bz, err := amino.MarshalAny(allowance)
+	if err != nil {
+		panic(err)
+	}
+	stor.Set(AllowanceStoreKey(granter, grantee), bz)
+}
+
+func decodeAllowance(bz []byte) (allowance FeeAllowance) {
+	err := amino.UnmarshalAny(bz, &allowance)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
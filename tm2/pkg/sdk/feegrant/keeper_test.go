@@ -0,0 +1,83 @@
+package feegrant
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestKeeper(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	granter := crypto.AddressFromPreimage([]byte("granter"))
+	granter2 := crypto.AddressFromPreimage([]byte("granter2"))
+	grantee := crypto.AddressFromPreimage([]byte("grantee"))
+
+	fee := std.NewCoins(std.NewCoin("ugnot", 60))
+
+	// No allowance
+	require.Nil(t, env.fgk.GetAllowance(ctx, granter, grantee))
+	err := env.fgk.UseGrantedFees(ctx, granter, grantee, fee, nil)
+	assert.True(t, errors.As(err, &NoAllowanceError{}))
+
+	// Granting creates the account of the grantee
+	require.Nil(t, env.acck.GetAccount(ctx, grantee))
+	allowance := &BasicAllowance{SpendLimit: std.NewCoins(std.NewCoin("ugnot", 100))}
+	require.NoError(t, env.fgk.GrantAllowance(ctx, granter, grantee, allowance))
+	require.NotNil(t, env.acck.GetAccount(ctx, grantee))
+	require.Equal(t, allowance, env.fgk.GetAllowance(ctx, granter, grantee))
+
+	require.NoError(t, env.fgk.GrantAllowance(ctx, granter2, grantee, &BasicAllowance{}))
+
+	var grants []Grant
+	env.fgk.IterateAllowances(ctx, grantee, func(grant Grant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	require.Len(t, grants, 2)
+	for _, grant := range grants {
+		assert.Equal(t, grantee, grant.Grantee)
+		assert.Equal(t, grant.Allowance, env.fgk.GetAllowance(ctx, grant.Granter, grantee))
+	}
+
+	// Using the allowance updates it
+	require.NoError(t, env.fgk.UseGrantedFees(ctx, granter, grantee, fee, nil))
+	assert.Equal(t,
+		std.NewCoins(std.NewCoin("ugnot", 40)),
+		env.fgk.GetAllowance(ctx, granter, grantee).(*BasicAllowance).SpendLimit,
+	)
+
+	err = env.fgk.UseGrantedFees(ctx, granter, grantee, fee, nil)
+	assert.True(t, errors.As(err, &FeeLimitExceededError{}))
+
+	// Using it up deletes it
+	require.NoError(t, env.fgk.UseGrantedFees(ctx, granter, grantee, std.NewCoins(std.NewCoin("ugnot", 40)), nil))
+	require.Nil(t, env.fgk.GetAllowance(ctx, granter, grantee))
+
+	// Revoke
+	require.NoError(t, env.fgk.RevokeAllowance(ctx, granter2, grantee))
+	require.Nil(t, env.fgk.GetAllowance(ctx, granter2, grantee))
+	err = env.fgk.RevokeAllowance(ctx, granter2, grantee)
+	assert.True(t, errors.As(err, &NoAllowanceError{}))
+}
+
+func TestKeeper_InvalidAllowance(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+
+	granter := crypto.AddressFromPreimage([]byte("granter"))
+	grantee := crypto.AddressFromPreimage([]byte("grantee"))
+
+	err := env.fgk.GrantAllowance(env.ctx, granter, grantee, &PeriodicAllowance{})
+	assert.True(t, errors.As(err, &InvalidAllowanceError{}))
+	assert.Nil(t, env.acck.GetAccount(env.ctx, grantee))
+}
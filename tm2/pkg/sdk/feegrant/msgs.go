@@ -0,0 +1,96 @@
+package feegrant
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// MsgGrantAllowance - grant an allowance to pay the fees of the grantee,
+// replacing any existing one
+type MsgGrantAllowance struct {
+	Granter   crypto.Address `json:"granter" yaml:"granter"`
+	Grantee   crypto.Address `json:"grantee" yaml:"grantee"`
+	Allowance FeeAllowance   `json:"allowance" yaml:"allowance"`
+}
+
+var _ std.Msg = MsgGrantAllowance{}
+
+// NewMsgGrantAllowance - construct a msg granting the allowance to grantee.
+func NewMsgGrantAllowance(granter, grantee crypto.Address, allowance FeeAllowance) MsgGrantAllowance {
+	return MsgGrantAllowance{Granter: granter, Grantee: grantee, Allowance: allowance}
+}
+
+// Route Implements Msg.
+func (msg MsgGrantAllowance) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgGrantAllowance) Type() string { return "grant" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgGrantAllowance) ValidateBasic() error {
+	if err := validateGranterGrantee(msg.Granter, msg.Grantee); err != nil {
+		return err
+	}
+	if msg.Allowance == nil {
+		return ErrInvalidAllowance("missing allowance")
+	}
+	return msg.Allowance.ValidateBasic()
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgGrantAllowance) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgGrantAllowance) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
+
+// MsgRevokeAllowance - revoke the allowance granted to the grantee
+type MsgRevokeAllowance struct {
+	Granter crypto.Address `json:"granter" yaml:"granter"`
+	Grantee crypto.Address `json:"grantee" yaml:"grantee"`
+}
+
+var _ std.Msg = MsgRevokeAllowance{}
+
+// NewMsgRevokeAllowance - construct a msg revoking the allowance of grantee.
+func NewMsgRevokeAllowance(granter, grantee crypto.Address) MsgRevokeAllowance {
+	return MsgRevokeAllowance{Granter: granter, Grantee: grantee}
+}
+
+// Route Implements Msg.
+func (msg MsgRevokeAllowance) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgRevokeAllowance) Type() string { return "revoke" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgRevokeAllowance) ValidateBasic() error {
+	return validateGranterGrantee(msg.Granter, msg.Grantee)
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRevokeAllowance) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRevokeAllowance) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Granter}
+}
+
+func validateGranterGrantee(granter, grantee crypto.Address) error {
+	if granter.IsZero() {
+		return std.ErrInvalidAddress("missing granter address")
+	}
+	if grantee.IsZero() {
+		return std.ErrInvalidAddress("missing grantee address")
+	}
+	if granter == grantee {
+		return std.ErrInvalidAddress("granter and grantee must differ")
+	}
+	return nil
+}
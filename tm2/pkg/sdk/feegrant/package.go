@@ -0,0 +1,23 @@
+package feegrant
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+var Package = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/sdk/feegrant",
+	"feegrant",
+	amino.GetCallersDirname(),
+).WithDependencies().WithTypes(
+	NoAllowanceError{}, "NoAllowanceError",
+	InvalidAllowanceError{}, "InvalidAllowanceError",
+	FeeLimitExceededError{}, "FeeLimitExceededError",
+	FeeLimitExpiredError{}, "FeeLimitExpiredError",
+	MsgNotAllowedError{}, "MsgNotAllowedError",
+	&BasicAllowance{}, "BasicAllowance",
+	&PeriodicAllowance{}, "PeriodicAllowance",
+	&AllowedMsgAllowance{}, "AllowedMsgAllowance",
+	Grant{}, "Grant",
+	MsgGrantAllowance{}, "MsgGrantAllowance",
+	MsgRevokeAllowance{}, "MsgRevokeAllowance",
+))
@@ -12,13 +12,13 @@ var isAlphaNumeric = regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString
 func (app *BaseApp) Check(tx Tx) (result Result) {
 	ctx := app.getContextForTx(RunTxModeCheck, nil)
 
-	return app.runTx(ctx, tx)
+	return app.runTx(ctx, tx, nil)
 }
 
 func (app *BaseApp) Simulate(txBytes []byte, tx Tx) (result Result) {
 	ctx := app.getContextForTx(RunTxModeSimulate, txBytes)
 
-	return app.runTx(ctx, tx)
+	return app.runTx(ctx, tx, nil)
 }
 
 func (app *BaseApp) Deliver(tx Tx, ctxFns ...ContextFn) (result Result) {
@@ -32,7 +32,7 @@ func (app *BaseApp) Deliver(tx Tx, ctxFns ...ContextFn) (result Result) {
 		ctx = ctxFn(ctx)
 	}
 
-	return app.runTx(ctx, tx)
+	return app.runTx(ctx, tx, nil)
 }
 
 // ContextFn is the custom execution context builder.
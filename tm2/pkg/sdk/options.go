@@ -99,3 +99,14 @@ func (app *BaseApp) SetEndTxHook(endTx EndTxHook) {
 	}
 	app.endTxHook = endTx
 }
+
+// SetParallelTxs enables the parallel execution of the transactions of each
+// block by the given number of workers, see parallel.go. filter selects the
+// transactions which may be executed in parallel; all of them may if nil.
+func (app *BaseApp) SetParallelTxs(workers int, filter ParallelTxFilter) {
+	if app.sealed {
+		panic("SetParallelTxs() on sealed BaseApp")
+	}
+	app.parallelTxs = workers
+	app.parallelTxFilter = filter
+}
@@ -0,0 +1,217 @@
+package sdk
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/tracking"
+)
+
+// Parallel execution of the transactions of a block.
+//
+// When enabled with [BaseApp.SetParallelTxs], BeginBlock executes the
+// transactions of the block speculatively, in parallel, each on top of the
+// state at the beginning of the block, while recording the values they read
+// from the multistore, and the application state outside of it they access
+// (see [AccessSet]).
+//
+// DeliverTx then goes through the transactions in block order, as usual. The
+// ante handler is always run again; if everything read by the speculative
+// execution of the messages is unchanged after it, its result and writes are
+// used as is, otherwise the messages are executed again. Either way, the
+// results are the same as those of a sequential execution.
+
+// AccessSet records the keys of the application state kept outside of the
+// multistore, like caches shared by transactions, read and written by a
+// transaction. During the parallel execution of a block, the context of the
+// messages of each transaction has an AccessSet, which the application must
+// fill for such state.
+type AccessSet struct {
+	reads  map[string]struct{}
+	writes map[string]struct{}
+}
+
+// NewAccessSet returns a new, empty AccessSet.
+func NewAccessSet() *AccessSet {
+	return &AccessSet{
+		reads:  make(map[string]struct{}),
+		writes: make(map[string]struct{}),
+	}
+}
+
+// AddRead records that the state at key was read.
+func (as *AccessSet) AddRead(key string) {
+	as.reads[key] = struct{}{}
+}
+
+// AddWrite records that the state at key was written.
+func (as *AccessSet) AddWrite(key string) {
+	as.writes[key] = struct{}{}
+}
+
+// speculation is the result of the speculative execution of a transaction.
+type speculation struct {
+	ms        tracking.MultiStore
+	gasWanted int64   // as returned by the ante handler
+	anteGas   int64   // gas consumed by the ante handler
+	ctx       Context // context of the messages
+	result    Result  // result of the messages
+	executed  bool    // false if the messages were not executed
+}
+
+// parallelBlock is the state of the parallel execution of a block.
+type parallelBlock struct {
+	txs     [][]byte
+	specs   []*speculation      // nil for txs which were not speculated
+	next    int                 // index of the next tx to deliver
+	written map[string]struct{} // AccessSet writes of the committed txs
+	barrier bool                // whether no speculation can be used anymore
+}
+
+// nextSpeculation returns the speculation of the next tx to deliver, txBytes,
+// or nil if there is none.
+func (pb *parallelBlock) nextSpeculation(txBytes []byte) *speculation {
+	if pb == nil {
+		return nil
+	}
+	i := pb.next
+	pb.next++
+	if i >= len(pb.txs) || !bytes.Equal(pb.txs[i], txBytes) {
+		// not the txs passed to BeginBlock.
+		pb.barrier = true
+		return nil
+	}
+	return pb.specs[i]
+}
+
+// commit records the messages of a tx as committed. Those of txs which were
+// not speculated may have accessed anything, so no speculation can be used
+// after them.
+func (pb *parallelBlock) commit(ctx Context, spec *speculation) {
+	if spec == nil {
+		pb.barrier = true
+		return
+	}
+	for key := range ctx.AccessSet().writes {
+		pb.written[key] = struct{}{}
+	}
+}
+
+// valid reports whether the speculation spec can be used in place of the
+// execution of the messages of its tx, after the ante handler returned ctx
+// and gasWanted.
+func (pb *parallelBlock) valid(spec *speculation, ctx Context, gasWanted int64) bool {
+	if !spec.executed || pb.barrier {
+		return false
+	}
+	if spec.gasWanted != gasWanted ||
+		spec.anteGas != ctx.GasMeter().GasConsumed() ||
+		spec.ctx.GasMeter().Limit() != ctx.GasMeter().Limit() {
+		return false
+	}
+	if !spec.ms.Valid(ctx.MultiStore()) {
+		return false
+	}
+	for key := range spec.ctx.AccessSet().reads {
+		if _, ok := pb.written[key]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// speculateTxs executes txs speculatively and in parallel, on top of the
+// current deliver state, and sets app.parallel.
+func (app *BaseApp) speculateTxs(txs [][]byte) {
+	pb := &parallelBlock{
+		txs:     txs,
+		specs:   make([]*speculation, len(txs)),
+		written: make(map[string]struct{}),
+	}
+	ctxs := make([]Context, len(txs))
+	for i, tx := range txs {
+		ctxs[i] = app.getContextForTx(RunTxModeDeliver, tx)
+	}
+
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for range min(app.parallelTxs, len(txs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				pb.specs[i] = app.speculate(ctxs[i], txs[i])
+			}
+		}()
+	}
+	for i := range txs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	app.parallel = pb
+}
+
+// speculate executes the tx txBytes like runTx, but on a tracking store on top
+// of the multistore of ctx, without committing anything. It runs concurrently
+// with the speculation of the other txs of the block.
+func (app *BaseApp) speculate(ctx Context, txBytes []byte) (spec *speculation) {
+	var tx Tx
+	if err := amino.Unmarshal(txBytes, &tx); err != nil {
+		return nil
+	}
+	if app.parallelTxFilter != nil && !app.parallelTxFilter(tx) {
+		return nil
+	}
+	msgs := tx.GetMsgs()
+	if err := validateBasicTxMsgs(msgs); err != nil {
+		return nil
+	}
+
+	spec = &speculation{
+		ms: tracking.NewMultiStore(ctx.MultiStore(), app.storeKeys),
+	}
+	defer func() {
+		// The tx will be executed again, and panic again if it must.
+		if r := recover(); r != nil {
+			spec.executed = false
+		}
+	}()
+
+	ctx = ctx.
+		WithMultiStore(spec.ms).
+		WithGasMeter(store.NewInfiniteGasMeter()).
+		WithBlockGasMeter(store.NewInfiniteGasMeter()).
+		WithEventLogger(NewEventLogger()).
+		WithSpeculative(true)
+
+	if app.anteHandler != nil {
+		anteCtx, msCache := app.cacheTxContext(ctx)
+		newCtx, result, abort := app.anteHandler(anteCtx, tx, false)
+		if abort {
+			return spec
+		}
+		ctx = newCtx.WithMultiStore(spec.ms)
+		msCache.MultiWrite()
+		spec.gasWanted = result.GasWanted
+	}
+	spec.anteGas = ctx.GasMeter().GasConsumed()
+
+	// Only track the messages, as the ante handler is run again anyway.
+	spec.ms.Track()
+	runMsgCtx, msCache := app.cacheTxContext(ctx)
+	runMsgCtx = runMsgCtx.WithAccessSet(NewAccessSet())
+	if app.beginTxHook != nil {
+		runMsgCtx = app.beginTxHook(runMsgCtx)
+	}
+	spec.result = app.runMsgs(runMsgCtx, msgs, RunTxModeDeliver)
+	if spec.result.IsOK() {
+		msCache.MultiWrite()
+	}
+	spec.ctx = runMsgCtx
+	spec.executed = true
+	return spec
+}
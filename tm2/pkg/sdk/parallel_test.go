@@ -0,0 +1,171 @@
+package sdk
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+type pendingKey struct{}
+
+// parallelTestApp is an app whose messages read and write counters in the
+// main store, or an int kept outside of it, committed by the end tx hook.
+type parallelTestApp struct {
+	*BaseApp
+	outside int64
+	calls   atomic.Int64 // number of messages processed
+}
+
+func newParallelTestApp(t *testing.T, workers int) *parallelTestApp {
+	t.Helper()
+
+	pta := &parallelTestApp{}
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx Context, tx Tx, simulate bool) (newCtx Context, res Result, abort bool) {
+			newCtx = ctx.WithGasMeter(store.NewGasMeter(100000))
+			if getFailOnAnte(tx) {
+				res.Error = ABCIError(std.ErrInternal("ante handler failure"))
+				return newCtx, res, true
+			}
+			// all the txs conflict in the ante handler.
+			st := newCtx.GasStore(mainKey)
+			setIntOnStore(st, []byte("fees"), getIntFromStore(st, []byte("fees"))+1)
+			res.GasWanted = 100000
+			return
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		process := func(ctx Context, msg Msg) (res Result) {
+			pta.calls.Add(1)
+			switch m := msg.(type) {
+			case msgCounter:
+				if m.FailOnHandler {
+					res.Error = ABCIError(std.ErrInternal("message handler failure"))
+					return
+				}
+				ctx.GasMeter().ConsumeGas(m.Counter*2000, "counter-handler")
+				key := []byte(fmt.Sprintf("k%d", m.Counter))
+				v := getIntFromStore(ctx.Store(mainKey), key)
+				setIntOnStore(ctx.Store(mainKey), key, v+1)
+				res.Data = []byte(fmt.Sprint(v))
+			case msgCounter2:
+				if as := ctx.AccessSet(); as != nil {
+					as.AddRead("outside")
+				}
+				res.Data = []byte(fmt.Sprint(pta.outside))
+				if m.Counter > 0 {
+					if as := ctx.AccessSet(); as != nil {
+						as.AddWrite("outside")
+					}
+					*ctx.Value(pendingKey{}).(*int64) = pta.outside + m.Counter
+				}
+			}
+			return
+		}
+		bapp.Router().AddRoute(routeMsgCounter, newTestHandler(process))
+		bapp.Router().AddRoute(routeMsgCounter2, newTestHandler(process))
+	}
+	hooksOpt := func(bapp *BaseApp) {
+		bapp.SetBeginTxHook(func(ctx Context) Context {
+			pending := new(int64)
+			*pending = -1
+			return ctx.WithValue(pendingKey{}, pending)
+		})
+		bapp.SetEndTxHook(func(ctx Context, result Result) {
+			if pending := *ctx.Value(pendingKey{}).(*int64); result.IsOK() && pending >= 0 {
+				pta.outside = pending
+			}
+		})
+	}
+	parallelOpt := func(bapp *BaseApp) {
+		if workers > 0 {
+			bapp.SetParallelTxs(workers, func(tx Tx) bool {
+				// msgCounter 7 can't be executed in parallel.
+				for _, msg := range tx.GetMsgs() {
+					if m, ok := msg.(msgCounter); ok && m.Counter == 7 {
+						return false
+					}
+				}
+				return true
+			})
+		}
+	}
+	pta.BaseApp = setupBaseApp(t, anteOpt, routerOpt, hooksOpt, parallelOpt)
+	pta.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+	return pta
+}
+
+func (pta *parallelTestApp) deliverBlock(t *testing.T, height int64, txs []std.Tx) ([]abci.ResponseDeliverTx, []byte) {
+	t.Helper()
+
+	txsBytes := make([][]byte, len(txs))
+	for i, tx := range txs {
+		bz, err := amino.Marshal(tx)
+		require.NoError(t, err)
+		txsBytes[i] = bz
+	}
+
+	header := &bft.Header{ChainID: "test-chain", Height: height}
+	pta.BeginBlock(abci.RequestBeginBlock{Header: header, Txs: txsBytes})
+	responses := make([]abci.ResponseDeliverTx, len(txsBytes))
+	for i, bz := range txsBytes {
+		responses[i] = pta.DeliverTx(abci.RequestDeliverTx{Tx: bz})
+	}
+	pta.EndBlock(abci.RequestEndBlock{})
+	return responses, pta.Commit().Data
+}
+
+func TestParallelTxs(t *testing.T) {
+	t.Parallel()
+
+	failOnAnte := newTxCounter(0, 3)
+	setFailOnAnte(&failOnAnte, true)
+	failOnHandler := newTxCounter(0, 2)
+	setFailOnHandler(&failOnHandler, true)
+	txs := []std.Tx{
+		newTxCounter(0, 0),
+		newTxCounter(0, 1),
+		newTxCounter(0, 0), // conflicts with tx 0
+		failOnHandler,
+		failOnAnte,
+		{Msgs: []Msg{msgCounter2{Counter: 5}}, Memo: "{}"},
+		{Msgs: []Msg{msgCounter2{Counter: 0}}, Memo: "{}"}, // conflicts with tx 5
+		newTxCounter(0, 100), // out of gas
+		newTxCounter(0, 5, 6),
+		newTxCounter(0, 1), // conflicts with tx 1
+		newTxCounter(0, 9),
+		newTxCounter(0, 7), // not executed in parallel
+		newTxCounter(0, 8),
+	}
+
+	seq := newParallelTestApp(t, 0)
+	par := newParallelTestApp(t, 4)
+	for height := int64(1); height <= 2; height++ {
+		seqCalls, parCalls := seq.calls.Load(), par.calls.Load()
+		seqResponses, seqHash := seq.deliverBlock(t, height, txs)
+		parResponses, parHash := par.deliverBlock(t, height, txs)
+
+		require.Equal(t, seqResponses, parResponses)
+		require.Equal(t, seqHash, parHash)
+		require.Equal(t, seq.outside, par.outside)
+		// the conflicting txs see the writes of the previous ones.
+		assert.Equal(t, []byte(fmt.Sprint(2*height-1)), parResponses[2].Data)
+		assert.Equal(t, []byte(fmt.Sprint(5*height)), parResponses[6].Data)
+
+		// some speculations were used, and some were not.
+		seqCalls = seq.calls.Load() - seqCalls
+		parCalls = par.calls.Load() - parCalls
+		assert.Greater(t, parCalls, seqCalls)
+		assert.Less(t, parCalls, 2*seqCalls)
+	}
+	assert.Equal(t, int64(10), seq.outside)
+}
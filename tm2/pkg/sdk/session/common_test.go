@@ -0,0 +1,76 @@
+package session
+
+// DONTCOVER
+
+import (
+	"time"
+
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+type testEnv struct {
+	ctx sdk.Context
+	sk  SessionKeeper
+}
+
+func setupTestEnv() testEnv {
+	db := memdb.NewMemDB()
+
+	sessionCapKey := store.NewStoreKey("sessionCapKey")
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(sessionCapKey, iavl.StoreConstructor, db)
+	ms.LoadLatestVersion()
+	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, &bft.Header{ChainID: "test-chain-id", Time: testTime}, log.NewNoopLogger())
+
+	sk := NewSessionKeeper(sessionCapKey)
+
+	return testEnv{ctx: ctx, sk: sk}
+}
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// withBlockTime returns ctx at the given block time.
+func withBlockTime(ctx sdk.Context, t time.Time) sdk.Context {
+	return ctx.WithBlockHeader(&bft.Header{ChainID: ctx.ChainID(), Time: t})
+}
+
+// testCallMsg is a msg calling a function of a realm.
+type testCallMsg struct {
+	std.Msg
+	caller  crypto.Address
+	pkgPath string
+	fn      string
+	send    std.Coins
+}
+
+func (msg testCallMsg) Route() string                { return "vm" }
+func (msg testCallMsg) Type() string                 { return "exec" }
+func (msg testCallMsg) GetSigners() []crypto.Address { return []crypto.Address{msg.caller} }
+func (msg testCallMsg) GetPkgPath() string           { return msg.pkgPath }
+func (msg testCallMsg) GetFunc() string              { return msg.fn }
+func (msg testCallMsg) GetReceived() std.Coins       { return msg.send }
+
+// newTestSession returns a session of account, allowing the calls of
+// gno.land/r/demo/game.Move and of any function of gno.land/r/demo/chat.
+func newTestSession(account crypto.Address, spendLimit std.Coins) Session {
+	return Session{
+		Account: account,
+		PubKey:  ed25519.GenPrivKeyFromSecret([]byte("session")).PubKey(),
+		Scopes: []Scope{
+			{PkgPath: "gno.land/r/demo/game", Funcs: []string{"Move"}},
+			{PkgPath: "gno.land/r/demo/chat"},
+		},
+		SpendLimit: spendLimit,
+		Expiration: testTime.Add(time.Hour),
+	}
+}
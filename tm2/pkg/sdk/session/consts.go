@@ -0,0 +1,28 @@
+package session
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	// module name
+	ModuleName = "session"
+
+	// RouterKey is the route of the session messages
+	RouterKey = ModuleName
+
+	// SessionStoreKeyPrefix prefix for session-by-account-and-key store
+	SessionStoreKeyPrefix = "/sk/"
+)
+
+// AccountStoreKeyPrefix returns the prefix of the keys of the sessions of
+// the given account.
+func AccountStoreKeyPrefix(account crypto.Address) []byte {
+	return append([]byte(SessionStoreKeyPrefix), account.Bytes()...)
+}
+
+// SessionStoreKey returns the key of the session of account, whose key has
+// the given address.
+func SessionStoreKey(account, address crypto.Address) []byte {
+	return append(AccountStoreKeyPrefix(account), address.Bytes()...)
+}
@@ -0,0 +1,47 @@
+package session
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// for convenience:
+type abciError struct{}
+
+func (abciError) AssertABCIError() {}
+
+// declare all session errors.
+// NOTE: these are meant to be used in conjunction with pkgs/errors.
+type NoSessionError struct{ abciError }
+
+type (
+	InvalidSessionError     struct{ abciError }
+	SessionExpiredError     struct{ abciError }
+	SpendLimitExceededError struct{ abciError }
+	MsgNotAllowedError      struct{ abciError }
+)
+
+func (e NoSessionError) Error() string          { return "session not found" }
+func (e InvalidSessionError) Error() string     { return "invalid session" }
+func (e SessionExpiredError) Error() string     { return "session expired" }
+func (e SpendLimitExceededError) Error() string { return "session spend limit exceeded" }
+func (e MsgNotAllowedError) Error() string      { return "message not allowed by the session" }
+
+func ErrNoSession(msg string) error {
+	return errors.Wrap(NoSessionError{}, msg)
+}
+
+func ErrInvalidSession(msg string) error {
+	return errors.Wrap(InvalidSessionError{}, msg)
+}
+
+func ErrSessionExpired(msg string) error {
+	return errors.Wrap(SessionExpiredError{}, msg)
+}
+
+func ErrSpendLimitExceeded(msg string) error {
+	return errors.Wrap(SpendLimitExceededError{}, msg)
+}
+
+func ErrMsgNotAllowed(msg string) error {
+	return errors.Wrap(MsgNotAllowedError{}, msg)
+}
@@ -0,0 +1,175 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type sessionHandler struct {
+	sk SessionKeeper
+}
+
+// NewHandler returns a handler for "session" type messages.
+func NewHandler(sk SessionKeeper) sessionHandler {
+	return sessionHandler{
+		sk: sk,
+	}
+}
+
+func (sh sessionHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	switch msg := msg.(type) {
+	case MsgCreateSession:
+		return sh.handleMsgCreateSession(ctx, msg)
+
+	case MsgRevokeSession:
+		return sh.handleMsgRevokeSession(ctx, msg)
+
+	default:
+		errMsg := fmt.Sprintf("unrecognized session message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgCreateSession.
+func (sh sessionHandler) handleMsgCreateSession(ctx sdk.Context, msg MsgCreateSession) sdk.Result {
+	err := sh.sk.CreateSession(ctx, msg.Session())
+	if err != nil {
+		return abciResult(err)
+	}
+
+	return sdk.Result{}
+}
+
+// Handle MsgRevokeSession.
+func (sh sessionHandler) handleMsgRevokeSession(ctx sdk.Context, msg MsgRevokeSession) sdk.Result {
+	err := sh.sk.RevokeSession(ctx, msg.Account, msg.Address)
+	if err != nil {
+		return abciResult(err)
+	}
+
+	return sdk.Result{}
+}
+
+//----------------------------------------
+// Query
+
+// query paths
+const (
+	QuerySession  = "session"  // session/<account>/<address>
+	QuerySessions = "sessions" // sessions/<account>
+)
+
+func (sh sessionHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	switch secondPart(req.Path) {
+	case QuerySession:
+		return sh.querySession(ctx, req)
+	case QuerySessions:
+		return sh.querySessions(ctx, req)
+	default:
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("unknown session query endpoint"))
+		return
+	}
+}
+
+// querySession fetches the session of a key of an account. The account
+// and session key addresses are passed as path components.
+func (sh sessionHandler) querySession(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	parts := strings.Split(req.Path, "/")
+	if len(parts) != 4 {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("expected session/<account>/<address>"))
+		return
+	}
+
+	account, err := crypto.AddressFromBech32(parts[2])
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid account address " + parts[2]))
+		return
+	}
+	address, err := crypto.AddressFromBech32(parts[3])
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid session key address " + parts[3]))
+		return
+	}
+
+	session := sh.sk.GetSession(ctx, account, address)
+	if session == nil {
+		res = sdk.ABCIResponseQueryFromError(
+			ErrNoSession(fmt.Sprintf("account %s, session key %s", account, address)))
+		return
+	}
+
+	bz, err := amino.MarshalJSONIndent(session, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+// querySessions fetches all the sessions of an account.
+// The account address is passed as path component.
+func (sh sessionHandler) querySessions(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	b32addr := thirdPart(req.Path)
+	account, err := crypto.AddressFromBech32(b32addr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query address " + b32addr))
+		return
+	}
+
+	sessions := []Session{}
+	sh.sk.IterateSessions(ctx, account, func(session Session) bool {
+		sessions = append(sessions, session)
+		return false
+	})
+
+	bz, err := amino.MarshalJSONIndent(sessions, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+//----------------------------------------
+// misc
+
+func abciResult(err error) sdk.Result {
+	return sdk.ABCIResultFromError(err)
+}
+
+// returns the second component of a path.
+func secondPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return ""
+	} else {
+		return parts[1]
+	}
+}
+
+// returns the third component of a path.
+func thirdPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return ""
+	} else {
+		return parts[2]
+	}
+}
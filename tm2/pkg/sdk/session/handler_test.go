@@ -0,0 +1,75 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(SessionKeeper{})
+	res := h.Process(sdk.NewContext(sdk.RunTxModeDeliver, nil, &bft.Header{ChainID: "test-chain"}, nil), tu.NewTestMsg())
+	require.False(t, res.IsOK())
+	require.True(t, strings.Contains(res.Log, "unrecognized session message type"))
+}
+
+func TestCreateRevoke(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.sk)
+
+	account := crypto.AddressFromPreimage([]byte("account"))
+	session := newTestSession(account, std.NewCoins(std.NewCoin("ugnot", 100)))
+	address := session.Address()
+
+	msg := NewMsgCreateSession(session)
+	require.NoError(t, msg.ValidateBasic())
+
+	res := h.Process(env.ctx, msg)
+	require.True(t, res.IsOK())
+
+	// Query the session
+	res2 := h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("session/%s/%s/%s", QuerySession, account, address),
+	})
+	require.Nil(t, res2.Error)
+
+	var queried Session
+	require.NoError(t, amino.UnmarshalJSON(res2.Data, &queried))
+	require.Equal(t, session, queried)
+
+	// Query the sessions of the account
+	res2 = h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("session/%s/%s", QuerySessions, account),
+	})
+	require.Nil(t, res2.Error)
+
+	var sessions []Session
+	require.NoError(t, amino.UnmarshalJSON(res2.Data, &sessions))
+	require.Equal(t, []Session{session}, sessions)
+
+	// Revoke
+	res = h.Process(env.ctx, NewMsgRevokeSession(account, address))
+	require.True(t, res.IsOK())
+
+	res = h.Process(env.ctx, NewMsgRevokeSession(account, address))
+	require.False(t, res.IsOK())
+
+	res2 = h.Query(env.ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("session/%s/%s/%s", QuerySession, account, address),
+	})
+	require.NotNil(t, res2.Error)
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// SessionKeeperI manages the session keys of the accounts.
+type SessionKeeperI interface {
+	auth.SessionKeeperI
+
+	CreateSession(ctx sdk.Context, session Session) error
+	RevokeSession(ctx sdk.Context, account, address crypto.Address) error
+	GetSession(ctx sdk.Context, account, address crypto.Address) *Session
+	IterateSessions(ctx sdk.Context, account crypto.Address, process func(Session) (stop bool))
+}
+
+var _ SessionKeeperI = SessionKeeper{}
+
+// SessionKeeper stores the sessions. It implements SessionKeeperI.
+type SessionKeeper struct {
+	// The (unexposed) key used to access the store from the Context.
+	key store.StoreKey
+}
+
+// NewSessionKeeper returns a new SessionKeeper.
+func NewSessionKeeper(key store.StoreKey) SessionKeeper {
+	return SessionKeeper{
+		key: key,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (sk SessionKeeper) Logger(ctx sdk.Context) *slog.Logger {
+	return ctx.Logger().With("module", ModuleName)
+}
+
+// CreateSession stores the session, replacing any existing session of the
+// same key.
+func (sk SessionKeeper) CreateSession(ctx sdk.Context, session Session) error {
+	if err := session.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if !ctx.BlockTime().Before(session.Expiration) {
+		return ErrInvalidSession(fmt.Sprintf("expiration %s is in the past", session.Expiration))
+	}
+
+	sk.setSession(ctx, session)
+	return nil
+}
+
+// RevokeSession deletes the session of the key of account with the given
+// address.
+func (sk SessionKeeper) RevokeSession(ctx sdk.Context, account, address crypto.Address) error {
+	if sk.GetSession(ctx, account, address) == nil {
+		return ErrNoSession(fmt.Sprintf("account %s, session key %s", account, address))
+	}
+
+	stor := ctx.GasStore(sk.key)
+	stor.Delete(SessionStoreKey(account, address))
+	return nil
+}
+
+// GetSession returns the session of the key of account with the given
+// address, or nil.
+func (sk SessionKeeper) GetSession(ctx sdk.Context, account, address crypto.Address) *Session {
+	stor := ctx.GasStore(sk.key)
+	bz := stor.Get(SessionStoreKey(account, address))
+	if bz == nil {
+		return nil
+	}
+	session := decodeSession(bz)
+	return &session
+}
+
+// IterateSessions iterates over the sessions of account.
+func (sk SessionKeeper) IterateSessions(ctx sdk.Context, account crypto.Address, process func(Session) (stop bool)) {
+	stor := ctx.GasStore(sk.key)
+	iter := store.PrefixIterator(stor, AccountStoreKeyPrefix(account))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if process(decodeSession(iter.Value())) {
+			return
+		}
+	}
+}
+
+// UseSession implements auth.SessionKeeperI. It checks that the session of
+// pubKey allows it to sign a tx containing msgs on behalf of account, and
+// takes the coins spent by the tx from its spend limit. The fee is the one
+// paid by the account, if any.
+func (sk SessionKeeper) UseSession(ctx sdk.Context, account crypto.Address, pubKey crypto.PubKey, fee std.Coins, msgs []std.Msg) error {
+	session := sk.GetSession(ctx, account, pubKey.Address())
+	if session == nil {
+		return ErrNoSession(fmt.Sprintf("account %s, session key %s", account, pubKey.Address()))
+	}
+
+	// NOTE: the state changes of a failing ante handler are discarded, so
+	// expired sessions are only deleted when revoked.
+	if err := session.Accept(ctx, fee, msgs); err != nil {
+		return err
+	}
+
+	sk.setSession(ctx, *session)
+	return nil
+}
+
+func (sk SessionKeeper) setSession(ctx sdk.Context, session Session) {
+	stor := ctx.GasStore(sk.key)
+	bz, err := amino.Marshal(session)
+	if err != nil {
+		panic(err)
+	}
+	stor.Set(SessionStoreKey(session.Account, session.Address()), bz)
+}
+
+func decodeSession(bz []byte) (session Session) {
+	err := amino.Unmarshal(bz, &session)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
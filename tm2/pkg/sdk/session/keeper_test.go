@@ -0,0 +1,74 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestKeeper(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	account := crypto.AddressFromPreimage([]byte("account"))
+	account2 := crypto.AddressFromPreimage([]byte("account2"))
+
+	session := newTestSession(account, std.NewCoins(std.NewCoin("ugnot", 100)))
+	pubKey := session.PubKey
+	msgs := []std.Msg{testCallMsg{caller: account, pkgPath: "gno.land/r/demo/game", fn: "Move"}}
+	fee := std.NewCoins(std.NewCoin("ugnot", 60))
+
+	// No session
+	require.Nil(t, env.sk.GetSession(ctx, account, pubKey.Address()))
+	err := env.sk.UseSession(ctx, account, pubKey, fee, msgs)
+	assert.True(t, errors.As(err, &NoSessionError{}))
+
+	// Expired sessions can't be created
+	expired := session
+	expired.Expiration = testTime
+	err = env.sk.CreateSession(ctx, expired)
+	assert.True(t, errors.As(err, &InvalidSessionError{}))
+
+	require.NoError(t, env.sk.CreateSession(ctx, session))
+	require.Equal(t, &session, env.sk.GetSession(ctx, account, pubKey.Address()))
+
+	// The session is only for account
+	err = env.sk.UseSession(ctx, account2, pubKey, fee, msgs)
+	assert.True(t, errors.As(err, &NoSessionError{}))
+
+	session2 := newTestSession(account, nil)
+	session2.PubKey = ed25519.GenPrivKeyFromSecret([]byte("session2")).PubKey()
+	require.NoError(t, env.sk.CreateSession(ctx, session2))
+
+	var sessions []Session
+	env.sk.IterateSessions(ctx, account, func(s Session) bool {
+		sessions = append(sessions, s)
+		return false
+	})
+	assert.Len(t, sessions, 2)
+
+	// Using the session updates its spend limit
+	require.NoError(t, env.sk.UseSession(ctx, account, pubKey, fee, msgs))
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 40)), env.sk.GetSession(ctx, account, pubKey.Address()).SpendLimit)
+
+	err = env.sk.UseSession(ctx, account, pubKey, fee, msgs)
+	assert.True(t, errors.As(err, &SpendLimitExceededError{}))
+
+	err = env.sk.UseSession(withBlockTime(ctx, testTime.Add(2*time.Hour)), account, pubKey, nil, msgs)
+	assert.True(t, errors.As(err, &SessionExpiredError{}))
+
+	// Revoke
+	require.NoError(t, env.sk.RevokeSession(ctx, account, pubKey.Address()))
+	require.Nil(t, env.sk.GetSession(ctx, account, pubKey.Address()))
+	err = env.sk.RevokeSession(ctx, account, pubKey.Address())
+	assert.True(t, errors.As(err, &NoSessionError{}))
+}
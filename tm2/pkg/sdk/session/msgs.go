@@ -0,0 +1,104 @@
+package session
+
+import (
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// MsgCreateSession - authorize a session key to sign txs on behalf of the
+// account, within the scopes, replacing any existing session of the key
+type MsgCreateSession struct {
+	Account    crypto.Address `json:"account" yaml:"account"`
+	PubKey     crypto.PubKey  `json:"pub_key" yaml:"pub_key"`
+	Scopes     []Scope        `json:"scopes" yaml:"scopes"`
+	SpendLimit std.Coins      `json:"spend_limit" yaml:"spend_limit"`
+	Expiration time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+var _ std.Msg = MsgCreateSession{}
+
+// NewMsgCreateSession - construct a msg creating the session.
+func NewMsgCreateSession(session Session) MsgCreateSession {
+	return MsgCreateSession{
+		Account:    session.Account,
+		PubKey:     session.PubKey,
+		Scopes:     session.Scopes,
+		SpendLimit: session.SpendLimit,
+		Expiration: session.Expiration,
+	}
+}
+
+// Session returns the session created by the msg.
+func (msg MsgCreateSession) Session() Session {
+	return Session{
+		Account:    msg.Account,
+		PubKey:     msg.PubKey,
+		Scopes:     msg.Scopes,
+		SpendLimit: msg.SpendLimit,
+		Expiration: msg.Expiration,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgCreateSession) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgCreateSession) Type() string { return "create" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgCreateSession) ValidateBasic() error {
+	return msg.Session().ValidateBasic()
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgCreateSession) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgCreateSession) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Account}
+}
+
+// MsgRevokeSession - revoke the session of the key with the given address
+type MsgRevokeSession struct {
+	Account crypto.Address `json:"account" yaml:"account"`
+	Address crypto.Address `json:"address" yaml:"address"`
+}
+
+var _ std.Msg = MsgRevokeSession{}
+
+// NewMsgRevokeSession - construct a msg revoking the session of the key.
+func NewMsgRevokeSession(account, address crypto.Address) MsgRevokeSession {
+	return MsgRevokeSession{Account: account, Address: address}
+}
+
+// Route Implements Msg.
+func (msg MsgRevokeSession) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgRevokeSession) Type() string { return "revoke" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgRevokeSession) ValidateBasic() error {
+	if msg.Account.IsZero() {
+		return std.ErrInvalidAddress("missing account address")
+	}
+	if msg.Address.IsZero() {
+		return std.ErrInvalidAddress("missing session key address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgRevokeSession) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgRevokeSession) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Account}
+}
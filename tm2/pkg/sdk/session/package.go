@@ -0,0 +1,21 @@
+package session
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+var Package = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/sdk/session",
+	"session",
+	amino.GetCallersDirname(),
+).WithDependencies().WithTypes(
+	NoSessionError{}, "NoSessionError",
+	InvalidSessionError{}, "InvalidSessionError",
+	SessionExpiredError{}, "SessionExpiredError",
+	SpendLimitExceededError{}, "SpendLimitExceededError",
+	MsgNotAllowedError{}, "MsgNotAllowedError",
+	Scope{}, "Scope",
+	Session{}, "Session",
+	MsgCreateSession{}, "MsgCreateSession",
+	MsgRevokeSession{}, "MsgRevokeSession",
+))
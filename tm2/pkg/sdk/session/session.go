@@ -0,0 +1,124 @@
+package session
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// CallMsg is implemented by msgs calling a function of a realm, the only
+// msgs a session key can sign.
+type CallMsg interface {
+	std.Msg
+	GetPkgPath() string
+	GetFunc() string
+	GetReceived() std.Coins
+}
+
+// Scope allows a session key to call the functions Funcs of the realm
+// PkgPath. Empty Funcs allow all the functions of the realm.
+type Scope struct {
+	PkgPath string   `json:"pkg_path" yaml:"pkg_path"`
+	Funcs   []string `json:"funcs" yaml:"funcs"`
+}
+
+// Session authorizes the secondary key PubKey to sign txs on behalf of
+// Account, until Expiration. Every msg of the txs must be a call within the
+// scopes of the session, and the coins sent by the calls and the fees paid
+// by the account are taken from SpendLimit. An empty SpendLimit allows no
+// spending.
+type Session struct {
+	Account    crypto.Address `json:"account" yaml:"account"`
+	PubKey     crypto.PubKey  `json:"pub_key" yaml:"pub_key"`
+	Scopes     []Scope        `json:"scopes" yaml:"scopes"`
+	SpendLimit std.Coins      `json:"spend_limit" yaml:"spend_limit"`
+	Expiration time.Time      `json:"expiration" yaml:"expiration"`
+}
+
+// Address returns the address of the session key.
+func (s Session) Address() crypto.Address {
+	return s.PubKey.Address()
+}
+
+// Accept checks whether the session can sign a tx containing the given
+// msgs, whose fee is paid by the account, and takes the coins spent from
+// the spend limit.
+func (s *Session) Accept(ctx sdk.Context, fee std.Coins, msgs []std.Msg) error {
+	if !ctx.BlockTime().Before(s.Expiration) {
+		return ErrSessionExpired(fmt.Sprintf("expired at %s", s.Expiration))
+	}
+
+	spent := fee
+	for _, msg := range msgs {
+		cmsg, err := s.allowMsg(msg)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(msg.GetSigners(), s.Account) {
+			spent = spent.Add(cmsg.GetReceived())
+		}
+	}
+
+	if spent.IsZero() {
+		return nil
+	}
+
+	left := s.SpendLimit.SubUnsafe(spent)
+	if left.IsAnyNegative() {
+		return ErrSpendLimitExceeded(fmt.Sprintf("spent %s, spend limit %s", spent, s.SpendLimit))
+	}
+	s.SpendLimit = left
+
+	return nil
+}
+
+func (s Session) allowMsg(msg std.Msg) (CallMsg, error) {
+	cmsg, ok := msg.(CallMsg)
+	if !ok {
+		return nil, ErrMsgNotAllowed(fmt.Sprintf("%s/%s is not a realm call", msg.Route(), msg.Type()))
+	}
+
+	for _, scope := range s.Scopes {
+		if scope.PkgPath != cmsg.GetPkgPath() {
+			continue
+		}
+		if len(scope.Funcs) == 0 || slices.Contains(scope.Funcs, cmsg.GetFunc()) {
+			return cmsg, nil
+		}
+	}
+
+	return nil, ErrMsgNotAllowed(cmsg.GetPkgPath() + "." + cmsg.GetFunc())
+}
+
+// ValidateBasic performs stateless validation of the session.
+func (s Session) ValidateBasic() error {
+	if s.Account.IsZero() {
+		return std.ErrInvalidAddress("missing account address")
+	}
+	if s.PubKey == nil {
+		return ErrInvalidSession("missing session key")
+	}
+	if s.PubKey.Address() == s.Account {
+		return ErrInvalidSession("session key must differ from the account key")
+	}
+	if len(s.Scopes) == 0 {
+		return ErrInvalidSession("no scopes")
+	}
+	for _, scope := range s.Scopes {
+		if scope.PkgPath == "" {
+			return ErrInvalidSession("missing package path of scope")
+		}
+	}
+	if !s.SpendLimit.IsValid() {
+		return ErrInvalidSession("invalid spend limit " + s.SpendLimit.String())
+	}
+	if s.Expiration.IsZero() {
+		return ErrInvalidSession("missing expiration")
+	}
+	return nil
+}
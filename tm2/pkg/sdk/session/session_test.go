@@ -0,0 +1,121 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestSessionAccept(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	account := crypto.AddressFromPreimage([]byte("account"))
+	other := crypto.AddressFromPreimage([]byte("other"))
+
+	t.Run("scopes", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestSession(account, nil)
+
+		require.NoError(t, s.Accept(ctx, nil, []std.Msg{
+			testCallMsg{caller: account, pkgPath: "gno.land/r/demo/game", fn: "Move"},
+			testCallMsg{caller: account, pkgPath: "gno.land/r/demo/chat", fn: "Post"},
+		}))
+
+		err := s.Accept(ctx, nil, []std.Msg{
+			testCallMsg{caller: account, pkgPath: "gno.land/r/demo/game", fn: "Move"},
+			testCallMsg{caller: account, pkgPath: "gno.land/r/demo/game", fn: "Withdraw"},
+		})
+		assert.True(t, errors.As(err, &MsgNotAllowedError{}))
+
+		err = s.Accept(ctx, nil, []std.Msg{
+			testCallMsg{caller: account, pkgPath: "gno.land/r/demo/bank", fn: "Move"},
+		})
+		assert.True(t, errors.As(err, &MsgNotAllowedError{}))
+
+		err = s.Accept(ctx, nil, []std.Msg{
+			bank.NewMsgSend(account, other, std.NewCoins(std.NewCoin("ugnot", 1))),
+		})
+		assert.True(t, errors.As(err, &MsgNotAllowedError{}))
+	})
+
+	t.Run("spend limit", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestSession(account, std.NewCoins(std.NewCoin("ugnot", 100)))
+		fee := std.NewCoins(std.NewCoin("ugnot", 10))
+
+		msgs := []std.Msg{
+			testCallMsg{caller: account, pkgPath: "gno.land/r/demo/game", fn: "Move", send: std.NewCoins(std.NewCoin("ugnot", 50))},
+			// sent by another signer, not counted
+			testCallMsg{caller: other, pkgPath: "gno.land/r/demo/game", fn: "Move", send: std.NewCoins(std.NewCoin("ugnot", 50))},
+		}
+		require.NoError(t, s.Accept(ctx, fee, msgs))
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 40)), s.SpendLimit)
+
+		err := s.Accept(ctx, fee, msgs)
+		assert.True(t, errors.As(err, &SpendLimitExceededError{}))
+		assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 40)), s.SpendLimit)
+
+		err = s.Accept(ctx, std.NewCoins(std.NewCoin("foo", 1)), nil)
+		assert.True(t, errors.As(err, &SpendLimitExceededError{}))
+	})
+
+	t.Run("no spend limit", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestSession(account, nil)
+
+		err := s.Accept(ctx, std.NewCoins(std.NewCoin("ugnot", 1)), nil)
+		assert.True(t, errors.As(err, &SpendLimitExceededError{}))
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestSession(account, nil)
+
+		err := s.Accept(withBlockTime(ctx, s.Expiration), nil, nil)
+		assert.True(t, errors.As(err, &SessionExpiredError{}))
+	})
+}
+
+func TestSessionValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	account := crypto.AddressFromPreimage([]byte("account"))
+
+	valid := newTestSession(account, std.NewCoins(std.NewCoin("ugnot", 100)))
+	require.NoError(t, valid.ValidateBasic())
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*Session)
+	}{
+		{"missing account", func(s *Session) { s.Account = crypto.Address{} }},
+		{"missing key", func(s *Session) { s.PubKey = nil }},
+		{"account key", func(s *Session) { s.Account = s.PubKey.Address() }},
+		{"no scopes", func(s *Session) { s.Scopes = nil }},
+		{"missing package path", func(s *Session) { s.Scopes = []Scope{{Funcs: []string{"Move"}}} }},
+		{"invalid spend limit", func(s *Session) { s.SpendLimit = std.Coins{std.NewCoin("ugnot", 0)} }},
+		{"missing expiration", func(s *Session) { s.Expiration = time.Time{} }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newTestSession(account, std.NewCoins(std.NewCoin("ugnot", 100)))
+			tc.modify(&s)
+			assert.Error(t, s.ValidateBasic())
+		})
+	}
+}
@@ -2,13 +2,12 @@ package std
 
 import (
 	"fmt"
+	"math/big"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/gnolang/gno/tm2/pkg/errors"
-	"github.com/gnolang/gno/tm2/pkg/overflow"
 )
 
 // -----------------------------------------------------------------------------
@@ -18,13 +17,18 @@ import (
 // A negative amount is invalid.
 type Coin struct {
 	Denom  string `json:"denom"`
-	Amount int64  `json:"amount"`
+	Amount Int    `json:"amount"`
 }
 
 // NewCoin returns a new coin with a denomination and amount.
 // It will panic if the amount is negative.
 // To construct a negative (invalid) amount, use an operation.
 func NewCoin(denom string, amount int64) Coin {
+	return NewCoinFromInt(denom, NewInt(amount))
+}
+
+// NewCoinFromInt is like NewCoin, for amounts which may not fit in an int64.
+func NewCoinFromInt(denom string, amount Int) Coin {
 	if err := validate(denom, amount); err != nil {
 		panic(err)
 	}
@@ -56,19 +60,19 @@ func (coin Coin) String() string {
 	if coin.IsZero() {
 		return ""
 	} else {
-		return fmt.Sprintf("%d%v", coin.Amount, coin.Denom)
+		return fmt.Sprintf("%v%v", coin.Amount, coin.Denom)
 	}
 }
 
 // validate returns an error if the Coin has a negative amount or if
 // the denom is invalid.
-func validate(denom string, amount int64) error {
+func validate(denom string, amount Int) error {
 	if err := validateDenom(denom); err != nil {
 		return err
 	}
 
-	if amount < 0 {
-		return fmt.Errorf("negative coin amount: %d", amount)
+	if amount.IsNegative() {
+		return fmt.Errorf("negative coin amount: %v", amount)
 	}
 
 	return nil
@@ -84,7 +88,7 @@ func (coin Coin) IsValid() bool {
 
 // IsZero returns if this represents no money
 func (coin Coin) IsZero() bool {
-	return coin.Amount == 0
+	return coin.Amount.IsZero()
 }
 
 // IsGTE returns true if they are the same type and the receiver is
@@ -93,7 +97,7 @@ func (coin Coin) IsGTE(other Coin) bool {
 	if coin.Denom != other.Denom {
 		panic(fmt.Sprintf("invalid coin denominations; %s, %s", coin.Denom, other.Denom))
 	}
-	return coin.Amount >= other.Amount
+	return coin.Amount.GTE(other.Amount)
 }
 
 // IsLT returns true if they are the same type and the receiver is
@@ -102,7 +106,7 @@ func (coin Coin) IsLT(other Coin) bool {
 	if coin.Denom != other.Denom {
 		panic(fmt.Sprintf("invalid coin denominations; %s, %s", coin.Denom, other.Denom))
 	}
-	return coin.Amount < other.Amount
+	return coin.Amount.LT(other.Amount)
 }
 
 // IsEqual returns true if the two sets of Coins have the same value
@@ -110,7 +114,7 @@ func (coin Coin) IsEqual(other Coin) bool {
 	if coin.Denom != other.Denom {
 		panic(fmt.Sprintf("invalid coin denominations; %s, %s", coin.Denom, other.Denom))
 	}
-	return coin.Amount == other.Amount
+	return coin.Amount.Equal(other.Amount)
 }
 
 // Adds amounts of two coins with same denom.
@@ -129,11 +133,11 @@ func (coin Coin) AddUnsafe(coinB Coin) Coin {
 	if coin.Denom != coinB.Denom {
 		panic(fmt.Sprintf("invalid coin denominations; %s, %s", coin.Denom, coinB.Denom))
 	}
-	sum, ok := overflow.Add64(coin.Amount, coinB.Amount)
-	if !ok {
+	sum := new(big.Int).Add(coin.Amount.big(), coinB.Amount.big())
+	if sum.BitLen() > MaxIntBitLen {
 		panic(fmt.Sprintf("coin add overflow/underflow: %v, %v", coin, coinB))
 	}
-	return Coin{coin.Denom, sum}
+	return Coin{coin.Denom, newInt(sum)}
 }
 
 // Subtracts amounts of two coins with same denom.
@@ -152,21 +156,21 @@ func (coin Coin) SubUnsafe(coinB Coin) Coin {
 	if coin.Denom != coinB.Denom {
 		panic(fmt.Sprintf("invalid coin denominations; %s, %s", coin.Denom, coinB.Denom))
 	}
-	dff, ok := overflow.Sub64(coin.Amount, coinB.Amount)
-	if !ok {
+	dff := new(big.Int).Sub(coin.Amount.big(), coinB.Amount.big())
+	if dff.BitLen() > MaxIntBitLen {
 		panic(fmt.Sprintf("coin subtract overflow/underflow: %v, %v", coin, coinB))
 	}
-	return Coin{coin.Denom, dff}
+	return Coin{coin.Denom, newInt(dff)}
 }
 
 // IsPositive returns true if coin amount is positive.
 func (coin Coin) IsPositive() bool {
-	return coin.Amount > 0
+	return coin.Amount.IsPositive()
 }
 
 // IsNegative returns true if the coin amount is negative and false otherwise.
 func (coin Coin) IsNegative() bool {
-	return coin.Amount < 0
+	return coin.Amount.IsNegative()
 }
 
 // -----------------------------------------------------------------------------
@@ -337,7 +341,7 @@ func (coins Coins) DenomsSubsetOf(coinsB Coins) bool {
 	}
 
 	for _, coin := range coins {
-		if coinsB.AmountOf(coin.Denom) == 0 {
+		if coinsB.AmountOf(coin.Denom).IsZero() {
 			return false
 		}
 	}
@@ -385,7 +389,7 @@ func (coins Coins) IsAllGT(coinsB Coins) bool {
 
 	for _, coinB := range coinsB {
 		amountA, amountB := coins.AmountOf(coinB.Denom), coinB.Amount
-		if amountA <= amountB {
+		if amountA.LTE(amountB) {
 			return false
 		}
 	}
@@ -406,7 +410,7 @@ func (coins Coins) IsAllGTE(coinsB Coins) bool {
 	}
 
 	for _, coinB := range coinsB {
-		if coinB.Amount > coins.AmountOf(coinB.Denom) {
+		if coinB.Amount.GT(coins.AmountOf(coinB.Denom)) {
 			return false
 		}
 	}
@@ -441,7 +445,7 @@ func (coins Coins) IsAnyGT(coinsB Coins) bool {
 
 	for _, coin := range coins {
 		amt := coinsB.AmountOf(coin.Denom)
-		if coin.Amount > amt && amt != 0 {
+		if coin.Amount.GT(amt) && !amt.IsZero() {
 			return true
 		}
 	}
@@ -461,7 +465,7 @@ func (coins Coins) IsAnyGTE(coinsB Coins) bool {
 
 	for _, coin := range coins {
 		amt := coinsB.AmountOf(coin.Denom)
-		if coin.Amount >= amt && amt != 0 {
+		if coin.Amount.GTE(amt) && !amt.IsZero() {
 			return true
 		}
 	}
@@ -504,19 +508,19 @@ func (coins Coins) Empty() bool {
 }
 
 // Returns the amount of a denom from coins, which may be negative.
-func (coins Coins) AmountOf(denom string) int64 {
+func (coins Coins) AmountOf(denom string) Int {
 	mustValidateDenom(denom)
 
 	switch len(coins) {
 	case 0:
-		return 0
+		return Int{}
 
 	case 1:
 		coin := coins[0]
 		if coin.Denom == denom {
 			return coin.Amount
 		}
-		return 0
+		return Int{}
 
 	default:
 		midIdx := len(coins) / 2 // 2:1, 3:1, 4:2
@@ -574,7 +578,7 @@ func (coins Coins) negative() Coins {
 	for _, coin := range coins {
 		res = append(res, Coin{
 			Denom:  coin.Denom,
-			Amount: -1 * coin.Amount,
+			Amount: coin.Amount.Neg(),
 		})
 	}
 
@@ -656,7 +660,7 @@ func ParseCoin(coinStr string) (coin Coin, err error) {
 
 	denomStr, amountStr := matches[2], matches[1]
 
-	amount, err := strconv.ParseInt(amountStr, 10, 64)
+	amount, err := ParseInt(amountStr)
 	if err != nil {
 		return Coin{}, errors.Wrapf(err, "failed to parse coin amount: %s", amountStr)
 	}
@@ -665,7 +669,7 @@ func ParseCoin(coinStr string) (coin Coin, err error) {
 		return Coin{}, fmt.Errorf("invalid denom cannot contain upper case characters or spaces: %w", err)
 	}
 
-	return NewCoin(denomStr, amount), nil
+	return NewCoinFromInt(denomStr, amount), nil
 }
 
 func MustParseCoins(coinsStr string) Coins {
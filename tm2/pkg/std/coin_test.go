@@ -22,7 +22,7 @@ func TestCoin(t *testing.T) {
 
 	require.Panics(t, func() { NewCoin(testDenom1, -1) })
 	require.Panics(t, func() { NewCoin(strings.ToUpper(testDenom1), 10) })
-	require.Equal(t, int64(5), NewCoin(testDenom1, 5).Amount)
+	require.Equal(t, NewInt(5), NewCoin(testDenom1, 5).Amount)
 }
 
 func TestIsEqualCoin(t *testing.T) {
@@ -56,14 +56,14 @@ func TestCoinIsValid(t *testing.T) {
 		coin       Coin
 		expectPass bool
 	}{
-		{Coin{testDenom1, int64(-1)}, false},
-		{Coin{testDenom1, int64(0)}, true},
-		{Coin{testDenom1, int64(1)}, true},
-		{Coin{"Atom", int64(1)}, false},
-		{Coin{"a", int64(1)}, false},
-		{Coin{"a very long coin denom", int64(1)}, false},
-		{Coin{"atOm", int64(1)}, false},
-		{Coin{"     ", int64(1)}, false},
+		{Coin{testDenom1, NewInt(-1)}, false},
+		{Coin{testDenom1, NewInt(0)}, true},
+		{Coin{testDenom1, NewInt(1)}, true},
+		{Coin{"Atom", NewInt(1)}, false},
+		{Coin{"a", NewInt(1)}, false},
+		{Coin{"a very long coin denom", NewInt(1)}, false},
+		{Coin{"atOm", NewInt(1)}, false},
+		{Coin{"     ", NewInt(1)}, false},
 	}
 
 	for i, tc := range cases {
@@ -126,7 +126,7 @@ func TestSubCoin(t *testing.T) {
 		expected int64
 	}{NewCoin(testDenom1, 1), NewCoin(testDenom1, 1), 0}
 	res := tc.inputOne.Sub(tc.inputTwo)
-	require.Equal(t, tc.expected, res.Amount)
+	require.Equal(t, tc.expected, res.Amount.Int64())
 }
 
 func TestIsGTECoin(t *testing.T) {
@@ -246,9 +246,9 @@ func TestEqualCoins(t *testing.T) {
 func TestAddCoins(t *testing.T) {
 	t.Parallel()
 
-	zero := int64(0)
-	one := int64(1)
-	two := int64(2)
+	zero := NewInt(0)
+	one := NewInt(1)
+	two := NewInt(2)
 
 	cases := []struct {
 		inputOne Coins
@@ -272,9 +272,9 @@ func TestAddCoins(t *testing.T) {
 func TestSubCoins(t *testing.T) {
 	t.Parallel()
 
-	zero := int64(0)
-	one := int64(1)
-	two := int64(2)
+	zero := NewInt(0)
+	one := NewInt(1)
+	two := NewInt(2)
 
 	testCases := []struct {
 		inputOne    Coins
@@ -304,48 +304,48 @@ func TestCoins(t *testing.T) {
 	t.Parallel()
 
 	good := Coins{
-		{"gas", int64(1)},
-		{"mineral", int64(1)},
-		{"tree", int64(1)},
+		{"gas", NewInt(1)},
+		{"mineral", NewInt(1)},
+		{"tree", NewInt(1)},
 	}
 	mixedCase1 := Coins{
-		{"gAs", int64(1)},
-		{"MineraL", int64(1)},
-		{"TREE", int64(1)},
+		{"gAs", NewInt(1)},
+		{"MineraL", NewInt(1)},
+		{"TREE", NewInt(1)},
 	}
 	mixedCase2 := Coins{
-		{"gAs", int64(1)},
-		{"mineral", int64(1)},
+		{"gAs", NewInt(1)},
+		{"mineral", NewInt(1)},
 	}
 	mixedCase3 := Coins{
-		{"gAs", int64(1)},
+		{"gAs", NewInt(1)},
 	}
 	empty := NewCoins()
 	badSort1 := Coins{
-		{"tree", int64(1)},
-		{"gas", int64(1)},
-		{"mineral", int64(1)},
+		{"tree", NewInt(1)},
+		{"gas", NewInt(1)},
+		{"mineral", NewInt(1)},
 	}
 
 	// both are after the first one, but the second and third are in the wrong order
 	badSort2 := Coins{
-		{"gas", int64(1)},
-		{"tree", int64(1)},
-		{"mineral", int64(1)},
+		{"gas", NewInt(1)},
+		{"tree", NewInt(1)},
+		{"mineral", NewInt(1)},
 	}
 	badAmt := Coins{
-		{"gas", int64(1)},
-		{"tree", int64(0)},
-		{"mineral", int64(1)},
+		{"gas", NewInt(1)},
+		{"tree", NewInt(0)},
+		{"mineral", NewInt(1)},
 	}
 	dup := Coins{
-		{"gas", int64(1)},
-		{"gas", int64(1)},
-		{"mineral", int64(1)},
+		{"gas", NewInt(1)},
+		{"gas", NewInt(1)},
+		{"mineral", NewInt(1)},
 	}
 	neg := Coins{
-		{"gas", int64(-1)},
-		{"mineral", int64(1)},
+		{"gas", NewInt(-1)},
+		{"mineral", NewInt(1)},
 	}
 
 	assert.True(t, good.IsValid(), "Coins are valid")
@@ -367,8 +367,8 @@ func TestCoins(t *testing.T) {
 func TestCoinsGT(t *testing.T) {
 	t.Parallel()
 
-	one := int64(1)
-	two := int64(2)
+	one := NewInt(1)
+	two := NewInt(2)
 
 	assert.False(t, Coins{}.IsAllGT(Coins{}))
 	assert.True(t, Coins{{testDenom1, one}}.IsAllGT(Coins{}))
@@ -381,8 +381,8 @@ func TestCoinsGT(t *testing.T) {
 func TestCoinsLT(t *testing.T) {
 	t.Parallel()
 
-	one := int64(1)
-	two := int64(2)
+	one := NewInt(1)
+	two := NewInt(2)
 
 	assert.False(t, Coins{}.IsAllLT(Coins{}))
 	assert.False(t, Coins{{testDenom1, one}}.IsAllLT(Coins{}))
@@ -398,8 +398,8 @@ func TestCoinsLT(t *testing.T) {
 func TestCoinsLTE(t *testing.T) {
 	t.Parallel()
 
-	one := int64(1)
-	two := int64(2)
+	one := NewInt(1)
+	two := NewInt(2)
 
 	assert.True(t, Coins{}.IsAllLTE(Coins{}))
 	assert.False(t, Coins{{testDenom1, one}}.IsAllLTE(Coins{}))
@@ -415,7 +415,7 @@ func TestCoinsLTE(t *testing.T) {
 func TestParse(t *testing.T) {
 	t.Parallel()
 
-	one := int64(1)
+	one := NewInt(1)
 
 	cases := []struct {
 		input    string
@@ -424,11 +424,11 @@ func TestParse(t *testing.T) {
 	}{
 		{"", true, nil},
 		{"1foo", true, Coins{{"foo", one}}},
-		{"10bar", true, Coins{{"bar", int64(10)}}},
-		{"99bar,1foo", true, Coins{{"bar", int64(99)}, {"foo", one}}},
-		{"98 bar , 1 foo  ", true, Coins{{"bar", int64(98)}, {"foo", one}}},
-		{"  55\t \t bling\n", true, Coins{{"bling", int64(55)}}},
-		{"2foo, 97 bar", true, Coins{{"bar", int64(97)}, {"foo", int64(2)}}},
+		{"10bar", true, Coins{{"bar", NewInt(10)}}},
+		{"99bar,1foo", true, Coins{{"bar", NewInt(99)}, {"foo", one}}},
+		{"98 bar , 1 foo  ", true, Coins{{"bar", NewInt(98)}, {"foo", one}}},
+		{"  55\t \t bling\n", true, Coins{{"bling", NewInt(55)}}},
+		{"2foo, 97 bar", true, Coins{{"bar", NewInt(97)}, {"foo", NewInt(2)}}},
 		{"5foo-bar", false, nil},
 		{"5 mycoin,", false, nil},             // no empty coins in a list
 		{"2 3foo, 97 bar", false, nil},        // 3foo is invalid coin name
@@ -533,9 +533,9 @@ func TestAmountOf(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		assert.Equal(t, tc.amountOfGAS, tc.coins.AmountOf("gas"))
-		assert.Equal(t, tc.amountOfMINERAL, tc.coins.AmountOf("mineral"))
-		assert.Equal(t, tc.amountOfTREE, tc.coins.AmountOf("tree"))
+		assert.Equal(t, tc.amountOfGAS, tc.coins.AmountOf("gas").Int64())
+		assert.Equal(t, tc.amountOfMINERAL, tc.coins.AmountOf("mineral").Int64())
+		assert.Equal(t, tc.amountOfTREE, tc.coins.AmountOf("tree").Int64())
 	}
 
 	assert.Panics(t, func() { cases[0].coins.AmountOf("Invalid") })
@@ -544,8 +544,8 @@ func TestAmountOf(t *testing.T) {
 func TestCoinsIsAnyGTE(t *testing.T) {
 	t.Parallel()
 
-	one := int64(1)
-	two := int64(2)
+	one := NewInt(1)
+	two := NewInt(2)
 
 	assert.False(t, Coins{}.IsAnyGTE(Coins{}))
 	assert.False(t, Coins{{testDenom1, one}}.IsAnyGTE(Coins{}))
@@ -566,8 +566,8 @@ func TestCoinsIsAnyGTE(t *testing.T) {
 func TestCoinsIsAllGT(t *testing.T) {
 	t.Parallel()
 
-	one := int64(1)
-	two := int64(2)
+	one := NewInt(1)
+	two := NewInt(2)
 
 	assert.False(t, Coins{}.IsAllGT(Coins{}))
 	assert.True(t, Coins{{testDenom1, one}}.IsAllGT(Coins{}))
@@ -588,8 +588,8 @@ func TestCoinsIsAllGT(t *testing.T) {
 func TestCoinsIsAllGTE(t *testing.T) {
 	t.Parallel()
 
-	one := int64(1)
-	two := int64(2)
+	one := NewInt(1)
+	two := NewInt(2)
 
 	assert.True(t, Coins{}.IsAllGTE(Coins{}))
 	assert.True(t, Coins{{testDenom1, one}}.IsAllGTE(Coins{}))
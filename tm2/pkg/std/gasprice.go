@@ -30,12 +30,12 @@ func ParseGasPrice(gasprice string) (GasPrice, error) {
 		return GasPrice{}, errors.New("invalid gas price: %s (invalid gas denom)", gasprice)
 	}
 
-	if gas.Amount <= 0 {
+	if !gas.Amount.IsPositive() || !gas.Amount.IsInt64() {
 		return GasPrice{}, errors.New("invalid gas price: %s (invalid gas amount)", gasprice)
 	}
 
 	return GasPrice{
-		Gas:   gas.Amount,
+		Gas:   gas.Amount.Int64(),
 		Price: price,
 	}, nil
 }
@@ -66,10 +66,10 @@ func (gp GasPrice) IsGTE(gpB GasPrice) (bool, error) {
 	}
 
 	gpg := big.NewInt(gp.Gas)
-	gpa := big.NewInt(gp.Price.Amount)
+	gpa := gp.Price.Amount.BigInt()
 
 	gpBg := big.NewInt(gpB.Gas)
-	gpBa := big.NewInt(gpB.Price.Amount)
+	gpBa := gpB.Price.Amount.BigInt()
 
 	prod1 := big.NewInt(0).Mul(gpa, gpBg) // gp's price amount * gpB's gas
 	prod2 := big.NewInt(0).Mul(gpg, gpBa) // gpB's gas * pg's price amount
@@ -24,14 +24,14 @@ func TestGasPriceGTE(t *testing.T) {
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			gpB: GasPrice{
 				Gas: 100,
 				Price: Coin{
 					Denom:  "btc", // Different denomination
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			expectError: true,
@@ -44,14 +44,14 @@ func TestGasPriceGTE(t *testing.T) {
 				Gas: 0, // Zero Gas in gp
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			gpB: GasPrice{
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			expectError: true,
@@ -63,14 +63,14 @@ func TestGasPriceGTE(t *testing.T) {
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			gpB: GasPrice{
 				Gas: 0, // Zero Gas in gpB
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			expectError: true,
@@ -83,14 +83,14 @@ func TestGasPriceGTE(t *testing.T) {
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 600, // Greater price
+					Amount: NewInt(600), // Greater price
 				},
 			},
 			gpB: GasPrice{
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			expectError: false,
@@ -102,14 +102,14 @@ func TestGasPriceGTE(t *testing.T) {
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			gpB: GasPrice{
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			expectError: false,
@@ -121,14 +121,14 @@ func TestGasPriceGTE(t *testing.T) {
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 400, // Lesser price
+					Amount: NewInt(400), // Lesser price
 				},
 			},
 			gpB: GasPrice{
 				Gas: 100,
 				Price: Coin{
 					Denom:  "atom",
-					Amount: 500,
+					Amount: NewInt(500),
 				},
 			},
 			expectError: false,
@@ -0,0 +1,186 @@
+package std
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// MaxIntBitLen is the maximum bit length of an Int.
+// Results of arithmetic operations which exceed it panic.
+const MaxIntBitLen = 256
+
+// Int is a signed integer of up to MaxIntBitLen bits, used for coin amounts.
+// Int has value semantics: operations never modify their operands, and the
+// zero value represents 0.
+type Int struct {
+	i *big.Int // nil for zero, never modified once set
+}
+
+// NewInt returns an Int set to n.
+func NewInt(n int64) Int {
+	return newInt(big.NewInt(n))
+}
+
+// NewIntFromBigInt returns an Int set to a copy of i, which must not exceed
+// MaxIntBitLen bits. A nil i is 0.
+func NewIntFromBigInt(i *big.Int) Int {
+	if i == nil {
+		return Int{}
+	}
+	return newInt(new(big.Int).Set(i))
+}
+
+// ParseInt parses a decimal integer.
+func ParseInt(s string) (Int, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Int{}, fmt.Errorf("invalid integer: %q", s)
+	}
+	if i.BitLen() > MaxIntBitLen {
+		return Int{}, fmt.Errorf("integer out of range: %s", s)
+	}
+	return newInt(i), nil
+}
+
+// newInt takes ownership of i. Zero is normalized to a nil pointer, so that
+// equal Ints are deeply equal.
+func newInt(i *big.Int) Int {
+	if i.BitLen() > MaxIntBitLen {
+		panic(fmt.Sprintf("integer overflow: %s exceeds %d bits", i, MaxIntBitLen))
+	}
+	if i.Sign() == 0 {
+		return Int{}
+	}
+	return Int{i: i}
+}
+
+func (x Int) big() *big.Int {
+	if x.i == nil {
+		return new(big.Int)
+	}
+	return x.i
+}
+
+// BigInt returns a copy of x as a *big.Int.
+func (x Int) BigInt() *big.Int {
+	return new(big.Int).Set(x.big())
+}
+
+// IsInt64 returns true if x fits in an int64.
+func (x Int) IsInt64() bool {
+	return x.i == nil || x.i.IsInt64()
+}
+
+// Int64 returns x as an int64. It panics if x does not fit in an int64.
+func (x Int) Int64() int64 {
+	if !x.IsInt64() {
+		panic(fmt.Sprintf("integer overflow: %s does not fit in an int64", x))
+	}
+	return x.big().Int64()
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of x.
+func (x Int) Sign() int {
+	return x.big().Sign()
+}
+
+// IsZero returns true if x is 0.
+func (x Int) IsZero() bool {
+	return x.i == nil
+}
+
+// IsPositive returns true if x is strictly positive.
+func (x Int) IsPositive() bool {
+	return x.Sign() > 0
+}
+
+// IsNegative returns true if x is strictly negative.
+func (x Int) IsNegative() bool {
+	return x.Sign() < 0
+}
+
+// Add returns x+y. It panics on overflow.
+func (x Int) Add(y Int) Int {
+	return newInt(new(big.Int).Add(x.big(), y.big()))
+}
+
+// Sub returns x-y. It panics on overflow.
+func (x Int) Sub(y Int) Int {
+	return newInt(new(big.Int).Sub(x.big(), y.big()))
+}
+
+// Mul returns x*y. It panics on overflow.
+func (x Int) Mul(y Int) Int {
+	return newInt(new(big.Int).Mul(x.big(), y.big()))
+}
+
+// Quo returns x/y, truncated towards zero. It panics if y is 0.
+func (x Int) Quo(y Int) Int {
+	if y.IsZero() {
+		panic("division by zero")
+	}
+	return newInt(new(big.Int).Quo(x.big(), y.big()))
+}
+
+// Neg returns -x.
+func (x Int) Neg() Int {
+	return newInt(new(big.Int).Neg(x.big()))
+}
+
+// Cmp compares x and y and returns -1, 0 or +1.
+func (x Int) Cmp(y Int) int {
+	return x.big().Cmp(y.big())
+}
+
+// Equal returns true if x == y.
+func (x Int) Equal(y Int) bool { return x.Cmp(y) == 0 }
+
+// GT returns true if x > y.
+func (x Int) GT(y Int) bool { return x.Cmp(y) > 0 }
+
+// GTE returns true if x >= y.
+func (x Int) GTE(y Int) bool { return x.Cmp(y) >= 0 }
+
+// LT returns true if x < y.
+func (x Int) LT(y Int) bool { return x.Cmp(y) < 0 }
+
+// LTE returns true if x <= y.
+func (x Int) LTE(y Int) bool { return x.Cmp(y) <= 0 }
+
+// String returns the decimal representation of x.
+func (x Int) String() string {
+	return x.big().String()
+}
+
+// MarshalAmino encodes x as its decimal representation.
+// NOTE: Coin, which holds the amounts of the wire types, is encoded by
+// Coin.MarshalAmino, as the same string as when its amount was an int64.
+func (x Int) MarshalAmino() (string, error) {
+	return x.String(), nil
+}
+
+func (x *Int) UnmarshalAmino(s string) (err error) {
+	*x, err = ParseInt(s)
+	return err
+}
+
+// MarshalJSON encodes x as a JSON number if it fits in an int64, as the
+// amounts were before Int, and otherwise as a JSON string, as JSON numbers
+// beyond int64 are not decoded exactly by most clients.
+func (x Int) MarshalJSON() ([]byte, error) {
+	if x.IsInt64() {
+		return json.Marshal(x.Int64())
+	}
+	return json.Marshal(x.String())
+}
+
+// UnmarshalJSON accepts a JSON string or number.
+func (x *Int) UnmarshalJSON(bz []byte) (err error) {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		s = string(bz)
+	}
+	*x, err = ParseInt(s)
+	return err
+}
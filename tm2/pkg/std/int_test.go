@@ -0,0 +1,162 @@
+package std
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInt(t *testing.T) {
+	t.Parallel()
+
+	var zero Int
+	assert.True(t, zero.IsZero())
+	assert.Equal(t, "0", zero.String())
+	assert.Equal(t, NewInt(0), zero)
+	assert.Equal(t, NewInt(5).Sub(NewInt(5)), zero)
+
+	x := NewInt(1 << 62)
+	y := x.Add(x).Add(x)
+	assert.False(t, y.IsInt64())
+	assert.Equal(t, "13835058055282163712", y.String())
+	assert.Panics(t, func() { y.Int64() })
+	assert.Equal(t, int64(1<<62), y.Sub(x).Sub(x).Int64())
+	assert.True(t, y.GT(x) && x.LT(y) && y.GTE(y) && y.LTE(y) && y.Equal(y))
+	assert.Equal(t, -1, y.Neg().Sign())
+	assert.Equal(t, NewInt(3), y.Quo(x))
+	assert.Panics(t, func() { x.Quo(zero) })
+
+	// Operands are never modified.
+	b := big.NewInt(7)
+	z := NewIntFromBigInt(b)
+	b.SetInt64(8)
+	z.BigInt().SetInt64(9)
+	assert.Equal(t, int64(7), z.Int64())
+}
+
+func TestIntOverflow(t *testing.T) {
+	t.Parallel()
+
+	max := NewIntFromBigInt(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), MaxIntBitLen), big.NewInt(1)))
+	assert.Panics(t, func() { max.Add(NewInt(1)) })
+	assert.Panics(t, func() { max.Neg().Sub(NewInt(1)) })
+	assert.Panics(t, func() { max.Mul(NewInt(2)) })
+	assert.NotPanics(t, func() { max.Sub(NewInt(1)) })
+
+	_, err := ParseInt(max.String())
+	require.NoError(t, err)
+	_, err = ParseInt(max.String() + "0")
+	require.Error(t, err)
+	_, err = ParseInt("12a")
+	require.Error(t, err)
+}
+
+func TestIntEncoding(t *testing.T) {
+	t.Parallel()
+
+	x, err := ParseInt("1" + strings.Repeat("0", 30))
+	require.NoError(t, err)
+
+	bz, err := json.Marshal(x)
+	require.NoError(t, err)
+	assert.Equal(t, `"1000000000000000000000000000000"`, string(bz))
+
+	var y Int
+	require.NoError(t, json.Unmarshal(bz, &y))
+	assert.Equal(t, x, y)
+	require.NoError(t, json.Unmarshal([]byte("42"), &y))
+	assert.Equal(t, NewInt(42), y)
+
+	// Ints which fit in an int64 are JSON numbers.
+	bz, err = json.Marshal(NewInt(-42))
+	require.NoError(t, err)
+	assert.Equal(t, `-42`, string(bz))
+}
+
+// TestCoinEncodingCompatibility checks that the encodings of the amounts
+// which fit in an int64 did not change when Coin.Amount became an Int.
+func TestCoinEncodingCompatibility(t *testing.T) {
+	t.Parallel()
+
+	var (
+		coin  = NewCoin("ugnot", 100)
+		coins = NewCoins(NewCoin("foo", math.MaxInt64), NewCoin("ugnot", 1))
+		fee   = NewFee(1000, NewCoin("ugnot", 10))
+	)
+
+	tests := []struct {
+		name       string
+		value      any
+		binary     string // hex
+		aminoJSON  string
+		stdlibJSON string
+	}{
+		{
+			"coin",
+			coin,
+			"0a0831303075676e6f74",
+			`"100ugnot"`,
+			`{"denom":"ugnot","amount":100}`,
+		},
+		{
+			"coins",
+			coins,
+			"0a1d39323233333732303336383534373735383037666f6f2c3175676e6f74",
+			`"9223372036854775807foo,1ugnot"`,
+			`[{"denom":"foo","amount":9223372036854775807},{"denom":"ugnot","amount":1}]`,
+		},
+		{
+			"fee",
+			fee,
+			"08d00f1207313075676e6f74",
+			`{"gas_wanted":"1000","gas_fee":"10ugnot"}`,
+			`{"gas_wanted":1000,"gas_fee":{"denom":"ugnot","amount":10}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.binary, hex.EncodeToString(amino.MustMarshal(tc.value)))
+			assert.Equal(t, tc.aminoJSON, string(amino.MustMarshalJSON(tc.value)))
+
+			bz, err := json.Marshal(tc.value)
+			require.NoError(t, err)
+			assert.Equal(t, tc.stdlibJSON, string(bz))
+		})
+	}
+}
+
+func TestCoinBeyondInt64(t *testing.T) {
+	t.Parallel()
+
+	coin, err := ParseCoin("100000000000000000000000ufoo")
+	require.NoError(t, err)
+	assert.False(t, coin.Amount.IsInt64())
+	assert.Equal(t, "100000000000000000000000ufoo", coin.String())
+
+	coins := Coins{coin}.Add(Coins{coin})
+	assert.Equal(t, "200000000000000000000000ufoo", coins.String())
+	assert.True(t, coins.IsAllGT(Coins{coin}))
+
+	// Binary and JSON amino round trips.
+	bz, err := amino.Marshal(coins)
+	require.NoError(t, err)
+	var coins2 Coins
+	require.NoError(t, amino.Unmarshal(bz, &coins2))
+	assert.Equal(t, coins, coins2)
+
+	bz, err = amino.MarshalJSON(coins)
+	require.NoError(t, err)
+	coins2 = nil
+	require.NoError(t, amino.UnmarshalJSON(bz, &coins2))
+	assert.Equal(t, coins, coins2)
+}
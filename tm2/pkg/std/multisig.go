@@ -0,0 +1,59 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+var ErrNotMultisig = errors.New("not a multisig public key")
+
+// CombineMultisig combines the partial signatures of signBytes made by the
+// keys of a multisig account, with public key pub, into the signature of the
+// account. Each partial signature is verified, and there must be at least as
+// many as the threshold of pub; a key signing several times is only counted
+// once.
+func CombineMultisig(pub crypto.PubKey, signBytes []byte, sigs []Signature) (Signature, error) {
+	mpub, ok := pub.(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return Signature{}, ErrNotMultisig
+	}
+
+	msig := multisig.NewMultisig(len(mpub.PubKeys))
+	for _, sig := range sigs {
+		if sig.PubKey == nil {
+			return Signature{}, errors.New("partial signature without public key")
+		}
+		if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+			return Signature{}, fmt.Errorf("invalid partial signature of %s", sig.PubKey.Address())
+		}
+		if err := msig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, mpub.PubKeys); err != nil {
+			return Signature{}, err
+		}
+	}
+	if n := len(msig.Sigs); n < int(mpub.K) {
+		return Signature{}, fmt.Errorf("not enough partial signatures: %d of %d required", n, mpub.K)
+	}
+
+	return Signature{
+		PubKey:    mpub,
+		Signature: msig.Marshal(),
+	}, nil
+}
+
+// IsMultisigKey returns whether pub is the public key of a multisig account
+// which includes key.
+func IsMultisigKey(pub, key crypto.PubKey) bool {
+	mpub, ok := pub.(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return false
+	}
+	for _, k := range mpub.PubKeys {
+		if k.Equals(key) {
+			return true
+		}
+	}
+	return false
+}
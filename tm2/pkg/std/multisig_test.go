@@ -0,0 +1,103 @@
+package std
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineMultisig(t *testing.T) {
+	t.Parallel()
+
+	privs := make([]crypto.PrivKey, 5)
+	pubs := make([]crypto.PubKey, 5)
+	for i := range privs {
+		privs[i] = secp256k1.GenPrivKey()
+		pubs[i] = privs[i].PubKey()
+	}
+	pub := multisig.NewPubKeyMultisigThreshold(3, pubs)
+	signBytes := []byte("sign bytes")
+
+	sign := func(t *testing.T, i int) Signature {
+		t.Helper()
+
+		sig, err := privs[i].Sign(signBytes)
+		require.NoError(t, err)
+
+		return Signature{PubKey: pubs[i], Signature: sig}
+	}
+
+	t.Run("threshold reached", func(t *testing.T) {
+		t.Parallel()
+
+		sig, err := CombineMultisig(pub, signBytes, []Signature{sign(t, 4), sign(t, 0), sign(t, 2)})
+		require.NoError(t, err)
+
+		assert.True(t, sig.PubKey.Equals(pub))
+		assert.True(t, pub.VerifyBytes(signBytes, sig.Signature))
+		assert.False(t, pub.VerifyBytes([]byte("other bytes"), sig.Signature))
+	})
+
+	t.Run("not enough signatures", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := CombineMultisig(pub, signBytes, []Signature{sign(t, 0), sign(t, 1)})
+		assert.ErrorContains(t, err, "not enough partial signatures: 2 of 3 required")
+	})
+
+	t.Run("duplicate signatures", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := CombineMultisig(pub, signBytes, []Signature{sign(t, 0), sign(t, 1), sign(t, 1)})
+		assert.ErrorContains(t, err, "not enough partial signatures: 2 of 3 required")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+
+		invalid := sign(t, 2)
+		invalid.PubKey = pubs[3]
+
+		_, err := CombineMultisig(pub, signBytes, []Signature{sign(t, 0), sign(t, 1), invalid})
+		assert.ErrorContains(t, err, "invalid partial signature")
+	})
+
+	t.Run("key not in multisig", func(t *testing.T) {
+		t.Parallel()
+
+		other := secp256k1.GenPrivKey()
+		sig, err := other.Sign(signBytes)
+		require.NoError(t, err)
+
+		_, err = CombineMultisig(pub, signBytes, []Signature{
+			sign(t, 0), sign(t, 1), {PubKey: other.PubKey(), Signature: sig},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("not a multisig", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := CombineMultisig(pubs[0], signBytes, []Signature{sign(t, 0)})
+		assert.ErrorIs(t, err, ErrNotMultisig)
+	})
+}
+
+func TestIsMultisigKey(t *testing.T) {
+	t.Parallel()
+
+	pubs := []crypto.PubKey{
+		secp256k1.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+	}
+	pub := multisig.NewPubKeyMultisigThreshold(1, pubs)
+
+	assert.True(t, IsMultisigKey(pub, pubs[0]))
+	assert.True(t, IsMultisigKey(pub, pubs[1]))
+	assert.False(t, IsMultisigKey(pub, secp256k1.GenPrivKey().PubKey()))
+	assert.False(t, IsMultisigKey(pubs[0], pubs[0]))
+}
@@ -12,6 +12,10 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 
 	// Account
 	&BaseAccount{}, "BaseAccount",
+	&ContinuousVestingAccount{}, "ContinuousVestingAccount",
+	&DelayedVestingAccount{}, "DelayedVestingAccount",
+	&PeriodicVestingAccount{}, "PeriodicVestingAccount",
+	VestingPeriod{}, "VestingPeriod",
 	// Coin
 	&Coin{}, "Coin",
 	// GasPrice
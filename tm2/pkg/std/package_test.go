@@ -30,7 +30,7 @@ func TestAminoGasPrice(t *testing.T) {
 		Gas: 100,
 		Price: std.Coin{
 			Denom:  "token",
-			Amount: 10,
+			Amount: std.NewInt(10),
 		},
 	}
 	// Binary
@@ -57,7 +57,7 @@ func TestAminoGasPrice(t *testing.T) {
 func TestAminoCoin(t *testing.T) {
 	coin := std.Coin{
 		Denom:  "token",
-		Amount: 10,
+		Amount: std.NewInt(10),
 	}
 
 	// Binary
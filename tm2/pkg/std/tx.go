@@ -50,6 +50,12 @@ func (tx Tx) ValidateBasic() error {
 	if !tx.Fee.GasFee.IsValid() {
 		return ErrInsufficientFee(fmt.Sprintf("invalid fee %s amount provided", tx.Fee.GasFee))
 	}
+	if tx.Fee.Granter != "" {
+		granter, err := crypto.AddressFromBech32(tx.Fee.Granter)
+		if err != nil || granter.IsZero() {
+			return ErrInvalidAddress("invalid fee granter address " + tx.Fee.Granter)
+		}
+	}
 	if len(stdSigs) == 0 {
 		return ErrNoSignatures("no signers")
 	}
@@ -123,9 +129,13 @@ func (tx Tx) GetSignBytes(chainID string, accountNumber uint64, sequence uint64)
 // Fee includes the amount of coins paid in fees and the maximum
 // gas to be used by the transaction. The ratio yields an effective "gasprice",
 // which must be above some miminum to be accepted into the mempool.
+//
+// The fees are paid by the first signer, unless a Granter is set: the fees are
+// then paid by the granter, from a fee allowance granted to the first signer.
 type Fee struct {
-	GasWanted int64 `json:"gas_wanted" yaml:"gas_wanted"`
-	GasFee    Coin  `json:"gas_fee" yaml:"gas_fee"`
+	GasWanted int64  `json:"gas_wanted" yaml:"gas_wanted"`
+	GasFee    Coin   `json:"gas_fee" yaml:"gas_fee"`
+	Granter   string `json:"granter,omitempty" yaml:"granter,omitempty"` // bech32 address, optional
 }
 
 // NewFee returns a new instance of Fee
@@ -136,6 +146,24 @@ func NewFee(gasWanted int64, gasFee Coin) Fee {
 	}
 }
 
+// WithGranter returns a copy of the fee, paid by the given granter.
+func (fee Fee) WithGranter(granter crypto.Address) Fee {
+	fee.Granter = granter.String()
+	return fee
+}
+
+// GetGranter returns the address of the fee granter, if any.
+func (fee Fee) GetGranter() (granter crypto.Address, ok bool) {
+	if fee.Granter == "" {
+		return crypto.Address{}, false
+	}
+	granter, err := crypto.AddressFromBech32(fee.Granter)
+	if err != nil {
+		return crypto.Address{}, false
+	}
+	return granter, true
+}
+
 // Bytes for signing later
 func (fee Fee) Bytes() []byte {
 	bz, err := amino.MarshalJSON(fee) // TODO
@@ -0,0 +1,318 @@
+package std
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// VestingAccount is an Account holding coins which are locked until they
+// vest, following a schedule. The locked coins can't be spent, but they are
+// part of the coins of the account.
+//
+// The times of the schedule are UNIX timestamps, in seconds.
+type VestingAccount interface {
+	Account
+
+	GetOriginalVesting() Coins
+	GetStartTime() int64
+	GetEndTime() int64
+
+	// GetVestedCoins returns the coins of the original vesting which are
+	// unlocked at the given time.
+	GetVestedCoins(blockTime time.Time) Coins
+
+	// GetVestingCoins returns the coins of the original vesting which are
+	// still locked at the given time.
+	GetVestingCoins(blockTime time.Time) Coins
+
+	// Validate checks the vesting schedule of the account.
+	Validate() error
+}
+
+// SpendableCoins returns the coins of the account which can be spent at the
+// given time. Only the vesting coins of a VestingAccount are locked, coins
+// received since can be spent.
+func SpendableCoins(acc Account, blockTime time.Time) Coins {
+	coins := acc.GetCoins()
+
+	vacc, ok := acc.(VestingAccount)
+	if !ok {
+		return coins
+	}
+
+	locked := vacc.GetVestingCoins(blockTime)
+
+	var spendable Coins
+	for _, coin := range coins {
+		amt := coin.Amount.Sub(locked.AmountOf(coin.Denom))
+		if amt.IsPositive() {
+			spendable = append(spendable, Coin{Denom: coin.Denom, Amount: amt})
+		}
+	}
+	return spendable
+}
+
+//----------------------------------------
+// ContinuousVestingAccount
+
+var _ VestingAccount = &ContinuousVestingAccount{}
+
+// ContinuousVestingAccount vests its coins linearly, from StartTime to
+// EndTime.
+type ContinuousVestingAccount struct {
+	BaseAccount
+
+	OriginalVesting Coins `json:"original_vesting" yaml:"original_vesting"`
+	StartTime       int64 `json:"start_time" yaml:"start_time"`
+	EndTime         int64 `json:"end_time" yaml:"end_time"`
+}
+
+// NewContinuousVestingAccount creates a new ContinuousVestingAccount object
+func NewContinuousVestingAccount(base BaseAccount, originalVesting Coins, startTime, endTime int64) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseAccount:     base,
+		OriginalVesting: originalVesting,
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}
+}
+
+// GetOriginalVesting - Implements VestingAccount.
+func (acc *ContinuousVestingAccount) GetOriginalVesting() Coins { return acc.OriginalVesting }
+
+// GetStartTime - Implements VestingAccount.
+func (acc *ContinuousVestingAccount) GetStartTime() int64 { return acc.StartTime }
+
+// GetEndTime - Implements VestingAccount.
+func (acc *ContinuousVestingAccount) GetEndTime() int64 { return acc.EndTime }
+
+// GetVestedCoins - Implements VestingAccount.
+func (acc *ContinuousVestingAccount) GetVestedCoins(blockTime time.Time) Coins {
+	now := blockTime.Unix()
+	switch {
+	case now <= acc.StartTime:
+		return nil
+	case now >= acc.EndTime:
+		return acc.OriginalVesting
+	}
+
+	var (
+		elapsed  = NewInt(now - acc.StartTime)
+		duration = NewInt(acc.EndTime - acc.StartTime)
+		vested   Coins
+	)
+	for _, coin := range acc.OriginalVesting {
+		amt := coin.Amount.Mul(elapsed).Quo(duration)
+		if amt.IsPositive() {
+			vested = append(vested, Coin{Denom: coin.Denom, Amount: amt})
+		}
+	}
+	return vested
+}
+
+// GetVestingCoins - Implements VestingAccount.
+func (acc *ContinuousVestingAccount) GetVestingCoins(blockTime time.Time) Coins {
+	return vestingCoins(acc.OriginalVesting, acc.GetVestedCoins(blockTime))
+}
+
+// Validate - Implements VestingAccount.
+func (acc *ContinuousVestingAccount) Validate() error {
+	if acc.StartTime >= acc.EndTime {
+		return errors.New("vesting start time must be before end time")
+	}
+	return validateOriginalVesting(acc.OriginalVesting)
+}
+
+// String implements fmt.Stringer
+func (acc ContinuousVestingAccount) String() string {
+	return fmt.Sprintf(`%s
+  OriginalVesting: %s
+  StartTime:       %d
+  EndTime:         %d`,
+		acc.BaseAccount, acc.OriginalVesting, acc.StartTime, acc.EndTime,
+	)
+}
+
+//----------------------------------------
+// DelayedVestingAccount
+
+var _ VestingAccount = &DelayedVestingAccount{}
+
+// DelayedVestingAccount vests all its coins at once, at EndTime.
+type DelayedVestingAccount struct {
+	BaseAccount
+
+	OriginalVesting Coins `json:"original_vesting" yaml:"original_vesting"`
+	EndTime         int64 `json:"end_time" yaml:"end_time"`
+}
+
+// NewDelayedVestingAccount creates a new DelayedVestingAccount object
+func NewDelayedVestingAccount(base BaseAccount, originalVesting Coins, endTime int64) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseAccount:     base,
+		OriginalVesting: originalVesting,
+		EndTime:         endTime,
+	}
+}
+
+// GetOriginalVesting - Implements VestingAccount.
+func (acc *DelayedVestingAccount) GetOriginalVesting() Coins { return acc.OriginalVesting }
+
+// GetStartTime - Implements VestingAccount. Coins are locked from the
+// creation of the account.
+func (acc *DelayedVestingAccount) GetStartTime() int64 { return 0 }
+
+// GetEndTime - Implements VestingAccount.
+func (acc *DelayedVestingAccount) GetEndTime() int64 { return acc.EndTime }
+
+// GetVestedCoins - Implements VestingAccount.
+func (acc *DelayedVestingAccount) GetVestedCoins(blockTime time.Time) Coins {
+	if blockTime.Unix() >= acc.EndTime {
+		return acc.OriginalVesting
+	}
+	return nil
+}
+
+// GetVestingCoins - Implements VestingAccount.
+func (acc *DelayedVestingAccount) GetVestingCoins(blockTime time.Time) Coins {
+	return vestingCoins(acc.OriginalVesting, acc.GetVestedCoins(blockTime))
+}
+
+// Validate - Implements VestingAccount.
+func (acc *DelayedVestingAccount) Validate() error {
+	return validateOriginalVesting(acc.OriginalVesting)
+}
+
+// String implements fmt.Stringer
+func (acc DelayedVestingAccount) String() string {
+	return fmt.Sprintf(`%s
+  OriginalVesting: %s
+  EndTime:         %d`,
+		acc.BaseAccount, acc.OriginalVesting, acc.EndTime,
+	)
+}
+
+//----------------------------------------
+// PeriodicVestingAccount
+
+var _ VestingAccount = &PeriodicVestingAccount{}
+
+// VestingPeriod is a period of a PeriodicVestingAccount, of Length seconds,
+// at the end of which Amount vests.
+type VestingPeriod struct {
+	Length int64 `json:"length" yaml:"length"`
+	Amount Coins `json:"amount" yaml:"amount"`
+}
+
+// PeriodicVestingAccount vests its coins at the end of each of its
+// consecutive periods, starting at StartTime.
+type PeriodicVestingAccount struct {
+	BaseAccount
+
+	OriginalVesting Coins           `json:"original_vesting" yaml:"original_vesting"`
+	StartTime       int64           `json:"start_time" yaml:"start_time"`
+	VestingPeriods  []VestingPeriod `json:"vesting_periods" yaml:"vesting_periods"`
+}
+
+// NewPeriodicVestingAccount creates a new PeriodicVestingAccount object,
+// vesting the sum of the amounts of the periods.
+func NewPeriodicVestingAccount(base BaseAccount, startTime int64, periods []VestingPeriod) *PeriodicVestingAccount {
+	var originalVesting Coins
+	for _, period := range periods {
+		originalVesting = originalVesting.Add(period.Amount)
+	}
+
+	return &PeriodicVestingAccount{
+		BaseAccount:     base,
+		OriginalVesting: originalVesting,
+		StartTime:       startTime,
+		VestingPeriods:  periods,
+	}
+}
+
+// GetOriginalVesting - Implements VestingAccount.
+func (acc *PeriodicVestingAccount) GetOriginalVesting() Coins { return acc.OriginalVesting }
+
+// GetStartTime - Implements VestingAccount.
+func (acc *PeriodicVestingAccount) GetStartTime() int64 { return acc.StartTime }
+
+// GetEndTime - Implements VestingAccount. It is the end of the last period.
+func (acc *PeriodicVestingAccount) GetEndTime() int64 {
+	end := acc.StartTime
+	for _, period := range acc.VestingPeriods {
+		end += period.Length
+	}
+	return end
+}
+
+// GetVestedCoins - Implements VestingAccount.
+func (acc *PeriodicVestingAccount) GetVestedCoins(blockTime time.Time) Coins {
+	var (
+		now    = blockTime.Unix()
+		end    = acc.StartTime
+		vested Coins
+	)
+	for _, period := range acc.VestingPeriods {
+		end += period.Length
+		if now < end {
+			break
+		}
+		vested = vested.Add(period.Amount)
+	}
+	return vested
+}
+
+// GetVestingCoins - Implements VestingAccount.
+func (acc *PeriodicVestingAccount) GetVestingCoins(blockTime time.Time) Coins {
+	return vestingCoins(acc.OriginalVesting, acc.GetVestedCoins(blockTime))
+}
+
+// Validate - Implements VestingAccount.
+func (acc *PeriodicVestingAccount) Validate() error {
+	if len(acc.VestingPeriods) == 0 {
+		return errors.New("no vesting periods")
+	}
+
+	var total Coins
+	for i, period := range acc.VestingPeriods {
+		if period.Length <= 0 {
+			return fmt.Errorf("vesting period %d: length must be positive", i)
+		}
+		if !period.Amount.IsValid() {
+			return fmt.Errorf("vesting period %d: invalid amount %s", i, period.Amount)
+		}
+		total = total.Add(period.Amount)
+	}
+
+	if !total.IsEqual(acc.OriginalVesting) {
+		return fmt.Errorf("original vesting %s does not match the vesting periods total %s", acc.OriginalVesting, total)
+	}
+	return validateOriginalVesting(acc.OriginalVesting)
+}
+
+// String implements fmt.Stringer
+func (acc PeriodicVestingAccount) String() string {
+	return fmt.Sprintf(`%s
+  OriginalVesting: %s
+  StartTime:       %d
+  VestingPeriods:  %v`,
+		acc.BaseAccount, acc.OriginalVesting, acc.StartTime, acc.VestingPeriods,
+	)
+}
+
+//----------------------------------------
+// misc
+
+// vestingCoins returns the coins of originalVesting which are not vested.
+func vestingCoins(originalVesting, vested Coins) Coins {
+	return originalVesting.Sub(vested)
+}
+
+func validateOriginalVesting(originalVesting Coins) error {
+	if !originalVesting.IsValid() || originalVesting.IsZero() {
+		return fmt.Errorf("invalid original vesting %s", originalVesting)
+	}
+	return nil
+}
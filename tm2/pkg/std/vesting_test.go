@@ -0,0 +1,101 @@
+package std
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+func TestContinuousVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	base := NewBaseAccountWithAddress(crypto.AddressFromPreimage([]byte("vesting")))
+	base.Coins = NewCoins(NewCoin("ugnot", 1500))
+	acc := NewContinuousVestingAccount(base, NewCoins(NewCoin("ugnot", 1000)), 100, 200)
+	require.NoError(t, acc.Validate())
+
+	for _, tc := range []struct {
+		time      int64
+		locked    Coins
+		spendable Coins
+	}{
+		{50, NewCoins(NewCoin("ugnot", 1000)), NewCoins(NewCoin("ugnot", 500))},
+		{100, NewCoins(NewCoin("ugnot", 1000)), NewCoins(NewCoin("ugnot", 500))},
+		{125, NewCoins(NewCoin("ugnot", 750)), NewCoins(NewCoin("ugnot", 750))},
+		{200, nil, NewCoins(NewCoin("ugnot", 1500))},
+	} {
+		blockTime := time.Unix(tc.time, 0)
+		assert.True(t, tc.locked.IsEqual(acc.GetVestingCoins(blockTime)), "time %d", tc.time)
+		assert.True(t, tc.spendable.IsEqual(SpendableCoins(acc, blockTime)), "time %d", tc.time)
+	}
+
+	// Spent coins reduce the spendable coins first
+	acc.Coins = NewCoins(NewCoin("ugnot", 900))
+	assert.Empty(t, SpendableCoins(acc, time.Unix(100, 0)))
+
+	acc.EndTime = 100
+	assert.Error(t, acc.Validate())
+}
+
+func TestDelayedVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	base := NewBaseAccountWithAddress(crypto.AddressFromPreimage([]byte("vesting")))
+	base.Coins = NewCoins(NewCoin("foo", 10), NewCoin("ugnot", 1000))
+	acc := NewDelayedVestingAccount(base, NewCoins(NewCoin("ugnot", 1000)), 200)
+	require.NoError(t, acc.Validate())
+
+	assert.True(t, NewCoins(NewCoin("foo", 10)).IsEqual(SpendableCoins(acc, time.Unix(199, 0))))
+	assert.True(t, base.Coins.IsEqual(SpendableCoins(acc, time.Unix(200, 0))))
+}
+
+func TestPeriodicVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	base := NewBaseAccountWithAddress(crypto.AddressFromPreimage([]byte("vesting")))
+	base.Coins = NewCoins(NewCoin("ugnot", 1000))
+	acc := NewPeriodicVestingAccount(base, 100, []VestingPeriod{
+		{Length: 10, Amount: NewCoins(NewCoin("ugnot", 300))},
+		{Length: 20, Amount: NewCoins(NewCoin("ugnot", 700))},
+	})
+	require.NoError(t, acc.Validate())
+
+	assert.Equal(t, int64(130), acc.GetEndTime())
+	assert.True(t, NewCoins(NewCoin("ugnot", 1000)).IsEqual(acc.GetOriginalVesting()))
+	assert.Empty(t, acc.GetVestedCoins(time.Unix(109, 0)))
+	assert.True(t, NewCoins(NewCoin("ugnot", 300)).IsEqual(acc.GetVestedCoins(time.Unix(110, 0))))
+	assert.True(t, NewCoins(NewCoin("ugnot", 700)).IsEqual(acc.GetVestingCoins(time.Unix(129, 0))))
+	assert.True(t, NewCoins(NewCoin("ugnot", 1000)).IsEqual(SpendableCoins(acc, time.Unix(130, 0))))
+
+	acc.OriginalVesting = NewCoins(NewCoin("ugnot", 1))
+	assert.Error(t, acc.Validate())
+}
+
+func TestAminoVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	base := NewBaseAccountWithAddress(crypto.AddressFromPreimage([]byte("vesting")))
+	base.Coins = NewCoins(NewCoin("ugnot", 1000))
+
+	var acc Account = NewContinuousVestingAccount(base, NewCoins(NewCoin("ugnot", 1000)), 100, 200)
+
+	bz, err := amino.MarshalAny(acc)
+	require.NoError(t, err)
+
+	var decoded Account
+	require.NoError(t, amino.UnmarshalAny(bz, &decoded))
+	assert.Equal(t, acc, decoded)
+
+	// The base account can be read from the JSON, as for other accounts
+	jsonBz, err := amino.MarshalJSON(acc)
+	require.NoError(t, err)
+
+	var qret struct{ BaseAccount BaseAccount }
+	require.NoError(t, amino.UnmarshalJSON(jsonBz, &qret))
+	assert.Equal(t, base, qret.BaseAccount)
+}
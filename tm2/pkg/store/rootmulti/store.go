@@ -430,6 +430,31 @@ func (si storeInfo) Hash() []byte {
 	return hasher.Sum(nil)
 }
 
+// ----------------------------------------
+// Inspection
+
+// GetLatestVersion returns the latest version committed
+// by the multistore in the given DB, or 0 if there is none.
+func GetLatestVersion(db dbm.DB) int64 {
+	return getLatestVersion(db)
+}
+
+// GetStoreCommitIDs returns the commit IDs of each substore,
+// keyed by store name, as committed by the multistore at the given version.
+func GetStoreCommitIDs(db dbm.DB, ver int64) (map[string]types.CommitID, error) {
+	cInfo, err := getCommitInfo(db, ver)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]types.CommitID, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		ids[storeInfo.Name] = storeInfo.Core.CommitID
+	}
+
+	return ids, nil
+}
+
 // ----------------------------------------
 // Misc.
 
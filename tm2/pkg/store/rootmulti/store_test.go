@@ -168,6 +168,34 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestGetStoreCommitIDs(t *testing.T) {
+	t.Parallel()
+
+	var db dbm.DB = memdb.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.NoError(t, store.LoadLatestVersion())
+
+	// Empty DB has no versions
+	require.Equal(t, int64(0), GetLatestVersion(db))
+
+	_, err := GetStoreCommitIDs(db, 1)
+	require.Error(t, err)
+
+	store.getStoreByName("store1").(types.Store).Set([]byte("key"), []byte("value"))
+	store.Commit()
+	commitID := store.Commit()
+
+	require.Equal(t, commitID.Version, GetLatestVersion(db))
+
+	ids, err := GetStoreCommitIDs(db, commitID.Version)
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+
+	for name, id := range ids {
+		require.Equal(t, store.getStoreByName(name).(types.CommitStore).LastCommitID(), id)
+	}
+}
+
 func TestParsePath(t *testing.T) {
 	t.Parallel()
 
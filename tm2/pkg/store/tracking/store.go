@@ -0,0 +1,223 @@
+// Package tracking implements a store which records the values read from its
+// parent and buffers the writes made to it, leaving the parent untouched.
+//
+// It is used to execute transactions speculatively on top of a shared state:
+// the recorded reads tell whether the execution is still valid on top of a
+// newer state, and the buffered writes can then be applied to it.
+package tracking
+
+import (
+	"bytes"
+
+	"github.com/gnolang/gno/tm2/pkg/store/cache"
+	"github.com/gnolang/gno/tm2/pkg/store/cachemulti"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+var _ types.Store = &Store{}
+
+// Store records the reads made through it and buffers the writes. Writes made
+// before the last call to Track are only visible through the store; reads and
+// writes made after it are recorded, to be validated and applied elsewhere.
+//
+// A Store is not safe for concurrent use, but many may share a parent, as long
+// as it is not modified.
+type Store struct {
+	parent   types.Store
+	base     map[string][]byte // writes before Track; nil value if deleted
+	writes   map[string][]byte // writes after Track; nil value if deleted
+	reads    map[string][]byte // first value read after Track; nil if absent
+	tracking bool
+	iterated bool // an iterator was used after Track
+}
+
+// New returns a new Store on top of parent.
+func New(parent types.Store) *Store {
+	return &Store{
+		parent: parent,
+		base:   make(map[string][]byte),
+		writes: make(map[string][]byte),
+		reads:  make(map[string][]byte),
+	}
+}
+
+// Track starts the recording of reads and writes. Writes made so far are kept,
+// but won't be applied by Apply.
+func (ts *Store) Track() {
+	for key, value := range ts.writes {
+		ts.base[key] = value
+	}
+	clear(ts.writes)
+	clear(ts.reads)
+	ts.tracking = true
+	ts.iterated = false
+}
+
+// Valid reports whether all the values read since Track are the same in
+// other, meaning that the writes would be the same if made on top of it.
+// Iterators are not tracked, so Valid is always false if one was used.
+func (ts *Store) Valid(other types.Store) bool {
+	if ts.iterated {
+		return false
+	}
+	for key, value := range ts.reads {
+		ovalue := other.Get([]byte(key))
+		if (value == nil) != (ovalue == nil) || !bytes.Equal(value, ovalue) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply applies the writes made since Track to other.
+func (ts *Store) Apply(other types.Store) {
+	for key, value := range ts.writes {
+		if value == nil {
+			other.Delete([]byte(key))
+		} else {
+			other.Set([]byte(key), value)
+		}
+	}
+}
+
+// Implements Store.
+func (ts *Store) Get(key []byte) []byte {
+	types.AssertValidKey(key)
+	skey := string(key)
+	// reads of the tracked writes don't depend on the parent.
+	if value, ok := ts.writes[skey]; ok {
+		return value
+	}
+	value, ok := ts.base[skey]
+	if !ok {
+		value = ts.parent.Get(key)
+	}
+	if ts.tracking {
+		if _, ok := ts.reads[skey]; !ok {
+			ts.reads[skey] = value
+		}
+	}
+	return value
+}
+
+// Implements Store.
+func (ts *Store) Has(key []byte) bool {
+	return ts.Get(key) != nil
+}
+
+// Implements Store.
+func (ts *Store) Set(key, value []byte) {
+	types.AssertValidKey(key)
+	types.AssertValidValue(value)
+	ts.writes[string(key)] = value
+}
+
+// Implements Store.
+func (ts *Store) Delete(key []byte) {
+	types.AssertValidKey(key)
+	ts.writes[string(key)] = nil
+}
+
+// Implements Store.
+func (ts *Store) Iterator(start, end []byte) types.Iterator {
+	return ts.overlay().Iterator(start, end)
+}
+
+// Implements Store.
+func (ts *Store) ReverseIterator(start, end []byte) types.Iterator {
+	return ts.overlay().ReverseIterator(start, end)
+}
+
+// overlay returns a cache of the parent with the buffered writes, to iterate
+// over.
+func (ts *Store) overlay() types.Store {
+	if ts.tracking {
+		ts.iterated = true
+	}
+	overlay := cache.New(ts.parent)
+	for _, writes := range []map[string][]byte{ts.base, ts.writes} {
+		for key, value := range writes {
+			if value == nil {
+				overlay.Delete([]byte(key))
+			} else {
+				overlay.Set([]byte(key), value)
+			}
+		}
+	}
+	return overlay
+}
+
+// Implements Store.
+func (ts *Store) CacheWrap() types.Store {
+	return cache.New(ts)
+}
+
+// Implements Store.
+func (ts *Store) Write() {
+	panic("unexpected .Write() on tracking.Store")
+}
+
+// ----------------------------------------
+// MultiStore
+
+var _ types.MultiStore = MultiStore{}
+
+// MultiStore is a MultiStore of tracking Stores.
+type MultiStore struct {
+	stores map[types.StoreKey]*Store
+}
+
+// NewMultiStore returns a new MultiStore tracking the stores of parent with
+// the given keys.
+func NewMultiStore(parent types.MultiStore, keys []types.StoreKey) MultiStore {
+	stores := make(map[types.StoreKey]*Store, len(keys))
+	for _, key := range keys {
+		stores[key] = New(parent.GetStore(key))
+	}
+	return MultiStore{stores: stores}
+}
+
+// Track calls Track on each underlying store.
+func (tms MultiStore) Track() {
+	for _, store := range tms.stores {
+		store.Track()
+	}
+}
+
+// Valid reports whether Valid is true for each underlying store, given the
+// store of other with the same key.
+func (tms MultiStore) Valid(other types.MultiStore) bool {
+	for key, store := range tms.stores {
+		if !store.Valid(other.GetStore(key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply calls Apply on each underlying store, given the store of other with
+// the same key.
+func (tms MultiStore) Apply(other types.MultiStore) {
+	for key, store := range tms.stores {
+		store.Apply(other.GetStore(key))
+	}
+}
+
+// Implements MultiStore.
+func (tms MultiStore) GetStore(key types.StoreKey) types.Store {
+	return tms.stores[key]
+}
+
+// Implements MultiStore.
+func (tms MultiStore) MultiCacheWrap() types.MultiStore {
+	stores := make(map[types.StoreKey]types.Store, len(tms.stores))
+	for key, store := range tms.stores {
+		stores[key] = store
+	}
+	return cachemulti.New(stores, nil)
+}
+
+// Implements MultiStore.
+func (tms MultiStore) MultiWrite() {
+	panic("unexpected .MultiWrite() on tracking.MultiStore")
+}
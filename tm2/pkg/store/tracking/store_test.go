@@ -0,0 +1,102 @@
+package tracking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+func bz(s string) []byte { return []byte(s) }
+
+func newParent() types.Store {
+	parent := dbadapter.Store{DB: memdb.NewMemDB()}
+	parent.Set(bz("a"), bz("1"))
+	parent.Set(bz("b"), bz("2"))
+	parent.Set(bz("c"), bz("3"))
+	return parent
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	parent := newParent()
+	ts := New(parent)
+
+	// writes before Track are visible, but not applied.
+	ts.Set(bz("a"), bz("10"))
+	ts.Track()
+	assert.Equal(t, bz("10"), ts.Get(bz("a")))
+	assert.Equal(t, bz("2"), ts.Get(bz("b")))
+	assert.False(t, ts.Has(bz("d")))
+	ts.Set(bz("b"), bz("20"))
+	ts.Delete(bz("c"))
+	ts.Set(bz("e"), bz("5"))
+	assert.Equal(t, bz("20"), ts.Get(bz("b")))
+	assert.Nil(t, ts.Get(bz("c")))
+
+	// the parent is untouched.
+	assert.Equal(t, bz("1"), parent.Get(bz("a")))
+	assert.Equal(t, bz("3"), parent.Get(bz("c")))
+
+	// the reads are those of a state where a is 10.
+	assert.False(t, ts.Valid(parent))
+	other := newParent()
+	other.Set(bz("a"), bz("10"))
+	other.Set(bz("c"), bz("30"))
+	assert.True(t, ts.Valid(other))
+	other.Set(bz("d"), bz("4"))
+	assert.False(t, ts.Valid(other))
+	other.Delete(bz("d"))
+	require.True(t, ts.Valid(other))
+
+	ts.Apply(other)
+	assert.Equal(t, bz("10"), other.Get(bz("a")))
+	assert.Equal(t, bz("20"), other.Get(bz("b")))
+	assert.Nil(t, other.Get(bz("c")))
+	assert.Equal(t, bz("5"), other.Get(bz("e")))
+}
+
+func TestStoreIterator(t *testing.T) {
+	t.Parallel()
+
+	ts := New(newParent())
+	ts.Delete(bz("b"))
+	ts.Track()
+	ts.Set(bz("d"), bz("4"))
+
+	var keys []string
+	iter := ts.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	iter.Close()
+	assert.Equal(t, []string{"a", "c", "d"}, keys)
+
+	// iterators are not tracked.
+	assert.False(t, ts.Valid(ts))
+}
+
+func TestStoreCacheWrap(t *testing.T) {
+	t.Parallel()
+
+	parent := newParent()
+	ts := New(parent)
+	ts.Track()
+
+	cs := ts.CacheWrap()
+	assert.Equal(t, bz("1"), cs.Get(bz("a")))
+	cs.Set(bz("a"), bz("10"))
+	cs.Set(bz("f"), bz("6"))
+	assert.Equal(t, bz("10"), cs.Get(bz("a")))
+	assert.Nil(t, ts.writes["a"])
+	cs.Write()
+
+	assert.Equal(t, map[string][]byte{"a": bz("1")}, ts.reads)
+	assert.Equal(t, map[string][]byte{"a": bz("10"), "f": bz("6")}, ts.writes)
+	assert.Equal(t, bz("1"), parent.Get(bz("a")))
+}
@@ -4,7 +4,10 @@ import (
 	"errors"
 )
 
-var errEndpointNotSet = errors.New("telemetry exporter endpoint not set")
+var (
+	errEndpointNotSet   = errors.New("telemetry exporter endpoint not set")
+	errListenAddrNotSet = errors.New("telemetry prometheus listen address not set")
+)
 
 // Config is the configuration struct for the tm2 telemetry package
 type Config struct {
@@ -13,6 +16,9 @@ type Config struct {
 	ServiceName       string `json:"service_name" toml:"service_name" comment:"in Prometheus this is transformed into the label 'exported_job'"`
 	ServiceInstanceID string `json:"service_instance_id" toml:"service_instance_id" comment:"the ID helps to distinguish instances of the same service that exist at the same time (e.g. instances of a horizontally scaled service), in Prometheus this is transformed into the label 'exported_instance"`
 	ExporterEndpoint  string `json:"exporter_endpoint" toml:"exporter_endpoint" comment:"the endpoint to export metrics to, like a local OpenTelemetry collector"`
+
+	PrometheusEnabled    bool   `json:"prometheus_enabled" toml:"prometheus_enabled" comment:"if true, the metrics (and Go runtime stats) are served for Prometheus scraping, at /metrics"`
+	PrometheusListenAddr string `json:"prometheus_listen_addr" toml:"prometheus_listen_addr" comment:"the address the Prometheus metrics endpoint listens on"`
 }
 
 // DefaultTelemetryConfig is the default configuration used for the node
@@ -23,15 +29,24 @@ func DefaultTelemetryConfig() *Config {
 		ServiceName:       "tm2",
 		ServiceInstanceID: "tm2-node-1",
 		ExporterEndpoint:  "",
+
+		PrometheusEnabled:    false,
+		PrometheusListenAddr: ":26660",
 	}
 }
 
 // ValidateBasic performs basic telemetry config validation and
 // returns an error if any check fails
 func (cfg *Config) ValidateBasic() error {
-	if cfg.ExporterEndpoint == "" {
+	// The exporter endpoint is optional
+	// only if metrics are served for Prometheus
+	if cfg.ExporterEndpoint == "" && !cfg.PrometheusEnabled {
 		return errEndpointNotSet
 	}
 
+	if cfg.PrometheusEnabled && cfg.PrometheusListenAddr == "" {
+		return errListenAddrNotSet
+	}
+
 	return nil
 }
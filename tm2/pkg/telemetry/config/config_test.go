@@ -18,6 +18,26 @@ func TestConfig_ValidateBasic(t *testing.T) {
 		assert.ErrorIs(t, c.ValidateBasic(), errEndpointNotSet)
 	})
 
+	t.Run("prometheus listen address not set", func(t *testing.T) {
+		t.Parallel()
+
+		c := DefaultTelemetryConfig()
+		c.PrometheusEnabled = true
+		c.PrometheusListenAddr = "" // empty
+
+		assert.ErrorIs(t, c.ValidateBasic(), errListenAddrNotSet)
+	})
+
+	t.Run("valid prometheus-only configuration", func(t *testing.T) {
+		t.Parallel()
+
+		c := DefaultTelemetryConfig()
+		c.ExporterEndpoint = "" // empty
+		c.PrometheusEnabled = true
+
+		assert.NoError(t, c.ValidateBasic())
+	})
+
 	t.Run("valid configuration", func(t *testing.T) {
 		t.Parallel()
 
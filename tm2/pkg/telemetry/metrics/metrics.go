@@ -21,6 +21,9 @@ const (
 	inboundPeersKey  = "inbound_peers_gauge"
 	outboundPeersKey = "outbound_peers_gauge"
 
+	peerSendBytesKey    = "peer_send_bytes_counter"
+	peerReceiveBytesKey = "peer_receive_bytes_counter"
+
 	numMempoolTxsKey = "num_mempool_txs_hist"
 	numCachedTxsKey  = "num_cached_txs_hist"
 
@@ -28,6 +31,11 @@ const (
 	vmGasUsedKey   = "vm_gas_used_hist"
 	vmCPUCyclesKey = "vm_cpu_cycles_hist"
 
+	vmObjectCacheHitsKey   = "vm_object_cache_hits_counter"
+	vmObjectCacheMissesKey = "vm_object_cache_misses_counter"
+
+	consensusHeightKey      = "consensus_height_gauge"
+	consensusRoundKey       = "consensus_round_gauge"
 	validatorCountKey       = "validator_count_hist"
 	validatorVotingPowerKey = "validator_vp_hist"
 	blockIntervalKey        = "block_interval_hist"
@@ -48,6 +56,12 @@ var (
 	// OutboundPeers measures the active number of outbound peers
 	OutboundPeers metric.Int64Gauge
 
+	// PeerSendBytes measures the number of message bytes sent to peers
+	PeerSendBytes metric.Int64Counter
+
+	// PeerReceiveBytes measures the number of message bytes received from peers
+	PeerReceiveBytes metric.Int64Counter
+
 	// Mempool //
 
 	// NumMempoolTxs measures the number of transaction inside the mempool
@@ -67,11 +81,23 @@ var (
 	// VMCPUCycles measures the VM CPU cycles
 	VMCPUCycles metric.Int64Histogram
 
+	// VMObjectCacheHits measures the number of objects found in the VM object cache
+	VMObjectCacheHits metric.Int64Counter
+
+	// VMObjectCacheMisses measures the number of objects missing from the VM object cache
+	VMObjectCacheMisses metric.Int64Counter
+
 	// Consensus //
 
 	// BuildBlockTimer measures the block build duration
 	BuildBlockTimer metric.Int64Histogram
 
+	// ConsensusHeight measures the height the consensus is currently at
+	ConsensusHeight metric.Int64Gauge
+
+	// ConsensusRound measures the round the consensus is currently at
+	ConsensusRound metric.Int64Gauge
+
 	// ValidatorsCount measures the size of the active validator set
 	ValidatorsCount metric.Int64Histogram
 
@@ -102,47 +128,46 @@ var (
 func Init(config config.Config) error {
 	var (
 		ctx = context.Background()
-		exp sdkMetric.Exporter
+		err error
+
+		opts = []sdkMetric.Option{
+			sdkMetric.WithResource(
+				resource.NewWithAttributes(
+					semconv.SchemaURL,
+					semconv.ServiceNameKey.String(config.ServiceName),
+					semconv.ServiceVersionKey.String("1.0.0"),
+					semconv.ServiceInstanceIDKey.String(config.ServiceInstanceID),
+				),
+			),
+		}
 	)
 
-	u, err := url.Parse(config.ExporterEndpoint)
-	if err != nil {
-		return fmt.Errorf("error parsing exporter endpoint: %s, %w", config.ExporterEndpoint, err)
+	// Push the metrics to the exporter endpoint, if any
+	if config.ExporterEndpoint != "" {
+		exp, err := newExporter(ctx, config.ExporterEndpoint)
+		if err != nil {
+			return err
+		}
+
+		// Default period is 1m
+		opts = append(opts, sdkMetric.WithReader(sdkMetric.NewPeriodicReader(exp)))
 	}
 
-	// Use oltp metric exporter with http/https or grpc
-	switch u.Scheme {
-	case "http", "https":
-		exp, err = otlpmetrichttp.New(
-			ctx,
-			otlpmetrichttp.WithEndpointURL(config.ExporterEndpoint),
-		)
+	// Serve the metrics for Prometheus scraping, if enabled
+	if config.PrometheusEnabled {
+		reader, handler, err := newPrometheusReader()
 		if err != nil {
-			return fmt.Errorf("unable to create http metrics exporter, %w", err)
+			return err
 		}
-	default:
-		exp, err = otlpmetricgrpc.New(
-			ctx,
-			otlpmetricgrpc.WithEndpoint(config.ExporterEndpoint),
-			otlpmetricgrpc.WithInsecure(),
-		)
-		if err != nil {
-			return fmt.Errorf("unable to create grpc metrics exporter, %w", err)
+
+		if err := servePrometheus(config.PrometheusListenAddr, handler); err != nil {
+			return fmt.Errorf("unable to serve prometheus metrics, %w", err)
 		}
+
+		opts = append(opts, sdkMetric.WithReader(reader))
 	}
 
-	provider := sdkMetric.NewMeterProvider(
-		// Default period is 1m
-		sdkMetric.WithReader(sdkMetric.NewPeriodicReader(exp)),
-		sdkMetric.WithResource(
-			resource.NewWithAttributes(
-				semconv.SchemaURL,
-				semconv.ServiceNameKey.String(config.ServiceName),
-				semconv.ServiceVersionKey.String("1.0.0"),
-				semconv.ServiceInstanceIDKey.String(config.ServiceInstanceID),
-			),
-		),
-	)
+	provider := sdkMetric.NewMeterProvider(opts...)
 	otel.SetMeterProvider(provider)
 	meter := provider.Meter(config.MeterName)
 
@@ -174,6 +199,22 @@ func Init(config config.Config) error {
 	// Initialize OutboundPeers Gauge
 	OutboundPeers.Record(ctx, 0)
 
+	if PeerSendBytes, err = meter.Int64Counter(
+		peerSendBytesKey,
+		metric.WithDescription("message bytes sent to peers"),
+		metric.WithUnit("B"),
+	); err != nil {
+		return fmt.Errorf("unable to create counter, %w", err)
+	}
+
+	if PeerReceiveBytes, err = meter.Int64Counter(
+		peerReceiveBytesKey,
+		metric.WithDescription("message bytes received from peers"),
+		metric.WithUnit("B"),
+	); err != nil {
+		return fmt.Errorf("unable to create counter, %w", err)
+	}
+
 	// Mempool //
 	if NumMempoolTxs, err = meter.Int64Histogram(
 		numMempoolTxsKey,
@@ -211,7 +252,35 @@ func Init(config config.Config) error {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
+	if VMObjectCacheHits, err = meter.Int64Counter(
+		vmObjectCacheHitsKey,
+		metric.WithDescription("VM object cache hits"),
+	); err != nil {
+		return fmt.Errorf("unable to create counter, %w", err)
+	}
+
+	if VMObjectCacheMisses, err = meter.Int64Counter(
+		vmObjectCacheMissesKey,
+		metric.WithDescription("VM object cache misses"),
+	); err != nil {
+		return fmt.Errorf("unable to create counter, %w", err)
+	}
+
 	// Consensus //
+	if ConsensusHeight, err = meter.Int64Gauge(
+		consensusHeightKey,
+		metric.WithDescription("current consensus height"),
+	); err != nil {
+		return fmt.Errorf("unable to create gauge, %w", err)
+	}
+
+	if ConsensusRound, err = meter.Int64Gauge(
+		consensusRoundKey,
+		metric.WithDescription("current consensus round"),
+	); err != nil {
+		return fmt.Errorf("unable to create gauge, %w", err)
+	}
+
 	if ValidatorsCount, err = meter.Int64Histogram(
 		validatorCountKey,
 		metric.WithDescription("size of the active validator set"),
@@ -276,3 +345,36 @@ func Init(config config.Config) error {
 
 	return nil
 }
+
+// newExporter creates a new OTLP metrics exporter for the given endpoint,
+// using http/https or grpc, depending on the endpoint scheme
+func newExporter(ctx context.Context, endpoint string) (sdkMetric.Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing exporter endpoint: %s, %w", endpoint, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		exp, err := otlpmetrichttp.New(
+			ctx,
+			otlpmetrichttp.WithEndpointURL(endpoint),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create http metrics exporter, %w", err)
+		}
+
+		return exp, nil
+	default:
+		exp, err := otlpmetricgrpc.New(
+			ctx,
+			otlpmetricgrpc.WithEndpoint(endpoint),
+			otlpmetricgrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create grpc metrics exporter, %w", err)
+		}
+
+		return exp, nil
+	}
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// prometheusPath is the HTTP path the Prometheus metrics are served on
+const prometheusPath = "/metrics"
+
+// newPrometheusReader creates a new metric reader that collects the
+// instruments on every Prometheus scrape, along with the Go runtime
+// and process stats, and the HTTP handler that serves them
+func newPrometheusReader() (sdkMetric.Reader, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+
+	// Register the Go runtime and process collectors
+	if err := registry.Register(collectors.NewGoCollector()); err != nil {
+		return nil, nil, fmt.Errorf("unable to register go collector, %w", err)
+	}
+
+	if err := registry.Register(
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	); err != nil {
+		return nil, nil, fmt.Errorf("unable to register process collector, %w", err)
+	}
+
+	reader, err := otelprom.New(otelprom.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create prometheus exporter, %w", err)
+	}
+
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
+	return reader, handler, nil
+}
+
+// servePrometheus starts serving the given Prometheus metrics handler
+// on the given listen address, in the background
+func servePrometheus(listenAddr string, handler http.Handler) error {
+	// Listen right away, so any address errors are caught early
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s, %w", listenAddr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(prometheusPath, handler)
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// The metrics are served for the lifetime of the process,
+	// and a failing endpoint should not take the node down
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func TestPrometheus_Reader(t *testing.T) {
+	t.Parallel()
+
+	reader, handler, err := newPrometheusReader()
+	require.NoError(t, err)
+
+	// Record a sample instrument
+	provider := sdkMetric.NewMeterProvider(sdkMetric.WithReader(reader))
+	defer provider.Shutdown(context.Background())
+
+	counter, err := provider.Meter("test").Int64Counter(vmExecMsgKey)
+	require.NoError(t, err)
+
+	counter.Add(context.Background(), 10)
+
+	// Scrape the metrics
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	// Make sure the instrument and the runtime stats are exposed
+	assert.Contains(t, string(body), vmExecMsgKey+"_total")
+	assert.Contains(t, string(body), "go_goroutines")
+}
+
+func TestPrometheus_Serve_InvalidAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, servePrometheus("invalid address", http.NotFoundHandler()))
+}